@@ -83,10 +83,52 @@ type ClusterColocationProfileSpec struct {
 	// +kubebuilder:pruning:PreserveUnknownFields
 	// +kubebuilder:validation:Schemaless
 	Patch runtime.RawExtension `json:"patch,omitempty"`
+
+	// DryRun indicates the profile does not mutate the Pods but records the would-be patch
+	// in the Pod annotation config.koordinator.sh/colocation-profile-dry-run and the metrics.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
 }
 
 // ClusterColocationProfileStatus represents information about the status of a ClusterColocationProfile.
 type ClusterColocationProfileStatus struct {
+	// ObservedGeneration is the most recent generation of the profile observed by the webhook.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// MutatedPods is the number of Pods mutated by the profile in the last statistic window.
+	// +optional
+	MutatedPods int64 `json:"mutatedPods,omitempty"`
+	// DryRunPods is the number of Pods recorded by the profile in dry-run mode in the last statistic window.
+	// +optional
+	DryRunPods int64 `json:"dryRunPods,omitempty"`
+	// LastMutationTime is the last time the profile mutated a Pod or recorded a dry-run patch.
+	// +optional
+	LastMutationTime *metav1.Time `json:"lastMutationTime,omitempty"`
+	// LastError is the last error occurred when applying the profile.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+	// Replicas are the statistics reported by each webhook replica, keyed by the replica name.
+	// They are aggregated into the fields above by the leader replica.
+	// +optional
+	Replicas map[string]ColocationProfileReplicaStatus `json:"replicas,omitempty"`
+}
+
+// ColocationProfileReplicaStatus represents the statistics of a ClusterColocationProfile reported by a webhook replica.
+type ColocationProfileReplicaStatus struct {
+	// MutatedPods is the number of Pods mutated by the profile on the replica in the last statistic window.
+	// +optional
+	MutatedPods int64 `json:"mutatedPods,omitempty"`
+	// DryRunPods is the number of Pods recorded by the profile in dry-run mode on the replica in the last statistic window.
+	// +optional
+	DryRunPods int64 `json:"dryRunPods,omitempty"`
+	// LastMutationTime is the last time the profile mutated a Pod or recorded a dry-run patch on the replica.
+	// +optional
+	LastMutationTime *metav1.Time `json:"lastMutationTime,omitempty"`
+	// LastError is the last error occurred when applying the profile on the replica.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+	// UpdateTime is the time the replica reported the statistics.
+	UpdateTime metav1.Time `json:"updateTime"`
 }
 
 // +genclient
@@ -33,7 +33,7 @@ func (in *ClusterColocationProfile) DeepCopyInto(out *ClusterColocationProfile)
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterColocationProfile.
@@ -139,6 +139,17 @@ func (in *ClusterColocationProfileSpec) DeepCopy() *ClusterColocationProfileSpec
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterColocationProfileStatus) DeepCopyInto(out *ClusterColocationProfileStatus) {
 	*out = *in
+	if in.LastMutationTime != nil {
+		in, out := &in.LastMutationTime, &out.LastMutationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = make(map[string]ColocationProfileReplicaStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterColocationProfileStatus.
@@ -150,3 +161,23 @@ func (in *ClusterColocationProfileStatus) DeepCopy() *ClusterColocationProfileSt
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ColocationProfileReplicaStatus) DeepCopyInto(out *ColocationProfileReplicaStatus) {
+	*out = *in
+	if in.LastMutationTime != nil {
+		in, out := &in.LastMutationTime, &out.LastMutationTime
+		*out = (*in).DeepCopy()
+	}
+	in.UpdateTime.DeepCopyInto(&out.UpdateTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ColocationProfileReplicaStatus.
+func (in *ColocationProfileReplicaStatus) DeepCopy() *ColocationProfileReplicaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ColocationProfileReplicaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
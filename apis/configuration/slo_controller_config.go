@@ -30,6 +30,8 @@ const (
 	ResourceQOSConfigKey       = "resource-qos-config"
 	CPUBurstConfigKey          = "cpu-burst-config"
 	SystemConfigKey            = "system-config"
+	PodStrategyConfigKey       = "pod-strategy-config"
+	RolloutConfigKey           = "rollout-config"
 )
 
 // +k8s:deepcopy-gen=true
@@ -88,6 +90,41 @@ type SystemCfg struct {
 	NodeStrategies  []NodeSystemStrategy        `json:"nodeStrategies,omitempty" validate:"dive"`
 }
 
+// +k8s:deepcopy-gen=true
+type PodStrategyCfg struct {
+	// PodStrategies override the node-level strategies for the pods selected by namespaces and labels.
+	// They are propagated to every NodeSLO and the first matched strategy takes effect for a pod.
+	PodStrategies []slov1alpha1.PodStrategy `json:"podStrategies,omitempty" validate:"dive"`
+}
+
+// RolloutCfg configures the staged rollout of the slo-controller config changes to the NodeSLOs.
+// A new config generation is applied to the canary nodes first, and then to the percentages of nodes in the steps.
+// The rollout is paused automatically if any updated node reports unhealthy status in its NodeSLO.
+// +k8s:deepcopy-gen=true
+type RolloutCfg struct {
+	// Enable indicates whether the config changes are rolled out by stages. If disabled, a new config generation is
+	// applied to all nodes at once.
+	Enable *bool `json:"enable,omitempty"`
+	// CanaryNodeSelector selects the canary nodes which apply a new config generation first.
+	// A nil selector selects no canary node.
+	CanaryNodeSelector *metav1.LabelSelector `json:"canaryNodeSelector,omitempty"`
+	// StepPercents are the ascending percentages of the nodes applying the new config generation in each step after
+	// the canary step, e.g. [10, 50]. The new config generation is applied to all nodes after the last step.
+	StepPercents []int64 `json:"stepPercents,omitempty" validate:"dive,min=1,max=100"`
+	// StepIntervalSeconds is the minimum duration of each step before progressing to the next one.
+	StepIntervalSeconds *int64 `json:"stepIntervalSeconds,omitempty" validate:"omitempty,min=0"`
+	// StepTimeoutSeconds is the maximum duration to wait for the nodes of each step to apply the new config. The
+	// rollout is paused with the pending nodes if some Ready nodes have not applied it after the timeout, while the
+	// NotReady nodes are not waited. The default is 1800 seconds.
+	StepTimeoutSeconds *int64 `json:"stepTimeoutSeconds,omitempty" validate:"omitempty,min=0"`
+	// MaxPodsEvictedPerHourPerNode pauses the rollout if any updated node evicts pods at a higher rate since applying
+	// the new config. The rate is averaged over one hour at least, so the routine evictions do not add up over the
+	// long rollouts.
+	MaxPodsEvictedPerHourPerNode *int64 `json:"maxPodsEvictedPerHourPerNode,omitempty" validate:"omitempty,min=0"`
+	// MaxApplyErrorsPerNode pauses the rollout if any updated node reports more errors when applying the new config.
+	MaxApplyErrorsPerNode *int64 `json:"maxApplyErrorsPerNode,omitempty" validate:"omitempty,min=0"`
+}
+
 // +k8s:deepcopy-gen=true
 type ResourceQOSCfg struct {
 	ClusterStrategy *slov1alpha1.ResourceQOSStrategy `json:"clusterStrategy,omitempty"`
@@ -199,6 +236,8 @@ type ColocationStrategy struct {
 	MetricAggregateDurationSeconds *int64                       `json:"metricAggregateDurationSeconds,omitempty" validate:"omitempty,min=1"`
 	MetricReportIntervalSeconds    *int64                       `json:"metricReportIntervalSeconds,omitempty" validate:"omitempty,min=1"`
 	MetricAggregatePolicy          *slov1alpha1.AggregatePolicy `json:"metricAggregatePolicy,omitempty"`
+	// MetricPodPolicy opts into the container breakdowns and extra metric families of the pods in NodeMetric
+	MetricPodPolicy *slov1alpha1.PodMetricPolicy `json:"metricPodPolicy,omitempty"`
 
 	CPUReclaimThresholdPercent    *int64           `json:"cpuReclaimThresholdPercent,omitempty" validate:"omitempty,min=0,max=100"`
 	MemoryReclaimThresholdPercent *int64           `json:"memoryReclaimThresholdPercent,omitempty" validate:"omitempty,min=0,max=100"`
@@ -214,6 +253,25 @@ type ColocationStrategy struct {
 	// MidMemoryAllocatable <= NodeMemoryAllocatable * MidMemoryThresholdPercent / 100.
 	MidMemoryThresholdPercent *int64 `json:"midMemoryThresholdPercent,omitempty" validate:"omitempty,min=0,max=100"`
 
+	// BatchGPUCoreThresholdPercent defines the maximum percentage of each GPU's cores that can be used by the
+	// non-Batch pods and the Batch pods. The Batch gpu-core is not reclaimed if it is not set.
+	// BatchGPUCoreAllocatable := GPUCoreTotal * BatchGPUCoreThresholdPercent / 100 - (GPUCoreUsed - BatchGPUCoreUsed).
+	BatchGPUCoreThresholdPercent *int64 `json:"batchGPUCoreThresholdPercent,omitempty" validate:"omitempty,min=0,max=100"`
+	// BatchGPUMemoryThresholdPercent defines the maximum percentage of each GPU's memory that can be used by the
+	// non-Batch pods and the Batch pods. The Batch gpu-memory is not reclaimed if it is not set.
+	// BatchGPUMemoryAllocatable := GPUMemoryTotal * BatchGPUMemoryThresholdPercent / 100 - (GPUMemoryUsed - BatchGPUMemoryUsed).
+	BatchGPUMemoryThresholdPercent *int64 `json:"batchGPUMemoryThresholdPercent,omitempty" validate:"omitempty,min=0,max=100"`
+
+	// BatchEphemeralStorageThresholdPercent defines the maximum percentage of the node allocatable ephemeral-storage
+	// that can be used by the non-Batch pods and the Batch pods. The Batch ephemeral-storage is not reclaimed if it is
+	// not set.
+	// BatchEphemeralStorageAllocatable := NodeAllocatable * BatchEphemeralStorageThresholdPercent / 100 - (NodeUsed - BatchRequest).
+	BatchEphemeralStorageThresholdPercent *int64 `json:"batchEphemeralStorageThresholdPercent,omitempty" validate:"omitempty,min=0,max=100"`
+	// BatchHugePagesThresholdPercent defines the maximum percentage of the node allocatable hugepages of each page size
+	// that can be used by the non-Batch pods and the Batch pods. The Batch hugepages is not reclaimed if it is not set.
+	// BatchHugePagesAllocatable := NodeAllocatable * BatchHugePagesThresholdPercent / 100 - (NodeUsed - BatchRequest).
+	BatchHugePagesThresholdPercent *int64 `json:"batchHugePagesThresholdPercent,omitempty" validate:"omitempty,min=0,max=100"`
+
 	ColocationStrategyExtender `json:",inline"` // for third-party extension
 }
 
@@ -99,6 +99,11 @@ func (in *ColocationStrategy) DeepCopyInto(out *ColocationStrategy) {
 		*out = new(v1alpha1.AggregatePolicy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MetricPodPolicy != nil {
+		in, out := &in.MetricPodPolicy, &out.MetricPodPolicy
+		*out = new(v1alpha1.PodMetricPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CPUReclaimThresholdPercent != nil {
 		in, out := &in.CPUReclaimThresholdPercent, &out.CPUReclaimThresholdPercent
 		*out = new(int64)
@@ -139,6 +144,26 @@ func (in *ColocationStrategy) DeepCopyInto(out *ColocationStrategy) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.BatchGPUCoreThresholdPercent != nil {
+		in, out := &in.BatchGPUCoreThresholdPercent, &out.BatchGPUCoreThresholdPercent
+		*out = new(int64)
+		**out = **in
+	}
+	if in.BatchGPUMemoryThresholdPercent != nil {
+		in, out := &in.BatchGPUMemoryThresholdPercent, &out.BatchGPUMemoryThresholdPercent
+		*out = new(int64)
+		**out = **in
+	}
+	if in.BatchEphemeralStorageThresholdPercent != nil {
+		in, out := &in.BatchEphemeralStorageThresholdPercent, &out.BatchEphemeralStorageThresholdPercent
+		*out = new(int64)
+		**out = **in
+	}
+	if in.BatchHugePagesThresholdPercent != nil {
+		in, out := &in.BatchHugePagesThresholdPercent, &out.BatchHugePagesThresholdPercent
+		*out = new(int64)
+		**out = **in
+	}
 	in.ColocationStrategyExtender.DeepCopyInto(&out.ColocationStrategyExtender)
 }
 
@@ -311,6 +336,28 @@ func (in *NodeSystemStrategy) DeepCopy() *NodeSystemStrategy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodStrategyCfg) DeepCopyInto(out *PodStrategyCfg) {
+	*out = *in
+	if in.PodStrategies != nil {
+		in, out := &in.PodStrategies, &out.PodStrategies
+		*out = make([]v1alpha1.PodStrategy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodStrategyCfg.
+func (in *PodStrategyCfg) DeepCopy() *PodStrategyCfg {
+	if in == nil {
+		return nil
+	}
+	out := new(PodStrategyCfg)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceQOSCfg) DeepCopyInto(out *ResourceQOSCfg) {
 	*out = *in
@@ -365,6 +412,56 @@ func (in *ResourceThresholdCfg) DeepCopy() *ResourceThresholdCfg {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutCfg) DeepCopyInto(out *RolloutCfg) {
+	*out = *in
+	if in.Enable != nil {
+		in, out := &in.Enable, &out.Enable
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CanaryNodeSelector != nil {
+		in, out := &in.CanaryNodeSelector, &out.CanaryNodeSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StepPercents != nil {
+		in, out := &in.StepPercents, &out.StepPercents
+		*out = make([]int64, len(*in))
+		copy(*out, *in)
+	}
+	if in.StepIntervalSeconds != nil {
+		in, out := &in.StepIntervalSeconds, &out.StepIntervalSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.StepTimeoutSeconds != nil {
+		in, out := &in.StepTimeoutSeconds, &out.StepTimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxPodsEvictedPerHourPerNode != nil {
+		in, out := &in.MaxPodsEvictedPerHourPerNode, &out.MaxPodsEvictedPerHourPerNode
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxApplyErrorsPerNode != nil {
+		in, out := &in.MaxApplyErrorsPerNode, &out.MaxApplyErrorsPerNode
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutCfg.
+func (in *RolloutCfg) DeepCopy() *RolloutCfg {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutCfg)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SystemCfg) DeepCopyInto(out *SystemCfg) {
 	*out = *in
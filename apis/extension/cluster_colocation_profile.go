@@ -22,6 +22,12 @@ import (
 
 const (
 	AnnotationSkipUpdateResource = "config.koordinator.sh/skip-update-resources"
+	// AnnotationColocationProfile records the ClusterColocationProfiles applied to a workload pod template,
+	// the value is a comma-separated list of profile names.
+	AnnotationColocationProfile = "config.koordinator.sh/colocation-profiles"
+	// AnnotationColocationProfileDryRun records the would-be patches of the dry-run ClusterColocationProfiles,
+	// the value is a JSON object keyed by the profile names.
+	AnnotationColocationProfileDryRun = "config.koordinator.sh/colocation-profile-dry-run"
 )
 
 func ShouldSkipUpdateResource(profile *configv1alpha1.ClusterColocationProfile) bool {
@@ -38,8 +38,30 @@ const (
 	AnnotationSharedWeight = QuotaKoordinatorPrefix + "/shared-weight"
 	AnnotationRuntime      = QuotaKoordinatorPrefix + "/runtime"
 	AnnotationRequest      = QuotaKoordinatorPrefix + "/request"
+
+	// AnnotationAdmissionPolicy indicates how the webhook handles the Pods whose requests exceed the quota's max.
+	AnnotationAdmissionPolicy = QuotaKoordinatorPrefix + "/admission-policy"
 )
 
+// QuotaAdmissionPolicy is the policy of the webhook handling the Pods whose requests exceed the quota's max.
+type QuotaAdmissionPolicy string
+
+const (
+	// QuotaAdmissionPolicyNone does not check the quota's max when the Pods are created.
+	QuotaAdmissionPolicyNone QuotaAdmissionPolicy = ""
+	// QuotaAdmissionPolicyReject rejects the Pods whose requests would exceed the max of the quota or its ancestors.
+	QuotaAdmissionPolicyReject QuotaAdmissionPolicy = "Reject"
+	// QuotaAdmissionPolicyWarn admits the Pods whose requests would exceed the max with a warning.
+	QuotaAdmissionPolicyWarn QuotaAdmissionPolicy = "Warn"
+	// QuotaAdmissionPolicyQueue admits the Pods to wait for the released quota with a warning,
+	// but rejects the Pods whose requests exceed the max even if the quota is not used.
+	QuotaAdmissionPolicyQueue QuotaAdmissionPolicy = "Queue"
+)
+
+func GetQuotaAdmissionPolicy(quota *v1alpha1.ElasticQuota) QuotaAdmissionPolicy {
+	return QuotaAdmissionPolicy(quota.Annotations[AnnotationAdmissionPolicy])
+}
+
 func GetParentQuotaName(quota *v1alpha1.ElasticQuota) string {
 	parentName := quota.Labels[LabelQuotaParent]
 	if parentName == "" {
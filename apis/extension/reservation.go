@@ -38,6 +38,10 @@ const (
 
 	// AnnotationReservationAffinity represents the constraints of Pod selection Reservation
 	AnnotationReservationAffinity = SchedulingDomainPrefix + "/reservation-affinity"
+
+	// AnnotationReservationReleaseUnused indicates the Available reservation returns the reserved but unallocated
+	// resources back to the node, while keeping the reservation bound on the node for the current owners.
+	AnnotationReservationReleaseUnused = SchedulingDomainPrefix + "/reservation-release-unused"
 )
 
 type ReservationAllocated struct {
@@ -99,6 +103,10 @@ func IsReservationAllocateOnce(r *schedulingv1alpha1.Reservation) bool {
 	return pointer.BoolDeref(r.Spec.AllocateOnce, true)
 }
 
+func IsReservationReleaseUnused(r *schedulingv1alpha1.Reservation) bool {
+	return r.Annotations[AnnotationReservationReleaseUnused] == "true"
+}
+
 func GetReservationAffinity(annotations map[string]string) (*ReservationAffinity, error) {
 	var affinity ReservationAffinity
 	if s := annotations[AnnotationReservationAffinity]; s != "" {
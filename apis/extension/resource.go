@@ -29,6 +29,16 @@ const (
 	MidCPU      corev1.ResourceName = ResourceDomainPrefix + "mid-cpu"
 	MidMemory   corev1.ResourceName = ResourceDomainPrefix + "mid-memory"
 
+	// BatchGPUCore and BatchGPUMemory are the GPU resources reclaimed from the idle GPUs for the Batch pods.
+	BatchGPUCore   corev1.ResourceName = ResourceDomainPrefix + "batch-gpu-core"
+	BatchGPUMemory corev1.ResourceName = ResourceDomainPrefix + "batch-gpu-memory"
+
+	// BatchEphemeralStorage is the local ephemeral storage reclaimed from the unused node storage for the Batch pods.
+	BatchEphemeralStorage corev1.ResourceName = ResourceDomainPrefix + "batch-ephemeral-storage"
+	// BatchHugePages2Mi and BatchHugePages1Gi are the hugepages reclaimed from the idle hugepages for the Batch pods.
+	BatchHugePages2Mi corev1.ResourceName = ResourceDomainPrefix + "batch-hugepages-2Mi"
+	BatchHugePages1Gi corev1.ResourceName = ResourceDomainPrefix + "batch-hugepages-1Gi"
+
 	ResourceNvidiaGPU      corev1.ResourceName = "nvidia.com/gpu"
 	ResourceHygonDCU       corev1.ResourceName = "dcu.com/gpu"
 	ResourceRDMA           corev1.ResourceName = DomainPrefix + "rdma"
@@ -32,6 +32,11 @@ const (
 
 	// AnnotationDeviceAllocated represents the device allocated by the pod
 	AnnotationDeviceAllocated = SchedulingDomainPrefix + "/device-allocated"
+
+	// AnnotationDeviceBatchResources represents the reclaimable resources of each device on the Device CRD.
+	// koord-manager updates it according to the device usages, and koord-scheduler allocates the Batch device
+	// resources (e.g. kubernetes.io/batch-gpu-core) only from them.
+	AnnotationDeviceBatchResources = SchedulingDomainPrefix + "/device-batch-resources"
 )
 
 // CustomUsageThresholds supports user-defined node resource utilization thresholds.
@@ -125,3 +130,53 @@ func SetDeviceAllocations(obj metav1.Object, allocations DeviceAllocations) erro
 	obj.SetAnnotations(annotations)
 	return nil
 }
+
+// DeviceBatchResources describes the reclaimable resources of each device which can be allocated to the Batch pods.
+/*
+{
+  "gpu": [
+    {
+      "minor": 0,
+      "resources": {
+        "kubernetes.io/batch-gpu-core": 60,
+        "kubernetes.io/batch-gpu-memory": "8Gi"
+      }
+    }
+  ]
+}
+*/
+type DeviceBatchResources map[schedulingv1alpha1.DeviceType][]*DeviceBatchResource
+
+type DeviceBatchResource struct {
+	Minor     int32               `json:"minor"`
+	Resources corev1.ResourceList `json:"resources"`
+}
+
+func GetDeviceBatchResources(annotations map[string]string) (DeviceBatchResources, error) {
+	batchResources := DeviceBatchResources{}
+	data, ok := annotations[AnnotationDeviceBatchResources]
+	if !ok {
+		return nil, nil
+	}
+	err := json.Unmarshal([]byte(data), &batchResources)
+	if err != nil {
+		return nil, err
+	}
+	return batchResources, nil
+}
+
+func SetDeviceBatchResources(obj metav1.Object, batchResources DeviceBatchResources) error {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	data, err := json.Marshal(batchResources)
+	if err != nil {
+		return err
+	}
+
+	annotations[AnnotationDeviceBatchResources] = string(data)
+	obj.SetAnnotations(annotations)
+	return nil
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extension
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultWorkloadProfileNamespace is the default namespace of the workload profile ConfigMap.
+	DefaultWorkloadProfileNamespace = "koordinator-system"
+	// WorkloadProfileConfigMapName is the name of the ConfigMap which stores the historical usage profiles
+	// of workloads aggregated by the slo-controller.
+	WorkloadProfileConfigMapName = "koord-workload-profile"
+	// WorkloadProfileDataKey is the data key of the workload profile ConfigMap.
+	WorkloadProfileDataKey = "profiles"
+)
+
+// WorkloadReference identifies the workload which owns a Pod, e.g. Deployment, StatefulSet or Job.
+type WorkloadReference struct {
+	Namespace string `json:"namespace"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+}
+
+func (w WorkloadReference) String() string {
+	return fmt.Sprintf("%s/%s/%s", w.Namespace, w.Kind, w.Name)
+}
+
+// WorkloadProfile describes the per-replica resource usage of a workload aggregated from historical PodMetricInfo.
+type WorkloadProfile struct {
+	WorkloadReference `json:",inline"`
+	// Usage is the per-replica resource usage indexed by the aggregation type, e.g. p95.
+	Usage map[AggregationType]corev1.ResourceList `json:"usage,omitempty"`
+	// Samples is the number of PodMetricInfo samples aggregated into the profile.
+	Samples int64 `json:"samples,omitempty"`
+	// UpdateTime is the time of the last sample aggregated into the profile.
+	UpdateTime *metav1.Time `json:"updateTime,omitempty"`
+}
+
+// GetPodWorkloadReference returns the workload which controls the pod.
+// Pods created by a Deployment are attributed to the Deployment rather than the ReplicaSet,
+// so that the profile survives rolling updates.
+func GetPodWorkloadReference(pod *corev1.Pod) *WorkloadReference {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return nil
+	}
+	ref := &WorkloadReference{
+		Namespace: pod.Namespace,
+		Kind:      owner.Kind,
+		Name:      owner.Name,
+	}
+	if owner.Kind == "ReplicaSet" {
+		if hash := pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]; hash != "" && strings.HasSuffix(owner.Name, "-"+hash) {
+			ref.Kind = "Deployment"
+			ref.Name = strings.TrimSuffix(owner.Name, "-"+hash)
+		}
+	}
+	return ref
+}
+
+func GetWorkloadProfiles(configMap *corev1.ConfigMap) ([]*WorkloadProfile, error) {
+	if configMap == nil {
+		return nil, nil
+	}
+	data, ok := configMap.Data[WorkloadProfileDataKey]
+	if !ok || data == "" {
+		return nil, nil
+	}
+	var profiles []*WorkloadProfile
+	if err := json.Unmarshal([]byte(data), &profiles); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+func SetWorkloadProfiles(configMap *corev1.ConfigMap, profiles []*WorkloadProfile) error {
+	data, err := json.Marshal(profiles)
+	if err != nil {
+		return err
+	}
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+	configMap.Data[WorkloadProfileDataKey] = string(data)
+	return nil
+}
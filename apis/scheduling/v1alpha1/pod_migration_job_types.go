@@ -165,6 +165,7 @@ const (
 	PodMigrationJobConditionReservationPodBoundReservation PodMigrationJobConditionType = "PodBoundReservation"
 	PodMigrationJobConditionBoundPodReady                  PodMigrationJobConditionType = "BoundPodReady"
 	PodMigrationJobConditionReservationBound               PodMigrationJobConditionType = "ReservationBound"
+	PodMigrationJobConditionVerified                       PodMigrationJobConditionType = "Verified"
 )
 
 // These are valid reasons of PodMigrationJob.
@@ -183,6 +184,11 @@ const (
 	PodMigrationJobReasonEvictComplete             = "EvictComplete"
 	PodMigrationJobReasonWaitForPodBindReservation = "WaitForPodBindReservation"
 	PodMigrationJobReasonWaitForBoundPodReady      = "WaitForBoundPodReady"
+	PodMigrationJobReasonWaitForVerification       = "WaitForVerification"
+	PodMigrationJobReasonVerificationFailed        = "VerificationFailed"
+	PodMigrationJobReasonVerificationSucceeded     = "VerificationSucceeded"
+	PodMigrationJobReasonWaitForArbitration        = "WaitForArbitration"
+	PodMigrationJobReasonAdmitted                  = "Admitted"
 )
 
 type PodMigrationJobConditionStatus string
@@ -120,6 +120,8 @@ type ReservationStatus struct {
 	// +optional
 	NodeName string `json:"nodeName,omitempty"`
 	// Resource reserved and allocatable for owners.
+	// The Available reservation can be resized in-place by updating the resources of `template`,
+	// and the allocatable is updated once the node has sufficient free resources.
 	// +optional
 	Allocatable corev1.ResourceList `json:"allocatable,omitempty"`
 	// Resource allocated by current owners.
@@ -168,6 +170,8 @@ type ReservationConditionType string
 const (
 	ReservationConditionScheduled ReservationConditionType = "Scheduled"
 	ReservationConditionReady     ReservationConditionType = "Ready"
+	// ReservationConditionResized indicates whether the latest in-place resize of an Available reservation succeeded.
+	ReservationConditionResized ReservationConditionType = "Resized"
 )
 
 type ConditionStatus string
@@ -185,6 +189,9 @@ const (
 	ReasonReservationAvailable = "Available"
 	ReasonReservationSucceeded = "Succeeded"
 	ReasonReservationExpired   = "Expired"
+
+	ReasonReservationResized      = "Resized"
+	ReasonReservationResizeFailed = "ResizeFailed"
 )
 
 type ReservationCondition struct {
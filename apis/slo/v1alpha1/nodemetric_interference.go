@@ -0,0 +1,46 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// PodInterferenceMetric is the interference signals of a Pod averaged in the aggregation duration of NodeMetric.
+// It is also used for the interference signals of a container. The signals not reported are nil.
+type PodInterferenceMetric struct {
+	// PSI is the pressure stall information of the Pod cgroup.
+	PSI *PodPSIMetric `json:"psi,omitempty"`
+	// CPI is the cycles per instruction of the containers of the Pod.
+	CPI *resource.Quantity `json:"cpi,omitempty"`
+	// CPUThrottledRatio is the ratio of the throttled cpu periods of the Pod cgroup, ranges from 0 to 1.
+	CPUThrottledRatio *resource.Quantity `json:"cpuThrottledRatio,omitempty"`
+}
+
+// PodPSIMetric is the percentages of the time the tasks of the Pod stalled on the resources in the last 10 seconds.
+type PodPSIMetric struct {
+	CPU    PSIStats `json:"cpu,omitempty"`
+	Memory PSIStats `json:"memory,omitempty"`
+	IO     PSIStats `json:"io,omitempty"`
+}
+
+type PSIStats struct {
+	// Some is the percentage of the time that at least one task stalled on the resource.
+	Some *resource.Quantity `json:"some,omitempty"`
+	// Full is the percentage of the time that all the non-idle tasks stalled on the resource simultaneously.
+	Full *resource.Quantity `json:"full,omitempty"`
+}
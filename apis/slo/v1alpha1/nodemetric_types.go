@@ -45,10 +45,26 @@ type PodMetricInfo struct {
 	Name      string      `json:"name,omitempty"`
 	Namespace string      `json:"namespace,omitempty"`
 	PodUsage  ResourceMap `json:"podUsage,omitempty"`
+	// AggregatedPodUsages are the aggregated usages of the pod in the durations of the NodeAggregatePolicy,
+	// reported only if enabled by the PodMetricPolicy
+	AggregatedPodUsages []AggregatedUsage `json:"aggregatedPodUsages,omitempty"`
+	// ContainersMetric is the metrics of the containers, reported only if enabled by the PodMetricPolicy
+	ContainersMetric []*ContainerMetricInfo `json:"containersMetric,omitempty"`
+	// Interference is the interference signals of the pod, reported only if enabled by the PodMetricPolicy
+	Interference *PodInterferenceMetric `json:"interference,omitempty"`
 	// Third party extensions for PodMetric
 	Extensions *ExtensionsMap `json:"extensions,omitempty"`
 }
 
+type ContainerMetricInfo struct {
+	Name           string      `json:"name,omitempty"`
+	ContainerUsage ResourceMap `json:"containerUsage,omitempty"`
+	// Interference is the interference signals of the container, reported only if enabled by the PodMetricPolicy
+	Interference *PodInterferenceMetric `json:"interference,omitempty"`
+	// Third party extensions for ContainerMetric
+	Extensions *ExtensionsMap `json:"extensions,omitempty"`
+}
+
 // NodeMetricSpec defines the desired state of NodeMetric
 type NodeMetricSpec struct {
 	// CollectPolicy defines the Metric collection policy
@@ -63,6 +79,38 @@ type NodeMetricCollectPolicy struct {
 	ReportIntervalSeconds *int64 `json:"reportIntervalSeconds,omitempty"`
 	// NodeAggregatePolicy represents the target grain of node aggregated usage
 	NodeAggregatePolicy *AggregatePolicy `json:"nodeAggregatePolicy,omitempty"`
+	// PodMetricPolicy represents the optional pod metrics to report, e.g. container breakdowns and extra metric families
+	PodMetricPolicy *PodMetricPolicy `json:"podMetricPolicy,omitempty"`
+}
+
+type PodMetricFamily string
+
+const (
+	// PodMetricFamilyCPUThrottled is the ratio of the throttled cpu periods.
+	PodMetricFamilyCPUThrottled PodMetricFamily = "CPUThrottled"
+	// PodMetricFamilyPSI is the pressure stall information of cpu, memory and io.
+	PodMetricFamilyPSI PodMetricFamily = "PSI"
+	// PodMetricFamilyCPI is the cycles per instruction.
+	PodMetricFamilyCPI PodMetricFamily = "CPI"
+	// PodMetricFamilyGPU is the gpu usage of the containers. The gpu usage of the pods is always reported.
+	PodMetricFamilyGPU PodMetricFamily = "GPU"
+)
+
+// PodMetricPolicy defines the optional pod metrics to report. Since all pods of the node are reported in one NodeMetric,
+// the reported pods and containers are limited to bound the object size.
+type PodMetricPolicy struct {
+	// EnableContainerMetrics indicates whether to report the metrics of each container
+	EnableContainerMetrics *bool `json:"enableContainerMetrics,omitempty"`
+	// EnableAggregatedUsages indicates whether to report the aggregated usages of all pods in the durations of the
+	// NodeAggregatePolicy, so that the pod usages can be compared with the aggregated node usages
+	EnableAggregatedUsages *bool `json:"enableAggregatedUsages,omitempty"`
+	// MetricFamilies are the extra metric families to report for the pods and the containers
+	MetricFamilies []PodMetricFamily `json:"metricFamilies,omitempty" validate:"dive,oneof=CPUThrottled PSI CPI GPU"`
+	// MaxPods limits the number of pods reported with the container metrics and extra metric families,
+	// the pods with higher cpu usage are preferred
+	MaxPods *int64 `json:"maxPods,omitempty" validate:"omitempty,min=0"`
+	// MaxContainersPerPod limits the number of containers reported for each pod
+	MaxContainersPerPod *int64 `json:"maxContainersPerPod,omitempty" validate:"omitempty,min=0"`
 }
 
 type AggregatePolicy struct {
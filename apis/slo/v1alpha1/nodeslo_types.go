@@ -18,6 +18,14 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
+)
+
+const (
+	// AnnotationNodeSLOConfigGeneration is the generation of the slo-controller config which the NodeSLO spec is
+	// generated from. It is set by the slo-controller and reported back in the status by the koordlet.
+	AnnotationNodeSLOConfigGeneration = apiext.DomainPrefix + "slo-config-generation"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -305,6 +313,22 @@ type SystemStrategy struct {
 	MemcgReapBackGround *int64 `json:"memcgReapBackGround,omitempty" validate:"omitempty,min=0,max=1"`
 }
 
+// PodStrategy overrides the node-level qos strategies for the pods matching the namespaces and the pod selector.
+type PodStrategy struct {
+	// Name is the identifier of the strategy, which should be unique in a NodeSLO.
+	Name string `json:"name" validate:"required"`
+	// Namespaces selects the pods by namespace. Empty means all namespaces.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// PodSelector selects the pods by labels. Nil means all pods in the namespaces.
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+	// CPUQOS overrides the cpu qos config of the node-level ResourceQOSStrategy.
+	CPUQOS *CPUQOS `json:"cpuQOS,omitempty" validate:"omitempty"`
+	// MemoryQOS overrides the memory qos config of the node-level ResourceQOSStrategy.
+	MemoryQOS *PodMemoryQOSConfig `json:"memoryQOS,omitempty" validate:"omitempty"`
+	// CPUBurst overrides the node-level CPUBurstStrategy.
+	CPUBurst *CPUBurstConfig `json:"cpuBurst,omitempty" validate:"omitempty"`
+}
+
 // NodeSLOSpec defines the desired state of NodeSLO
 type NodeSLOSpec struct {
 	// BE pods will be limited if node resource usage overload
@@ -317,12 +341,24 @@ type NodeSLOSpec struct {
 	SystemStrategy *SystemStrategy `json:"systemStrategy,omitempty"`
 	// Third party extensions for NodeSLO
 	Extensions *ExtensionsMap `json:"extensions,omitempty"`
+	// Pod-level strategies which override the node-level strategies for the matched pods.
+	// The first matched strategy takes effect.
+	PodStrategies []PodStrategy `json:"podStrategies,omitempty"`
 }
 
 // NodeSLOStatus defines the observed state of NodeSLO
 type NodeSLOStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	// ConfigGeneration is the generation of the slo-controller config which the koordlet has applied.
+	ConfigGeneration string `json:"configGeneration,omitempty"`
+	// ConfigGenerationTime is the time when the koordlet applied the config generation.
+	ConfigGenerationTime *metav1.Time `json:"configGenerationTime,omitempty"`
+	// PodsEvicted is the number of pods evicted by the koordlet since the config generation was applied.
+	PodsEvicted int64 `json:"podsEvicted"`
+	// ApplyErrors is the number of errors the koordlet met when applying the NodeSLO since the config generation
+	// was applied.
+	ApplyErrors int64 `json:"applyErrors"`
+	// UpdateTime is the last time the status was reported by the koordlet.
+	UpdateTime *metav1.Time `json:"updateTime,omitempty"`
 }
 
 // +genclient
@@ -227,6 +227,31 @@ func (in *CPUQOSCfg) DeepCopy() *CPUQOSCfg {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerMetricInfo) DeepCopyInto(out *ContainerMetricInfo) {
+	*out = *in
+	in.ContainerUsage.DeepCopyInto(&out.ContainerUsage)
+	if in.Interference != nil {
+		in, out := &in.Interference, &out.Interference
+		*out = new(PodInterferenceMetric)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerMetricInfo.
+func (in *ContainerMetricInfo) DeepCopy() *ContainerMetricInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerMetricInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IOCfg) DeepCopyInto(out *IOCfg) {
 	*out = *in
@@ -403,6 +428,11 @@ func (in *NodeMetricCollectPolicy) DeepCopyInto(out *NodeMetricCollectPolicy) {
 		*out = new(AggregatePolicy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PodMetricPolicy != nil {
+		in, out := &in.PodMetricPolicy, &out.PodMetricPolicy
+		*out = new(PodMetricPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeMetricCollectPolicy.
@@ -544,7 +574,7 @@ func (in *NodeSLO) DeepCopyInto(out *NodeSLO) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSLO.
@@ -639,6 +669,14 @@ func (in *NodeSLOSpec) DeepCopy() *NodeSLOSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeSLOStatus) DeepCopyInto(out *NodeSLOStatus) {
 	*out = *in
+	if in.ConfigGenerationTime != nil {
+		in, out := &in.ConfigGenerationTime, &out.ConfigGenerationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.UpdateTime != nil {
+		in, out := &in.UpdateTime, &out.UpdateTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSLOStatus.
@@ -651,6 +689,61 @@ func (in *NodeSLOStatus) DeepCopy() *NodeSLOStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PSIStats) DeepCopyInto(out *PSIStats) {
+	*out = *in
+	if in.Some != nil {
+		in, out := &in.Some, &out.Some
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Full != nil {
+		in, out := &in.Full, &out.Full
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PSIStats.
+func (in *PSIStats) DeepCopy() *PSIStats {
+	if in == nil {
+		return nil
+	}
+	out := new(PSIStats)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodInterferenceMetric) DeepCopyInto(out *PodInterferenceMetric) {
+	*out = *in
+	if in.PSI != nil {
+		in, out := &in.PSI, &out.PSI
+		*out = new(PodPSIMetric)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CPI != nil {
+		in, out := &in.CPI, &out.CPI
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.CPUThrottledRatio != nil {
+		in, out := &in.CPUThrottledRatio, &out.CPUThrottledRatio
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodInterferenceMetric.
+func (in *PodInterferenceMetric) DeepCopy() *PodInterferenceMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(PodInterferenceMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodMemoryQOSConfig) DeepCopyInto(out *PodMemoryQOSConfig) {
 	*out = *in
@@ -671,6 +764,29 @@ func (in *PodMemoryQOSConfig) DeepCopy() *PodMemoryQOSConfig {
 func (in *PodMetricInfo) DeepCopyInto(out *PodMetricInfo) {
 	*out = *in
 	in.PodUsage.DeepCopyInto(&out.PodUsage)
+	if in.AggregatedPodUsages != nil {
+		in, out := &in.AggregatedPodUsages, &out.AggregatedPodUsages
+		*out = make([]AggregatedUsage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ContainersMetric != nil {
+		in, out := &in.ContainersMetric, &out.ContainersMetric
+		*out = make([]*ContainerMetricInfo, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(ContainerMetricInfo)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Interference != nil {
+		in, out := &in.Interference, &out.Interference
+		*out = new(PodInterferenceMetric)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Extensions != nil {
 		in, out := &in.Extensions, &out.Extensions
 		*out = (*in).DeepCopy()
@@ -687,6 +803,104 @@ func (in *PodMetricInfo) DeepCopy() *PodMetricInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodMetricPolicy) DeepCopyInto(out *PodMetricPolicy) {
+	*out = *in
+	if in.EnableContainerMetrics != nil {
+		in, out := &in.EnableContainerMetrics, &out.EnableContainerMetrics
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableAggregatedUsages != nil {
+		in, out := &in.EnableAggregatedUsages, &out.EnableAggregatedUsages
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MetricFamilies != nil {
+		in, out := &in.MetricFamilies, &out.MetricFamilies
+		*out = make([]PodMetricFamily, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxPods != nil {
+		in, out := &in.MaxPods, &out.MaxPods
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxContainersPerPod != nil {
+		in, out := &in.MaxContainersPerPod, &out.MaxContainersPerPod
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodMetricPolicy.
+func (in *PodMetricPolicy) DeepCopy() *PodMetricPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PodMetricPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodPSIMetric) DeepCopyInto(out *PodPSIMetric) {
+	*out = *in
+	in.CPU.DeepCopyInto(&out.CPU)
+	in.Memory.DeepCopyInto(&out.Memory)
+	in.IO.DeepCopyInto(&out.IO)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodPSIMetric.
+func (in *PodPSIMetric) DeepCopy() *PodPSIMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(PodPSIMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodStrategy) DeepCopyInto(out *PodStrategy) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodSelector != nil {
+		in, out := &in.PodSelector, &out.PodSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CPUQOS != nil {
+		in, out := &in.CPUQOS, &out.CPUQOS
+		*out = new(CPUQOS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MemoryQOS != nil {
+		in, out := &in.MemoryQOS, &out.MemoryQOS
+		*out = new(PodMemoryQOSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CPUBurst != nil {
+		in, out := &in.CPUBurst, &out.CPUBurst
+		*out = new(CPUBurstConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodStrategy.
+func (in *PodStrategy) DeepCopy() *PodStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(PodStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReclaimableMetric) DeepCopyInto(out *ReclaimableMetric) {
 	*out = *in
@@ -26,6 +26,7 @@ import (
 	"k8s.io/client-go/tools/leaderelection"
 	ctrl "sigs.k8s.io/controller-runtime"
 
+	koordinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
 	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
 )
 
@@ -46,6 +47,8 @@ type Config struct {
 	KubeConfig         *restclient.Config
 	InformerFactory    informers.SharedInformerFactory
 	DynInformerFactory dynamicinformer.DynamicSharedInformerFactory
+	// KoordInformerFactory is the informer factory of koordinator resources shared by the plugins.
+	KoordInformerFactory koordinformers.SharedInformerFactory
 
 	// nolint:staticcheck // SA1019 this deprecated field still needs to be used for now. It will be removed once the migration is done.
 	EventBroadcaster events.EventBroadcasterAdapter
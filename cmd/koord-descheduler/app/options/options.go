@@ -24,6 +24,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/uuid"
 	apiserveroptions "k8s.io/apiserver/pkg/server/options"
 	"k8s.io/client-go/dynamic"
@@ -44,6 +45,8 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	deschedulerappconfig "github.com/koordinator-sh/koordinator/cmd/koord-descheduler/app/config"
+	koordclientset "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned"
+	koordinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
 	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
 	deschedulerscheme "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config/scheme"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config/v1alpha2"
@@ -283,6 +286,16 @@ func (o *Options) Config() (*deschedulerappconfig.Config, error) {
 	c.InformerFactory = informers.NewSharedInformerFactory(mgr, 0)
 	dynClient := dynamic.NewForConfigOrDie(kubeConfig)
 	c.DynInformerFactory = dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynClient, 0, corev1.NamespaceAll, nil)
+
+	// koordinator CRDs don't support protobuf
+	koordKubeConfig := *kubeConfig
+	koordKubeConfig.ContentType = runtime.ContentTypeJSON
+	koordKubeConfig.AcceptContentTypes = runtime.ContentTypeJSON
+	koordClient, err := koordclientset.NewForConfig(&koordKubeConfig)
+	if err != nil {
+		return nil, err
+	}
+	c.KoordInformerFactory = koordinformers.NewSharedInformerFactory(koordClient, 0)
 	c.LeaderElection = leaderElectionConfig
 
 	return c, nil
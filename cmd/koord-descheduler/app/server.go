@@ -60,6 +60,7 @@ import (
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/evictions"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/fieldindex"
 	frameworkruntime "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/runtime"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/utils/report"
 	"github.com/koordinator-sh/koordinator/pkg/util/transformer"
 )
 
@@ -222,6 +223,11 @@ func StartDescheduler(ctx context.Context, cc *deschedulerappconfig.CompletedCon
 		cc.DynInformerFactory.WaitForCacheSync(ctx.Done())
 	}
 
+	if cc.KoordInformerFactory != nil {
+		cc.KoordInformerFactory.Start(ctx.Done())
+		cc.KoordInformerFactory.WaitForCacheSync(ctx.Done())
+	}
+
 	cc.Manager.Start(ctx)
 }
 
@@ -246,6 +252,7 @@ func newHealthzAndMetricsHandler(config *deschedulerconfig.DeschedulerConfigurat
 	pathRecorderMux := mux.NewPathRecorderMux("koord-descheduler")
 	healthz.InstallHandler(pathRecorderMux, checks...)
 	installMetricHandler(pathRecorderMux)
+	report.InstallHandler(pathRecorderMux)
 	if config.EnableProfiling {
 		routes.Profiling{}.Install(pathRecorderMux)
 		if config.EnableContentionProfiling {
@@ -314,6 +321,7 @@ func Setup(ctx context.Context, opts *options.Options, outOfTreeRegistryOptions
 		descheduler.WithDeschedulingInterval(cc.ComponentConfig.DeschedulingInterval.Duration),
 		descheduler.WithNodeSelector(cc.ComponentConfig.NodeSelector),
 		descheduler.WithEvictionLimiter(evictionLimiter),
+		descheduler.WithKoordinatorSharedInformerFactory(cc.KoordInformerFactory),
 		descheduler.WithPodAssignedToNodeFn(podAssignedToNode(cc.Manager.GetClient())),
 		descheduler.WithBuildFrameworkCapturer(func(profile deschedulerconfig.DeschedulerProfile) {
 			completedProfiles = append(completedProfiles, profile)
@@ -18,16 +18,52 @@ package extensions
 
 import (
 	"context"
+	"flag"
+	"net/http"
 
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
-// for third-party extensions
-func PrepareExtensions(restConfig *rest.Config, mgr ctrl.Manager) {
+// Extension is a third-party extension compiled into the koord-manager, e.g. a controller, a webhook, or the plugins
+// of the noderesource and nodeslo controllers. An extension registers itself into the DefaultRegistry in the init()
+// of its package, and the koord-manager imports the package to compile it in.
+type Extension interface {
+	// Name is the unique name of the extension, which is used to enable or disable it by the `--extensions` flag.
+	Name() string
+	// Setup adds the extension to the manager before the manager starts. It is called before the built-in controllers
+	// are added, so the plugins of the noderesource and nodeslo controllers registered in Setup are set up by the
+	// controllers as the built-in plugins.
+	Setup(restConfig *rest.Config, mgr ctrl.Manager) error
+}
+
+// FlagsInitializer is implemented by the extensions which have their own command line flags.
+type FlagsInitializer interface {
+	InitFlags(fs *flag.FlagSet)
+}
+
+// Starter is implemented by the extensions which need to do something when the manager is starting.
+// Start must not block.
+type Starter interface {
+	Start(ctx context.Context) error
+}
+
+// HealthChecker is implemented by the extensions which report their health in the healthz endpoint of the manager.
+type HealthChecker interface {
+	HealthCheck(req *http.Request) error
+}
 
+// InitFlags adds the flags of the DefaultRegistry and the registered extensions.
+func InitFlags(fs *flag.FlagSet) {
+	DefaultRegistry.InitFlags(fs)
 }
 
-func StartExtensions(ctx context.Context, mgr ctrl.Manager) {
+// PrepareExtensions sets up the enabled extensions of the DefaultRegistry.
+func PrepareExtensions(restConfig *rest.Config, mgr ctrl.Manager) error {
+	return DefaultRegistry.Prepare(restConfig, mgr)
+}
 
+// StartExtensions starts the prepared extensions of the DefaultRegistry.
+func StartExtensions(ctx context.Context, mgr ctrl.Manager) error {
+	return DefaultRegistry.Start(ctx)
 }
@@ -0,0 +1,81 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extensions
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	ExtensionSubsystem = "koord_manager_extension"
+
+	ExtensionKey = "extension"
+	PhaseKey     = "phase"
+
+	PhaseSetup = "setup"
+	PhaseStart = "start"
+)
+
+func init() {
+	metrics.Registry.MustRegister(ExtensionCollectors...)
+}
+
+var (
+	ExtensionEnabled = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: ExtensionSubsystem,
+		Name:      "enabled",
+		Help:      "whether the extension is enabled, 1 for enabled and 0 for disabled",
+	}, []string{ExtensionKey})
+
+	ExtensionHealthy = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: ExtensionSubsystem,
+		Name:      "healthy",
+		Help:      "the result of the latest health check of the extension, 1 for healthy and 0 for unhealthy",
+	}, []string{ExtensionKey})
+
+	ExtensionErrorCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: ExtensionSubsystem,
+		Name:      "error_count",
+		Help:      "the count of the errors of the extension in each phase",
+	}, []string{ExtensionKey, PhaseKey})
+
+	ExtensionCollectors = []prometheus.Collector{
+		ExtensionEnabled,
+		ExtensionHealthy,
+		ExtensionErrorCount,
+	}
+)
+
+func recordExtensionEnabled(name string, enabled bool) {
+	ExtensionEnabled.With(prometheus.Labels{ExtensionKey: name}).Set(boolToFloat64(enabled))
+}
+
+func recordExtensionHealthy(name string, healthy bool) {
+	ExtensionHealthy.With(prometheus.Labels{ExtensionKey: name}).Set(boolToFloat64(healthy))
+}
+
+func recordExtensionError(name string, phase string) {
+	ExtensionErrorCount.With(prometheus.Labels{ExtensionKey: name, PhaseKey: phase}).Inc()
+}
+
+func boolToFloat64(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extensions
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/component-base/featuregate"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	utilfeature "github.com/koordinator-sh/koordinator/pkg/util/feature"
+)
+
+// DefaultRegistry is the registry of the extensions compiled into the koord-manager.
+var DefaultRegistry = NewRegistry(utilfeature.DefaultMutableFeatureGate)
+
+// Register adds the extension into the DefaultRegistry.
+func Register(extension Extension, opts ...RegisterOption) error {
+	return DefaultRegistry.Register(extension, opts...)
+}
+
+// MustRegister adds the extension into the DefaultRegistry and panics if failed.
+func MustRegister(extension Extension, opts ...RegisterOption) {
+	if err := Register(extension, opts...); err != nil {
+		panic(err)
+	}
+}
+
+type RegisterOption func(r *registration)
+
+// WithFeatureGate makes the extension enabled only if the feature is enabled. The feature should be added into
+// the feature gate of the koord-manager before the flags are parsed.
+func WithFeatureGate(feature featuregate.Feature) RegisterOption {
+	return func(r *registration) {
+		r.feature = feature
+	}
+}
+
+type registration struct {
+	extension Extension
+	feature   featuregate.Feature
+}
+
+type Registry struct {
+	lock          sync.RWMutex
+	featureGate   featuregate.MutableFeatureGate
+	registrations map[string]*registration
+	// Extensions is the list of the extensions to enable, which has the same format as the `--controllers` flag.
+	Extensions []string
+	prepared   []Extension
+}
+
+func NewRegistry(featureGate featuregate.MutableFeatureGate) *Registry {
+	return &Registry{
+		featureGate:   featureGate,
+		registrations: map[string]*registration{},
+		Extensions:    []string{"*"},
+	}
+}
+
+func (r *Registry) Register(extension Extension, opts ...RegisterOption) error {
+	if extension == nil || extension.Name() == "" {
+		return fmt.Errorf("extension name must not be empty")
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, exist := r.registrations[extension.Name()]; exist {
+		return fmt.Errorf("extension %s already exists", extension.Name())
+	}
+	reg := &registration{extension: extension}
+	for _, opt := range opts {
+		opt(reg)
+	}
+	r.registrations[extension.Name()] = reg
+	klog.V(4).Infof("extension %s registered", extension.Name())
+	return nil
+}
+
+// Names returns the sorted names of the registered extensions.
+func (r *Registry) Names() []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.sortedNames()
+}
+
+func (r *Registry) InitFlags(fs *flag.FlagSet) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	fs.Var(&stringSliceValue{value: &r.Extensions}, "extensions", fmt.Sprintf("A list of extensions to enable. "+
+		"'-extensions=*' enables all extensions. "+
+		"'-extensions=foo' means only the 'foo' extension is enabled. "+
+		"'-extensions=*,-foo' means all extensions except the 'foo' extension are enabled.\n"+
+		"All extensions: %s", strings.Join(r.sortedNames(), ", ")))
+	for _, name := range r.sortedNames() {
+		if initializer, ok := r.registrations[name].extension.(FlagsInitializer); ok {
+			initializer.InitFlags(fs)
+		}
+	}
+}
+
+// Prepare sets up the enabled extensions with the manager in the order of their names.
+// An extension is enabled if it is enabled by the `--extensions` flag and its feature gate.
+// It must be called before the built-in controllers are added to the manager, so that the plugins registered by the
+// extensions are set up together with the built-in plugins by the controllers.
+func (r *Registry) Prepare(restConfig *rest.Config, mgr ctrl.Manager) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, name := range r.sortedNames() {
+		reg := r.registrations[name]
+		if !r.isEnabled(reg) {
+			klog.Warningf("extension %q is disabled", name)
+			recordExtensionEnabled(name, false)
+			continue
+		}
+
+		if err := reg.extension.Setup(restConfig, mgr); err != nil {
+			klog.Errorf("unable to setup extension %s, err: %v", name, err)
+			recordExtensionError(name, PhaseSetup)
+			return err
+		}
+		if checker, ok := reg.extension.(HealthChecker); ok {
+			if err := mgr.AddHealthzCheck(genHealthzCheckName(name), newHealthzChecker(name, checker)); err != nil {
+				klog.Errorf("unable to add healthz check for extension %s, err: %v", name, err)
+				recordExtensionError(name, PhaseSetup)
+				return err
+			}
+		}
+		recordExtensionEnabled(name, true)
+		r.prepared = append(r.prepared, reg.extension)
+		klog.V(4).Infof("extension %q added", name)
+	}
+	return nil
+}
+
+// Start starts the prepared extensions which implement the Starter.
+func (r *Registry) Start(ctx context.Context) error {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	for _, extension := range r.prepared {
+		starter, ok := extension.(Starter)
+		if !ok {
+			continue
+		}
+		if err := starter.Start(ctx); err != nil {
+			klog.Errorf("unable to start extension %s, err: %v", extension.Name(), err)
+			recordExtensionError(extension.Name(), PhaseStart)
+			return err
+		}
+		klog.V(4).Infof("extension %q started", extension.Name())
+	}
+	return nil
+}
+
+func (r *Registry) isEnabled(reg *registration) bool {
+	if !isExtensionEnabled(reg.extension.Name(), r.Extensions) {
+		return false
+	}
+	if reg.feature == "" {
+		return true
+	}
+	// an unknown feature makes the feature gate panic
+	if _, ok := r.featureGate.GetAll()[reg.feature]; !ok {
+		klog.Warningf("feature %s of extension %s is not added into the feature gate", reg.feature, reg.extension.Name())
+		return false
+	}
+	return r.featureGate.Enabled(reg.feature)
+}
+
+func (r *Registry) sortedNames() []string {
+	names := make([]string, 0, len(r.registrations))
+	for name := range r.registrations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func isExtensionEnabled(name string, extensions []string) bool {
+	hasStar := false
+	for _, e := range extensions {
+		if e == name {
+			return true
+		}
+		if e == "-"+name {
+			return false
+		}
+		if e == "*" {
+			hasStar = true
+		}
+	}
+	return hasStar
+}
+
+func genHealthzCheckName(name string) string {
+	return "extension-" + name
+}
+
+func newHealthzChecker(name string, checker HealthChecker) func(req *http.Request) error {
+	return func(req *http.Request) error {
+		err := checker.HealthCheck(req)
+		recordExtensionHealthy(name, err == nil)
+		return err
+	}
+}
+
+// stringSliceValue is a comma-separated list flag value.
+type stringSliceValue struct {
+	value *[]string
+}
+
+func (v *stringSliceValue) String() string {
+	if v.value == nil {
+		return ""
+	}
+	return strings.Join(*v.value, ",")
+}
+
+func (v *stringSliceValue) Set(s string) error {
+	*v.value = strings.Split(s, ",")
+	return nil
+}
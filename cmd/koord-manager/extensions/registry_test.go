@@ -0,0 +1,206 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extensions
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/rest"
+	"k8s.io/component-base/featuregate"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+type fakeManager struct {
+	manager.Manager
+	healthzChecks map[string]healthz.Checker
+}
+
+func (m *fakeManager) AddHealthzCheck(name string, check healthz.Checker) error {
+	if m.healthzChecks == nil {
+		m.healthzChecks = map[string]healthz.Checker{}
+	}
+	m.healthzChecks[name] = check
+	return nil
+}
+
+type fakeExtension struct {
+	name     string
+	setupErr error
+	setup    bool
+}
+
+func (e *fakeExtension) Name() string {
+	return e.name
+}
+
+func (e *fakeExtension) Setup(restConfig *rest.Config, mgr ctrl.Manager) error {
+	if e.setupErr != nil {
+		return e.setupErr
+	}
+	e.setup = true
+	return nil
+}
+
+type fakeStartableExtension struct {
+	fakeExtension
+	started   bool
+	healthErr error
+}
+
+func (e *fakeStartableExtension) Start(ctx context.Context) error {
+	e.started = true
+	return nil
+}
+
+func (e *fakeStartableExtension) HealthCheck(req *http.Request) error {
+	return e.healthErr
+}
+
+func TestRegistryRegister(t *testing.T) {
+	r := NewRegistry(featuregate.NewFeatureGate())
+	assert.NoError(t, r.Register(&fakeExtension{name: "b"}))
+	assert.NoError(t, r.Register(&fakeExtension{name: "a"}))
+	assert.Error(t, r.Register(&fakeExtension{name: "a"}))
+	assert.Error(t, r.Register(&fakeExtension{}))
+	assert.Equal(t, []string{"a", "b"}, r.Names())
+}
+
+type fakeFlagsExtension struct {
+	fakeExtension
+	value string
+}
+
+func (e *fakeFlagsExtension) InitFlags(fs *flag.FlagSet) {
+	fs.StringVar(&e.value, "fake-flag", "", "fake flag")
+}
+
+func TestRegistryInitFlags(t *testing.T) {
+	r := NewRegistry(featuregate.NewFeatureGate())
+	e := &fakeFlagsExtension{fakeExtension: fakeExtension{name: "a"}}
+	assert.NoError(t, r.Register(e))
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	r.InitFlags(fs)
+	assert.Nil(t, pflag.CommandLine.Lookup("extensions"))
+	assert.Equal(t, "*", fs.Lookup("extensions").DefValue)
+
+	assert.NoError(t, fs.Parse([]string{"--extensions=*,-b", "--fake-flag=test"}))
+	assert.Equal(t, []string{"*", "-b"}, r.Extensions)
+	assert.Equal(t, "test", e.value)
+}
+
+func TestRegistryPrepareAndStart(t *testing.T) {
+	const (
+		enabledFeature  featuregate.Feature = "EnabledExtension"
+		disabledFeature featuregate.Feature = "DisabledExtension"
+	)
+	tests := []struct {
+		name        string
+		extensions  []string
+		setupErr    error
+		wantErr     bool
+		wantSetup   []string
+		wantStarted bool
+	}{
+		{
+			name:        "enable all extensions",
+			extensions:  []string{"*"},
+			wantSetup:   []string{"a", "b"},
+			wantStarted: true,
+		},
+		{
+			name:        "enable all extensions except one",
+			extensions:  []string{"*", "-b"},
+			wantSetup:   []string{"a"},
+			wantStarted: false,
+		},
+		{
+			name:        "enable extensions by name",
+			extensions:  []string{"b", "c", "d"},
+			wantSetup:   []string{"b"},
+			wantStarted: true,
+		},
+		{
+			name:        "failed to setup extension",
+			extensions:  []string{"*"},
+			setupErr:    fmt.Errorf("expected error"),
+			wantErr:     true,
+			wantSetup:   nil,
+			wantStarted: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			featureGate := featuregate.NewFeatureGate()
+			assert.NoError(t, featureGate.Add(map[featuregate.Feature]featuregate.FeatureSpec{
+				enabledFeature:  {Default: true, PreRelease: featuregate.Alpha},
+				disabledFeature: {Default: false, PreRelease: featuregate.Alpha},
+			}))
+			extA := &fakeExtension{name: "a", setupErr: tt.setupErr}
+			extB := &fakeStartableExtension{fakeExtension: fakeExtension{name: "b"}, healthErr: fmt.Errorf("unhealthy")}
+			// extensions with a disabled or unknown feature are never enabled
+			extC := &fakeExtension{name: "c"}
+			extD := &fakeExtension{name: "d"}
+			r := NewRegistry(featureGate)
+			assert.NoError(t, r.Register(extA))
+			assert.NoError(t, r.Register(extB, WithFeatureGate(enabledFeature)))
+			assert.NoError(t, r.Register(extC, WithFeatureGate(disabledFeature)))
+			assert.NoError(t, r.Register(extD, WithFeatureGate("UnknownExtension")))
+			r.Extensions = tt.extensions
+
+			mgr := &fakeManager{}
+			err := r.Prepare(nil, mgr)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			if err == nil {
+				err = r.Start(context.TODO())
+				assert.NoError(t, err)
+			}
+
+			var gotSetup []string
+			for _, ext := range []*fakeExtension{extA, &extB.fakeExtension, extC, extD} {
+				if ext.setup {
+					gotSetup = append(gotSetup, ext.name)
+				}
+			}
+			assert.Equal(t, tt.wantSetup, gotSetup)
+			assert.Equal(t, tt.wantStarted, extB.started)
+
+			checker, ok := mgr.healthzChecks[genHealthzCheckName("b")]
+			assert.Equal(t, extB.setup, ok)
+			if ok {
+				assert.Error(t, checker(nil))
+				assert.Equal(t, float64(0), testutil.ToFloat64(ExtensionHealthy.WithLabelValues("b")))
+			}
+		})
+	}
+}
+
+func Test_isExtensionEnabled(t *testing.T) {
+	assert.True(t, isExtensionEnabled("a", []string{"*"}))
+	assert.True(t, isExtensionEnabled("a", []string{"a"}))
+	assert.False(t, isExtensionEnabled("a", []string{"b"}))
+	assert.False(t, isExtensionEnabled("a", []string{"-a", "*"}))
+	assert.False(t, isExtensionEnabled("a", nil))
+}
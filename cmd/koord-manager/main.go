@@ -74,6 +74,7 @@ func main() {
 	flag.StringVar(&syncPeriodStr, "sync-period", "", "Determines the minimum frequency at which watched resources are reconciled.")
 	opts := options.NewOptions()
 	opts.InitFlags(flag.CommandLine)
+	extensions.InitFlags(flag.CommandLine)
 	sloconfig.InitFlags(flag.CommandLine)
 	utilfeature.DefaultMutableFeatureGate.AddFlag(pflag.CommandLine)
 	klog.InitFlags(nil)
@@ -134,12 +135,16 @@ func main() {
 		os.Exit(1)
 	}
 
+	// the extensions are prepared before the controllers, since they may register the plugins of the controllers
+	if err := extensions.PrepareExtensions(cfg, mgr); err != nil {
+		setupLog.Error(err, "unable to setup extensions")
+		os.Exit(1)
+	}
+
 	if err := opts.ApplyTo(mgr); err != nil {
 		setupLog.Error(err, "unable to setup controllers")
 		os.Exit(1)
 	}
-
-	extensions.PrepareExtensions(cfg, mgr)
 	// +kubebuilder:scaffold:builder
 
 	ctx := ctrl.SetupSignalHandler()
@@ -171,7 +176,10 @@ func main() {
 	}
 
 	setupLog.Info("starting manager")
-	extensions.StartExtensions(ctx, mgr)
+	if err := extensions.StartExtensions(ctx, mgr); err != nil {
+		setupLog.Error(err, "unable to start extensions")
+		os.Exit(1)
+	}
 	if err := mgr.Start(ctx); err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
@@ -24,14 +24,17 @@ import (
 	"github.com/koordinator-sh/koordinator/pkg/slo-controller/nodemetric"
 	"github.com/koordinator-sh/koordinator/pkg/slo-controller/noderesource"
 	"github.com/koordinator-sh/koordinator/pkg/slo-controller/nodeslo"
+	"github.com/koordinator-sh/koordinator/pkg/slo-controller/workloadprofile"
 )
 
 var controllerInitFlags = map[string]func(*flag.FlagSet){
-	noderesource.Name: noderesource.InitFlags,
+	noderesource.Name:    noderesource.InitFlags,
+	workloadprofile.Name: workloadprofile.InitFlags,
 }
 
 var controllerAddFuncs = map[string]func(manager.Manager) error{
-	nodemetric.Name:   nodemetric.Add,
-	noderesource.Name: noderesource.Add,
-	nodeslo.Name:      nodeslo.Add,
+	nodemetric.Name:      nodemetric.Add,
+	noderesource.Name:    noderesource.Add,
+	nodeslo.Name:         nodeslo.Add,
+	workloadprofile.Name: workloadprofile.Add,
 }
@@ -0,0 +1,217 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cobra"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/events"
+	cliflag "k8s.io/component-base/cli/flag"
+	"k8s.io/component-base/cli/globalflag"
+	"k8s.io/component-base/logs"
+	"k8s.io/component-base/term"
+	kubeschedulerappconfig "k8s.io/kubernetes/cmd/kube-scheduler/app/config"
+	"k8s.io/kubernetes/pkg/scheduler"
+	"k8s.io/kubernetes/pkg/scheduler/apis/config/latest"
+	"sigs.k8s.io/yaml"
+
+	schedulerserverconfig "github.com/koordinator-sh/koordinator/cmd/koord-scheduler/app/config"
+	"github.com/koordinator-sh/koordinator/cmd/koord-scheduler/app/options"
+	koordinatorinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+	frameworkexthelper "github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/helper"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/services"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/replay"
+)
+
+type replayOptions struct {
+	*options.Options
+	SnapshotFiles []string
+	PodsFile      string
+	Output        string
+	Timeout       time.Duration
+}
+
+// newReplayCommand creates the command to replay the pending pods against a captured cluster snapshot offline.
+func newReplayCommand(registryOptions ...Option) *cobra.Command {
+	opts := &replayOptions{
+		Options: options.NewOptions(),
+		Output:  "yaml",
+		Timeout: 10 * time.Minute,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "replay",
+		Short: "Replay pending pods against a captured cluster snapshot offline",
+		Long: `Replay loads a captured cluster snapshot into fake clients, schedules the pending pods
+one by one in order with the configured profiles, and reports the placement of the pods,
+the fragmentation of the nodes and the usage of the ElasticQuotas. Nothing is written to
+the cluster, so the outcomes of different plugin args can be compared offline.
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := runReplay(opts, cmd.OutOrStdout(), registryOptions...); err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				os.Exit(1)
+			}
+		},
+		Args: cobra.NoArgs,
+	}
+
+	nfs := cliflag.NamedFlagSets{}
+	fs := nfs.FlagSet("replay")
+	fs.StringVar(&opts.ConfigFile, "config", opts.ConfigFile, "The path to the scheduler configuration file, the default configuration is used if empty.")
+	fs.StringSliceVar(&opts.SnapshotFiles, "snapshot", opts.SnapshotFiles, "The paths to the YAML or JSON files of the captured Nodes, Pods, NodeMetrics, Devices, ElasticQuotas and Reservations.")
+	fs.StringVar(&opts.PodsFile, "pods", opts.PodsFile, "The path to the YAML or JSON file of the pending pods, which are replayed in order.")
+	fs.StringVarP(&opts.Output, "output", "o", opts.Output, "The format of the report, yaml or json.")
+	fs.DurationVar(&opts.Timeout, "timeout", opts.Timeout, "The timeout of the replay, the pods without outcomes are reported as unschedulable.")
+	globalflag.AddGlobalFlags(nfs.FlagSet("global"), cmd.Name(), logs.SkipLoggingConfigurationFlags())
+	for _, f := range nfs.FlagSets {
+		cmd.Flags().AddFlagSet(f)
+	}
+
+	cols, _, _ := term.TerminalSize(cmd.OutOrStdout())
+	cliflag.SetUsageAndHelpFunc(cmd, nfs, cols)
+	return cmd
+}
+
+func (o *replayOptions) Validate() []error {
+	var errs []error
+	if len(o.SnapshotFiles) == 0 {
+		errs = append(errs, fmt.Errorf("--snapshot is required"))
+	}
+	if o.PodsFile == "" {
+		errs = append(errs, fmt.Errorf("--pods is required"))
+	}
+	if o.Output != "yaml" && o.Output != "json" {
+		errs = append(errs, fmt.Errorf("unsupported output format %q", o.Output))
+	}
+	return errs
+}
+
+func runReplay(opts *replayOptions, out io.Writer, registryOptions ...Option) error {
+	if errs := opts.Validate(); len(errs) > 0 {
+		return utilerrors.NewAggregate(errs)
+	}
+	if err := opts.Logs.ValidateAndApply(utilfeature.DefaultFeatureGate); err != nil {
+		return err
+	}
+
+	// the report is written to stdout, keep it clean of the debug logs of the services
+	gin.SetMode(gin.ReleaseMode)
+
+	snapshot, err := replay.LoadSnapshot(opts.SnapshotFiles...)
+	if err != nil {
+		return err
+	}
+	pods, err := replay.LoadPods(opts.PodsFile)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.Timeout)
+	defer cancel()
+
+	clients := snapshot.NewClientSets()
+	cc, sched, err := setupReplay(ctx, opts.Options, clients, registryOptions...)
+	if err != nil {
+		return err
+	}
+	replayer := replay.New(sched, clients, cc.InformerFactory.Core().V1().Pods().Lister(), ctx.Done())
+
+	cc.InformerFactory.Start(ctx.Done())
+	cc.DynInformerFactory.Start(ctx.Done())
+	cc.KoordinatorSharedInformerFactory.Start(ctx.Done())
+	cc.InformerFactory.WaitForCacheSync(ctx.Done())
+	cc.DynInformerFactory.WaitForCacheSync(ctx.Done())
+	cc.KoordinatorSharedInformerFactory.WaitForCacheSync(ctx.Done())
+	// NOTE: The controllers of the plugins are not started, e.g. the ElasticQuota status is not updated,
+	// since they would mutate the snapshot concurrently and make the outcomes unstable.
+	go sched.Run(ctx)
+
+	// the pods without the scheduler name are scheduled by the first profile as the scheduler config is applied to
+	for _, pod := range pods {
+		if pod.Spec.SchedulerName == "" && len(cc.ComponentConfig.Profiles) > 0 {
+			pod.Spec.SchedulerName = cc.ComponentConfig.Profiles[0].SchedulerName
+		}
+	}
+	report, err := replayer.Replay(ctx, pods)
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	if opts.Output == "json" {
+		data, err = json.MarshalIndent(report, "", "  ")
+	} else {
+		data, err = yaml.Marshal(report)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, string(data))
+	return err
+}
+
+// setupReplay creates a scheduler serving the snapshot from the fake clients rather than the cluster.
+func setupReplay(ctx context.Context, opts *options.Options, clients *replay.ClientSets, outOfTreeRegistryOptions ...Option) (*schedulerserverconfig.CompletedConfig, *scheduler.Scheduler, error) {
+	if cfg, err := latest.Default(); err != nil {
+		return nil, nil, err
+	} else {
+		opts.ComponentConfig = cfg
+	}
+	// the replay never serves
+	opts.SecureServing.BindPort = 0
+	if errs := opts.Validate(); len(errs) > 0 {
+		return nil, nil, utilerrors.NewAggregate(errs)
+	}
+
+	c := &kubeschedulerappconfig.Config{}
+	if err := opts.ApplyTo(c); err != nil {
+		return nil, nil, err
+	}
+	c.Client = clients.KubeClient
+	c.KubeConfig = &restclient.Config{}
+	c.EventBroadcaster = events.NewEventBroadcasterAdapter(clients.KubeClient)
+	c.InformerFactory = frameworkexthelper.NewForceSyncSharedInformerFactory(scheduler.NewInformerFactory(clients.KubeClient, 0))
+	c.DynInformerFactory = dynamicinformer.NewDynamicSharedInformerFactory(clients.DynamicClient, 0)
+
+	appConfig := &schedulerserverconfig.Config{
+		Config:                           c,
+		ServicesEngine:                   services.NewEngine(gin.New()),
+		KoordinatorClient:                clients.KoordClient,
+		KoordinatorSharedInformerFactory: koordinatorinformers.NewSharedInformerFactory(clients.KoordClient, 0),
+	}
+	extenderOptions := []frameworkext.Option{
+		frameworkext.WithPluginHandleWrapper(clients.WrapPluginHandle),
+	}
+	cc, sched, _, err := setup(ctx, opts, appConfig, extenderOptions, outOfTreeRegistryOptions...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cc, sched, nil
+}
@@ -64,6 +64,7 @@ import (
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/defaultprofile"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/eventhandlers"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/services"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/simulator"
 	utilroutes "github.com/koordinator-sh/koordinator/pkg/util/routes"
 	"github.com/koordinator-sh/koordinator/pkg/util/transformer"
 )
@@ -120,6 +121,8 @@ for cost reduction and efficiency enhancement.
 		klog.ErrorS(err, "Failed to mark flag filename")
 	}
 
+	cmd.AddCommand(newReplayCommand(registryOptions...))
+
 	return cmd
 }
 
@@ -344,6 +347,11 @@ func Setup(ctx context.Context, opts *options.Options, outOfTreeRegistryOptions
 		return nil, nil, nil, err
 	}
 
+	return setup(ctx, opts, c, nil, outOfTreeRegistryOptions...)
+}
+
+// setup creates a scheduler based on the config, extenderOptions are appended to the options of the FrameworkExtenderFactory.
+func setup(ctx context.Context, opts *options.Options, c *schedulerserverconfig.Config, extenderOptions []frameworkext.Option, outOfTreeRegistryOptions ...Option) (*schedulerserverconfig.CompletedConfig, *scheduler.Scheduler, *frameworkext.FrameworkExtenderFactory, error) {
 	// Get the completed config
 	cc := c.Complete()
 
@@ -355,9 +363,11 @@ func Setup(ctx context.Context, opts *options.Options, outOfTreeRegistryOptions
 	// NOTE(joseph): K8s scheduling framework does not provide extension point for initialization.
 	// Currently, only by copying the initialization code and implementing custom initialization.
 	frameworkExtenderFactory, err := frameworkext.NewFrameworkExtenderFactory(
-		frameworkext.WithServicesEngine(cc.ServicesEngine),
-		frameworkext.WithKoordinatorClientSet(cc.KoordinatorClient),
-		frameworkext.WithKoordinatorSharedInformerFactory(cc.KoordinatorSharedInformerFactory),
+		append([]frameworkext.Option{
+			frameworkext.WithServicesEngine(cc.ServicesEngine),
+			frameworkext.WithKoordinatorClientSet(cc.KoordinatorClient),
+			frameworkext.WithKoordinatorSharedInformerFactory(cc.KoordinatorSharedInformerFactory),
+		}, extenderOptions...)...,
 	)
 	if err != nil {
 		return nil, nil, nil, err
@@ -410,7 +420,8 @@ func Setup(ctx context.Context, opts *options.Options, outOfTreeRegistryOptions
 	}
 
 	frameworkExtenderFactory.InterceptSchedulerError(sched)
-	frameworkExtenderFactory.InitScheduler(&frameworkext.SchedulerAdapter{Scheduler: sched})
+	schedulerAdapter := frameworkext.NewSchedulerAdapter(sched)
+	frameworkExtenderFactory.InitScheduler(schedulerAdapter)
 	schedAdapter := frameworkExtenderFactory.Scheduler()
 
 	eventhandlers.AddScheduleEventHandler(sched, schedAdapter, frameworkExtenderFactory.KoordinatorSharedInformerFactory())
@@ -422,5 +433,7 @@ func Setup(ctx context.Context, opts *options.Options, outOfTreeRegistryOptions
 	)
 	frameworkExtenderFactory.RegisterErrorHandler(reservationErrorHandler)
 
+	cc.ServicesEngine.RegisterService(simulator.Name, simulator.New(schedulerAdapter, cc.KoordinatorSharedInformerFactory))
+
 	return &cc, sched, frameworkExtenderFactory, nil
 }
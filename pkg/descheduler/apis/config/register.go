@@ -48,6 +48,9 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&DeschedulerConfiguration{},
 		&MigrationControllerArgs{},
 		&LowNodeLoadArgs{},
+		&GPUFragmentationArgs{},
+		&InterferenceAwareArgs{},
+		&HighNodeLoadArgs{},
 	)
 	return nil
 }
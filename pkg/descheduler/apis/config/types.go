@@ -74,6 +74,8 @@ type DeschedulerProfile struct {
 	Name         string
 	PluginConfig []PluginConfig
 	Plugins      *Plugins
+	// Windows restricts the profile to run only in the time windows, the profile always runs if no window is set.
+	Windows []DeschedulingWindow
 }
 
 type Plugins struct {
@@ -91,6 +93,22 @@ type PluginSet struct {
 type Plugin struct {
 	// Name defines the name of plugin
 	Name string
+	// Windows restricts the plugin to run only in the time windows, the plugin always runs if no window is set.
+	Windows []DeschedulingWindow
+}
+
+// DeschedulingWindow is a recurring time window in which the descheduling is active.
+type DeschedulingWindow struct {
+	// Schedule is a cron expression in the standard 5-field format that indicates when the window opens,
+	// e.g. "0 2 * * 1-5" opens the window at 02:00 on weekdays.
+	Schedule string
+	// Duration indicates how long the window lasts after it opens.
+	Duration metav1.Duration
+	// TimeZone is the name of the time zone of the Schedule, e.g. "Asia/Shanghai".
+	// The local time zone of the descheduler is used by default.
+	TimeZone string
+	// MaxEvictions restricts the maximum number of pods to be evicted in each occurrence of the window.
+	MaxEvictions *uint
 }
 
 type PluginConfig struct {
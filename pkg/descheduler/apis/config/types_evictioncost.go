@@ -0,0 +1,47 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EvictionCostModel estimates the cost of evicting a Pod with the weighted terms.
+// Each term is normalized into [0, 100], the score is the weighted average of the terms,
+// and the Pods with the lower score are evicted first.
+type EvictionCostModel struct {
+	// PodAgeWeight is the weight of the age of the Pod, the older Pod costs more.
+	PodAgeWeight int64
+	// MaxPodAge is the age at which the term of the pod age reaches the maximum.
+	MaxPodAge metav1.Duration
+	// RestartCountWeight is the weight of the restart count of the Pod, the Pod restarting less costs more.
+	RestartCountWeight int64
+	// ConnectionsWeight is the weight of the ongoing connections of the Pod.
+	ConnectionsWeight int64
+	// ConnectionsReadinessGate is the condition type of a readiness gate of the Pod,
+	// the Pod is considered having ongoing connections if the condition is True.
+	ConnectionsReadinessGate corev1.PodConditionType
+	// QoSClassWeight is the weight of the Koordinator QoSClass of the Pod, the Pod with the higher QoSClass costs more.
+	QoSClassWeight int64
+	// WorkloadUnavailableWeight is the weight of the ratio of the unavailable Pods in the workload of the Pod,
+	// the Pod of the workload with more unavailable Pods costs more.
+	WorkloadUnavailableWeight int64
+	// EvictionCostAnnotationWeight is the weight of the eviction cost annotation of the Pod,
+	// the annotation value is clamped into [-100, 100].
+	EvictionCostAnnotationWeight int64
+}
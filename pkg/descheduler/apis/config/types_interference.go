@@ -0,0 +1,89 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// InterferenceEvictionTarget is the kind of the Pods evicted from the interference hotspots.
+type InterferenceEvictionTarget string
+
+const (
+	// InterferenceEvictionTargetVictim evicts the most affected LS Pods from the hotspots.
+	InterferenceEvictionTargetVictim InterferenceEvictionTarget = "Victim"
+	// InterferenceEvictionTargetAggressor evicts the BE/batch Pods consuming the most resources from the hotspots.
+	InterferenceEvictionTargetAggressor InterferenceEvictionTarget = "Aggressor"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// InterferenceAwareArgs holds arguments used to configure InterferenceAware plugin.
+type InterferenceAwareArgs struct {
+	metav1.TypeMeta
+
+	// Paused indicates whether the InterferenceAware should to work or not.
+	// Default is false
+	Paused bool
+
+	// DryRun means only execute the entire deschedule logic but don't migrate Pod
+	// Default is false
+	DryRun bool
+
+	// EvictableNamespaces carries a list of included/excluded namespaces
+	// for which the pods are evictable.
+	EvictableNamespaces *Namespaces
+
+	// NodeSelector selects the nodes to be checked for interference
+	NodeSelector *metav1.LabelSelector
+
+	// NodeFit if enabled, it will check whether the candidate Pods have suitable nodes, including NodeAffinity, TaintTolerance, and whether resources are sufficient.
+	// by default, NodeFit is set to true.
+	NodeFit bool
+
+	// Thresholds defines the interference signals above which an LS Pod is considered to be affected.
+	Thresholds *InterferenceThresholds
+
+	// MinVictims is the minimum number of the affected LS Pods for a node to be considered as an interference hotspot.
+	// Default is 1
+	MinVictims int32
+
+	// EvictionTarget is the kind of the Pods evicted from the hotspots, Victim or Aggressor.
+	// Default is Aggressor
+	EvictionTarget InterferenceEvictionTarget
+
+	// MaxPodsToEvictPerNode is the maximum number of the Pods evicted from a hotspot in a descheduling round.
+	// Default is 1
+	MaxPodsToEvictPerNode int32
+
+	// AnomalyCondition indicates the node is an interference hotspot only after it is abnormal consecutively,
+	// to avoid reacting to the spikes.
+	AnomalyCondition *LoadAnomalyCondition
+}
+
+// InterferenceThresholds defines the interference signals of an affected Pod, zero disables the signal.
+type InterferenceThresholds struct {
+	// CPUPressure is the percentage of the time that the tasks of the Pod stalled on CPU (PSI some).
+	CPUPressure int64
+	// MemoryPressure is the percentage of the time that the tasks of the Pod stalled on memory (PSI some).
+	MemoryPressure int64
+	// IOPressure is the percentage of the time that the tasks of the Pod stalled on IO (PSI some).
+	IOPressure int64
+	// MilliCPI is the cycles per instruction of the Pod in thousandths.
+	MilliCPI int64
+}
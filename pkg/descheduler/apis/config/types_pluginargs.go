@@ -107,6 +107,67 @@ type MigrationControllerArgs struct {
 
 	// SchedulerNames defines options to assign schedulers that can handle reservation if pmj.mode is ReservationFirst, koord-scheduler by default.
 	SchedulerNames []string
+
+	// ArbitrationArgs defines the cluster-wide arbitration of the PodMigrationJobs.
+	// If nil, the PodMigrationJobs are processed independently as soon as they are reconciled.
+	ArbitrationArgs *ArbitrationArgs
+
+	// VerificationArgs defines the verification of the PodMigrationJobs in ReservationFirst mode after the bound Pod is ready.
+	// If nil, the PodMigrationJobs succeed once the bound Pod is ready.
+	VerificationArgs *VerificationArgs
+
+	// WorkloadRules describe how to get the replicas and selector of the workloads without the scale subresource,
+	// so that the Pods owned by them are protected by MaxMigratingPerWorkload and MaxUnavailablePerWorkload.
+	// The workloads are read from the informer cache, so the descheduler needs the permissions to list and watch them.
+	WorkloadRules []WorkloadRule
+}
+
+// WorkloadRule describes where the replicas and selector are in the objects of a kind of workload.
+type WorkloadRule struct {
+	// APIVersion and Kind identify the workload, the rule applies to all the versions of the group.
+	APIVersion string
+	Kind       string
+	// ReplicasPath is the dot-separated field path of the desired replicas, e.g. spec.replicas.
+	ReplicasPath string
+	// SelectorPath is the dot-separated field path of the label selector, e.g. spec.selector.
+	// The field can be either a LabelSelector or a string in the label selector syntax.
+	SelectorPath string
+}
+
+// ArbitrationArgs holds arguments used to arbitrate the pending PodMigrationJobs.
+// The pending jobs are collected and sorted by eviction cost, priority, source plugin and age periodically,
+// then admitted in batches, and the jobs in one batch never belong to the same workload.
+type ArbitrationArgs struct {
+	// Interval is the period of the arbitration, at most one batch is admitted in each period.
+	Interval metav1.Duration
+	// MaxJobsPerBatch is the maximum number of PodMigrationJobs admitted in a batch.
+	MaxJobsPerBatch int32
+	// SourcePriority orders the PodMigrationJobs by the descheduling plugins triggering them,
+	// the jobs triggered by the plugins ahead are admitted first, the jobs triggered by the plugins not listed are admitted last.
+	SourcePriority []string
+	// EvictionCostModel replaces the eviction cost annotation with the estimated eviction cost to sort the jobs,
+	// and the explanation of the estimated cost is reported in the status message of the pending jobs.
+	EvictionCostModel *EvictionCostModel
+}
+
+// VerificationArgs holds arguments used to verify the PodMigrationJobs after the bound Pods are ready.
+// The job fails if the bound Pod is not healthy during the verification or the load of the source node does not drop,
+// and the workload of the Pod is not migrated again until the backoff expires.
+type VerificationArgs struct {
+	// HealthyDuration is how long the bound Pod must keep ready without restart loops.
+	HealthyDuration metav1.Duration
+	// MaxRestarts is the maximum number of the container restarts of the bound Pod during the verification.
+	MaxRestarts int32
+	// VerifySourceNodeLoad checks whether the load of the source node dropped after the migration by the NodeMetric.
+	VerifySourceNodeLoad bool
+	// MinSourceNodeLoadDropPercent is the minimum percentage by which the CPU or memory usage of the source node
+	// must drop for the load verification to pass.
+	MinSourceNodeLoadDropPercent int32
+	// InitialBackoff is the backoff of the workload after the first failed verification,
+	// and the backoff is doubled after each consecutive failure.
+	InitialBackoff metav1.Duration
+	// MaxBackoff is the maximum backoff of the workload.
+	MaxBackoff metav1.Duration
 }
 
 type MigrationLimitObjectType string
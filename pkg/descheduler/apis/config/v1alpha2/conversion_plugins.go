@@ -22,6 +22,28 @@ import (
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
 )
 
+func Convert_v1alpha2_HighNodeLoadArgs_To_config_HighNodeLoadArgs(in *HighNodeLoadArgs, out *config.HighNodeLoadArgs, s conversion.Scope) error {
+	if err := autoConvert_v1alpha2_HighNodeLoadArgs_To_config_HighNodeLoadArgs(in, out, s); err != nil {
+		return err
+	}
+
+	pool := config.LowNodeLoadNodePool{
+		Name:             "__default_node_pool__",
+		NodeSelector:     out.NodeSelector,
+		HighThresholds:   out.HighThresholds,
+		LowThresholds:    out.LowThresholds,
+		ResourceWeights:  out.ResourceWeights,
+		AnomalyCondition: out.AnomalyCondition,
+	}
+	out.NodePools = append([]config.LowNodeLoadNodePool{pool}, out.NodePools...)
+	out.NodeSelector = nil
+	out.HighThresholds = nil
+	out.LowThresholds = nil
+	out.ResourceWeights = nil
+	out.AnomalyCondition = nil
+	return nil
+}
+
 func Convert_v1alpha2_LowNodeLoadArgs_To_config_LowNodeLoadArgs(in *LowNodeLoadArgs, out *config.LowNodeLoadArgs, s conversion.Scope) error {
 	if err := autoConvert_v1alpha2_LowNodeLoadArgs_To_config_LowNodeLoadArgs(in, out, s); err != nil {
 		return err
@@ -35,6 +57,9 @@ func Convert_v1alpha2_LowNodeLoadArgs_To_config_LowNodeLoadArgs(in *LowNodeLoadA
 		LowThresholds:          out.LowThresholds,
 		ResourceWeights:        out.ResourceWeights,
 		AnomalyCondition:       out.AnomalyCondition,
+		ProdHighThresholds:     out.ProdHighThresholds,
+		ProdLowThresholds:      out.ProdLowThresholds,
+		Aggregated:             out.Aggregated,
 	}
 	out.NodePools = append([]config.LowNodeLoadNodePool{pool}, out.NodePools...)
 	out.NodeSelector = nil
@@ -43,5 +68,8 @@ func Convert_v1alpha2_LowNodeLoadArgs_To_config_LowNodeLoadArgs(in *LowNodeLoadA
 	out.LowThresholds = nil
 	out.ResourceWeights = nil
 	out.AnomalyCondition = nil
+	out.ProdHighThresholds = nil
+	out.ProdLowThresholds = nil
+	out.Aggregated = nil
 	return nil
 }
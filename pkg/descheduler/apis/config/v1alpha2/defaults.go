@@ -43,6 +43,28 @@ const (
 	defaultMigrationEvictQPS           = 10
 	defaultMigrationEvictBurst         = 1
 	defaultSchedulerSupportReservation = "koord-scheduler"
+
+	defaultArbitrationInterval        = 1 * time.Second
+	defaultArbitrationMaxJobsPerBatch = 10
+
+	defaultVerificationHealthyDuration              = 5 * time.Minute
+	defaultVerificationMinSourceNodeLoadDropPercent = 10
+	defaultVerificationInitialBackoff               = 5 * time.Minute
+	defaultVerificationMaxBackoff                   = 1 * time.Hour
+
+	defaultGPUFragmentationMaxPodGPUCore          = 50
+	defaultGPUFragmentationFragmentationThreshold = 30
+	defaultGPUFragmentationMaxGPUsPerRound        = 2
+
+	defaultHighNodeLoadMaxNodesToDrain = 1
+
+	defaultInterferenceCPUPressure           = 20
+	defaultInterferenceMemoryPressure        = 20
+	defaultInterferenceMinVictims            = 1
+	defaultInterferenceMaxPodsToEvictPerNode = 1
+	defaultInterferenceEvictionTarget        = InterferenceEvictionTargetAggressor
+
+	defaultEvictionCostMaxPodAge = 24 * time.Hour
 )
 
 var (
@@ -241,6 +263,38 @@ func SetDefaults_MigrationControllerArgs(obj *MigrationControllerArgs) {
 	if len(obj.ObjectLimiters) == 0 {
 		obj.ObjectLimiters = defaultObjectLimiters
 	}
+	if obj.ArbitrationArgs != nil {
+		if obj.ArbitrationArgs.Interval.Duration == 0 {
+			obj.ArbitrationArgs.Interval = metav1.Duration{Duration: defaultArbitrationInterval}
+		}
+		if obj.ArbitrationArgs.MaxJobsPerBatch == 0 {
+			obj.ArbitrationArgs.MaxJobsPerBatch = defaultArbitrationMaxJobsPerBatch
+		}
+		setDefaultsEvictionCostModel(obj.ArbitrationArgs.EvictionCostModel)
+	}
+	if obj.VerificationArgs != nil {
+		if obj.VerificationArgs.HealthyDuration.Duration == 0 {
+			obj.VerificationArgs.HealthyDuration = metav1.Duration{Duration: defaultVerificationHealthyDuration}
+		}
+		if obj.VerificationArgs.MinSourceNodeLoadDropPercent == 0 {
+			obj.VerificationArgs.MinSourceNodeLoadDropPercent = defaultVerificationMinSourceNodeLoadDropPercent
+		}
+		if obj.VerificationArgs.InitialBackoff.Duration == 0 {
+			obj.VerificationArgs.InitialBackoff = metav1.Duration{Duration: defaultVerificationInitialBackoff}
+		}
+		if obj.VerificationArgs.MaxBackoff.Duration == 0 {
+			obj.VerificationArgs.MaxBackoff = metav1.Duration{Duration: defaultVerificationMaxBackoff}
+		}
+	}
+}
+
+func setDefaultsEvictionCostModel(obj *EvictionCostModel) {
+	if obj == nil {
+		return
+	}
+	if obj.MaxPodAge.Duration == 0 {
+		obj.MaxPodAge = metav1.Duration{Duration: defaultEvictionCostMaxPodAge}
+	}
 }
 
 func SetDefaults_LowNodeLoadArgs(obj *LowNodeLoadArgs) {
@@ -253,6 +307,47 @@ func SetDefaults_LowNodeLoadArgs(obj *LowNodeLoadArgs) {
 		obj.AnomalyCondition.ConsecutiveAbnormalities = defaultLoadAnomalyCondition.ConsecutiveAbnormalities
 	}
 
+	defaultResourceWeights := map[corev1.ResourceName]int64{
+		corev1.ResourceCPU:    1,
+		corev1.ResourceMemory: 1,
+	}
+	for resourceName := range obj.LowThresholds {
+		defaultResourceWeights[resourceName] = 1
+	}
+	for resourceName := range obj.HighThresholds {
+		defaultResourceWeights[resourceName] = 1
+	}
+	for resourceName := range obj.ProdLowThresholds {
+		defaultResourceWeights[resourceName] = 1
+	}
+	for resourceName := range obj.ProdHighThresholds {
+		defaultResourceWeights[resourceName] = 1
+	}
+	if obj.ResourceWeights == nil {
+		obj.ResourceWeights = defaultResourceWeights
+	} else {
+		for resourceName, weight := range defaultResourceWeights {
+			if v := obj.ResourceWeights[resourceName]; v <= 0 {
+				obj.ResourceWeights[resourceName] = weight
+			}
+		}
+	}
+	setDefaultsEvictionCostModel(obj.EvictionCostModel)
+}
+
+func SetDefaults_HighNodeLoadArgs(obj *HighNodeLoadArgs) {
+	if obj.NodeFit == nil {
+		obj.NodeFit = pointer.Bool(true)
+	}
+	if obj.MaxNodesToDrain == nil {
+		obj.MaxNodesToDrain = pointer.Int32(defaultHighNodeLoadMaxNodesToDrain)
+	}
+	if obj.AnomalyCondition == nil {
+		obj.AnomalyCondition = defaultLoadAnomalyCondition
+	} else if obj.AnomalyCondition.ConsecutiveAbnormalities == 0 {
+		obj.AnomalyCondition.ConsecutiveAbnormalities = defaultLoadAnomalyCondition.ConsecutiveAbnormalities
+	}
+
 	defaultResourceWeights := map[corev1.ResourceName]int64{
 		corev1.ResourceCPU:    1,
 		corev1.ResourceMemory: 1,
@@ -273,3 +368,44 @@ func SetDefaults_LowNodeLoadArgs(obj *LowNodeLoadArgs) {
 		}
 	}
 }
+
+func SetDefaults_GPUFragmentationArgs(obj *GPUFragmentationArgs) {
+	if obj.NodeFit == nil {
+		obj.NodeFit = pointer.Bool(true)
+	}
+	if obj.MaxPodGPUCore == nil {
+		obj.MaxPodGPUCore = pointer.Int64(defaultGPUFragmentationMaxPodGPUCore)
+	}
+	if obj.FragmentationThreshold == nil {
+		obj.FragmentationThreshold = pointer.Int64(defaultGPUFragmentationFragmentationThreshold)
+	}
+	if obj.MaxGPUsPerRound == nil {
+		obj.MaxGPUsPerRound = pointer.Int32(defaultGPUFragmentationMaxGPUsPerRound)
+	}
+}
+
+func SetDefaults_InterferenceAwareArgs(obj *InterferenceAwareArgs) {
+	if obj.NodeFit == nil {
+		obj.NodeFit = pointer.Bool(true)
+	}
+	if obj.Thresholds == nil {
+		obj.Thresholds = &InterferenceThresholds{
+			CPUPressure:    defaultInterferenceCPUPressure,
+			MemoryPressure: defaultInterferenceMemoryPressure,
+		}
+	}
+	if obj.MinVictims == nil {
+		obj.MinVictims = pointer.Int32(defaultInterferenceMinVictims)
+	}
+	if obj.EvictionTarget == "" {
+		obj.EvictionTarget = defaultInterferenceEvictionTarget
+	}
+	if obj.MaxPodsToEvictPerNode == nil {
+		obj.MaxPodsToEvictPerNode = pointer.Int32(defaultInterferenceMaxPodsToEvictPerNode)
+	}
+	if obj.AnomalyCondition == nil {
+		obj.AnomalyCondition = defaultLoadAnomalyCondition
+	} else if obj.AnomalyCondition.ConsecutiveAbnormalities == 0 {
+		obj.AnomalyCondition.ConsecutiveAbnormalities = defaultLoadAnomalyCondition.ConsecutiveAbnormalities
+	}
+}
@@ -96,6 +96,26 @@ func TestSetDefaults_LowNodeLoadArgs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "set evictionCostModel",
+			args: &LowNodeLoadArgs{
+				EvictionCostModel: &EvictionCostModel{
+					PodAgeWeight: 1,
+				},
+			},
+			expected: &LowNodeLoadArgs{
+				NodeFit:          pointer.Bool(true),
+				AnomalyCondition: defaultLoadAnomalyCondition,
+				ResourceWeights: map[corev1.ResourceName]int64{
+					corev1.ResourceCPU:    1,
+					corev1.ResourceMemory: 1,
+				},
+				EvictionCostModel: &EvictionCostModel{
+					PodAgeWeight: 1,
+					MaxPodAge:    metav1.Duration{Duration: defaultEvictionCostMaxPodAge},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -104,3 +124,147 @@ func TestSetDefaults_LowNodeLoadArgs(t *testing.T) {
 		})
 	}
 }
+
+func TestSetDefaults_GPUFragmentationArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     *GPUFragmentationArgs
+		expected *GPUFragmentationArgs
+	}{
+		{
+			name: "set default args",
+			args: &GPUFragmentationArgs{},
+			expected: &GPUFragmentationArgs{
+				NodeFit:                pointer.Bool(true),
+				MaxPodGPUCore:          pointer.Int64(defaultGPUFragmentationMaxPodGPUCore),
+				FragmentationThreshold: pointer.Int64(defaultGPUFragmentationFragmentationThreshold),
+				MaxGPUsPerRound:        pointer.Int32(defaultGPUFragmentationMaxGPUsPerRound),
+			},
+		},
+		{
+			name: "keep the configured args",
+			args: &GPUFragmentationArgs{
+				NodeFit:                pointer.Bool(false),
+				MaxPodGPUCore:          pointer.Int64(25),
+				FragmentationThreshold: pointer.Int64(10),
+				MaxGPUsPerRound:        pointer.Int32(4),
+			},
+			expected: &GPUFragmentationArgs{
+				NodeFit:                pointer.Bool(false),
+				MaxPodGPUCore:          pointer.Int64(25),
+				FragmentationThreshold: pointer.Int64(10),
+				MaxGPUsPerRound:        pointer.Int32(4),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDefaults_GPUFragmentationArgs(tt.args)
+			assert.Equal(t, tt.expected, tt.args)
+		})
+	}
+}
+
+func TestSetDefaults_HighNodeLoadArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     *HighNodeLoadArgs
+		expected *HighNodeLoadArgs
+	}{
+		{
+			name: "set default args",
+			args: &HighNodeLoadArgs{},
+			expected: &HighNodeLoadArgs{
+				NodeFit:          pointer.Bool(true),
+				MaxNodesToDrain:  pointer.Int32(defaultHighNodeLoadMaxNodesToDrain),
+				AnomalyCondition: defaultLoadAnomalyCondition,
+				ResourceWeights: map[corev1.ResourceName]int64{
+					corev1.ResourceCPU:    1,
+					corev1.ResourceMemory: 1,
+				},
+			},
+		},
+		{
+			name: "keep the configured args",
+			args: &HighNodeLoadArgs{
+				NodeFit:         pointer.Bool(false),
+				MaxNodesToDrain: pointer.Int32(3),
+				LowThresholds: ResourceThresholds{
+					corev1.ResourcePods: 10,
+				},
+			},
+			expected: &HighNodeLoadArgs{
+				NodeFit:          pointer.Bool(false),
+				MaxNodesToDrain:  pointer.Int32(3),
+				AnomalyCondition: defaultLoadAnomalyCondition,
+				LowThresholds: ResourceThresholds{
+					corev1.ResourcePods: 10,
+				},
+				ResourceWeights: map[corev1.ResourceName]int64{
+					corev1.ResourceCPU:    1,
+					corev1.ResourceMemory: 1,
+					corev1.ResourcePods:   1,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDefaults_HighNodeLoadArgs(tt.args)
+			assert.Equal(t, tt.expected, tt.args)
+		})
+	}
+}
+
+func TestSetDefaults_InterferenceAwareArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     *InterferenceAwareArgs
+		expected *InterferenceAwareArgs
+	}{
+		{
+			name: "set default args",
+			args: &InterferenceAwareArgs{},
+			expected: &InterferenceAwareArgs{
+				NodeFit: pointer.Bool(true),
+				Thresholds: &InterferenceThresholds{
+					CPUPressure:    defaultInterferenceCPUPressure,
+					MemoryPressure: defaultInterferenceMemoryPressure,
+				},
+				MinVictims:            pointer.Int32(defaultInterferenceMinVictims),
+				EvictionTarget:        InterferenceEvictionTargetAggressor,
+				MaxPodsToEvictPerNode: pointer.Int32(defaultInterferenceMaxPodsToEvictPerNode),
+				AnomalyCondition:      defaultLoadAnomalyCondition,
+			},
+		},
+		{
+			name: "keep the configured args",
+			args: &InterferenceAwareArgs{
+				NodeFit:               pointer.Bool(false),
+				Thresholds:            &InterferenceThresholds{MilliCPI: 2000},
+				MinVictims:            pointer.Int32(2),
+				EvictionTarget:        InterferenceEvictionTargetVictim,
+				MaxPodsToEvictPerNode: pointer.Int32(3),
+				AnomalyCondition: &LoadAnomalyCondition{
+					ConsecutiveAbnormalities: 2,
+				},
+			},
+			expected: &InterferenceAwareArgs{
+				NodeFit:               pointer.Bool(false),
+				Thresholds:            &InterferenceThresholds{MilliCPI: 2000},
+				MinVictims:            pointer.Int32(2),
+				EvictionTarget:        InterferenceEvictionTargetVictim,
+				MaxPodsToEvictPerNode: pointer.Int32(3),
+				AnomalyCondition: &LoadAnomalyCondition{
+					ConsecutiveAbnormalities: 2,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDefaults_InterferenceAwareArgs(tt.args)
+			assert.Equal(t, tt.expected, tt.args)
+		})
+	}
+}
@@ -103,6 +103,8 @@ type DeschedulerProfile struct {
 	Name         string         `json:"name,omitempty"`
 	PluginConfig []PluginConfig `json:"pluginConfig,omitempty"`
 	Plugins      *Plugins       `json:"plugins,omitempty"`
+	// Windows restricts the profile to run only in the time windows, the profile always runs if no window is set.
+	Windows []DeschedulingWindow `json:"windows,omitempty"`
 }
 
 type Plugins struct {
@@ -120,6 +122,22 @@ type PluginSet struct {
 type Plugin struct {
 	// Name defines the name of plugin
 	Name string `json:"name,omitempty"`
+	// Windows restricts the plugin to run only in the time windows, the plugin always runs if no window is set.
+	Windows []DeschedulingWindow `json:"windows,omitempty"`
+}
+
+// DeschedulingWindow is a recurring time window in which the descheduling is active.
+type DeschedulingWindow struct {
+	// Schedule is a cron expression in the standard 5-field format that indicates when the window opens,
+	// e.g. "0 2 * * 1-5" opens the window at 02:00 on weekdays.
+	Schedule string `json:"schedule"`
+	// Duration indicates how long the window lasts after it opens.
+	Duration metav1.Duration `json:"duration"`
+	// TimeZone is the name of the time zone of the Schedule, e.g. "Asia/Shanghai".
+	// The local time zone of the descheduler is used by default.
+	TimeZone string `json:"timeZone,omitempty"`
+	// MaxEvictions restricts the maximum number of pods to be evicted in each occurrence of the window.
+	MaxEvictions *uint `json:"maxEvictions,omitempty"`
 }
 
 type PluginConfig struct {
@@ -0,0 +1,61 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// GPUFragmentationArgs holds arguments used to configure GPUFragmentation plugin.
+type GPUFragmentationArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Paused indicates whether the GPUFragmentation should to work or not.
+	// Default is false
+	Paused *bool `json:"paused,omitempty"`
+
+	// DryRun means only execute the entire deschedule logic but don't migrate Pod
+	// Default is false
+	DryRun *bool `json:"dryRun,omitempty"`
+
+	// EvictableNamespaces carries a list of included/excluded namespaces
+	// for which the shared-GPU pods are evictable.
+	EvictableNamespaces *Namespaces `json:"evictableNamespaces,omitempty"`
+
+	// NodeSelector selects the GPU nodes to be rebalanced
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+
+	// NodeFit if enabled, it will check whether the candidate Pods have suitable nodes, including NodeAffinity, TaintTolerance, and whether resources are sufficient.
+	// by default, NodeFit is set to true.
+	NodeFit *bool `json:"nodeFit,omitempty"`
+
+	// MaxPodGPUCore is the maximum gpu-core of the shared-GPU Pods to be migrated,
+	// the Pods using more gpu-core of a GPU are never migrated.
+	// Default is 50
+	MaxPodGPUCore *int64 `json:"maxPodGPUCore,omitempty"`
+
+	// FragmentationThreshold is the percentage of the free gpu-core on the partially used GPUs of a node
+	// to the total gpu-core of the node, the partially used GPUs of the nodes above it are consolidated.
+	// Default is 30
+	FragmentationThreshold *int64 `json:"fragmentationThreshold,omitempty"`
+
+	// MaxGPUsPerRound is the maximum number of the GPUs freed in a descheduling round.
+	// Default is 2
+	MaxGPUsPerRound *int32 `json:"maxGPUsPerRound,omitempty"`
+}
@@ -19,6 +19,8 @@ package v1alpha2
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -74,8 +76,79 @@ type LowNodeLoadArgs struct {
 	// it is determined that the node is abnormal, and the Pods need to be migrated to reduce the load.
 	AnomalyCondition *LoadAnomalyCondition `json:"anomalyCondition,omitempty"`
 
+	// ProdHighThresholds defines the target usage threshold of Prod Pods.
+	// If ProdHighThresholds or ProdLowThresholds is set, the nodes are classified by the total usage of Prod Pods
+	// instead of the node usage, and only the Prod Pods will be evicted.
+	ProdHighThresholds ResourceThresholds `json:"prodHighThresholds,omitempty"`
+
+	// ProdLowThresholds defines the low usage threshold of Prod Pods
+	ProdLowThresholds ResourceThresholds `json:"prodLowThresholds,omitempty"`
+
+	// Aggregated classifies the nodes by the percentile statistics of the node usage instead of the latest sample.
+	Aggregated *LoadAggregatedUsage `json:"aggregated,omitempty"`
+
 	// NodePools supports multiple different types of batch nodes to configure different strategies
 	NodePools []LowNodeLoadNodePool `json:"nodePools,omitempty"`
+
+	// EvictionCostModel orders the Pods to be evicted by the estimated eviction cost.
+	// If nil, the Pods are ordered by the priority, QoSClass, eviction cost annotation and resource usage.
+	EvictionCostModel *EvictionCostModel `json:"evictionCostModel,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// HighNodeLoadArgs holds arguments used to configure HighNodeLoad plugin.
+type HighNodeLoadArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Paused indicates whether the HighNodeLoad should to work or not.
+	// Default is false
+	Paused *bool `json:"paused,omitempty"`
+
+	// DryRun means only execute the entire deschedule logic but don't migrate Pod
+	// Default is false
+	DryRun *bool `json:"dryRun,omitempty"`
+
+	// NumberOfNodes can be configured to activate the strategy only when the number of under utilized nodes are above the configured value.
+	// By default, NumberOfNodes is set to zero.
+	NumberOfNodes *int32 `json:"numberOfNodes,omitempty"`
+
+	// MaxNodesToDrain is the maximum number of the under utilized nodes drained in a node pool per descheduling round.
+	// Default is 1
+	MaxNodesToDrain *int32 `json:"maxNodesToDrain,omitempty"`
+
+	// Naming this one differently since namespaces are still
+	// considered while considering resoures used by pods
+	// but then filtered out before eviction
+	EvictableNamespaces *Namespaces `json:"evictableNamespaces,omitempty"`
+
+	// NodeSelector selects the nodes that matched labelSelector
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+
+	// PodSelectors selects the pods that matched labelSelector
+	PodSelectors []LowNodeLoadPodSelector `json:"podSelectors,omitempty"`
+
+	// NodeFit if enabled, it will check whether the candidate Pods have suitable nodes, including NodeAffinity, TaintTolerance, and whether resources are sufficient.
+	// by default, NodeFit is set to true.
+	NodeFit *bool `json:"nodeFit,omitempty"`
+
+	// HighThresholds defines the usage threshold of resources that the destination nodes can not exceed after receiving the Pods
+	HighThresholds ResourceThresholds `json:"highThresholds,omitempty"`
+
+	// LowThresholds defines the usage threshold of resources below which the nodes are considered under utilized and drained
+	LowThresholds ResourceThresholds `json:"lowThresholds,omitempty"`
+
+	// ResourceWeights indicates the weights of resources.
+	// The weights of CPU and Memory are both 1 by default.
+	ResourceWeights map[corev1.ResourceName]int64 `json:"resourceWeights,omitempty"`
+
+	// AnomalyCondition indicates the node load anomaly thresholds,
+	// the default is 5 consecutive times under LowThresholds,
+	// it is determined that the node is under utilized, and the Pods need to be migrated to drain the node.
+	AnomalyCondition *LoadAnomalyCondition `json:"anomalyCondition,omitempty"`
+
+	// NodePools supports multiple different types of nodes to configure different strategies
+	NodePools []LowNodeLoadNodePool `json:"nodePools,omitempty"`
 }
 
 type LowNodeLoadNodePool struct {
@@ -102,6 +175,17 @@ type LowNodeLoadNodePool struct {
 	// the default is 5 consecutive times exceeding HighThresholds,
 	// it is determined that the node is abnormal, and the Pods need to be migrated to reduce the load.
 	AnomalyCondition *LoadAnomalyCondition `json:"anomalyCondition,omitempty"`
+
+	// ProdHighThresholds defines the target usage threshold of Prod Pods.
+	// If ProdHighThresholds or ProdLowThresholds is set, the nodes are classified by the total usage of Prod Pods
+	// instead of the node usage, and only the Prod Pods will be evicted.
+	ProdHighThresholds ResourceThresholds `json:"prodHighThresholds,omitempty"`
+
+	// ProdLowThresholds defines the low usage threshold of Prod Pods
+	ProdLowThresholds ResourceThresholds `json:"prodLowThresholds,omitempty"`
+
+	// Aggregated classifies the nodes by the percentile statistics of the node usage instead of the latest sample.
+	Aggregated *LoadAggregatedUsage `json:"aggregated,omitempty"`
 }
 
 type LowNodeLoadPodSelector struct {
@@ -119,3 +203,14 @@ type LoadAnomalyCondition struct {
 	// ConsecutiveNormalities indicates the number of consecutive normalities
 	ConsecutiveNormalities uint32 `json:"consecutiveNormalities,omitempty"`
 }
+
+// LoadAggregatedUsage selects the percentile statistics of the node usage reported in NodeMetric.
+// The usages of the Pods are selected from the same window if the koordlet reports them,
+// see PodMetricPolicy.EnableAggregatedUsages of NodeMetric.
+type LoadAggregatedUsage struct {
+	// UsageAggregationType indicates the percentile type of the node usage
+	UsageAggregationType extension.AggregationType `json:"usageAggregationType,omitempty"`
+	// UsageAggregatedDuration indicates the statistical period of the percentile of the node usage.
+	// If no specific period is set, the maximum period recorded by NodeMetrics will be used by default.
+	UsageAggregatedDuration metav1.Duration `json:"usageAggregatedDuration,omitempty"`
+}
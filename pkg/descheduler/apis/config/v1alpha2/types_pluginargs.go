@@ -109,6 +109,74 @@ type MigrationControllerArgs struct {
 	EvictionPolicy string `json:"evictionPolicy,omitempty"`
 	// DefaultDeleteOptions defines options when deleting migrated pods and preempted pods through the method specified by EvictionPolicy
 	DefaultDeleteOptions *metav1.DeleteOptions `json:"defaultDeleteOptions,omitempty"`
+
+	// ArbitrationArgs defines the cluster-wide arbitration of the PodMigrationJobs.
+	// If nil, the PodMigrationJobs are processed independently as soon as they are reconciled.
+	ArbitrationArgs *ArbitrationArgs `json:"arbitrationArgs,omitempty"`
+
+	// VerificationArgs defines the verification of the PodMigrationJobs in ReservationFirst mode after the bound Pod is ready.
+	// If nil, the PodMigrationJobs succeed once the bound Pod is ready.
+	VerificationArgs *VerificationArgs `json:"verificationArgs,omitempty"`
+
+	// WorkloadRules describe how to get the replicas and selector of the workloads without the scale subresource,
+	// so that the Pods owned by them are protected by MaxMigratingPerWorkload and MaxUnavailablePerWorkload.
+	// The workloads are read from the informer cache, so the descheduler needs the permissions to list and watch them.
+	WorkloadRules []WorkloadRule `json:"workloadRules,omitempty"`
+}
+
+// WorkloadRule describes where the replicas and selector are in the objects of a kind of workload.
+type WorkloadRule struct {
+	// APIVersion and Kind identify the workload, the rule applies to all the versions of the group.
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	// ReplicasPath is the dot-separated field path of the desired replicas, e.g. spec.replicas.
+	ReplicasPath string `json:"replicasPath"`
+	// SelectorPath is the dot-separated field path of the label selector, e.g. spec.selector.
+	// The field can be either a LabelSelector or a string in the label selector syntax.
+	SelectorPath string `json:"selectorPath,omitempty"`
+}
+
+// ArbitrationArgs holds arguments used to arbitrate the pending PodMigrationJobs.
+// The pending jobs are collected and sorted by eviction cost, priority, source plugin and age periodically,
+// then admitted in batches, and the jobs in one batch never belong to the same workload.
+type ArbitrationArgs struct {
+	// Interval is the period of the arbitration, at most one batch is admitted in each period.
+	// Default is 1 second
+	Interval metav1.Duration `json:"interval,omitempty"`
+	// MaxJobsPerBatch is the maximum number of PodMigrationJobs admitted in a batch.
+	// Default is 10
+	MaxJobsPerBatch int32 `json:"maxJobsPerBatch,omitempty"`
+	// SourcePriority orders the PodMigrationJobs by the descheduling plugins triggering them,
+	// the jobs triggered by the plugins ahead are admitted first, the jobs triggered by the plugins not listed are admitted last.
+	SourcePriority []string `json:"sourcePriority,omitempty"`
+	// EvictionCostModel replaces the eviction cost annotation with the estimated eviction cost to sort the jobs,
+	// and the explanation of the estimated cost is reported in the status message of the pending jobs.
+	EvictionCostModel *EvictionCostModel `json:"evictionCostModel,omitempty"`
+}
+
+// VerificationArgs holds arguments used to verify the PodMigrationJobs after the bound Pods are ready.
+// The job fails if the bound Pod is not healthy during the verification or the load of the source node does not drop,
+// and the workload of the Pod is not migrated again until the backoff expires.
+type VerificationArgs struct {
+	// HealthyDuration is how long the bound Pod must keep ready without restart loops.
+	// Default is 5 minutes
+	HealthyDuration metav1.Duration `json:"healthyDuration,omitempty"`
+	// MaxRestarts is the maximum number of the container restarts of the bound Pod during the verification.
+	// Default is 0
+	MaxRestarts int32 `json:"maxRestarts,omitempty"`
+	// VerifySourceNodeLoad checks whether the load of the source node dropped after the migration by the NodeMetric.
+	VerifySourceNodeLoad bool `json:"verifySourceNodeLoad,omitempty"`
+	// MinSourceNodeLoadDropPercent is the minimum percentage by which the CPU or memory usage of the source node
+	// must drop for the load verification to pass.
+	// Default is 10
+	MinSourceNodeLoadDropPercent int32 `json:"minSourceNodeLoadDropPercent,omitempty"`
+	// InitialBackoff is the backoff of the workload after the first failed verification,
+	// and the backoff is doubled after each consecutive failure.
+	// Default is 5 minutes
+	InitialBackoff metav1.Duration `json:"initialBackoff,omitempty"`
+	// MaxBackoff is the maximum backoff of the workload.
+	// Default is 1 hour
+	MaxBackoff metav1.Duration `json:"maxBackoff,omitempty"`
 }
 
 type MigrationLimitObjectType string
@@ -24,6 +24,7 @@ package v1alpha2
 import (
 	unsafe "unsafe"
 
+	extension "github.com/koordinator-sh/koordinator/apis/extension"
 	config "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -40,6 +41,16 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*ArbitrationArgs)(nil), (*config.ArbitrationArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ArbitrationArgs_To_config_ArbitrationArgs(a.(*ArbitrationArgs), b.(*config.ArbitrationArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.ArbitrationArgs)(nil), (*ArbitrationArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ArbitrationArgs_To_v1alpha2_ArbitrationArgs(a.(*config.ArbitrationArgs), b.(*ArbitrationArgs), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*DeschedulerProfile)(nil), (*config.DeschedulerProfile)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_DeschedulerProfile_To_config_DeschedulerProfile(a.(*DeschedulerProfile), b.(*config.DeschedulerProfile), scope)
 	}); err != nil {
@@ -50,6 +61,71 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*DeschedulingWindow)(nil), (*config.DeschedulingWindow)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_DeschedulingWindow_To_config_DeschedulingWindow(a.(*DeschedulingWindow), b.(*config.DeschedulingWindow), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.DeschedulingWindow)(nil), (*DeschedulingWindow)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_DeschedulingWindow_To_v1alpha2_DeschedulingWindow(a.(*config.DeschedulingWindow), b.(*DeschedulingWindow), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*EvictionCostModel)(nil), (*config.EvictionCostModel)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_EvictionCostModel_To_config_EvictionCostModel(a.(*EvictionCostModel), b.(*config.EvictionCostModel), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.EvictionCostModel)(nil), (*EvictionCostModel)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_EvictionCostModel_To_v1alpha2_EvictionCostModel(a.(*config.EvictionCostModel), b.(*EvictionCostModel), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*GPUFragmentationArgs)(nil), (*config.GPUFragmentationArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_GPUFragmentationArgs_To_config_GPUFragmentationArgs(a.(*GPUFragmentationArgs), b.(*config.GPUFragmentationArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.GPUFragmentationArgs)(nil), (*GPUFragmentationArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_GPUFragmentationArgs_To_v1alpha2_GPUFragmentationArgs(a.(*config.GPUFragmentationArgs), b.(*GPUFragmentationArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.HighNodeLoadArgs)(nil), (*HighNodeLoadArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_HighNodeLoadArgs_To_v1alpha2_HighNodeLoadArgs(a.(*config.HighNodeLoadArgs), b.(*HighNodeLoadArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*InterferenceAwareArgs)(nil), (*config.InterferenceAwareArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_InterferenceAwareArgs_To_config_InterferenceAwareArgs(a.(*InterferenceAwareArgs), b.(*config.InterferenceAwareArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.InterferenceAwareArgs)(nil), (*InterferenceAwareArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_InterferenceAwareArgs_To_v1alpha2_InterferenceAwareArgs(a.(*config.InterferenceAwareArgs), b.(*InterferenceAwareArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*InterferenceThresholds)(nil), (*config.InterferenceThresholds)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_InterferenceThresholds_To_config_InterferenceThresholds(a.(*InterferenceThresholds), b.(*config.InterferenceThresholds), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.InterferenceThresholds)(nil), (*InterferenceThresholds)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_InterferenceThresholds_To_v1alpha2_InterferenceThresholds(a.(*config.InterferenceThresholds), b.(*InterferenceThresholds), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*LoadAggregatedUsage)(nil), (*config.LoadAggregatedUsage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_LoadAggregatedUsage_To_config_LoadAggregatedUsage(a.(*LoadAggregatedUsage), b.(*config.LoadAggregatedUsage), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.LoadAggregatedUsage)(nil), (*LoadAggregatedUsage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_LoadAggregatedUsage_To_v1alpha2_LoadAggregatedUsage(a.(*config.LoadAggregatedUsage), b.(*LoadAggregatedUsage), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*LoadAnomalyCondition)(nil), (*config.LoadAnomalyCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_LoadAnomalyCondition_To_config_LoadAnomalyCondition(a.(*LoadAnomalyCondition), b.(*config.LoadAnomalyCondition), scope)
 	}); err != nil {
@@ -165,6 +241,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*VerificationArgs)(nil), (*config.VerificationArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_VerificationArgs_To_config_VerificationArgs(a.(*VerificationArgs), b.(*config.VerificationArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.VerificationArgs)(nil), (*VerificationArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_VerificationArgs_To_v1alpha2_VerificationArgs(a.(*config.VerificationArgs), b.(*VerificationArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*WorkloadRule)(nil), (*config.WorkloadRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_WorkloadRule_To_config_WorkloadRule(a.(*WorkloadRule), b.(*config.WorkloadRule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.WorkloadRule)(nil), (*WorkloadRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_WorkloadRule_To_v1alpha2_WorkloadRule(a.(*config.WorkloadRule), b.(*WorkloadRule), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*config.DeschedulerConfiguration)(nil), (*DeschedulerConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_config_DeschedulerConfiguration_To_v1alpha2_DeschedulerConfiguration(a.(*config.DeschedulerConfiguration), b.(*DeschedulerConfiguration), scope)
 	}); err != nil {
@@ -175,6 +271,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*HighNodeLoadArgs)(nil), (*config.HighNodeLoadArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_HighNodeLoadArgs_To_config_HighNodeLoadArgs(a.(*HighNodeLoadArgs), b.(*config.HighNodeLoadArgs), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*LowNodeLoadArgs)(nil), (*config.LowNodeLoadArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_LowNodeLoadArgs_To_config_LowNodeLoadArgs(a.(*LowNodeLoadArgs), b.(*config.LowNodeLoadArgs), scope)
 	}); err != nil {
@@ -183,6 +284,32 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1alpha2_ArbitrationArgs_To_config_ArbitrationArgs(in *ArbitrationArgs, out *config.ArbitrationArgs, s conversion.Scope) error {
+	out.Interval = in.Interval
+	out.MaxJobsPerBatch = in.MaxJobsPerBatch
+	out.SourcePriority = *(*[]string)(unsafe.Pointer(&in.SourcePriority))
+	out.EvictionCostModel = (*config.EvictionCostModel)(unsafe.Pointer(in.EvictionCostModel))
+	return nil
+}
+
+// Convert_v1alpha2_ArbitrationArgs_To_config_ArbitrationArgs is an autogenerated conversion function.
+func Convert_v1alpha2_ArbitrationArgs_To_config_ArbitrationArgs(in *ArbitrationArgs, out *config.ArbitrationArgs, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ArbitrationArgs_To_config_ArbitrationArgs(in, out, s)
+}
+
+func autoConvert_config_ArbitrationArgs_To_v1alpha2_ArbitrationArgs(in *config.ArbitrationArgs, out *ArbitrationArgs, s conversion.Scope) error {
+	out.Interval = in.Interval
+	out.MaxJobsPerBatch = in.MaxJobsPerBatch
+	out.SourcePriority = *(*[]string)(unsafe.Pointer(&in.SourcePriority))
+	out.EvictionCostModel = (*EvictionCostModel)(unsafe.Pointer(in.EvictionCostModel))
+	return nil
+}
+
+// Convert_config_ArbitrationArgs_To_v1alpha2_ArbitrationArgs is an autogenerated conversion function.
+func Convert_config_ArbitrationArgs_To_v1alpha2_ArbitrationArgs(in *config.ArbitrationArgs, out *ArbitrationArgs, s conversion.Scope) error {
+	return autoConvert_config_ArbitrationArgs_To_v1alpha2_ArbitrationArgs(in, out, s)
+}
+
 func autoConvert_v1alpha2_DeschedulerConfiguration_To_config_DeschedulerConfiguration(in *DeschedulerConfiguration, out *config.DeschedulerConfiguration, s conversion.Scope) error {
 	if err := v1alpha1.Convert_v1alpha1_LeaderElectionConfiguration_To_config_LeaderElectionConfiguration(&in.LeaderElection, &out.LeaderElection, s); err != nil {
 		return err
@@ -267,6 +394,7 @@ func autoConvert_v1alpha2_DeschedulerProfile_To_config_DeschedulerProfile(in *De
 		out.PluginConfig = nil
 	}
 	out.Plugins = (*config.Plugins)(unsafe.Pointer(in.Plugins))
+	out.Windows = *(*[]config.DeschedulingWindow)(unsafe.Pointer(&in.Windows))
 	return nil
 }
 
@@ -289,6 +417,7 @@ func autoConvert_config_DeschedulerProfile_To_v1alpha2_DeschedulerProfile(in *co
 		out.PluginConfig = nil
 	}
 	out.Plugins = (*Plugins)(unsafe.Pointer(in.Plugins))
+	out.Windows = *(*[]DeschedulingWindow)(unsafe.Pointer(&in.Windows))
 	return nil
 }
 
@@ -297,6 +426,341 @@ func Convert_config_DeschedulerProfile_To_v1alpha2_DeschedulerProfile(in *config
 	return autoConvert_config_DeschedulerProfile_To_v1alpha2_DeschedulerProfile(in, out, s)
 }
 
+func autoConvert_v1alpha2_DeschedulingWindow_To_config_DeschedulingWindow(in *DeschedulingWindow, out *config.DeschedulingWindow, s conversion.Scope) error {
+	out.Schedule = in.Schedule
+	out.Duration = in.Duration
+	out.TimeZone = in.TimeZone
+	out.MaxEvictions = (*uint)(unsafe.Pointer(in.MaxEvictions))
+	return nil
+}
+
+// Convert_v1alpha2_DeschedulingWindow_To_config_DeschedulingWindow is an autogenerated conversion function.
+func Convert_v1alpha2_DeschedulingWindow_To_config_DeschedulingWindow(in *DeschedulingWindow, out *config.DeschedulingWindow, s conversion.Scope) error {
+	return autoConvert_v1alpha2_DeschedulingWindow_To_config_DeschedulingWindow(in, out, s)
+}
+
+func autoConvert_config_DeschedulingWindow_To_v1alpha2_DeschedulingWindow(in *config.DeschedulingWindow, out *DeschedulingWindow, s conversion.Scope) error {
+	out.Schedule = in.Schedule
+	out.Duration = in.Duration
+	out.TimeZone = in.TimeZone
+	out.MaxEvictions = (*uint)(unsafe.Pointer(in.MaxEvictions))
+	return nil
+}
+
+// Convert_config_DeschedulingWindow_To_v1alpha2_DeschedulingWindow is an autogenerated conversion function.
+func Convert_config_DeschedulingWindow_To_v1alpha2_DeschedulingWindow(in *config.DeschedulingWindow, out *DeschedulingWindow, s conversion.Scope) error {
+	return autoConvert_config_DeschedulingWindow_To_v1alpha2_DeschedulingWindow(in, out, s)
+}
+
+func autoConvert_v1alpha2_EvictionCostModel_To_config_EvictionCostModel(in *EvictionCostModel, out *config.EvictionCostModel, s conversion.Scope) error {
+	out.PodAgeWeight = in.PodAgeWeight
+	out.MaxPodAge = in.MaxPodAge
+	out.RestartCountWeight = in.RestartCountWeight
+	out.ConnectionsWeight = in.ConnectionsWeight
+	out.ConnectionsReadinessGate = corev1.PodConditionType(in.ConnectionsReadinessGate)
+	out.QoSClassWeight = in.QoSClassWeight
+	out.WorkloadUnavailableWeight = in.WorkloadUnavailableWeight
+	out.EvictionCostAnnotationWeight = in.EvictionCostAnnotationWeight
+	return nil
+}
+
+// Convert_v1alpha2_EvictionCostModel_To_config_EvictionCostModel is an autogenerated conversion function.
+func Convert_v1alpha2_EvictionCostModel_To_config_EvictionCostModel(in *EvictionCostModel, out *config.EvictionCostModel, s conversion.Scope) error {
+	return autoConvert_v1alpha2_EvictionCostModel_To_config_EvictionCostModel(in, out, s)
+}
+
+func autoConvert_config_EvictionCostModel_To_v1alpha2_EvictionCostModel(in *config.EvictionCostModel, out *EvictionCostModel, s conversion.Scope) error {
+	out.PodAgeWeight = in.PodAgeWeight
+	out.MaxPodAge = in.MaxPodAge
+	out.RestartCountWeight = in.RestartCountWeight
+	out.ConnectionsWeight = in.ConnectionsWeight
+	out.ConnectionsReadinessGate = corev1.PodConditionType(in.ConnectionsReadinessGate)
+	out.QoSClassWeight = in.QoSClassWeight
+	out.WorkloadUnavailableWeight = in.WorkloadUnavailableWeight
+	out.EvictionCostAnnotationWeight = in.EvictionCostAnnotationWeight
+	return nil
+}
+
+// Convert_config_EvictionCostModel_To_v1alpha2_EvictionCostModel is an autogenerated conversion function.
+func Convert_config_EvictionCostModel_To_v1alpha2_EvictionCostModel(in *config.EvictionCostModel, out *EvictionCostModel, s conversion.Scope) error {
+	return autoConvert_config_EvictionCostModel_To_v1alpha2_EvictionCostModel(in, out, s)
+}
+
+func autoConvert_v1alpha2_GPUFragmentationArgs_To_config_GPUFragmentationArgs(in *GPUFragmentationArgs, out *config.GPUFragmentationArgs, s conversion.Scope) error {
+	if err := v1.Convert_Pointer_bool_To_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_bool_To_bool(&in.DryRun, &out.DryRun, s); err != nil {
+		return err
+	}
+	out.EvictableNamespaces = (*config.Namespaces)(unsafe.Pointer(in.EvictableNamespaces))
+	out.NodeSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NodeSelector))
+	if err := v1.Convert_Pointer_bool_To_bool(&in.NodeFit, &out.NodeFit, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_int64_To_int64(&in.MaxPodGPUCore, &out.MaxPodGPUCore, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_int64_To_int64(&in.FragmentationThreshold, &out.FragmentationThreshold, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_int32_To_int32(&in.MaxGPUsPerRound, &out.MaxGPUsPerRound, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha2_GPUFragmentationArgs_To_config_GPUFragmentationArgs is an autogenerated conversion function.
+func Convert_v1alpha2_GPUFragmentationArgs_To_config_GPUFragmentationArgs(in *GPUFragmentationArgs, out *config.GPUFragmentationArgs, s conversion.Scope) error {
+	return autoConvert_v1alpha2_GPUFragmentationArgs_To_config_GPUFragmentationArgs(in, out, s)
+}
+
+func autoConvert_config_GPUFragmentationArgs_To_v1alpha2_GPUFragmentationArgs(in *config.GPUFragmentationArgs, out *GPUFragmentationArgs, s conversion.Scope) error {
+	if err := v1.Convert_bool_To_Pointer_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_bool_To_Pointer_bool(&in.DryRun, &out.DryRun, s); err != nil {
+		return err
+	}
+	out.EvictableNamespaces = (*Namespaces)(unsafe.Pointer(in.EvictableNamespaces))
+	out.NodeSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NodeSelector))
+	if err := v1.Convert_bool_To_Pointer_bool(&in.NodeFit, &out.NodeFit, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_int64_To_Pointer_int64(&in.MaxPodGPUCore, &out.MaxPodGPUCore, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_int64_To_Pointer_int64(&in.FragmentationThreshold, &out.FragmentationThreshold, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_int32_To_Pointer_int32(&in.MaxGPUsPerRound, &out.MaxGPUsPerRound, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_config_GPUFragmentationArgs_To_v1alpha2_GPUFragmentationArgs is an autogenerated conversion function.
+func Convert_config_GPUFragmentationArgs_To_v1alpha2_GPUFragmentationArgs(in *config.GPUFragmentationArgs, out *GPUFragmentationArgs, s conversion.Scope) error {
+	return autoConvert_config_GPUFragmentationArgs_To_v1alpha2_GPUFragmentationArgs(in, out, s)
+}
+
+func autoConvert_v1alpha2_HighNodeLoadArgs_To_config_HighNodeLoadArgs(in *HighNodeLoadArgs, out *config.HighNodeLoadArgs, s conversion.Scope) error {
+	if err := v1.Convert_Pointer_bool_To_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_bool_To_bool(&in.DryRun, &out.DryRun, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_int32_To_int32(&in.NumberOfNodes, &out.NumberOfNodes, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_int32_To_int32(&in.MaxNodesToDrain, &out.MaxNodesToDrain, s); err != nil {
+		return err
+	}
+	out.EvictableNamespaces = (*config.Namespaces)(unsafe.Pointer(in.EvictableNamespaces))
+	out.NodeSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NodeSelector))
+	out.PodSelectors = *(*[]config.LowNodeLoadPodSelector)(unsafe.Pointer(&in.PodSelectors))
+	if err := v1.Convert_Pointer_bool_To_bool(&in.NodeFit, &out.NodeFit, s); err != nil {
+		return err
+	}
+	out.HighThresholds = *(*config.ResourceThresholds)(unsafe.Pointer(&in.HighThresholds))
+	out.LowThresholds = *(*config.ResourceThresholds)(unsafe.Pointer(&in.LowThresholds))
+	out.ResourceWeights = *(*map[corev1.ResourceName]int64)(unsafe.Pointer(&in.ResourceWeights))
+	if in.AnomalyCondition != nil {
+		in, out := &in.AnomalyCondition, &out.AnomalyCondition
+		*out = new(config.LoadAnomalyCondition)
+		if err := Convert_v1alpha2_LoadAnomalyCondition_To_config_LoadAnomalyCondition(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AnomalyCondition = nil
+	}
+	if in.NodePools != nil {
+		in, out := &in.NodePools, &out.NodePools
+		*out = make([]config.LowNodeLoadNodePool, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_LowNodeLoadNodePool_To_config_LowNodeLoadNodePool(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.NodePools = nil
+	}
+	return nil
+}
+
+func autoConvert_config_HighNodeLoadArgs_To_v1alpha2_HighNodeLoadArgs(in *config.HighNodeLoadArgs, out *HighNodeLoadArgs, s conversion.Scope) error {
+	if err := v1.Convert_bool_To_Pointer_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_bool_To_Pointer_bool(&in.DryRun, &out.DryRun, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_int32_To_Pointer_int32(&in.NumberOfNodes, &out.NumberOfNodes, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_int32_To_Pointer_int32(&in.MaxNodesToDrain, &out.MaxNodesToDrain, s); err != nil {
+		return err
+	}
+	out.EvictableNamespaces = (*Namespaces)(unsafe.Pointer(in.EvictableNamespaces))
+	out.NodeSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NodeSelector))
+	out.PodSelectors = *(*[]LowNodeLoadPodSelector)(unsafe.Pointer(&in.PodSelectors))
+	if err := v1.Convert_bool_To_Pointer_bool(&in.NodeFit, &out.NodeFit, s); err != nil {
+		return err
+	}
+	out.HighThresholds = *(*ResourceThresholds)(unsafe.Pointer(&in.HighThresholds))
+	out.LowThresholds = *(*ResourceThresholds)(unsafe.Pointer(&in.LowThresholds))
+	out.ResourceWeights = *(*map[corev1.ResourceName]int64)(unsafe.Pointer(&in.ResourceWeights))
+	if in.AnomalyCondition != nil {
+		in, out := &in.AnomalyCondition, &out.AnomalyCondition
+		*out = new(LoadAnomalyCondition)
+		if err := Convert_config_LoadAnomalyCondition_To_v1alpha2_LoadAnomalyCondition(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AnomalyCondition = nil
+	}
+	if in.NodePools != nil {
+		in, out := &in.NodePools, &out.NodePools
+		*out = make([]LowNodeLoadNodePool, len(*in))
+		for i := range *in {
+			if err := Convert_config_LowNodeLoadNodePool_To_v1alpha2_LowNodeLoadNodePool(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.NodePools = nil
+	}
+	return nil
+}
+
+// Convert_config_HighNodeLoadArgs_To_v1alpha2_HighNodeLoadArgs is an autogenerated conversion function.
+func Convert_config_HighNodeLoadArgs_To_v1alpha2_HighNodeLoadArgs(in *config.HighNodeLoadArgs, out *HighNodeLoadArgs, s conversion.Scope) error {
+	return autoConvert_config_HighNodeLoadArgs_To_v1alpha2_HighNodeLoadArgs(in, out, s)
+}
+
+func autoConvert_v1alpha2_InterferenceAwareArgs_To_config_InterferenceAwareArgs(in *InterferenceAwareArgs, out *config.InterferenceAwareArgs, s conversion.Scope) error {
+	if err := v1.Convert_Pointer_bool_To_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_bool_To_bool(&in.DryRun, &out.DryRun, s); err != nil {
+		return err
+	}
+	out.EvictableNamespaces = (*config.Namespaces)(unsafe.Pointer(in.EvictableNamespaces))
+	out.NodeSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NodeSelector))
+	if err := v1.Convert_Pointer_bool_To_bool(&in.NodeFit, &out.NodeFit, s); err != nil {
+		return err
+	}
+	out.Thresholds = (*config.InterferenceThresholds)(unsafe.Pointer(in.Thresholds))
+	if err := v1.Convert_Pointer_int32_To_int32(&in.MinVictims, &out.MinVictims, s); err != nil {
+		return err
+	}
+	out.EvictionTarget = config.InterferenceEvictionTarget(in.EvictionTarget)
+	if err := v1.Convert_Pointer_int32_To_int32(&in.MaxPodsToEvictPerNode, &out.MaxPodsToEvictPerNode, s); err != nil {
+		return err
+	}
+	if in.AnomalyCondition != nil {
+		in, out := &in.AnomalyCondition, &out.AnomalyCondition
+		*out = new(config.LoadAnomalyCondition)
+		if err := Convert_v1alpha2_LoadAnomalyCondition_To_config_LoadAnomalyCondition(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AnomalyCondition = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha2_InterferenceAwareArgs_To_config_InterferenceAwareArgs is an autogenerated conversion function.
+func Convert_v1alpha2_InterferenceAwareArgs_To_config_InterferenceAwareArgs(in *InterferenceAwareArgs, out *config.InterferenceAwareArgs, s conversion.Scope) error {
+	return autoConvert_v1alpha2_InterferenceAwareArgs_To_config_InterferenceAwareArgs(in, out, s)
+}
+
+func autoConvert_config_InterferenceAwareArgs_To_v1alpha2_InterferenceAwareArgs(in *config.InterferenceAwareArgs, out *InterferenceAwareArgs, s conversion.Scope) error {
+	if err := v1.Convert_bool_To_Pointer_bool(&in.Paused, &out.Paused, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_bool_To_Pointer_bool(&in.DryRun, &out.DryRun, s); err != nil {
+		return err
+	}
+	out.EvictableNamespaces = (*Namespaces)(unsafe.Pointer(in.EvictableNamespaces))
+	out.NodeSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NodeSelector))
+	if err := v1.Convert_bool_To_Pointer_bool(&in.NodeFit, &out.NodeFit, s); err != nil {
+		return err
+	}
+	out.Thresholds = (*InterferenceThresholds)(unsafe.Pointer(in.Thresholds))
+	if err := v1.Convert_int32_To_Pointer_int32(&in.MinVictims, &out.MinVictims, s); err != nil {
+		return err
+	}
+	out.EvictionTarget = InterferenceEvictionTarget(in.EvictionTarget)
+	if err := v1.Convert_int32_To_Pointer_int32(&in.MaxPodsToEvictPerNode, &out.MaxPodsToEvictPerNode, s); err != nil {
+		return err
+	}
+	if in.AnomalyCondition != nil {
+		in, out := &in.AnomalyCondition, &out.AnomalyCondition
+		*out = new(LoadAnomalyCondition)
+		if err := Convert_config_LoadAnomalyCondition_To_v1alpha2_LoadAnomalyCondition(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AnomalyCondition = nil
+	}
+	return nil
+}
+
+// Convert_config_InterferenceAwareArgs_To_v1alpha2_InterferenceAwareArgs is an autogenerated conversion function.
+func Convert_config_InterferenceAwareArgs_To_v1alpha2_InterferenceAwareArgs(in *config.InterferenceAwareArgs, out *InterferenceAwareArgs, s conversion.Scope) error {
+	return autoConvert_config_InterferenceAwareArgs_To_v1alpha2_InterferenceAwareArgs(in, out, s)
+}
+
+func autoConvert_v1alpha2_InterferenceThresholds_To_config_InterferenceThresholds(in *InterferenceThresholds, out *config.InterferenceThresholds, s conversion.Scope) error {
+	out.CPUPressure = in.CPUPressure
+	out.MemoryPressure = in.MemoryPressure
+	out.IOPressure = in.IOPressure
+	out.MilliCPI = in.MilliCPI
+	return nil
+}
+
+// Convert_v1alpha2_InterferenceThresholds_To_config_InterferenceThresholds is an autogenerated conversion function.
+func Convert_v1alpha2_InterferenceThresholds_To_config_InterferenceThresholds(in *InterferenceThresholds, out *config.InterferenceThresholds, s conversion.Scope) error {
+	return autoConvert_v1alpha2_InterferenceThresholds_To_config_InterferenceThresholds(in, out, s)
+}
+
+func autoConvert_config_InterferenceThresholds_To_v1alpha2_InterferenceThresholds(in *config.InterferenceThresholds, out *InterferenceThresholds, s conversion.Scope) error {
+	out.CPUPressure = in.CPUPressure
+	out.MemoryPressure = in.MemoryPressure
+	out.IOPressure = in.IOPressure
+	out.MilliCPI = in.MilliCPI
+	return nil
+}
+
+// Convert_config_InterferenceThresholds_To_v1alpha2_InterferenceThresholds is an autogenerated conversion function.
+func Convert_config_InterferenceThresholds_To_v1alpha2_InterferenceThresholds(in *config.InterferenceThresholds, out *InterferenceThresholds, s conversion.Scope) error {
+	return autoConvert_config_InterferenceThresholds_To_v1alpha2_InterferenceThresholds(in, out, s)
+}
+
+func autoConvert_v1alpha2_LoadAggregatedUsage_To_config_LoadAggregatedUsage(in *LoadAggregatedUsage, out *config.LoadAggregatedUsage, s conversion.Scope) error {
+	out.UsageAggregationType = extension.AggregationType(in.UsageAggregationType)
+	out.UsageAggregatedDuration = in.UsageAggregatedDuration
+	return nil
+}
+
+// Convert_v1alpha2_LoadAggregatedUsage_To_config_LoadAggregatedUsage is an autogenerated conversion function.
+func Convert_v1alpha2_LoadAggregatedUsage_To_config_LoadAggregatedUsage(in *LoadAggregatedUsage, out *config.LoadAggregatedUsage, s conversion.Scope) error {
+	return autoConvert_v1alpha2_LoadAggregatedUsage_To_config_LoadAggregatedUsage(in, out, s)
+}
+
+func autoConvert_config_LoadAggregatedUsage_To_v1alpha2_LoadAggregatedUsage(in *config.LoadAggregatedUsage, out *LoadAggregatedUsage, s conversion.Scope) error {
+	out.UsageAggregationType = extension.AggregationType(in.UsageAggregationType)
+	out.UsageAggregatedDuration = in.UsageAggregatedDuration
+	return nil
+}
+
+// Convert_config_LoadAggregatedUsage_To_v1alpha2_LoadAggregatedUsage is an autogenerated conversion function.
+func Convert_config_LoadAggregatedUsage_To_v1alpha2_LoadAggregatedUsage(in *config.LoadAggregatedUsage, out *LoadAggregatedUsage, s conversion.Scope) error {
+	return autoConvert_config_LoadAggregatedUsage_To_v1alpha2_LoadAggregatedUsage(in, out, s)
+}
+
 func autoConvert_v1alpha2_LoadAnomalyCondition_To_config_LoadAnomalyCondition(in *LoadAnomalyCondition, out *config.LoadAnomalyCondition, s conversion.Scope) error {
 	if err := v1.Convert_Pointer_v1_Duration_To_v1_Duration(&in.Timeout, &out.Timeout, s); err != nil {
 		return err
@@ -356,6 +820,9 @@ func autoConvert_v1alpha2_LowNodeLoadArgs_To_config_LowNodeLoadArgs(in *LowNodeL
 	} else {
 		out.AnomalyCondition = nil
 	}
+	out.ProdHighThresholds = *(*config.ResourceThresholds)(unsafe.Pointer(&in.ProdHighThresholds))
+	out.ProdLowThresholds = *(*config.ResourceThresholds)(unsafe.Pointer(&in.ProdLowThresholds))
+	out.Aggregated = (*config.LoadAggregatedUsage)(unsafe.Pointer(in.Aggregated))
 	if in.NodePools != nil {
 		in, out := &in.NodePools, &out.NodePools
 		*out = make([]config.LowNodeLoadNodePool, len(*in))
@@ -367,6 +834,7 @@ func autoConvert_v1alpha2_LowNodeLoadArgs_To_config_LowNodeLoadArgs(in *LowNodeL
 	} else {
 		out.NodePools = nil
 	}
+	out.EvictionCostModel = (*config.EvictionCostModel)(unsafe.Pointer(in.EvictionCostModel))
 	return nil
 }
 
@@ -401,6 +869,9 @@ func autoConvert_config_LowNodeLoadArgs_To_v1alpha2_LowNodeLoadArgs(in *config.L
 	} else {
 		out.AnomalyCondition = nil
 	}
+	out.ProdHighThresholds = *(*ResourceThresholds)(unsafe.Pointer(&in.ProdHighThresholds))
+	out.ProdLowThresholds = *(*ResourceThresholds)(unsafe.Pointer(&in.ProdLowThresholds))
+	out.Aggregated = (*LoadAggregatedUsage)(unsafe.Pointer(in.Aggregated))
 	if in.NodePools != nil {
 		in, out := &in.NodePools, &out.NodePools
 		*out = make([]LowNodeLoadNodePool, len(*in))
@@ -412,6 +883,7 @@ func autoConvert_config_LowNodeLoadArgs_To_v1alpha2_LowNodeLoadArgs(in *config.L
 	} else {
 		out.NodePools = nil
 	}
+	out.EvictionCostModel = (*EvictionCostModel)(unsafe.Pointer(in.EvictionCostModel))
 	return nil
 }
 
@@ -436,6 +908,9 @@ func autoConvert_v1alpha2_LowNodeLoadNodePool_To_config_LowNodeLoadNodePool(in *
 	} else {
 		out.AnomalyCondition = nil
 	}
+	out.ProdHighThresholds = *(*config.ResourceThresholds)(unsafe.Pointer(&in.ProdHighThresholds))
+	out.ProdLowThresholds = *(*config.ResourceThresholds)(unsafe.Pointer(&in.ProdLowThresholds))
+	out.Aggregated = (*config.LoadAggregatedUsage)(unsafe.Pointer(in.Aggregated))
 	return nil
 }
 
@@ -460,6 +935,9 @@ func autoConvert_config_LowNodeLoadNodePool_To_v1alpha2_LowNodeLoadNodePool(in *
 	} else {
 		out.AnomalyCondition = nil
 	}
+	out.ProdHighThresholds = *(*ResourceThresholds)(unsafe.Pointer(&in.ProdHighThresholds))
+	out.ProdLowThresholds = *(*ResourceThresholds)(unsafe.Pointer(&in.ProdLowThresholds))
+	out.Aggregated = (*LoadAggregatedUsage)(unsafe.Pointer(in.Aggregated))
 	return nil
 }
 
@@ -521,6 +999,9 @@ func autoConvert_v1alpha2_MigrationControllerArgs_To_config_MigrationControllerA
 	}
 	out.EvictionPolicy = in.EvictionPolicy
 	out.DefaultDeleteOptions = (*v1.DeleteOptions)(unsafe.Pointer(in.DefaultDeleteOptions))
+	out.ArbitrationArgs = (*config.ArbitrationArgs)(unsafe.Pointer(in.ArbitrationArgs))
+	out.VerificationArgs = (*config.VerificationArgs)(unsafe.Pointer(in.VerificationArgs))
+	out.WorkloadRules = *(*[]config.WorkloadRule)(unsafe.Pointer(&in.WorkloadRules))
 	return nil
 }
 
@@ -560,6 +1041,9 @@ func autoConvert_config_MigrationControllerArgs_To_v1alpha2_MigrationControllerA
 	out.EvictionPolicy = in.EvictionPolicy
 	out.DefaultDeleteOptions = (*v1.DeleteOptions)(unsafe.Pointer(in.DefaultDeleteOptions))
 	out.SchedulerNames = *(*[]string)(unsafe.Pointer(&in.SchedulerNames))
+	out.ArbitrationArgs = (*ArbitrationArgs)(unsafe.Pointer(in.ArbitrationArgs))
+	out.VerificationArgs = (*VerificationArgs)(unsafe.Pointer(in.VerificationArgs))
+	out.WorkloadRules = *(*[]WorkloadRule)(unsafe.Pointer(&in.WorkloadRules))
 	return nil
 }
 
@@ -614,6 +1098,7 @@ func Convert_config_Namespaces_To_v1alpha2_Namespaces(in *config.Namespaces, out
 
 func autoConvert_v1alpha2_Plugin_To_config_Plugin(in *Plugin, out *config.Plugin, s conversion.Scope) error {
 	out.Name = in.Name
+	out.Windows = *(*[]config.DeschedulingWindow)(unsafe.Pointer(&in.Windows))
 	return nil
 }
 
@@ -624,6 +1109,7 @@ func Convert_v1alpha2_Plugin_To_config_Plugin(in *Plugin, out *config.Plugin, s
 
 func autoConvert_config_Plugin_To_v1alpha2_Plugin(in *config.Plugin, out *Plugin, s conversion.Scope) error {
 	out.Name = in.Name
+	out.Windows = *(*[]DeschedulingWindow)(unsafe.Pointer(&in.Windows))
 	return nil
 }
 
@@ -743,3 +1229,59 @@ func autoConvert_config_PriorityThreshold_To_v1alpha2_PriorityThreshold(in *conf
 func Convert_config_PriorityThreshold_To_v1alpha2_PriorityThreshold(in *config.PriorityThreshold, out *PriorityThreshold, s conversion.Scope) error {
 	return autoConvert_config_PriorityThreshold_To_v1alpha2_PriorityThreshold(in, out, s)
 }
+
+func autoConvert_v1alpha2_VerificationArgs_To_config_VerificationArgs(in *VerificationArgs, out *config.VerificationArgs, s conversion.Scope) error {
+	out.HealthyDuration = in.HealthyDuration
+	out.MaxRestarts = in.MaxRestarts
+	out.VerifySourceNodeLoad = in.VerifySourceNodeLoad
+	out.MinSourceNodeLoadDropPercent = in.MinSourceNodeLoadDropPercent
+	out.InitialBackoff = in.InitialBackoff
+	out.MaxBackoff = in.MaxBackoff
+	return nil
+}
+
+// Convert_v1alpha2_VerificationArgs_To_config_VerificationArgs is an autogenerated conversion function.
+func Convert_v1alpha2_VerificationArgs_To_config_VerificationArgs(in *VerificationArgs, out *config.VerificationArgs, s conversion.Scope) error {
+	return autoConvert_v1alpha2_VerificationArgs_To_config_VerificationArgs(in, out, s)
+}
+
+func autoConvert_config_VerificationArgs_To_v1alpha2_VerificationArgs(in *config.VerificationArgs, out *VerificationArgs, s conversion.Scope) error {
+	out.HealthyDuration = in.HealthyDuration
+	out.MaxRestarts = in.MaxRestarts
+	out.VerifySourceNodeLoad = in.VerifySourceNodeLoad
+	out.MinSourceNodeLoadDropPercent = in.MinSourceNodeLoadDropPercent
+	out.InitialBackoff = in.InitialBackoff
+	out.MaxBackoff = in.MaxBackoff
+	return nil
+}
+
+// Convert_config_VerificationArgs_To_v1alpha2_VerificationArgs is an autogenerated conversion function.
+func Convert_config_VerificationArgs_To_v1alpha2_VerificationArgs(in *config.VerificationArgs, out *VerificationArgs, s conversion.Scope) error {
+	return autoConvert_config_VerificationArgs_To_v1alpha2_VerificationArgs(in, out, s)
+}
+
+func autoConvert_v1alpha2_WorkloadRule_To_config_WorkloadRule(in *WorkloadRule, out *config.WorkloadRule, s conversion.Scope) error {
+	out.APIVersion = in.APIVersion
+	out.Kind = in.Kind
+	out.ReplicasPath = in.ReplicasPath
+	out.SelectorPath = in.SelectorPath
+	return nil
+}
+
+// Convert_v1alpha2_WorkloadRule_To_config_WorkloadRule is an autogenerated conversion function.
+func Convert_v1alpha2_WorkloadRule_To_config_WorkloadRule(in *WorkloadRule, out *config.WorkloadRule, s conversion.Scope) error {
+	return autoConvert_v1alpha2_WorkloadRule_To_config_WorkloadRule(in, out, s)
+}
+
+func autoConvert_config_WorkloadRule_To_v1alpha2_WorkloadRule(in *config.WorkloadRule, out *WorkloadRule, s conversion.Scope) error {
+	out.APIVersion = in.APIVersion
+	out.Kind = in.Kind
+	out.ReplicasPath = in.ReplicasPath
+	out.SelectorPath = in.SelectorPath
+	return nil
+}
+
+// Convert_config_WorkloadRule_To_v1alpha2_WorkloadRule is an autogenerated conversion function.
+func Convert_config_WorkloadRule_To_v1alpha2_WorkloadRule(in *config.WorkloadRule, out *WorkloadRule, s conversion.Scope) error {
+	return autoConvert_config_WorkloadRule_To_v1alpha2_WorkloadRule(in, out, s)
+}
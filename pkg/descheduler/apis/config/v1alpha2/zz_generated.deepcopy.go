@@ -29,6 +29,33 @@ import (
 	intstr "k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArbitrationArgs) DeepCopyInto(out *ArbitrationArgs) {
+	*out = *in
+	out.Interval = in.Interval
+	if in.SourcePriority != nil {
+		in, out := &in.SourcePriority, &out.SourcePriority
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EvictionCostModel != nil {
+		in, out := &in.EvictionCostModel, &out.EvictionCostModel
+		*out = new(EvictionCostModel)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArbitrationArgs.
+func (in *ArbitrationArgs) DeepCopy() *ArbitrationArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(ArbitrationArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeschedulerConfiguration) DeepCopyInto(out *DeschedulerConfiguration) {
 	*out = *in
@@ -105,6 +132,13 @@ func (in *DeschedulerProfile) DeepCopyInto(out *DeschedulerProfile) {
 		*out = new(Plugins)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]DeschedulingWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -118,6 +152,313 @@ func (in *DeschedulerProfile) DeepCopy() *DeschedulerProfile {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeschedulingWindow) DeepCopyInto(out *DeschedulingWindow) {
+	*out = *in
+	out.Duration = in.Duration
+	if in.MaxEvictions != nil {
+		in, out := &in.MaxEvictions, &out.MaxEvictions
+		*out = new(uint)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeschedulingWindow.
+func (in *DeschedulingWindow) DeepCopy() *DeschedulingWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(DeschedulingWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EvictionCostModel) DeepCopyInto(out *EvictionCostModel) {
+	*out = *in
+	out.MaxPodAge = in.MaxPodAge
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvictionCostModel.
+func (in *EvictionCostModel) DeepCopy() *EvictionCostModel {
+	if in == nil {
+		return nil
+	}
+	out := new(EvictionCostModel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUFragmentationArgs) DeepCopyInto(out *GPUFragmentationArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Paused != nil {
+		in, out := &in.Paused, &out.Paused
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EvictableNamespaces != nil {
+		in, out := &in.EvictableNamespaces, &out.EvictableNamespaces
+		*out = new(Namespaces)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeFit != nil {
+		in, out := &in.NodeFit, &out.NodeFit
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxPodGPUCore != nil {
+		in, out := &in.MaxPodGPUCore, &out.MaxPodGPUCore
+		*out = new(int64)
+		**out = **in
+	}
+	if in.FragmentationThreshold != nil {
+		in, out := &in.FragmentationThreshold, &out.FragmentationThreshold
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxGPUsPerRound != nil {
+		in, out := &in.MaxGPUsPerRound, &out.MaxGPUsPerRound
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUFragmentationArgs.
+func (in *GPUFragmentationArgs) DeepCopy() *GPUFragmentationArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUFragmentationArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GPUFragmentationArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HighNodeLoadArgs) DeepCopyInto(out *HighNodeLoadArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Paused != nil {
+		in, out := &in.Paused, &out.Paused
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NumberOfNodes != nil {
+		in, out := &in.NumberOfNodes, &out.NumberOfNodes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxNodesToDrain != nil {
+		in, out := &in.MaxNodesToDrain, &out.MaxNodesToDrain
+		*out = new(int32)
+		**out = **in
+	}
+	if in.EvictableNamespaces != nil {
+		in, out := &in.EvictableNamespaces, &out.EvictableNamespaces
+		*out = new(Namespaces)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSelectors != nil {
+		in, out := &in.PodSelectors, &out.PodSelectors
+		*out = make([]LowNodeLoadPodSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeFit != nil {
+		in, out := &in.NodeFit, &out.NodeFit
+		*out = new(bool)
+		**out = **in
+	}
+	if in.HighThresholds != nil {
+		in, out := &in.HighThresholds, &out.HighThresholds
+		*out = make(ResourceThresholds, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LowThresholds != nil {
+		in, out := &in.LowThresholds, &out.LowThresholds
+		*out = make(ResourceThresholds, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ResourceWeights != nil {
+		in, out := &in.ResourceWeights, &out.ResourceWeights
+		*out = make(map[corev1.ResourceName]int64, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AnomalyCondition != nil {
+		in, out := &in.AnomalyCondition, &out.AnomalyCondition
+		*out = new(LoadAnomalyCondition)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodePools != nil {
+		in, out := &in.NodePools, &out.NodePools
+		*out = make([]LowNodeLoadNodePool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HighNodeLoadArgs.
+func (in *HighNodeLoadArgs) DeepCopy() *HighNodeLoadArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(HighNodeLoadArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HighNodeLoadArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterferenceAwareArgs) DeepCopyInto(out *InterferenceAwareArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Paused != nil {
+		in, out := &in.Paused, &out.Paused
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EvictableNamespaces != nil {
+		in, out := &in.EvictableNamespaces, &out.EvictableNamespaces
+		*out = new(Namespaces)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeFit != nil {
+		in, out := &in.NodeFit, &out.NodeFit
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Thresholds != nil {
+		in, out := &in.Thresholds, &out.Thresholds
+		*out = new(InterferenceThresholds)
+		**out = **in
+	}
+	if in.MinVictims != nil {
+		in, out := &in.MinVictims, &out.MinVictims
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxPodsToEvictPerNode != nil {
+		in, out := &in.MaxPodsToEvictPerNode, &out.MaxPodsToEvictPerNode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AnomalyCondition != nil {
+		in, out := &in.AnomalyCondition, &out.AnomalyCondition
+		*out = new(LoadAnomalyCondition)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterferenceAwareArgs.
+func (in *InterferenceAwareArgs) DeepCopy() *InterferenceAwareArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(InterferenceAwareArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InterferenceAwareArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterferenceThresholds) DeepCopyInto(out *InterferenceThresholds) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterferenceThresholds.
+func (in *InterferenceThresholds) DeepCopy() *InterferenceThresholds {
+	if in == nil {
+		return nil
+	}
+	out := new(InterferenceThresholds)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadAggregatedUsage) DeepCopyInto(out *LoadAggregatedUsage) {
+	*out = *in
+	out.UsageAggregatedDuration = in.UsageAggregatedDuration
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadAggregatedUsage.
+func (in *LoadAggregatedUsage) DeepCopy() *LoadAggregatedUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadAggregatedUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LoadAnomalyCondition) DeepCopyInto(out *LoadAnomalyCondition) {
 	*out = *in
@@ -211,6 +552,25 @@ func (in *LowNodeLoadArgs) DeepCopyInto(out *LowNodeLoadArgs) {
 		*out = new(LoadAnomalyCondition)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ProdHighThresholds != nil {
+		in, out := &in.ProdHighThresholds, &out.ProdHighThresholds
+		*out = make(ResourceThresholds, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ProdLowThresholds != nil {
+		in, out := &in.ProdLowThresholds, &out.ProdLowThresholds
+		*out = make(ResourceThresholds, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Aggregated != nil {
+		in, out := &in.Aggregated, &out.Aggregated
+		*out = new(LoadAggregatedUsage)
+		**out = **in
+	}
 	if in.NodePools != nil {
 		in, out := &in.NodePools, &out.NodePools
 		*out = make([]LowNodeLoadNodePool, len(*in))
@@ -218,6 +578,11 @@ func (in *LowNodeLoadArgs) DeepCopyInto(out *LowNodeLoadArgs) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.EvictionCostModel != nil {
+		in, out := &in.EvictionCostModel, &out.EvictionCostModel
+		*out = new(EvictionCostModel)
+		**out = **in
+	}
 	return
 }
 
@@ -273,6 +638,25 @@ func (in *LowNodeLoadNodePool) DeepCopyInto(out *LowNodeLoadNodePool) {
 		*out = new(LoadAnomalyCondition)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ProdHighThresholds != nil {
+		in, out := &in.ProdHighThresholds, &out.ProdHighThresholds
+		*out = make(ResourceThresholds, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ProdLowThresholds != nil {
+		in, out := &in.ProdLowThresholds, &out.ProdLowThresholds
+		*out = make(ResourceThresholds, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Aggregated != nil {
+		in, out := &in.Aggregated, &out.Aggregated
+		*out = new(LoadAggregatedUsage)
+		**out = **in
+	}
 	return
 }
 
@@ -388,6 +772,21 @@ func (in *MigrationControllerArgs) DeepCopyInto(out *MigrationControllerArgs) {
 		*out = new(v1.DeleteOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ArbitrationArgs != nil {
+		in, out := &in.ArbitrationArgs, &out.ArbitrationArgs
+		*out = new(ArbitrationArgs)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VerificationArgs != nil {
+		in, out := &in.VerificationArgs, &out.VerificationArgs
+		*out = new(VerificationArgs)
+		**out = **in
+	}
+	if in.WorkloadRules != nil {
+		in, out := &in.WorkloadRules, &out.WorkloadRules
+		*out = make([]WorkloadRule, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -482,6 +881,13 @@ func (in ObjectLimiterMap) DeepCopy() ObjectLimiterMap {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Plugin) DeepCopyInto(out *Plugin) {
 	*out = *in
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]DeschedulingWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -518,12 +924,16 @@ func (in *PluginSet) DeepCopyInto(out *PluginSet) {
 	if in.Enabled != nil {
 		in, out := &in.Enabled, &out.Enabled
 		*out = make([]Plugin, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Disabled != nil {
 		in, out := &in.Disabled, &out.Disabled
 		*out = make([]Plugin, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
@@ -600,3 +1010,38 @@ func (in ResourceThresholds) DeepCopy() ResourceThresholds {
 	in.DeepCopyInto(out)
 	return *out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerificationArgs) DeepCopyInto(out *VerificationArgs) {
+	*out = *in
+	out.HealthyDuration = in.HealthyDuration
+	out.InitialBackoff = in.InitialBackoff
+	out.MaxBackoff = in.MaxBackoff
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerificationArgs.
+func (in *VerificationArgs) DeepCopy() *VerificationArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(VerificationArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadRule) DeepCopyInto(out *WorkloadRule) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadRule.
+func (in *WorkloadRule) DeepCopy() *WorkloadRule {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadRule)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -30,6 +30,9 @@ import (
 // All generated defaulters are covering - they call all nested defaulters.
 func RegisterDefaults(scheme *runtime.Scheme) error {
 	scheme.AddTypeDefaultingFunc(&DeschedulerConfiguration{}, func(obj interface{}) { SetObjectDefaults_DeschedulerConfiguration(obj.(*DeschedulerConfiguration)) })
+	scheme.AddTypeDefaultingFunc(&GPUFragmentationArgs{}, func(obj interface{}) { SetObjectDefaults_GPUFragmentationArgs(obj.(*GPUFragmentationArgs)) })
+	scheme.AddTypeDefaultingFunc(&HighNodeLoadArgs{}, func(obj interface{}) { SetObjectDefaults_HighNodeLoadArgs(obj.(*HighNodeLoadArgs)) })
+	scheme.AddTypeDefaultingFunc(&InterferenceAwareArgs{}, func(obj interface{}) { SetObjectDefaults_InterferenceAwareArgs(obj.(*InterferenceAwareArgs)) })
 	scheme.AddTypeDefaultingFunc(&LowNodeLoadArgs{}, func(obj interface{}) { SetObjectDefaults_LowNodeLoadArgs(obj.(*LowNodeLoadArgs)) })
 	scheme.AddTypeDefaultingFunc(&MigrationControllerArgs{}, func(obj interface{}) { SetObjectDefaults_MigrationControllerArgs(obj.(*MigrationControllerArgs)) })
 	return nil
@@ -39,6 +42,18 @@ func SetObjectDefaults_DeschedulerConfiguration(in *DeschedulerConfiguration) {
 	SetDefaults_DeschedulerConfiguration(in)
 }
 
+func SetObjectDefaults_GPUFragmentationArgs(in *GPUFragmentationArgs) {
+	SetDefaults_GPUFragmentationArgs(in)
+}
+
+func SetObjectDefaults_HighNodeLoadArgs(in *HighNodeLoadArgs) {
+	SetDefaults_HighNodeLoadArgs(in)
+}
+
+func SetObjectDefaults_InterferenceAwareArgs(in *InterferenceAwareArgs) {
+	SetDefaults_InterferenceAwareArgs(in)
+}
+
 func SetObjectDefaults_LowNodeLoadArgs(in *LowNodeLoadArgs) {
 	SetDefaults_LowNodeLoadArgs(in)
 }
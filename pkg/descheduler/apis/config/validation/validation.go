@@ -18,6 +18,7 @@ package validation
 
 import (
 	"reflect"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -28,6 +29,7 @@ import (
 
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/names"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/utils/window"
 )
 
 func ValidateDeschedulerConfiguration(cc *config.DeschedulerConfiguration) utilerrors.Aggregate {
@@ -72,6 +74,40 @@ func validateDeschedulerProfile(path *field.Path, profile *config.DeschedulerPro
 		errs = append(errs, field.Required(path.Child("name"), ""))
 	}
 	errs = append(errs, validatePluginConfig(path, profile)...)
+	errs = append(errs, validateDeschedulingWindows(path.Child("windows"), profile.Windows)...)
+	if profile.Plugins != nil {
+		pluginsPath := path.Child("plugins")
+		errs = append(errs, validatePluginSetWindows(pluginsPath.Child("deschedule"), &profile.Plugins.Deschedule)...)
+		errs = append(errs, validatePluginSetWindows(pluginsPath.Child("balance"), &profile.Plugins.Balance)...)
+	}
+	return errs
+}
+
+func validatePluginSetWindows(path *field.Path, pluginSet *config.PluginSet) []error {
+	var errs []error
+	for i := range pluginSet.Enabled {
+		errs = append(errs, validateDeschedulingWindows(path.Child("enabled").Index(i).Child("windows"), pluginSet.Enabled[i].Windows)...)
+	}
+	return errs
+}
+
+func validateDeschedulingWindows(path *field.Path, windows []config.DeschedulingWindow) []error {
+	var errs []error
+	for i := range windows {
+		w := &windows[i]
+		windowPath := path.Index(i)
+		if _, err := window.ParseSchedule(w.Schedule); err != nil {
+			errs = append(errs, field.Invalid(windowPath.Child("schedule"), w.Schedule, err.Error()))
+		}
+		if w.Duration.Duration <= 0 {
+			errs = append(errs, field.Invalid(windowPath.Child("duration"), w.Duration, "must be greater than 0"))
+		}
+		if w.TimeZone != "" {
+			if _, err := time.LoadLocation(w.TimeZone); err != nil {
+				errs = append(errs, field.Invalid(windowPath.Child("timeZone"), w.TimeZone, err.Error()))
+			}
+		}
+	}
 	return errs
 }
 
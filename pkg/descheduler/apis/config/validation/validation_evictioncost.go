@@ -0,0 +1,58 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+func validateEvictionCostModel(path *field.Path, model *deschedulerconfig.EvictionCostModel) field.ErrorList {
+	if model == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	weights := []struct {
+		name   string
+		weight int64
+	}{
+		{name: "podAgeWeight", weight: model.PodAgeWeight},
+		{name: "restartCountWeight", weight: model.RestartCountWeight},
+		{name: "connectionsWeight", weight: model.ConnectionsWeight},
+		{name: "qosClassWeight", weight: model.QoSClassWeight},
+		{name: "workloadUnavailableWeight", weight: model.WorkloadUnavailableWeight},
+		{name: "evictionCostAnnotationWeight", weight: model.EvictionCostAnnotationWeight},
+	}
+	var weightSum int64
+	for _, v := range weights {
+		if v.weight < 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child(v.name), v.weight, "must be greater than or equal to 0"))
+		}
+		weightSum += v.weight
+	}
+	if weightSum <= 0 {
+		allErrs = append(allErrs, field.Invalid(path, model, "at least one weight must be greater than 0"))
+	}
+	if model.PodAgeWeight > 0 && model.MaxPodAge.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("maxPodAge"), model.MaxPodAge, "must be greater than 0"))
+	}
+	if model.ConnectionsWeight > 0 && model.ConnectionsReadinessGate == "" {
+		allErrs = append(allErrs, field.Required(path.Child("connectionsReadinessGate"), "connectionsReadinessGate must be specified if connectionsWeight is set"))
+	}
+	return allErrs
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+func ValidateInterferenceAwareArgs(path *field.Path, args *deschedulerconfig.InterferenceAwareArgs) error {
+	var allErrs field.ErrorList
+
+	if args.EvictableNamespaces != nil && len(args.EvictableNamespaces.Include) > 0 && len(args.EvictableNamespaces.Exclude) > 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("evictableNamespaces"), args.EvictableNamespaces, "only one of Include/Exclude namespaces can be set"))
+	}
+
+	if args.NodeSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(args.NodeSelector); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("nodeSelector"), args.NodeSelector, err.Error()))
+		}
+	}
+
+	if args.Thresholds == nil {
+		allErrs = append(allErrs, field.Required(path.Child("thresholds"), "thresholds must be set"))
+	} else {
+		thresholdsPath := path.Child("thresholds")
+		pressures := []struct {
+			name  string
+			value int64
+		}{
+			{name: "cpuPressure", value: args.Thresholds.CPUPressure},
+			{name: "memoryPressure", value: args.Thresholds.MemoryPressure},
+			{name: "ioPressure", value: args.Thresholds.IOPressure},
+		}
+		for _, p := range pressures {
+			if p.value < 0 || p.value > 100 {
+				allErrs = append(allErrs, field.Invalid(thresholdsPath.Child(p.name), p.value, p.name+" must be in range [0, 100]"))
+			}
+		}
+		if args.Thresholds.MilliCPI < 0 {
+			allErrs = append(allErrs, field.Invalid(thresholdsPath.Child("milliCPI"), args.Thresholds.MilliCPI, "milliCPI must be greater than or equal to 0"))
+		}
+		if args.Thresholds.CPUPressure == 0 && args.Thresholds.MemoryPressure == 0 &&
+			args.Thresholds.IOPressure == 0 && args.Thresholds.MilliCPI == 0 {
+			allErrs = append(allErrs, field.Invalid(thresholdsPath, args.Thresholds, "at least one threshold must be set"))
+		}
+	}
+
+	if args.MinVictims <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("minVictims"), args.MinVictims, "minVictims must be greater than 0"))
+	}
+
+	if args.EvictionTarget != deschedulerconfig.InterferenceEvictionTargetVictim &&
+		args.EvictionTarget != deschedulerconfig.InterferenceEvictionTargetAggressor {
+		allErrs = append(allErrs, field.NotSupported(path.Child("evictionTarget"), args.EvictionTarget,
+			[]string{string(deschedulerconfig.InterferenceEvictionTargetVictim), string(deschedulerconfig.InterferenceEvictionTargetAggressor)}))
+	}
+
+	if args.MaxPodsToEvictPerNode <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("maxPodsToEvictPerNode"), args.MaxPodsToEvictPerNode, "maxPodsToEvictPerNode must be greater than 0"))
+	}
+
+	if args.AnomalyCondition != nil && args.AnomalyCondition.ConsecutiveAbnormalities <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("anomalyCondition", "consecutiveAbnormalities"), args.AnomalyCondition.ConsecutiveAbnormalities, "consecutiveAbnormalities must be greater than 0"))
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs.ToAggregate()
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/pointer"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config/v1alpha2"
+)
+
+func TestValidateInterferenceAwareArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    *v1alpha2.InterferenceAwareArgs
+		wantErr bool
+	}{
+		{
+			name:    "default args",
+			args:    &v1alpha2.InterferenceAwareArgs{},
+			wantErr: false,
+		},
+		{
+			name: "invalid evictableNamespaces",
+			args: &v1alpha2.InterferenceAwareArgs{
+				EvictableNamespaces: &v1alpha2.Namespaces{
+					Include: []string{"a"},
+					Exclude: []string{"b"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "pressure threshold out of range",
+			args: &v1alpha2.InterferenceAwareArgs{
+				Thresholds: &v1alpha2.InterferenceThresholds{
+					CPUPressure: 120,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "no threshold set",
+			args: &v1alpha2.InterferenceAwareArgs{
+				Thresholds: &v1alpha2.InterferenceThresholds{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "cpi threshold only",
+			args: &v1alpha2.InterferenceAwareArgs{
+				Thresholds: &v1alpha2.InterferenceThresholds{
+					MilliCPI: 2500,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid minVictims",
+			args: &v1alpha2.InterferenceAwareArgs{
+				MinVictims: pointer.Int32(0),
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported evictionTarget",
+			args: &v1alpha2.InterferenceAwareArgs{
+				EvictionTarget: "Neighbor",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid maxPodsToEvictPerNode",
+			args: &v1alpha2.InterferenceAwareArgs{
+				MaxPodsToEvictPerNode: pointer.Int32(0),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v1alpha2.SetDefaults_InterferenceAwareArgs(tt.args)
+			args := &deschedulerconfig.InterferenceAwareArgs{}
+			assert.NoError(t, v1alpha2.Convert_v1alpha2_InterferenceAwareArgs_To_config_InterferenceAwareArgs(tt.args, args, nil))
+			if err := ValidateInterferenceAwareArgs(nil, args); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateInterferenceAwareArgs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
+	"github.com/koordinator-sh/koordinator/apis/extension"
 	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
 )
 
@@ -42,36 +43,103 @@ func ValidateLowLoadUtilizationArgs(path *field.Path, args *deschedulerconfig.Lo
 		}
 	}
 
+	allErrs = append(allErrs, validateLowNodeLoadNodePools(path.Child("nodePools"), args.NodePools)...)
+	allErrs = append(allErrs, validateEvictionCostModel(path.Child("evictionCostModel"), args.EvictionCostModel)...)
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs.ToAggregate()
+}
+
+func ValidateHighNodeLoadArgs(path *field.Path, args *deschedulerconfig.HighNodeLoadArgs) error {
+	var allErrs field.ErrorList
+
+	if args.NumberOfNodes < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("numberOfNodes"), args.NumberOfNodes, "must be greater than or equal to 0"))
+	}
+
+	if args.MaxNodesToDrain <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("maxNodesToDrain"), args.MaxNodesToDrain, "must be greater than 0"))
+	}
+
+	if args.EvictableNamespaces != nil && len(args.EvictableNamespaces.Include) > 0 && len(args.EvictableNamespaces.Exclude) > 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("evictableNamespaces"), args.EvictableNamespaces, "only one of Include/Exclude namespaces can be set"))
+	}
+
+	for i, v := range args.PodSelectors {
+		if v.Selector != nil {
+			if _, err := metav1.LabelSelectorAsSelector(v.Selector); err != nil {
+				allErrs = append(allErrs, field.Invalid(path.Child("podSelectors").Index(i), v, err.Error()))
+			}
+		}
+	}
+
+	allErrs = append(allErrs, validateLowNodeLoadNodePools(path.Child("nodePools"), args.NodePools)...)
 	for i, nodePool := range args.NodePools {
-		nodePoolPath := path.Child("nodePools").Index(i)
+		if len(nodePool.ProdHighThresholds) > 0 || len(nodePool.ProdLowThresholds) > 0 {
+			allErrs = append(allErrs, field.Forbidden(path.Child("nodePools").Index(i), "prod thresholds are not supported by HighNodeLoad"))
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs.ToAggregate()
+}
+
+func validateLowNodeLoadNodePools(path *field.Path, nodePools []deschedulerconfig.LowNodeLoadNodePool) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, nodePool := range nodePools {
+		nodePoolPath := path.Index(i)
 		if nodePool.NodeSelector != nil {
 			if _, err := metav1.LabelSelectorAsSelector(nodePool.NodeSelector); err != nil {
 				allErrs = append(allErrs, field.Invalid(nodePoolPath.Child("nodeSelector"), nodePool.NodeSelector, err.Error()))
 			}
 		}
 
-		for resourceName, percentage := range nodePool.HighThresholds {
-			if percentage < 0 {
-				allErrs = append(allErrs, field.Invalid(nodePoolPath.Child("highThresholds").Key(string(resourceName)), percentage, "percentage must be greater than or equal to 0"))
+		allErrs = append(allErrs, validateThresholds(nodePoolPath, "highThresholds", "lowThresholds", nodePool.HighThresholds, nodePool.LowThresholds)...)
+
+		allErrs = append(allErrs, validateThresholds(nodePoolPath, "prodHighThresholds", "prodLowThresholds", nodePool.ProdHighThresholds, nodePool.ProdLowThresholds)...)
+		if nodePool.Aggregated != nil {
+			aggregatedPath := nodePoolPath.Child("aggregated")
+			switch nodePool.Aggregated.UsageAggregationType {
+			case extension.AVG, extension.P50, extension.P90, extension.P95, extension.P99:
+			default:
+				allErrs = append(allErrs, field.NotSupported(aggregatedPath.Child("usageAggregationType"), nodePool.Aggregated.UsageAggregationType,
+					[]string{string(extension.AVG), string(extension.P50), string(extension.P90), string(extension.P95), string(extension.P99)}))
 			}
-		}
-		for resourceName, percentage := range nodePool.LowThresholds {
-			if percentage < 0 {
-				allErrs = append(allErrs, field.Invalid(nodePoolPath.Child("lowThresholds").Key(string(resourceName)), percentage, "percentage must be greater than or equal to 0"))
+			if nodePool.Aggregated.UsageAggregatedDuration.Duration < 0 {
+				allErrs = append(allErrs, field.Invalid(aggregatedPath.Child("usageAggregatedDuration"), nodePool.Aggregated.UsageAggregatedDuration, "must be greater than or equal to 0"))
 			}
-			if highPercentage, ok := nodePool.HighThresholds[resourceName]; ok && percentage > highPercentage {
-				allErrs = append(allErrs, field.Invalid(nodePoolPath.Child("lowThresholds").Key(string(resourceName)), percentage, "low percentage must be less than or equal to highThresholds"))
+			if len(nodePool.ProdHighThresholds) > 0 || len(nodePool.ProdLowThresholds) > 0 {
+				allErrs = append(allErrs, field.Forbidden(aggregatedPath, "the aggregated usage of Prod Pods is not reported, aggregated and prod thresholds can not be set at the same time"))
 			}
 		}
 
-		if nodePool.AnomalyCondition.ConsecutiveAbnormalities <= 0 {
+		if nodePool.AnomalyCondition != nil && nodePool.AnomalyCondition.ConsecutiveAbnormalities <= 0 {
 			fieldPath := nodePoolPath.Child("anomalyDetectionThresholds").Child("consecutiveAbnormalities")
 			allErrs = append(allErrs, field.Invalid(fieldPath, nodePool.AnomalyCondition.ConsecutiveAbnormalities, "consecutiveAbnormalities must be greater than 0"))
 		}
 	}
 
-	if len(allErrs) == 0 {
-		return nil
+	return allErrs
+}
+
+func validateThresholds(path *field.Path, highName, lowName string, highThresholds, lowThresholds deschedulerconfig.ResourceThresholds) field.ErrorList {
+	var allErrs field.ErrorList
+	for resourceName, percentage := range highThresholds {
+		if percentage < 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child(highName).Key(string(resourceName)), percentage, "percentage must be greater than or equal to 0"))
+		}
 	}
-	return allErrs.ToAggregate()
+	for resourceName, percentage := range lowThresholds {
+		if percentage < 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child(lowName).Key(string(resourceName)), percentage, "percentage must be greater than or equal to 0"))
+		}
+		if highPercentage, ok := highThresholds[resourceName]; ok && percentage > highPercentage {
+			allErrs = append(allErrs, field.Invalid(path.Child(lowName).Key(string(resourceName)), percentage, "low percentage must be less than or equal to "+highName))
+		}
+	}
+	return allErrs
 }
@@ -0,0 +1,176 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config/v1alpha2"
+)
+
+func TestValidateHighNodeLoadArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    *v1alpha2.HighNodeLoadArgs
+		wantErr bool
+	}{
+		{
+			name:    "default args",
+			args:    &v1alpha2.HighNodeLoadArgs{},
+			wantErr: false,
+		},
+		{
+			name: "invalid maxNodesToDrain",
+			args: &v1alpha2.HighNodeLoadArgs{
+				MaxNodesToDrain: new(int32),
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid evictableNamespaces",
+			args: &v1alpha2.HighNodeLoadArgs{
+				EvictableNamespaces: &v1alpha2.Namespaces{
+					Include: []string{"a"},
+					Exclude: []string{"b"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "low thresholds above high thresholds",
+			args: &v1alpha2.HighNodeLoadArgs{
+				LowThresholds: v1alpha2.ResourceThresholds{
+					corev1.ResourceCPU: 60,
+				},
+				HighThresholds: v1alpha2.ResourceThresholds{
+					corev1.ResourceCPU: 50,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "prod thresholds are not supported",
+			args: &v1alpha2.HighNodeLoadArgs{
+				NodePools: []v1alpha2.LowNodeLoadNodePool{
+					{
+						Name: "test",
+						ProdHighThresholds: v1alpha2.ResourceThresholds{
+							corev1.ResourceCPU: 50,
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v1alpha2.SetDefaults_HighNodeLoadArgs(tt.args)
+			args := &deschedulerconfig.HighNodeLoadArgs{}
+			assert.NoError(t, v1alpha2.Convert_v1alpha2_HighNodeLoadArgs_To_config_HighNodeLoadArgs(tt.args, args, nil))
+			if err := ValidateHighNodeLoadArgs(nil, args); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateHighNodeLoadArgs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateLowNodeLoadArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    *v1alpha2.LowNodeLoadArgs
+		wantErr bool
+	}{
+		{
+			name:    "default args",
+			args:    &v1alpha2.LowNodeLoadArgs{},
+			wantErr: false,
+		},
+		{
+			name: "valid prod thresholds",
+			args: &v1alpha2.LowNodeLoadArgs{
+				ProdLowThresholds: v1alpha2.ResourceThresholds{
+					corev1.ResourceCPU: 30,
+				},
+				ProdHighThresholds: v1alpha2.ResourceThresholds{
+					corev1.ResourceCPU: 50,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "prod low thresholds above prod high thresholds",
+			args: &v1alpha2.LowNodeLoadArgs{
+				ProdLowThresholds: v1alpha2.ResourceThresholds{
+					corev1.ResourceCPU: 60,
+				},
+				ProdHighThresholds: v1alpha2.ResourceThresholds{
+					corev1.ResourceCPU: 50,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid aggregated usage",
+			args: &v1alpha2.LowNodeLoadArgs{
+				Aggregated: &v1alpha2.LoadAggregatedUsage{
+					UsageAggregationType:    extension.P95,
+					UsageAggregatedDuration: metav1.Duration{Duration: 5 * time.Minute},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unsupported aggregation type",
+			args: &v1alpha2.LowNodeLoadArgs{
+				Aggregated: &v1alpha2.LoadAggregatedUsage{
+					UsageAggregationType: "p80",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "aggregated usage with prod thresholds",
+			args: &v1alpha2.LowNodeLoadArgs{
+				ProdHighThresholds: v1alpha2.ResourceThresholds{
+					corev1.ResourceCPU: 50,
+				},
+				Aggregated: &v1alpha2.LoadAggregatedUsage{
+					UsageAggregationType: extension.P95,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v1alpha2.SetDefaults_LowNodeLoadArgs(tt.args)
+			args := &deschedulerconfig.LowNodeLoadArgs{}
+			assert.NoError(t, v1alpha2.Convert_v1alpha2_LowNodeLoadArgs_To_config_LowNodeLoadArgs(tt.args, args, nil))
+			if err := ValidateLowLoadUtilizationArgs(nil, args); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateLowLoadUtilizationArgs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -18,8 +18,10 @@ package validation
 
 import (
 	"fmt"
+	"strings"
 
 	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
@@ -79,8 +81,66 @@ func ValidateMigrationControllerArgs(path *field.Path, args *deschedulerconfig.M
 		allErrs = append(allErrs, field.Invalid(path.Child("defaultJobTTL"), args.DefaultJobTTL, "defaultJobTTL should be positive or zero"))
 	}
 
+	if args.ArbitrationArgs != nil {
+		arbitrationPath := path.Child("arbitrationArgs")
+		if args.ArbitrationArgs.Interval.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(arbitrationPath.Child("interval"), args.ArbitrationArgs.Interval, "interval must be greater than 0"))
+		}
+		if args.ArbitrationArgs.MaxJobsPerBatch <= 0 {
+			allErrs = append(allErrs, field.Invalid(arbitrationPath.Child("maxJobsPerBatch"), args.ArbitrationArgs.MaxJobsPerBatch, "maxJobsPerBatch must be greater than 0"))
+		}
+		allErrs = append(allErrs, validateEvictionCostModel(arbitrationPath.Child("evictionCostModel"), args.ArbitrationArgs.EvictionCostModel)...)
+	}
+
+	if args.VerificationArgs != nil {
+		verificationPath := path.Child("verificationArgs")
+		if args.VerificationArgs.HealthyDuration.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(verificationPath.Child("healthyDuration"), args.VerificationArgs.HealthyDuration, "healthyDuration must be greater than 0"))
+		}
+		if args.VerificationArgs.MaxRestarts < 0 {
+			allErrs = append(allErrs, field.Invalid(verificationPath.Child("maxRestarts"), args.VerificationArgs.MaxRestarts, "maxRestarts should be positive or zero"))
+		}
+		if percent := args.VerificationArgs.MinSourceNodeLoadDropPercent; percent <= 0 || percent > 100 {
+			allErrs = append(allErrs, field.Invalid(verificationPath.Child("minSourceNodeLoadDropPercent"), percent, "minSourceNodeLoadDropPercent should be in (0, 100]"))
+		}
+		if args.VerificationArgs.InitialBackoff.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(verificationPath.Child("initialBackoff"), args.VerificationArgs.InitialBackoff, "initialBackoff must be greater than 0"))
+		}
+		if args.VerificationArgs.MaxBackoff.Duration < args.VerificationArgs.InitialBackoff.Duration {
+			allErrs = append(allErrs, field.Invalid(verificationPath.Child("maxBackoff"), args.VerificationArgs.MaxBackoff, "maxBackoff must be greater than or equal to initialBackoff"))
+		}
+	}
+
+	for i, rule := range args.WorkloadRules {
+		rulePath := path.Child("workloadRules").Index(i)
+		if _, err := schema.ParseGroupVersion(rule.APIVersion); err != nil || rule.APIVersion == "" {
+			allErrs = append(allErrs, field.Invalid(rulePath.Child("apiVersion"), rule.APIVersion, "apiVersion must be a valid group version"))
+		}
+		if rule.Kind == "" {
+			allErrs = append(allErrs, field.Required(rulePath.Child("kind"), "kind must be specified"))
+		}
+		if rule.ReplicasPath == "" {
+			allErrs = append(allErrs, field.Required(rulePath.Child("replicasPath"), "replicasPath must be specified"))
+		} else if !isValidFieldPath(rule.ReplicasPath) {
+			allErrs = append(allErrs, field.Invalid(rulePath.Child("replicasPath"), rule.ReplicasPath, "replicasPath must be a dot-separated field path"))
+		}
+		if rule.SelectorPath != "" && !isValidFieldPath(rule.SelectorPath) {
+			allErrs = append(allErrs, field.Invalid(rulePath.Child("selectorPath"), rule.SelectorPath, "selectorPath must be a dot-separated field path"))
+		}
+	}
+
 	if len(allErrs) == 0 {
 		return nil
 	}
 	return allErrs.ToAggregate()
 }
+
+// isValidFieldPath checks the dot-separated field path, e.g. spec.selector, the leading dot is optional.
+func isValidFieldPath(path string) bool {
+	for _, field := range strings.Split(strings.TrimPrefix(path, "."), ".") {
+		if field == "" || strings.TrimSpace(field) != field {
+			return false
+		}
+	}
+	return true
+}
@@ -92,6 +92,131 @@ func TestValidateMigrationControllerArgs(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "default arbitrationArgs",
+			args: &v1alpha2.MigrationControllerArgs{
+				ArbitrationArgs: &v1alpha2.ArbitrationArgs{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid arbitrationArgs interval",
+			args: &v1alpha2.MigrationControllerArgs{
+				ArbitrationArgs: &v1alpha2.ArbitrationArgs{
+					Interval: metav1.Duration{Duration: -1 * time.Second},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid arbitrationArgs evictionCostModel",
+			args: &v1alpha2.MigrationControllerArgs{
+				ArbitrationArgs: &v1alpha2.ArbitrationArgs{
+					EvictionCostModel: &v1alpha2.EvictionCostModel{
+						PodAgeWeight:             1,
+						ConnectionsWeight:        2,
+						ConnectionsReadinessGate: "example.com/serving",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid arbitrationArgs evictionCostModel",
+			args: &v1alpha2.MigrationControllerArgs{
+				ArbitrationArgs: &v1alpha2.ArbitrationArgs{
+					EvictionCostModel: &v1alpha2.EvictionCostModel{
+						RestartCountWeight: -1,
+						ConnectionsWeight:  1,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid arbitrationArgs maxJobsPerBatch",
+			args: &v1alpha2.MigrationControllerArgs{
+				ArbitrationArgs: &v1alpha2.ArbitrationArgs{
+					MaxJobsPerBatch: -1,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "default verificationArgs",
+			args: &v1alpha2.MigrationControllerArgs{
+				VerificationArgs: &v1alpha2.VerificationArgs{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid verificationArgs maxRestarts",
+			args: &v1alpha2.MigrationControllerArgs{
+				VerificationArgs: &v1alpha2.VerificationArgs{
+					MaxRestarts: -1,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid verificationArgs minSourceNodeLoadDropPercent",
+			args: &v1alpha2.MigrationControllerArgs{
+				VerificationArgs: &v1alpha2.VerificationArgs{
+					MinSourceNodeLoadDropPercent: 101,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid verificationArgs maxBackoff",
+			args: &v1alpha2.MigrationControllerArgs{
+				VerificationArgs: &v1alpha2.VerificationArgs{
+					InitialBackoff: metav1.Duration{Duration: 10 * time.Minute},
+					MaxBackoff:     metav1.Duration{Duration: 5 * time.Minute},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid workloadRules",
+			args: &v1alpha2.MigrationControllerArgs{
+				WorkloadRules: []v1alpha2.WorkloadRule{
+					{
+						APIVersion:   "argoproj.io/v1alpha1",
+						Kind:         "Rollout",
+						ReplicasPath: "spec.replicas",
+						SelectorPath: "spec.selector",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid workloadRules",
+			args: &v1alpha2.MigrationControllerArgs{
+				WorkloadRules: []v1alpha2.WorkloadRule{
+					{
+						APIVersion: "argoproj.io/v1alpha1/v1",
+						Kind:       "Rollout",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid selectorPath of workloadRules",
+			args: &v1alpha2.MigrationControllerArgs{
+				WorkloadRules: []v1alpha2.WorkloadRule{
+					{
+						APIVersion:   "argoproj.io/v1alpha1",
+						Kind:         "Rollout",
+						ReplicasPath: ".spec.replicas",
+						SelectorPath: "spec..selector",
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
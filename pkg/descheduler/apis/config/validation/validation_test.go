@@ -18,6 +18,7 @@ package validation
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -103,6 +104,82 @@ func TestValidateDeschedulerConfiguration(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid windows",
+			args: &v1alpha2.DeschedulerConfiguration{
+				Profiles: []v1alpha2.DeschedulerProfile{
+					{
+						Name: "test",
+						Windows: []v1alpha2.DeschedulingWindow{
+							{
+								Schedule:     "0 2 * * 1-5",
+								Duration:     metav1.Duration{Duration: 3 * time.Hour},
+								TimeZone:     "Asia/Shanghai",
+								MaxEvictions: pointer.Uint(10),
+							},
+						},
+						Plugins: &v1alpha2.Plugins{
+							Balance: v1alpha2.PluginSet{
+								Enabled: []v1alpha2.Plugin{
+									{
+										Name: "LowNodeLoad",
+										Windows: []v1alpha2.DeschedulingWindow{
+											{
+												Schedule: "0 3 * * *",
+												Duration: metav1.Duration{Duration: time.Hour},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid profile windows",
+			args: &v1alpha2.DeschedulerConfiguration{
+				Profiles: []v1alpha2.DeschedulerProfile{
+					{
+						Name: "test",
+						Windows: []v1alpha2.DeschedulingWindow{
+							{
+								Schedule: "0 25 * * *",
+								TimeZone: "Invalid/Zone",
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid plugin windows",
+			args: &v1alpha2.DeschedulerConfiguration{
+				Profiles: []v1alpha2.DeschedulerProfile{
+					{
+						Name: "test",
+						Plugins: &v1alpha2.Plugins{
+							Deschedule: v1alpha2.PluginSet{
+								Enabled: []v1alpha2.Plugin{
+									{
+										Name: "RemovePodsViolatingNodeAffinity",
+										Windows: []v1alpha2.DeschedulingWindow{
+											{
+												Schedule: "0 3 * * *",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,259 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/controllerfinder"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/evictor"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/utils/sorter"
+	utilclient "github.com/koordinator-sh/koordinator/pkg/util/client"
+)
+
+// arbitrator admits the pending PodMigrationJobs cluster-wide. The jobs created by the descheduling plugins
+// are held in Pending until they are admitted, and the admitted jobs are enqueued to the Reconciler again.
+type arbitrator struct {
+	client.Client
+	args           *deschedulerconfig.ArbitrationArgs
+	sourcePriority map[string]int
+	costModel      *sorter.EvictionCostModel
+	eventCh        chan event.GenericEvent
+
+	lock     sync.Mutex
+	admitted map[types.UID]struct{}
+}
+
+func newArbitrator(c client.Client, args *deschedulerconfig.ArbitrationArgs, finder controllerfinder.Interface) *arbitrator {
+	sourcePriority := make(map[string]int, len(args.SourcePriority))
+	for i, v := range args.SourcePriority {
+		if _, ok := sourcePriority[v]; !ok {
+			sourcePriority[v] = i
+		}
+	}
+	var costModel *sorter.EvictionCostModel
+	if args.EvictionCostModel != nil {
+		costModel = sorter.NewEvictionCostModel(args.EvictionCostModel, controllerfinder.WorkloadUnavailable(finder))
+	}
+	return &arbitrator{
+		Client:         c,
+		args:           args,
+		sourcePriority: sourcePriority,
+		costModel:      costModel,
+		eventCh:        make(chan event.GenericEvent, args.MaxJobsPerBatch),
+		admitted:       map[types.UID]struct{}{},
+	}
+}
+
+func (a *arbitrator) Start(ctx context.Context) error {
+	wait.Until(a.arbitrate, a.args.Interval.Duration, ctx.Done())
+	return nil
+}
+
+func (a *arbitrator) isAdmitted(job *sev1alpha1.PodMigrationJob) bool {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	_, ok := a.admitted[job.UID]
+	return ok
+}
+
+func (a *arbitrator) delete(job *sev1alpha1.PodMigrationJob) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	delete(a.admitted, job.UID)
+}
+
+// arbitrationCandidate is a pending PodMigrationJob waiting for the arbitration.
+type arbitrationCandidate struct {
+	job *sev1alpha1.PodMigrationJob
+	// evictionCost is the eviction cost annotation of the Pod, or the estimated cost if the EvictionCostModel is configured.
+	evictionCost int64
+	// explanation explains the estimated eviction cost.
+	explanation string
+	priority    int32
+	sourceRank  int
+	// workload identifies the disruption budget the job consumes, it is the controller of the Pod,
+	// or the Pod itself if the Pod is not managed by any controller.
+	workload types.UID
+}
+
+func (a *arbitrator) arbitrate() {
+	jobList := &sev1alpha1.PodMigrationJobList{}
+	if err := a.Client.List(context.TODO(), jobList, utilclient.DisableDeepCopy); err != nil {
+		klog.Errorf("Failed to list PodMigrationJobs for arbitration, err: %v", err)
+		return
+	}
+
+	pendingJobs := map[types.UID]struct{}{}
+	var candidates []*arbitrationCandidate
+	for i := range jobList.Items {
+		job := &jobList.Items[i]
+		if job.Status.Phase != "" && job.Status.Phase != sev1alpha1.PodMigrationJobPending {
+			continue
+		}
+		pendingJobs[job.UID] = struct{}{}
+		if job.Spec.Paused || a.isAdmitted(job) {
+			continue
+		}
+		candidate, err := a.newCandidate(job)
+		if err != nil {
+			klog.Errorf("Failed to prepare MigrationJob %s for arbitration, err: %v", job.Name, err)
+			continue
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	// the admitted jobs are no longer tracked once they leave Pending
+	a.lock.Lock()
+	for uid := range a.admitted {
+		if _, ok := pendingJobs[uid]; !ok {
+			delete(a.admitted, uid)
+		}
+	}
+	a.lock.Unlock()
+
+	batch := a.selectBatch(candidates)
+	a.reportArbitration(candidates, batch)
+	for _, job := range batch {
+		klog.V(4).Infof("MigrationJob %s is admitted by arbitration", job.Name)
+		a.lock.Lock()
+		a.admitted[job.UID] = struct{}{}
+		a.lock.Unlock()
+		a.eventCh <- event.GenericEvent{Object: job.DeepCopy()}
+	}
+}
+
+// reportArbitration explains the estimated eviction cost in the status message of the jobs admitted or waiting for
+// arbitration. The admitted jobs are reported before they are enqueued to the Reconciler.
+// The status of a waiting job is written once when it starts waiting, the cost changed in the later rounds
+// is not rewritten to avoid updating every waiting job in each round.
+func (a *arbitrator) reportArbitration(candidates []*arbitrationCandidate, batch []*sev1alpha1.PodMigrationJob) {
+	admitted := make(map[types.UID]struct{}, len(batch))
+	for _, job := range batch {
+		admitted[job.UID] = struct{}{}
+	}
+	for _, candidate := range candidates {
+		if candidate.explanation == "" {
+			continue
+		}
+		reason := sev1alpha1.PodMigrationJobReasonWaitForArbitration
+		message := fmt.Sprintf("Waiting for arbitration with %s", candidate.explanation)
+		if _, ok := admitted[candidate.job.UID]; ok {
+			reason = sev1alpha1.PodMigrationJobReasonAdmitted
+			message = fmt.Sprintf("Admitted by arbitration with %s", candidate.explanation)
+		} else if candidate.job.Status.Reason == sev1alpha1.PodMigrationJobReasonWaitForArbitration {
+			continue
+		}
+		job := candidate.job.DeepCopy()
+		job.Status.Reason = reason
+		job.Status.Message = message
+		if err := a.Client.Status().Update(context.TODO(), job); err != nil {
+			klog.V(4).Infof("Failed to update the status message of MigrationJob %s, err: %v", job.Name, err)
+		}
+	}
+}
+
+func (a *arbitrator) newCandidate(job *sev1alpha1.PodMigrationJob) (*arbitrationCandidate, error) {
+	candidate := &arbitrationCandidate{
+		job:        job,
+		sourceRank: len(a.sourcePriority),
+		workload:   job.UID,
+	}
+	if rank, ok := a.sourcePriority[job.Annotations[evictor.AnnotationEvictTrigger]]; ok {
+		candidate.sourceRank = rank
+	}
+	if job.Spec.PodRef == nil {
+		return candidate, nil
+	}
+
+	pod := &corev1.Pod{}
+	err := a.Client.Get(context.TODO(), types.NamespacedName{Namespace: job.Spec.PodRef.Namespace, Name: job.Spec.PodRef.Name}, pod)
+	if errors.IsNotFound(err) {
+		// let the Reconciler abort the job as usual
+		return candidate, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if a.costModel != nil {
+		cost := a.costModel.Cost(pod)
+		candidate.evictionCost = cost.Score
+		candidate.explanation = cost.String()
+	} else {
+		evictionCost, _ := extension.GetEvictionCost(pod.Annotations)
+		candidate.evictionCost = int64(evictionCost)
+	}
+	if pod.Spec.Priority != nil {
+		candidate.priority = *pod.Spec.Priority
+	}
+	if ownerRef := metav1.GetControllerOf(pod); ownerRef != nil {
+		candidate.workload = ownerRef.UID
+	} else {
+		candidate.workload = pod.UID
+	}
+	return candidate, nil
+}
+
+// selectBatch sorts the candidates by eviction cost, priority, source plugin and age,
+// then picks at most MaxJobsPerBatch jobs and at most one job of each workload.
+func (a *arbitrator) selectBatch(candidates []*arbitrationCandidate) []*sev1alpha1.PodMigrationJob {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		ci, cj := candidates[i], candidates[j]
+		if ci.evictionCost != cj.evictionCost {
+			return ci.evictionCost < cj.evictionCost
+		}
+		if ci.priority != cj.priority {
+			return ci.priority < cj.priority
+		}
+		if ci.sourceRank != cj.sourceRank {
+			return ci.sourceRank < cj.sourceRank
+		}
+		if !ci.job.CreationTimestamp.Equal(&cj.job.CreationTimestamp) {
+			return ci.job.CreationTimestamp.Before(&cj.job.CreationTimestamp)
+		}
+		return ci.job.Name < cj.job.Name
+	})
+
+	var batch []*sev1alpha1.PodMigrationJob
+	workloads := map[types.UID]struct{}{}
+	for _, candidate := range candidates {
+		if len(batch) >= int(a.args.MaxJobsPerBatch) {
+			break
+		}
+		if _, ok := workloads[candidate.workload]; ok {
+			continue
+		}
+		workloads[candidate.workload] = struct{}{}
+		batch = append(batch, candidate.job)
+	}
+	return batch
+}
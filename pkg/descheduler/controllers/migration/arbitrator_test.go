@@ -0,0 +1,255 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/evictor"
+)
+
+func TestArbitrate(t *testing.T) {
+	reconciler := newTestReconciler()
+	a := newArbitrator(reconciler.Client, &deschedulerconfig.ArbitrationArgs{
+		Interval:        metav1.Duration{Duration: time.Second},
+		MaxJobsPerBatch: 3,
+		SourcePriority:  []string{"LowNodeLoad"},
+	}, nil)
+
+	now := time.Now()
+	newPod := func(name string, owner types.UID, cost string, priority int32) {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      name,
+				UID:       types.UID(name),
+			},
+			Spec: corev1.PodSpec{
+				Priority: pointer.Int32(priority),
+			},
+		}
+		if owner != "" {
+			pod.OwnerReferences = []metav1.OwnerReference{
+				{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: string(owner), UID: owner, Controller: pointer.Bool(true)},
+			}
+		}
+		if cost != "" {
+			pod.Annotations = map[string]string{extension.AnnotationEvictionCost: cost}
+		}
+		assert.NoError(t, reconciler.Create(context.TODO(), pod))
+	}
+	newJob := func(name, podName, trigger string, age time.Duration, phase sev1alpha1.PodMigrationJobPhase) {
+		job := &sev1alpha1.PodMigrationJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				UID:               types.UID(name),
+				CreationTimestamp: metav1.Time{Time: now.Add(-age)},
+				Annotations:       map[string]string{evictor.AnnotationEvictTrigger: trigger},
+			},
+			Spec: sev1alpha1.PodMigrationJobSpec{
+				PodRef: &corev1.ObjectReference{Namespace: "default", Name: podName},
+			},
+			Status: sev1alpha1.PodMigrationJobStatus{Phase: phase},
+		}
+		assert.NoError(t, reconciler.Create(context.TODO(), job))
+	}
+
+	newPod("pod-1", "rs-1", "", 100)
+	newPod("pod-2", "rs-1", "", 100)
+	newPod("pod-3", "rs-2", "10", 100)
+	newPod("pod-4", "rs-3", "", 9000)
+	newPod("pod-5", "", "", 100)
+	newPod("pod-6", "rs-4", "", 100)
+	// pod-1 and pod-2 belong to the same workload, only the older one is admitted in a batch
+	newJob("job-1", "pod-1", "RemovePodsViolatingNodeAffinity", time.Minute, sev1alpha1.PodMigrationJobPending)
+	newJob("job-2", "pod-2", "RemovePodsViolatingNodeAffinity", 2*time.Minute, sev1alpha1.PodMigrationJobPending)
+	// the higher eviction cost is admitted later
+	newJob("job-3", "pod-3", "LowNodeLoad", 3*time.Minute, sev1alpha1.PodMigrationJobPending)
+	// the higher priority is admitted later
+	newJob("job-4", "pod-4", "LowNodeLoad", 3*time.Minute, "")
+	// the job triggered by the preferred source is admitted first
+	newJob("job-5", "pod-5", "LowNodeLoad", 0, sev1alpha1.PodMigrationJobPending)
+	// the running job is not arbitrated
+	newJob("job-6", "pod-6", "LowNodeLoad", 5*time.Minute, sev1alpha1.PodMigrationJobRunning)
+
+	a.arbitrate()
+	var admitted []string
+	for len(a.eventCh) > 0 {
+		e := <-a.eventCh
+		admitted = append(admitted, e.Object.GetName())
+	}
+	assert.Equal(t, []string{"job-5", "job-2", "job-4"}, admitted)
+	for _, name := range []string{"job-5", "job-2", "job-4"} {
+		assert.True(t, a.isAdmitted(&sev1alpha1.PodMigrationJob{ObjectMeta: metav1.ObjectMeta{UID: types.UID(name)}}))
+	}
+
+	// the admitted jobs are forgotten once they leave Pending
+	job := &sev1alpha1.PodMigrationJob{}
+	assert.NoError(t, reconciler.Get(context.TODO(), types.NamespacedName{Name: "job-5"}, job))
+	job.Status.Phase = sev1alpha1.PodMigrationJobRunning
+	assert.NoError(t, reconciler.Status().Update(context.TODO(), job))
+
+	a.arbitrate()
+	admitted = nil
+	for len(a.eventCh) > 0 {
+		e := <-a.eventCh
+		admitted = append(admitted, e.Object.GetName())
+	}
+	assert.Equal(t, []string{"job-1", "job-3"}, admitted)
+	assert.False(t, a.isAdmitted(job))
+}
+
+func TestArbitrateWithEvictionCostModel(t *testing.T) {
+	reconciler := newTestReconciler()
+	now := time.Now()
+	servingPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "serving-pod",
+			UID:       "serving-pod",
+		},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+				{Type: "example.com/serving", Status: corev1.ConditionTrue},
+			},
+		},
+	}
+	rsPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "rs-pod",
+			UID:       "rs-pod",
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "rs-1", UID: "rs-1", Controller: pointer.Bool(true)},
+			},
+		},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+			},
+		},
+	}
+	idlePod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "idle-pod",
+			UID:       "idle-pod",
+		},
+	}
+	for _, pod := range []*corev1.Pod{servingPod, rsPod, idlePod} {
+		assert.NoError(t, reconciler.Create(context.TODO(), pod))
+		job := &sev1alpha1.PodMigrationJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "job-" + pod.Name,
+				UID:               types.UID("job-" + pod.Name),
+				CreationTimestamp: metav1.Time{Time: now},
+			},
+			Spec: sev1alpha1.PodMigrationJobSpec{
+				PodRef: &corev1.ObjectReference{Namespace: pod.Namespace, Name: pod.Name},
+			},
+			Status: sev1alpha1.PodMigrationJobStatus{Phase: sev1alpha1.PodMigrationJobPending},
+		}
+		assert.NoError(t, reconciler.Create(context.TODO(), job))
+	}
+
+	// the ReplicaSet expects 4 replicas while only rs-pod is available
+	a := newArbitrator(reconciler.Client, &deschedulerconfig.ArbitrationArgs{
+		Interval:        metav1.Duration{Duration: time.Second},
+		MaxJobsPerBatch: 1,
+		EvictionCostModel: &deschedulerconfig.EvictionCostModel{
+			ConnectionsWeight:         1,
+			ConnectionsReadinessGate:  "example.com/serving",
+			WorkloadUnavailableWeight: 1,
+		},
+	}, &fakeControllerFinder{pods: []*corev1.Pod{rsPod}, replicas: 4})
+
+	var admitted []string
+	var waitingVersion string
+	for i := 0; i < 3; i++ {
+		a.arbitrate()
+		for len(a.eventCh) > 0 {
+			e := <-a.eventCh
+			admitted = append(admitted, e.Object.GetName())
+		}
+		job := &sev1alpha1.PodMigrationJob{}
+		if i == 0 {
+			assert.NoError(t, reconciler.Get(context.TODO(), types.NamespacedName{Name: "job-serving-pod"}, job))
+			assert.Equal(t, sev1alpha1.PodMigrationJobReasonWaitForArbitration, job.Status.Reason)
+			assert.Equal(t, "Waiting for arbitration with eviction cost 50 (connections=100*1, workloadUnavailable=0*1)", job.Status.Message)
+			waitingVersion = job.ResourceVersion
+			assert.NoError(t, reconciler.Get(context.TODO(), types.NamespacedName{Name: "job-idle-pod"}, job))
+			assert.Equal(t, sev1alpha1.PodMigrationJobReasonAdmitted, job.Status.Reason)
+			assert.Equal(t, "Admitted by arbitration with eviction cost 0 (connections=0*1, workloadUnavailable=0*1)", job.Status.Message)
+		} else if i == 1 {
+			// the status of the job still waiting is not rewritten
+			assert.NoError(t, reconciler.Get(context.TODO(), types.NamespacedName{Name: "job-serving-pod"}, job))
+			assert.Equal(t, waitingVersion, job.ResourceVersion)
+		}
+	}
+	assert.Equal(t, []string{"job-idle-pod", "job-rs-pod", "job-serving-pod"}, admitted)
+}
+
+func TestMigrateWaitForArbitration(t *testing.T) {
+	reconciler := newTestReconciler()
+	reconciler.arbitrator = newArbitrator(reconciler.Client, &deschedulerconfig.ArbitrationArgs{
+		Interval:        metav1.Duration{Duration: time.Second},
+		MaxJobsPerBatch: 1,
+	}, nil)
+	job := &sev1alpha1.PodMigrationJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "test",
+			UID:               "test",
+			CreationTimestamp: metav1.Time{Time: time.Now()},
+		},
+		Spec: sev1alpha1.PodMigrationJobSpec{
+			PodRef: &corev1.ObjectReference{
+				Namespace: "default",
+				Name:      "test-pod",
+			},
+		},
+		Status: sev1alpha1.PodMigrationJobStatus{
+			Phase: sev1alpha1.PodMigrationJobPending,
+		},
+	}
+	assert.NoError(t, reconciler.Create(context.TODO(), job))
+
+	result, err := reconciler.doMigrate(context.TODO(), job)
+	assert.NoError(t, err)
+	assert.True(t, result.IsZero())
+	assert.NoError(t, reconciler.Get(context.TODO(), types.NamespacedName{Name: job.Name}, job))
+	assert.Equal(t, sev1alpha1.PodMigrationJobPending, job.Status.Phase)
+
+	// the pod is missing, so the admitted job is aborted
+	reconciler.arbitrator.arbitrate()
+	assert.True(t, reconciler.arbitrator.isAdmitted(job))
+	_, err = reconciler.doMigrate(context.TODO(), job)
+	assert.Error(t, err)
+	assert.NoError(t, reconciler.Get(context.TODO(), types.NamespacedName{Name: job.Name}, job))
+	assert.Equal(t, sev1alpha1.PodMigrationJobFailed, job.Status.Phase)
+}
@@ -20,9 +20,11 @@ import (
 	"context"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/reservation"
 )
 
 var (
@@ -34,6 +36,8 @@ type JobContext struct {
 	Annotations map[string]string
 	Timeout     *time.Duration
 	Mode        sev1alpha1.PodMigrationJobMode
+	// DestinationNodes restricts the Reservation created by the ReservationFirst job to the nodes if specified.
+	DestinationNodes []string
 }
 
 func WithContext(ctx context.Context, jobCtx *JobContext) context.Context {
@@ -75,3 +79,29 @@ func (c *JobContext) ApplyTo(job *sev1alpha1.PodMigrationJob) error {
 	}
 	return nil
 }
+
+// ApplyReservationTo restricts the Reservation of the job to the destination nodes via the node affinity.
+// The Reservation template is built from the Pod if the job does not specify it.
+func (c *JobContext) ApplyReservationTo(job *sev1alpha1.PodMigrationJob, pod *corev1.Pod) {
+	if c == nil || len(c.DestinationNodes) == 0 {
+		return
+	}
+	if job.Spec.ReservationOptions == nil {
+		job.Spec.ReservationOptions = &sev1alpha1.PodMigrateReservationOptions{}
+	}
+	reservationOptions := job.Spec.ReservationOptions
+	if reservationOptions.Template == nil {
+		reservationOptions.Template = &sev1alpha1.ReservationTemplateSpec{}
+	}
+	if reservationOptions.Template.Spec.Template == nil {
+		reservationOptions.Template.Spec.Template = &corev1.PodTemplateSpec{
+			ObjectMeta: *pod.ObjectMeta.DeepCopy(),
+			Spec:       *pod.Spec.DeepCopy(),
+		}
+	}
+	reservation.AppendNodeSelectorRequirement(&reservationOptions.Template.Spec.Template.Spec, corev1.NodeSelectorRequirement{
+		Key:      "metadata.name",
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   c.DestinationNodes,
+	})
+}
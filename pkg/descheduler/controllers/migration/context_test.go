@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
@@ -62,3 +63,49 @@ func TestJobContext(t *testing.T) {
 	}
 	assert.Equal(t, expectJob, job)
 }
+
+func TestJobContextApplyReservationTo(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-pod",
+		},
+		Spec: corev1.PodSpec{
+			NodeName: "test-node",
+		},
+	}
+	job := &sev1alpha1.PodMigrationJob{}
+	var nilJobCtx *JobContext
+	nilJobCtx.ApplyReservationTo(job, pod)
+	assert.Nil(t, job.Spec.ReservationOptions)
+
+	jobCtx := &JobContext{
+		DestinationNodes: []string{"node-1"},
+	}
+	jobCtx.ApplyReservationTo(job, pod)
+	expectTemplate := &corev1.PodTemplateSpec{
+		ObjectMeta: pod.ObjectMeta,
+		Spec: corev1.PodSpec{
+			NodeName: "test-node",
+			Affinity: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{
+							{
+								MatchFields: []corev1.NodeSelectorRequirement{
+									{
+										Key:      "metadata.name",
+										Operator: corev1.NodeSelectorOpIn,
+										Values:   []string{"node-1"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	assert.Equal(t, expectTemplate, job.Spec.ReservationOptions.Template.Spec.Template)
+	assert.Nil(t, pod.Spec.Affinity)
+}
@@ -33,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/apimachinery/pkg/util/uuid"
 	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/klog/v2"
 	k8spodutil "k8s.io/kubernetes/pkg/api/v1/pod"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -45,6 +46,7 @@ import (
 
 	"github.com/koordinator-sh/koordinator/apis/extension"
 	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	koordslolisters "github.com/koordinator-sh/koordinator/pkg/client/listers/slo/v1alpha1"
 	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config/validation"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/controllerfinder"
@@ -81,11 +83,15 @@ type Reconciler struct {
 	retryablePodFilter     framework.FilterFunc
 	defaultFilterPlugin    framework.FilterPlugin
 	assumedCache           *assumedCache
+	arbitrator             *arbitrator
+	nodeMetricLister       koordslolisters.NodeMetricLister
 	clock                  clock.Clock
 
 	lock           sync.Mutex
 	objectLimiters map[types.UID]*rate.Limiter
 	limiterCache   *gocache.Cache
+
+	workloadBackoff *flowcontrol.Backoff
 }
 
 func New(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
@@ -112,6 +118,9 @@ func New(args runtime.Object, handle framework.Handle) (framework.Plugin, error)
 		DeleteFunc: func(event event.DeleteEvent) bool {
 			job := event.Object.(*sev1alpha1.PodMigrationJob)
 			r.assumedCache.delete(job)
+			if r.arbitrator != nil {
+				r.arbitrator.delete(job)
+			}
 			// TODO(joseph): It's better that delete reservation asynchronously
 			if err = r.deleteReservation(context.TODO(), job); err != nil {
 				klog.Errorf("Failed to delete reservation, MigrationJob: %s, err: %v", job.Name, err)
@@ -123,6 +132,11 @@ func New(args runtime.Object, handle framework.Handle) (framework.Plugin, error)
 	if err = c.Watch(&source.Kind{Type: r.reservationInterpreter.GetReservationType()}, &handler.Funcs{}); err != nil {
 		return nil, err
 	}
+	if r.arbitrator != nil {
+		if err = c.Watch(&source.Channel{Source: r.arbitrator.eventCh}, &handler.EnqueueRequestForObject{}); err != nil {
+			return nil, err
+		}
+	}
 	return r, nil
 }
 
@@ -134,7 +148,7 @@ func newReconciler(args *deschedulerconfig.MigrationControllerArgs, handle frame
 		return nil, err
 	}
 
-	controllerFinder, err := controllerfinder.New(manager)
+	controllerFinder, err := controllerfinder.New(manager, args.WorkloadRules)
 	if err != nil {
 		return nil, err
 	}
@@ -153,10 +167,19 @@ func newReconciler(args *deschedulerconfig.MigrationControllerArgs, handle frame
 		return nil, err
 	}
 	r.initObjectLimiters()
+	if err := r.initVerification(handle); err != nil {
+		return nil, err
+	}
 
 	if err := manager.Add(r); err != nil {
 		return nil, err
 	}
+	if args.ArbitrationArgs != nil {
+		r.arbitrator = newArbitrator(r.Client, args.ArbitrationArgs, controllerFinder)
+		if err := manager.Add(r.arbitrator); err != nil {
+			return nil, err
+		}
+	}
 	return r, nil
 }
 
@@ -200,6 +223,9 @@ func (r *Reconciler) scavenger(stopCh <-chan struct{}) {
 }
 
 func (r *Reconciler) doScavenge() {
+	if r.workloadBackoff != nil {
+		r.workloadBackoff.GC()
+	}
 	jobList := &sev1alpha1.PodMigrationJobList{}
 	opts := &client.ListOptions{
 		LabelSelector: labels.Everything(),
@@ -214,6 +240,9 @@ func (r *Reconciler) doScavenge() {
 		if v.Spec.TTL != nil && v.Spec.TTL.Duration > 0 {
 			timeoutDuration = v.Spec.TTL.Duration + 5*time.Minute
 		}
+		if r.args.VerificationArgs != nil {
+			timeoutDuration += r.args.VerificationArgs.HealthyDuration.Duration
+		}
 		if r.clock.Since(v.CreationTimestamp.Time) < timeoutDuration {
 			continue
 		}
@@ -281,6 +310,11 @@ func (r *Reconciler) doMigrate(ctx context.Context, job *sev1alpha1.PodMigration
 	}
 
 	if job.Status.Phase == "" || job.Status.Phase == sev1alpha1.PodMigrationJobPending {
+		if r.arbitrator != nil && !r.arbitrator.isAdmitted(job) {
+			// the job is enqueued again once it is admitted by the arbitrator
+			klog.V(4).Infof("MigrationJob %s is waiting for arbitration", job.Name)
+			return reconcile.Result{}, nil
+		}
 		if result, err := r.preparePendingJob(ctx, job); err != nil || !result.IsZero() {
 			return result, err
 		}
@@ -379,10 +413,21 @@ func (r *Reconciler) doMigrate(ctx context.Context, job *sev1alpha1.PodMigration
 		return result, nil
 	}
 
-	msg := fmt.Sprintf("Bind pod %q is ready", podNamespacedName)
-	r.eventRecorder.Eventf(job, nil, corev1.EventTypeNormal, "BoundPodReady", "Migrating", msg)
-	if err := r.handleBoundPodReadySuccess(ctx, job); err != nil {
-		return reconcile.Result{}, err
+	if !r.isVerifying(job) {
+		msg := fmt.Sprintf("Bind pod %q is ready", podNamespacedName)
+		r.eventRecorder.Eventf(job, nil, corev1.EventTypeNormal, "BoundPodReady", "Migrating", msg)
+		if err := r.handleBoundPodReadySuccess(ctx, job); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	if r.args.VerificationArgs != nil {
+		verified, result, err := r.verifyMigration(ctx, job, podNamespacedName)
+		if err != nil {
+			return result, err
+		} else if !verified {
+			return result, nil
+		}
 	}
 
 	job.Status.PodRef = boundPod
@@ -465,6 +510,11 @@ func (r *Reconciler) abortJobIfTimeout(ctx context.Context, job *sev1alpha1.PodM
 		return false, nil
 	}
 
+	// the migration is complete when the job is being verified, so the TTL no longer applies
+	if r.isVerifying(job) {
+		return false, nil
+	}
+
 	timeout := job.Spec.TTL.Duration
 	elapsed := r.clock.Since(job.CreationTimestamp.Time)
 	if elapsed < timeout {
@@ -784,6 +834,7 @@ func (r *Reconciler) evictPod(ctx context.Context, job *sev1alpha1.PodMigrationJ
 		return false, reconcile.Result{}, err
 	}
 	r.trackEvictedPod(pod)
+	r.recordSourceNodeUsage(ctx, job, pod)
 
 	_, reason := evictor.GetEvictionTriggerAndReason(job.Annotations)
 	cond = &sev1alpha1.PodMigrationJobCondition{
@@ -19,14 +19,20 @@ package controllerfinder
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	appsv1alpha1 "github.com/openkruise/kruise-api/apps/v1alpha1"
 	appsv1beta1 "github.com/openkruise/kruise-api/apps/v1beta1"
+	gocache "github.com/patrickmn/go-cache"
 	apps "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apimachinery/pkg/types"
@@ -37,6 +43,14 @@ import (
 	scaleclient "k8s.io/client-go/scale"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+const (
+	// scaleResourcesExpiration is how long the discovered scale subresources are cached,
+	// the CRDs installed later are resolved after the expiration.
+	scaleResourcesExpiration = 10 * time.Minute
 )
 
 // ScaleAndSelector is used to return (controller, scale, selector) fields from the
@@ -80,12 +94,22 @@ type ControllerFinder struct {
 	mapper          meta.RESTMapper
 	scaleNamespacer scaleclient.ScalesGetter
 	discoveryClient discovery.DiscoveryInterface
+	// scaleResources caches whether the resources implement the scale subresource.
+	scaleResources *gocache.Cache
+	// workloadRules resolve the workloads without the scale subresource.
+	workloadRules []deschedulerconfig.WorkloadRule
+	// workloadReader reads the workloads resolved by workloadRules from the informer cache,
+	// since the client doesn't cache the unstructured objects.
+	workloadReader client.Reader
 }
 
-var New = func(manager manager.Manager) (Interface, error) {
+var New = func(manager manager.Manager, workloadRules []deschedulerconfig.WorkloadRule) (Interface, error) {
 	finder := &ControllerFinder{
-		Client: manager.GetClient(),
-		mapper: manager.GetRESTMapper(),
+		Client:         manager.GetClient(),
+		mapper:         manager.GetRESTMapper(),
+		scaleResources: gocache.New(scaleResourcesExpiration, scaleResourcesExpiration),
+		workloadRules:  workloadRules,
+		workloadReader: manager.GetCache(),
 	}
 	cfg := manager.GetConfig()
 	if cfg.GroupVersion == nil {
@@ -144,7 +168,7 @@ func (r *ControllerFinder) GetScaleAndSelectorForRef(apiVersion, kind, ns, name
 
 func (r *ControllerFinder) Finders() []PodControllerFinder {
 	return []PodControllerFinder{r.getPodReplicationController, r.getPodDeployment, r.getPodReplicaSet,
-		r.getPodStatefulSet, r.getPodKruiseCloneSet, r.getPodKruiseStatefulSet, r.getScaleController}
+		r.getPodStatefulSet, r.getPodKruiseCloneSet, r.getPodKruiseStatefulSet, r.getWorkloadByRule, r.getScaleController}
 }
 
 var (
@@ -380,8 +404,112 @@ func (r *ControllerFinder) getPodKruiseStatefulSet(ref ControllerReference, name
 	}, nil
 }
 
+// getWorkloadByRule returns the workload resolved by the configured WorkloadRule.
+func (r *ControllerFinder) getWorkloadByRule(ref ControllerReference, namespace string) (*ScaleAndSelector, error) {
+	rule := r.getWorkloadRule(ref.APIVersion, ref.Kind)
+	if rule == nil {
+		return nil, nil
+	}
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(ref.APIVersion)
+	obj.SetKind(ref.Kind)
+	err := r.workloadReader.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: ref.Name}, obj)
+	if err != nil {
+		// when error is NotFound, it is ok here.
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if ref.UID != "" && obj.GetUID() != ref.UID {
+		return nil, nil
+	}
+
+	replicas, err := getReplicasByPath(obj, rule.ReplicasPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get replicas of %s %s/%s, err: %w", ref.Kind, namespace, ref.Name, err)
+	}
+	var selector *metav1.LabelSelector
+	if rule.SelectorPath != "" {
+		selector, err = getSelectorByPath(obj, rule.SelectorPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get selector of %s %s/%s, err: %w", ref.Kind, namespace, ref.Name, err)
+		}
+	}
+	metadata := &metav1.PartialObjectMetadata{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), metadata); err != nil {
+		return nil, err
+	}
+	return &ScaleAndSelector{
+		Scale:    replicas,
+		Selector: selector,
+		ControllerReference: ControllerReference{
+			APIVersion: obj.GetAPIVersion(),
+			Kind:       obj.GetKind(),
+			Name:       obj.GetName(),
+			UID:        obj.GetUID(),
+		},
+		Metadata: metadata.ObjectMeta,
+	}, nil
+}
+
+func (r *ControllerFinder) getWorkloadRule(apiVersion, kind string) *deschedulerconfig.WorkloadRule {
+	for i := range r.workloadRules {
+		rule := &r.workloadRules[i]
+		gv, err := schema.ParseGroupVersion(rule.APIVersion)
+		if err != nil {
+			continue
+		}
+		if ok, _ := verifyGroupKind(apiVersion, kind, gv.WithKind(rule.Kind)); ok {
+			return rule
+		}
+	}
+	return nil
+}
+
+func getReplicasByPath(obj *unstructured.Unstructured, path string) (int32, error) {
+	value, found, err := unstructured.NestedFieldNoCopy(obj.Object, splitFieldPath(path)...)
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, fmt.Errorf("field %s not found", path)
+	}
+	switch v := value.(type) {
+	case int64:
+		return int32(v), nil
+	case float64:
+		return int32(v), nil
+	default:
+		return 0, fmt.Errorf("field %s is %T, not a number", path, value)
+	}
+}
+
+func getSelectorByPath(obj *unstructured.Unstructured, path string) (*metav1.LabelSelector, error) {
+	value, found, err := unstructured.NestedFieldNoCopy(obj.Object, splitFieldPath(path)...)
+	if err != nil || !found {
+		return nil, err
+	}
+	switch v := value.(type) {
+	case string:
+		return metav1.ParseToLabelSelector(v)
+	case map[string]interface{}:
+		selector := &metav1.LabelSelector{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(v, selector); err != nil {
+			return nil, err
+		}
+		return selector, nil
+	default:
+		return nil, fmt.Errorf("field %s is %T, not a label selector", path, value)
+	}
+}
+
+func splitFieldPath(path string) []string {
+	return strings.Split(strings.TrimPrefix(path, "."), ".")
+}
+
 func (r *ControllerFinder) getScaleController(ref ControllerReference, namespace string) (*ScaleAndSelector, error) {
-	if isValidGroupVersionKind(ref.APIVersion, ref.Kind) {
+	if isValidGroupVersionKind(ref.APIVersion, ref.Kind) || r.getWorkloadRule(ref.APIVersion, ref.Kind) != nil {
 		return nil, nil
 	}
 	gv, err := schema.ParseGroupVersion(ref.APIVersion)
@@ -395,13 +523,20 @@ func (r *ControllerFinder) getScaleController(ref ControllerReference, namespace
 
 	mapping, err := r.mapper.RESTMapping(gk, gv.Version)
 	if err != nil {
+		// the kind is not served, so the owner can not be resolved
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	// the owners without the scale subresource can only be resolved by the WorkloadRules
+	if ok, err := r.implementsScale(mapping.Resource); !ok || err != nil {
 		return nil, err
 	}
 	gr := mapping.Resource.GroupResource()
 	scale, err := r.scaleNamespacer.Scales(namespace).Get(context.TODO(), gr, ref.Name, metav1.GetOptions{})
 	if err != nil {
 		if errors.IsNotFound(err) {
-			// TODO, implementsScale
 			return nil, nil
 		}
 		return nil, err
@@ -426,6 +561,31 @@ func (r *ControllerFinder) getScaleController(ref ControllerReference, namespace
 	}, nil
 }
 
+// implementsScale checks whether the resource implements the scale subresource by the discovery.
+func (r *ControllerFinder) implementsScale(gvr schema.GroupVersionResource) (bool, error) {
+	key := gvr.String()
+	if r.scaleResources != nil {
+		if implemented, ok := r.scaleResources.Get(key); ok {
+			return implemented.(bool), nil
+		}
+	}
+	resourceList, err := r.discoveryClient.ServerResourcesForGroupVersion(gvr.GroupVersion().String())
+	if err != nil {
+		return false, err
+	}
+	implemented := false
+	for _, resource := range resourceList.APIResources {
+		if resource.Name == gvr.Resource+"/scale" {
+			implemented = true
+			break
+		}
+	}
+	if r.scaleResources != nil {
+		r.scaleResources.SetDefault(key, implemented)
+	}
+	return implemented, nil
+}
+
 func verifyGroupKind(apiVersion, kind string, gvk schema.GroupVersionKind) (bool, error) {
 	gv, err := schema.ParseGroupVersion(apiVersion)
 	if err != nil {
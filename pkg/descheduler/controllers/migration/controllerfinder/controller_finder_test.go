@@ -0,0 +1,210 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllerfinder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	fakescale "k8s.io/client-go/scale/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+func TestControllerFinder_GetWorkloadByRule(t *testing.T) {
+	tests := []struct {
+		name         string
+		rule         deschedulerconfig.WorkloadRule
+		workload     map[string]interface{}
+		wantScale    *ScaleAndSelector
+		wantErr      bool
+		wantNotFound bool
+	}{
+		{
+			name: "label selector",
+			rule: deschedulerconfig.WorkloadRule{
+				APIVersion:   "argoproj.io/v1alpha1",
+				Kind:         "Rollout",
+				ReplicasPath: "spec.replicas",
+				SelectorPath: ".spec.selector",
+			},
+			workload: map[string]interface{}{
+				"replicas": int64(5),
+				"selector": map[string]interface{}{
+					"matchLabels": map[string]interface{}{"app": "test"},
+				},
+			},
+			wantScale: &ScaleAndSelector{
+				Scale:    5,
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+			},
+		},
+		{
+			name: "string selector",
+			rule: deschedulerconfig.WorkloadRule{
+				APIVersion:   "argoproj.io/v1",
+				Kind:         "Rollout",
+				ReplicasPath: "spec.workers.replicas",
+				SelectorPath: "spec.workers.selector",
+			},
+			workload: map[string]interface{}{
+				"workers": map[string]interface{}{
+					"replicas": int64(2),
+					"selector": "app=test",
+				},
+			},
+			wantScale: &ScaleAndSelector{
+				Scale: 2,
+				Selector: &metav1.LabelSelector{
+					MatchLabels:      map[string]string{"app": "test"},
+					MatchExpressions: []metav1.LabelSelectorRequirement{},
+				},
+			},
+		},
+		{
+			name: "missing replicas",
+			rule: deschedulerconfig.WorkloadRule{
+				APIVersion:   "argoproj.io/v1alpha1",
+				Kind:         "Rollout",
+				ReplicasPath: "spec.replicas",
+			},
+			workload: map[string]interface{}{},
+			wantErr:  true,
+		},
+		{
+			name: "unmatched rule",
+			rule: deschedulerconfig.WorkloadRule{
+				APIVersion:   "argoproj.io/v1alpha1",
+				Kind:         "AnalysisRun",
+				ReplicasPath: "spec.replicas",
+			},
+			workload:     map[string]interface{}{"replicas": int64(5)},
+			wantNotFound: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			_ = clientgoscheme.AddToScheme(scheme)
+			workload := &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "argoproj.io/v1alpha1",
+				"kind":       "Rollout",
+				"metadata": map[string]interface{}{
+					"namespace": "default",
+					"name":      "test",
+					"uid":       "123456",
+				},
+				"spec": tt.workload,
+			}}
+			runtimeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(workload).Build()
+			r := &ControllerFinder{
+				Client:         runtimeClient,
+				workloadRules:  []deschedulerconfig.WorkloadRule{tt.rule},
+				workloadReader: runtimeClient,
+			}
+			got, err := r.getWorkloadByRule(ControllerReference{
+				APIVersion: "argoproj.io/v1alpha1",
+				Kind:       "Rollout",
+				Name:       "test",
+				UID:        "123456",
+			}, "default")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			if tt.wantNotFound {
+				assert.Nil(t, got)
+				return
+			}
+			assert.NotNil(t, got)
+			assert.Equal(t, tt.wantScale.Scale, got.Scale)
+			assert.Equal(t, tt.wantScale.Selector, got.Selector)
+			assert.Equal(t, "Rollout", got.Kind)
+			assert.Equal(t, "123456", string(got.UID))
+			assert.Equal(t, "test", got.Metadata.Name)
+		})
+	}
+}
+
+func TestControllerFinder_GetScaleController(t *testing.T) {
+	gv := schema.GroupVersion{Group: "example.com", Version: "v1"}
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{gv})
+	mapper.Add(gv.WithKind("Scalable"), meta.RESTScopeNamespace)
+	mapper.Add(gv.WithKind("Unscalable"), meta.RESTScopeNamespace)
+
+	discoveryClient := &fakediscovery.FakeDiscovery{Fake: &k8stesting.Fake{}}
+	discoveryClient.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: gv.String(),
+			APIResources: []metav1.APIResource{
+				{Name: "scalables", Namespaced: true, Kind: "Scalable"},
+				{Name: "scalables/scale", Namespaced: true, Kind: "Scale", Group: "autoscaling", Version: "v1"},
+				{Name: "unscalables", Namespaced: true, Kind: "Unscalable"},
+			},
+		},
+	}
+	scaleClient := &fakescale.FakeScaleClient{}
+	scaleClient.AddReactor("get", "scalables", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &autoscalingv1.Scale{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test",
+				UID:       "123456",
+			},
+			Spec:   autoscalingv1.ScaleSpec{Replicas: 4},
+			Status: autoscalingv1.ScaleStatus{Selector: "app=test"},
+		}, nil
+	})
+
+	runtimeClient := fake.NewClientBuilder().Build()
+	r := &ControllerFinder{
+		Client:          runtimeClient,
+		workloadReader:  runtimeClient,
+		mapper:          mapper,
+		scaleNamespacer: scaleClient,
+		discoveryClient: discoveryClient,
+	}
+	got, err := r.getScaleController(ControllerReference{APIVersion: gv.String(), Kind: "Scalable", Name: "test"}, "default")
+	assert.NoError(t, err)
+	assert.NotNil(t, got)
+	assert.Equal(t, int32(4), got.Scale)
+	assert.Equal(t, map[string]string{"app": "test"}, got.Selector.MatchLabels)
+
+	got, err = r.getScaleController(ControllerReference{APIVersion: gv.String(), Kind: "Unscalable", Name: "test"}, "default")
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+
+	got, err = r.getScaleController(ControllerReference{APIVersion: gv.String(), Kind: "Unknown", Name: "test"}, "default")
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+
+	r.workloadRules = []deschedulerconfig.WorkloadRule{{APIVersion: gv.String(), Kind: "Scalable", ReplicasPath: "spec.replicas"}}
+	got, err = r.GetScaleAndSelectorForRef(gv.String(), "Scalable", "default", "test", "")
+	assert.NoError(t, err)
+	assert.Nil(t, got, "the rule takes precedence over the scale subresource")
+}
@@ -27,10 +27,12 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
+	k8spodutil "k8s.io/kubernetes/pkg/api/v1/pod"
 	kubecontroller "k8s.io/kubernetes/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/fieldindex"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/utils/sorter"
 	"github.com/koordinator-sh/koordinator/pkg/util"
 	utilclient "github.com/koordinator-sh/koordinator/pkg/util/client"
 )
@@ -131,3 +133,31 @@ func (r *ControllerFinder) getReplicaSetsForDeployment(apiVersion, kind, ns, nam
 	}
 	return rss, nil
 }
+
+// WorkloadUnavailable returns the percentage of the unavailable Pods to the expected replicas of the workload of the Pod.
+func WorkloadUnavailable(finder Interface) sorter.WorkloadUnavailableFn {
+	return func(pod *corev1.Pod) (int64, error) {
+		ownerRef := metav1.GetControllerOf(pod)
+		if ownerRef == nil || finder == nil {
+			return 0, nil
+		}
+		pods, expectedReplicas, err := finder.GetPodsForRef(ownerRef, pod.Namespace, nil, false)
+		if err != nil {
+			return 0, err
+		}
+		if expectedReplicas <= 0 {
+			return 0, nil
+		}
+		var available int64
+		for _, p := range pods {
+			if kubecontroller.IsPodActive(p) && k8spodutil.IsPodReady(p) {
+				available++
+			}
+		}
+		unavailable := int64(expectedReplicas) - available
+		if unavailable <= 0 {
+			return 0, nil
+		}
+		return unavailable * 100 / int64(expectedReplicas), nil
+	}
+}
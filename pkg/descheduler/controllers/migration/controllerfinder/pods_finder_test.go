@@ -142,3 +142,53 @@ func TestControllerFinder_GetPodsForRef(t *testing.T) {
 		})
 	}
 }
+
+type fakeWorkloadFinder struct {
+	Interface
+	pods     []*corev1.Pod
+	replicas int32
+}
+
+func (f *fakeWorkloadFinder) GetPodsForRef(ownerReference *metav1.OwnerReference, ns string, labelSelector *metav1.LabelSelector, active bool) ([]*corev1.Pod, int32, error) {
+	return f.pods, f.replicas, nil
+}
+
+func TestWorkloadUnavailable(t *testing.T) {
+	buildPod := func(name string, controlled, ready bool) *corev1.Pod {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}}
+		if controlled {
+			pod.OwnerReferences = []metav1.OwnerReference{
+				{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "rs", UID: "rs", Controller: pointer.Bool(true)},
+			}
+		}
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: status}}
+		return pod
+	}
+	pods := []*corev1.Pod{
+		buildPod("pod-1", true, true),
+		buildPod("pod-2", true, true),
+		buildPod("pod-3", true, false),
+	}
+	tests := []struct {
+		name     string
+		pod      *corev1.Pod
+		replicas int32
+		want     int64
+	}{
+		{name: "missing and unready pods", pod: pods[0], replicas: 4, want: 50},
+		{name: "more pods than replicas", pod: pods[0], replicas: 2, want: 0},
+		{name: "workload scaled to zero", pod: pods[0], replicas: 0, want: 0},
+		{name: "pod without controller", pod: buildPod("bare-pod", false, false), replicas: 4, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := WorkloadUnavailable(&fakeWorkloadFinder{pods: pods, replicas: tt.replicas})(tt.pod)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
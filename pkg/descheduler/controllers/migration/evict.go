@@ -80,6 +80,9 @@ func CreatePodMigrationJob(ctx context.Context, pod *corev1.Pod, evictOptions fr
 		klog.Errorf("Failed to apply JobContext to PodMigrationJob for Pod %s/%s, err: %v", pod.Namespace, pod.Name, err)
 		return err
 	}
+	if job.Spec.Mode == sev1alpha1.PodMigrationJobModeReservationFirst {
+		jobCtx.ApplyReservationTo(job, pod)
+	}
 
 	err := client.Create(ctx, job)
 	if err != nil {
@@ -105,6 +105,7 @@ func (r *Reconciler) initFilters(args *deschedulerconfig.MigrationControllerArgs
 	}
 	retriablePodFilters := podutil.WrapFilterFuncs(
 		r.filterLimitedObject,
+		r.filterWorkloadBackoff,
 		r.filterMaxMigratingPerNode,
 		r.filterMaxMigratingPerNamespace,
 		r.filterMaxMigratingOrUnavailablePerWorkload,
@@ -444,6 +445,20 @@ func (r *Reconciler) trackEvictedPod(pod *corev1.Pod) {
 	r.limiterCache.Set(string(uid), 0, gocache.DefaultExpiration)
 }
 
+// filterWorkloadBackoff filters the Pods of the workloads backing off after failed migration verifications.
+func (r *Reconciler) filterWorkloadBackoff(pod *corev1.Pod) bool {
+	if r.workloadBackoff == nil {
+		return true
+	}
+	if ownerRef := metav1.GetControllerOf(pod); ownerRef != nil {
+		if r.workloadBackoff.IsInBackOffSinceUpdate(string(ownerRef.UID), r.clock.Now()) {
+			klog.Infof("Pod %q is filtered by workload %s/%s/%s is backing off after failed migration verification", klog.KObj(pod), ownerRef.Name, ownerRef.Kind, ownerRef.APIVersion)
+			return false
+		}
+	}
+	return true
+}
+
 func (r *Reconciler) filterLimitedObject(pod *corev1.Pod) bool {
 	if r.objectLimiters == nil || r.limiterCache == nil {
 		return true
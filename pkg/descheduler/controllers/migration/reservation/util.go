@@ -108,10 +108,22 @@ func appendSkipNodeAffinity(pod *corev1.Pod, reservationOptions *sev1alpha1.PodM
 		return
 	}
 
-	affinity := reservationOptions.Template.Spec.Template.Spec.Affinity
-	if reservationOptions.Template.Spec.Template.Spec.Affinity == nil {
+	AppendNodeSelectorRequirement(&reservationOptions.Template.Spec.Template.Spec, corev1.NodeSelectorRequirement{
+		Key:      "metadata.name",
+		Operator: corev1.NodeSelectorOpNotIn,
+		Values: []string{
+			pod.Spec.NodeName,
+		},
+	})
+}
+
+// AppendNodeSelectorRequirement appends the field requirement to every required node selector term of the Pod,
+// so that the requirement takes effect together with the existing node affinity.
+func AppendNodeSelectorRequirement(podSpec *corev1.PodSpec, requirement corev1.NodeSelectorRequirement) {
+	affinity := podSpec.Affinity
+	if affinity == nil {
 		affinity = &corev1.Affinity{}
-		reservationOptions.Template.Spec.Template.Spec.Affinity = affinity
+		podSpec.Affinity = affinity
 	}
 	if affinity.NodeAffinity == nil {
 		affinity.NodeAffinity = &corev1.NodeAffinity{}
@@ -120,24 +132,16 @@ func appendSkipNodeAffinity(pod *corev1.Pod, reservationOptions *sev1alpha1.PodM
 		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
 	}
 
-	skipNodeSelectorRequirement := corev1.NodeSelectorRequirement{
-		Key:      "metadata.name",
-		Operator: corev1.NodeSelectorOpNotIn,
-		Values: []string{
-			pod.Spec.NodeName,
-		},
-	}
-
 	for i := range affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
 		term := &affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[i]
-		term.MatchFields = append(term.MatchFields, skipNodeSelectorRequirement)
+		term.MatchFields = append(term.MatchFields, requirement)
 	}
 
 	if len(affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms) == 0 {
 		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms = []corev1.NodeSelectorTerm{
 			{
 				MatchFields: []corev1.NodeSelectorRequirement{
-					skipNodeSelectorRequirement,
+					requirement,
 				},
 			},
 		}
@@ -0,0 +1,323 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/klog/v2"
+	k8spodutil "k8s.io/kubernetes/pkg/api/v1/pod"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/util"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+)
+
+const (
+	defaultVerificationInterval = 10 * time.Second
+	// maxSourceNodeLoadVerificationDelay is how long the verification waits for the NodeMetric of the source node
+	// to be aggregated after the eviction.
+	maxSourceNodeLoadVerificationDelay = time.Hour
+)
+
+// AnnotationSourceNodeUsage records the usage of the source node when the Pod is evicted, which is the
+// baseline to verify whether the load of the source node dropped after the migration.
+const AnnotationSourceNodeUsage = "descheduler.koordinator.sh/source-node-usage"
+
+type sourceNodeUsage struct {
+	NodeName  string              `json:"nodeName"`
+	Usage     corev1.ResourceList `json:"usage"`
+	EvictedAt metav1.Time         `json:"evictedAt"`
+}
+
+func (r *Reconciler) initVerification(handle framework.Handle) error {
+	verificationArgs := r.args.VerificationArgs
+	if verificationArgs == nil {
+		return nil
+	}
+	r.workloadBackoff = flowcontrol.NewBackOff(verificationArgs.InitialBackoff.Duration, verificationArgs.MaxBackoff.Duration)
+	r.workloadBackoff.Clock = r.clock
+	if !verificationArgs.VerifySourceNodeLoad {
+		return nil
+	}
+
+	nodeMetricInformer := handle.KoordinatorSharedInformerFactory().Slo().V1alpha1().NodeMetrics()
+	nodeMetricInformer.Informer()
+	r.nodeMetricLister = nodeMetricInformer.Lister()
+	return nil
+}
+
+// isVerifying checks whether the bound Pod of the job is ready and the job is being verified.
+func (r *Reconciler) isVerifying(job *sev1alpha1.PodMigrationJob) bool {
+	if r.args.VerificationArgs == nil {
+		return false
+	}
+	_, cond := util.GetCondition(&job.Status, sev1alpha1.PodMigrationJobConditionBoundPodReady)
+	return cond != nil && cond.Status == sev1alpha1.PodMigrationJobConditionStatusTrue
+}
+
+// recordSourceNodeUsage persists the aggregated usage of the source node on the job,
+// so that the baseline survives restarts of the descheduler.
+func (r *Reconciler) recordSourceNodeUsage(ctx context.Context, job *sev1alpha1.PodMigrationJob, pod *corev1.Pod) {
+	if r.nodeMetricLister == nil || pod.Spec.NodeName == "" {
+		return
+	}
+	nodeMetric, err := r.nodeMetricLister.Get(pod.Spec.NodeName)
+	if err != nil {
+		klog.V(4).Infof("MigrationJob %s failed to get NodeMetric of source node %s, err: %v", job.Name, pod.Spec.NodeName, err)
+		return
+	}
+	usage := aggregatedNodeUsage(nodeMetric, time.Time{})
+	if usage == nil {
+		klog.V(4).Infof("MigrationJob %s skips recording the usage of source node %s without aggregated usage", job.Name, pod.Spec.NodeName)
+		return
+	}
+	data, err := json.Marshal(&sourceNodeUsage{
+		NodeName:  pod.Spec.NodeName,
+		Usage:     usage,
+		EvictedAt: metav1.NewTime(r.clock.Now()),
+	})
+	if err != nil {
+		return
+	}
+	patch := client.MergeFrom(job.DeepCopy())
+	if job.Annotations == nil {
+		job.Annotations = map[string]string{}
+	}
+	job.Annotations[AnnotationSourceNodeUsage] = string(data)
+	if err := r.Client.Patch(ctx, job, patch); err != nil {
+		klog.Errorf("MigrationJob %s failed to record the usage of source node %s, err: %v", job.Name, pod.Spec.NodeName, err)
+	}
+}
+
+func getSourceNodeUsage(job *sev1alpha1.PodMigrationJob) *sourceNodeUsage {
+	data, ok := job.Annotations[AnnotationSourceNodeUsage]
+	if !ok {
+		return nil
+	}
+	usage := &sourceNodeUsage{}
+	if err := json.Unmarshal([]byte(data), usage); err != nil {
+		klog.V(4).Infof("MigrationJob %s has invalid annotation %s, err: %v", job.Name, AnnotationSourceNodeUsage, err)
+		return nil
+	}
+	return usage
+}
+
+// aggregatedNodeUsage returns the median usage of the node, which is less noisy than a single sample.
+// Without notBefore the shortest aggregation window is used since it reflects the latest load.
+// Otherwise the longest window that starts after notBefore is used, so that the samples before notBefore are excluded.
+func aggregatedNodeUsage(nodeMetric *slov1alpha1.NodeMetric, notBefore time.Time) corev1.ResourceList {
+	if nodeMetric.Status.NodeMetric == nil || nodeMetric.Status.UpdateTime == nil {
+		return nil
+	}
+	var target *slov1alpha1.AggregatedUsage
+	for i := range nodeMetric.Status.NodeMetric.AggregatedNodeUsages {
+		aggregated := &nodeMetric.Status.NodeMetric.AggregatedNodeUsages[i]
+		if _, ok := aggregated.Usage[extension.P50]; !ok {
+			continue
+		}
+		if notBefore.IsZero() {
+			if target == nil || aggregated.Duration.Duration < target.Duration.Duration {
+				target = aggregated
+			}
+			continue
+		}
+		if nodeMetric.Status.UpdateTime.Add(-aggregated.Duration.Duration).Before(notBefore) {
+			continue
+		}
+		if target == nil || aggregated.Duration.Duration > target.Duration.Duration {
+			target = aggregated
+		}
+	}
+	if target == nil {
+		return nil
+	}
+	return target.Usage[extension.P50].ResourceList.DeepCopy()
+}
+
+// verifyMigration checks the bound Pod keeps ready without restart loops for HealthyDuration,
+// and the load of the source node dropped if VerifySourceNodeLoad is enabled.
+func (r *Reconciler) verifyMigration(ctx context.Context, job *sev1alpha1.PodMigrationJob, podNamespacedName types.NamespacedName) (bool, reconcile.Result, error) {
+	_, cond := util.GetCondition(&job.Status, sev1alpha1.PodMigrationJobConditionVerified)
+	if cond != nil && cond.Status == sev1alpha1.PodMigrationJobConditionStatusTrue {
+		return true, reconcile.Result{}, nil
+	}
+
+	klog.V(4).Infof("MigrationJob %s verifies whether boundpod %q is healthy", job.Name, podNamespacedName)
+	pod := &corev1.Pod{}
+	if err := r.Client.Get(ctx, podNamespacedName, pod); err != nil {
+		if errors.IsNotFound(err) {
+			err = r.abortJobByVerificationFailed(ctx, job, nil, fmt.Sprintf("Bound Pod %q is missing", podNamespacedName))
+			return false, reconcile.Result{}, err
+		}
+		return false, reconcile.Result{RequeueAfter: defaultRequeueAfter}, fmt.Errorf("failed to get pod %q", podNamespacedName)
+	}
+
+	if !k8spodutil.IsPodReady(pod) {
+		err := r.abortJobByVerificationFailed(ctx, job, pod, fmt.Sprintf("Bound Pod %q is not ready", podNamespacedName))
+		return false, reconcile.Result{}, err
+	}
+	verificationArgs := r.args.VerificationArgs
+	if restarts := getPodRestarts(pod); restarts > verificationArgs.MaxRestarts {
+		err := r.abortJobByVerificationFailed(ctx, job, pod, fmt.Sprintf("Bound Pod %q restarted %d times", podNamespacedName, restarts))
+		return false, reconcile.Result{}, err
+	}
+
+	var readySince time.Time
+	if _, readyCond := util.GetCondition(&job.Status, sev1alpha1.PodMigrationJobConditionBoundPodReady); readyCond != nil {
+		readySince = readyCond.LastTransitionTime.Time
+	}
+	healthyDuration := verificationArgs.HealthyDuration.Duration
+	if remaining := healthyDuration - r.clock.Since(readySince); remaining > 0 {
+		cond = &sev1alpha1.PodMigrationJobCondition{
+			Type:    sev1alpha1.PodMigrationJobConditionVerified,
+			Status:  sev1alpha1.PodMigrationJobConditionStatusFalse,
+			Reason:  sev1alpha1.PodMigrationJobReasonWaitForVerification,
+			Message: fmt.Sprintf("Waiting for Bound Pod %q to keep healthy for %v", podNamespacedName, healthyDuration),
+		}
+		err := r.updateCondition(ctx, job, cond)
+		requeueAfter := defaultVerificationInterval
+		if remaining < requeueAfter {
+			requeueAfter = remaining
+		}
+		return false, reconcile.Result{RequeueAfter: requeueAfter}, err
+	}
+
+	message := fmt.Sprintf("Bound Pod %q keeps healthy for %v", podNamespacedName, healthyDuration)
+	if verificationArgs.VerifySourceNodeLoad {
+		dropped, waiting, msg := r.verifySourceNodeLoad(job)
+		if waiting {
+			cond = &sev1alpha1.PodMigrationJobCondition{
+				Type:    sev1alpha1.PodMigrationJobConditionVerified,
+				Status:  sev1alpha1.PodMigrationJobConditionStatusFalse,
+				Reason:  sev1alpha1.PodMigrationJobReasonWaitForVerification,
+				Message: msg,
+			}
+			err := r.updateCondition(ctx, job, cond)
+			return false, reconcile.Result{RequeueAfter: defaultVerificationInterval}, err
+		}
+		if !dropped {
+			err := r.abortJobByVerificationFailed(ctx, job, pod, msg)
+			return false, reconcile.Result{}, err
+		}
+		message = fmt.Sprintf("%s, %s", message, msg)
+	}
+
+	cond = &sev1alpha1.PodMigrationJobCondition{
+		Type:    sev1alpha1.PodMigrationJobConditionVerified,
+		Status:  sev1alpha1.PodMigrationJobConditionStatusTrue,
+		Reason:  sev1alpha1.PodMigrationJobReasonVerificationSucceeded,
+		Message: message,
+	}
+	if err := r.updateCondition(ctx, job, cond); err != nil {
+		return false, reconcile.Result{}, err
+	}
+	r.eventRecorder.Eventf(job, nil, corev1.EventTypeNormal, sev1alpha1.PodMigrationJobReasonVerificationSucceeded, "Migrating", message)
+	return true, reconcile.Result{}, nil
+}
+
+// verifySourceNodeLoad compares the aggregated usage of the source node with the usage when the Pod was evicted,
+// the load is considered dropped if the usage of CPU or memory decreases by at least MinSourceNodeLoadDropPercent.
+// It returns waiting until an aggregation window of the NodeMetric starts after the eviction, and fails the verification
+// if the NodeMetric is not aggregated in maxSourceNodeLoadVerificationDelay.
+// The verification is skipped if the baseline is unknown.
+func (r *Reconciler) verifySourceNodeLoad(job *sev1alpha1.PodMigrationJob) (dropped bool, waiting bool, message string) {
+	baseline := getSourceNodeUsage(job)
+	if baseline == nil || r.nodeMetricLister == nil {
+		return true, false, "the load of source node is not verified without baseline"
+	}
+	var usage corev1.ResourceList
+	nodeMetric, err := r.nodeMetricLister.Get(baseline.NodeName)
+	if err == nil {
+		usage = aggregatedNodeUsage(nodeMetric, baseline.EvictedAt.Time)
+	}
+	if usage == nil {
+		if r.clock.Since(baseline.EvictedAt.Time) < maxSourceNodeLoadVerificationDelay {
+			return false, true, fmt.Sprintf("Waiting for NodeMetric of source node %q to be aggregated after the eviction", baseline.NodeName)
+		}
+		return false, false, fmt.Sprintf("NodeMetric of source node %q is not aggregated in %v after the eviction", baseline.NodeName, maxSourceNodeLoadVerificationDelay)
+	}
+	minDropPercent := int64(r.args.VerificationArgs.MinSourceNodeLoadDropPercent)
+	for _, resourceName := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		before, ok := baseline.Usage[resourceName]
+		if !ok || before.IsZero() {
+			continue
+		}
+		after := usage[resourceName]
+		if dropPercent := (before.MilliValue() - after.MilliValue()) * 100 / before.MilliValue(); dropPercent >= minDropPercent {
+			return true, false, fmt.Sprintf("the %s usage of source node %q dropped by %d%% from %s to %s",
+				resourceName, baseline.NodeName, dropPercent, before.String(), after.String())
+		}
+	}
+	return false, false, fmt.Sprintf("the load of source node %q does not drop by %d%% after the migration", baseline.NodeName, minDropPercent)
+}
+
+func (r *Reconciler) abortJobByVerificationFailed(ctx context.Context, job *sev1alpha1.PodMigrationJob, pod *corev1.Pod, message string) error {
+	if pod != nil {
+		r.backoffWorkload(pod)
+	}
+	util.UpdateCondition(&job.Status, &sev1alpha1.PodMigrationJobCondition{
+		Type:    sev1alpha1.PodMigrationJobConditionVerified,
+		Status:  sev1alpha1.PodMigrationJobConditionStatusFalse,
+		Reason:  sev1alpha1.PodMigrationJobReasonVerificationFailed,
+		Message: message,
+	})
+	job.Status.Phase = sev1alpha1.PodMigrationJobFailed
+	job.Status.Status = string(sev1alpha1.PodMigrationJobConditionVerified)
+	job.Status.Reason = sev1alpha1.PodMigrationJobReasonVerificationFailed
+	job.Status.Message = message
+	err := r.Client.Status().Update(ctx, job)
+	if err == nil {
+		r.eventRecorder.Eventf(job, nil, corev1.EventTypeWarning, sev1alpha1.PodMigrationJobReasonVerificationFailed, "Migrating", message)
+	}
+	return err
+}
+
+// backoffWorkload prevents the workload of the Pod from being migrated again until the backoff expires,
+// the backoff is doubled if the verification of the workload fails again before the backoff is reset.
+func (r *Reconciler) backoffWorkload(pod *corev1.Pod) {
+	if r.workloadBackoff == nil {
+		return
+	}
+	ownerRef := metav1.GetControllerOf(pod)
+	if ownerRef == nil {
+		return
+	}
+	id := string(ownerRef.UID)
+	r.workloadBackoff.Next(id, r.clock.Now())
+	klog.Infof("The workload %s/%s/%s failed the migration verification and backs off for %v", ownerRef.Name, ownerRef.Kind, ownerRef.APIVersion, r.workloadBackoff.Get(id))
+}
+
+func getPodRestarts(pod *corev1.Pod) int32 {
+	var restarts int32
+	for _, status := range pod.Status.ContainerStatuses {
+		restarts += status.RestartCount
+	}
+	return restarts
+}
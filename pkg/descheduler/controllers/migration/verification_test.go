@@ -0,0 +1,367 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/flowcontrol"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	koordslolisters "github.com/koordinator-sh/koordinator/pkg/client/listers/slo/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/util"
+)
+
+func newTestVerificationReconciler(now time.Time) *Reconciler {
+	reconciler := newTestReconciler()
+	fakeClock := clock.NewFakeClock(now)
+	reconciler.clock = fakeClock
+	reconciler.args.VerificationArgs = &deschedulerconfig.VerificationArgs{
+		HealthyDuration:              metav1.Duration{Duration: 5 * time.Minute},
+		MaxRestarts:                  1,
+		MinSourceNodeLoadDropPercent: 10,
+		InitialBackoff:               metav1.Duration{Duration: 5 * time.Minute},
+		MaxBackoff:                   metav1.Duration{Duration: 20 * time.Minute},
+	}
+	reconciler.workloadBackoff = flowcontrol.NewFakeBackOff(5*time.Minute, 20*time.Minute, fakeClock)
+	return reconciler
+}
+
+func TestVerifyMigration(t *testing.T) {
+	now := time.Now()
+	ownerRef := metav1.OwnerReference{
+		APIVersion: "apps/v1",
+		Kind:       "ReplicaSet",
+		Name:       "test-rs",
+		UID:        "test-rs-uid",
+		Controller: func() *bool { b := true; return &b }(),
+	}
+	tests := []struct {
+		name         string
+		pod          *corev1.Pod
+		readySince   time.Time
+		baseline     *sourceNodeUsage
+		wantVerified bool
+		wantRequeue  bool
+		wantPhase    sev1alpha1.PodMigrationJobPhase
+		wantReason   string
+		wantBackoff  bool
+	}{
+		{
+			name:       "bound pod is missing",
+			readySince: now.Add(-10 * time.Minute),
+			wantPhase:  sev1alpha1.PodMigrationJobFailed,
+			wantReason: sev1alpha1.PodMigrationJobReasonVerificationFailed,
+		},
+		{
+			name: "bound pod is not ready",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-pod", OwnerReferences: []metav1.OwnerReference{ownerRef}},
+			},
+			readySince:  now.Add(-time.Minute),
+			wantPhase:   sev1alpha1.PodMigrationJobFailed,
+			wantReason:  sev1alpha1.PodMigrationJobReasonVerificationFailed,
+			wantBackoff: true,
+		},
+		{
+			name: "bound pod restarts too many times",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-pod", OwnerReferences: []metav1.OwnerReference{ownerRef}},
+				Status: corev1.PodStatus{
+					Conditions:        []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+					ContainerStatuses: []corev1.ContainerStatus{{RestartCount: 1}, {RestartCount: 1}},
+				},
+			},
+			readySince:  now.Add(-time.Minute),
+			wantPhase:   sev1alpha1.PodMigrationJobFailed,
+			wantReason:  sev1alpha1.PodMigrationJobReasonVerificationFailed,
+			wantBackoff: true,
+		},
+		{
+			name: "wait for bound pod keeping healthy",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-pod", OwnerReferences: []metav1.OwnerReference{ownerRef}},
+				Status: corev1.PodStatus{
+					Conditions:        []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+					ContainerStatuses: []corev1.ContainerStatus{{RestartCount: 1}},
+				},
+			},
+			readySince:  now.Add(-time.Minute),
+			wantRequeue: true,
+			wantPhase:   sev1alpha1.PodMigrationJobRunning,
+			wantReason:  sev1alpha1.PodMigrationJobReasonWaitForVerification,
+		},
+		{
+			name: "bound pod keeps healthy",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-pod", OwnerReferences: []metav1.OwnerReference{ownerRef}},
+				Status: corev1.PodStatus{
+					Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+				},
+			},
+			readySince:   now.Add(-10 * time.Minute),
+			wantVerified: true,
+			wantPhase:    sev1alpha1.PodMigrationJobRunning,
+			wantReason:   sev1alpha1.PodMigrationJobReasonVerificationSucceeded,
+		},
+		{
+			name: "wait for NodeMetric of source node aggregated after the eviction",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-pod", OwnerReferences: []metav1.OwnerReference{ownerRef}},
+				Status: corev1.PodStatus{
+					Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+				},
+			},
+			readySince: now.Add(-10 * time.Minute),
+			baseline: &sourceNodeUsage{
+				NodeName:  "test-node",
+				Usage:     corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8")},
+				EvictedAt: metav1.NewTime(now.Add(-11 * time.Minute)),
+			},
+			wantRequeue: true,
+			wantPhase:   sev1alpha1.PodMigrationJobRunning,
+			wantReason:  sev1alpha1.PodMigrationJobReasonWaitForVerification,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reconciler := newTestVerificationReconciler(now)
+			job := &sev1alpha1.PodMigrationJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Status: sev1alpha1.PodMigrationJobStatus{
+					Phase: sev1alpha1.PodMigrationJobRunning,
+					Conditions: []sev1alpha1.PodMigrationJobCondition{
+						{
+							Type:               sev1alpha1.PodMigrationJobConditionBoundPodReady,
+							Status:             sev1alpha1.PodMigrationJobConditionStatusTrue,
+							LastTransitionTime: metav1.Time{Time: tt.readySince},
+						},
+					},
+				},
+			}
+			if tt.baseline != nil {
+				reconciler.args.VerificationArgs.VerifySourceNodeLoad = true
+				reconciler.nodeMetricLister = koordslolisters.NewNodeMetricLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{}))
+				data, err := json.Marshal(tt.baseline)
+				assert.NoError(t, err)
+				job.Annotations = map[string]string{AnnotationSourceNodeUsage: string(data)}
+			}
+			assert.Nil(t, reconciler.Client.Create(context.TODO(), job))
+			if tt.pod != nil {
+				assert.Nil(t, reconciler.Client.Create(context.TODO(), tt.pod))
+			}
+
+			verified, result, err := reconciler.verifyMigration(context.TODO(), job, types.NamespacedName{Namespace: "default", Name: "test-pod"})
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantVerified, verified)
+			assert.Equal(t, tt.wantRequeue, result.RequeueAfter > 0)
+			assert.True(t, result.RequeueAfter <= defaultVerificationInterval)
+
+			assert.True(t, reconciler.isVerifying(job))
+			_, cond := util.GetCondition(&job.Status, sev1alpha1.PodMigrationJobConditionVerified)
+			assert.NotNil(t, cond)
+			assert.Equal(t, tt.wantReason, cond.Reason)
+			assert.Equal(t, tt.wantPhase, job.Status.Phase)
+			assert.Equal(t, tt.wantBackoff, reconciler.workloadBackoff.Get(string(ownerRef.UID)) > 0)
+		})
+	}
+}
+
+func TestVerifySourceNodeLoad(t *testing.T) {
+	now := time.Now()
+	newNodeMetric := func(updateTime time.Time, usages map[time.Duration]corev1.ResourceList) *slov1alpha1.NodeMetric {
+		nodeMetric := &slov1alpha1.NodeMetric{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-node"},
+			Status: slov1alpha1.NodeMetricStatus{
+				UpdateTime: &metav1.Time{Time: updateTime},
+				NodeMetric: &slov1alpha1.NodeMetricInfo{
+					NodeUsage: slov1alpha1.ResourceMap{ResourceList: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}},
+				},
+			},
+		}
+		for duration, usage := range usages {
+			nodeMetric.Status.NodeMetric.AggregatedNodeUsages = append(nodeMetric.Status.NodeMetric.AggregatedNodeUsages, slov1alpha1.AggregatedUsage{
+				Usage:    map[extension.AggregationType]slov1alpha1.ResourceMap{extension.P50: {ResourceList: usage}},
+				Duration: metav1.Duration{Duration: duration},
+			})
+		}
+		return nodeMetric
+	}
+	baseline := &sourceNodeUsage{
+		NodeName:  "test-node",
+		Usage:     corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8"), corev1.ResourceMemory: resource.MustParse("8Gi")},
+		EvictedAt: metav1.NewTime(now),
+	}
+	staleBaseline := &sourceNodeUsage{
+		NodeName:  baseline.NodeName,
+		Usage:     baseline.Usage,
+		EvictedAt: metav1.NewTime(now.Add(-2 * maxSourceNodeLoadVerificationDelay)),
+	}
+	tests := []struct {
+		name        string
+		baseline    *sourceNodeUsage
+		nodeMetric  *slov1alpha1.NodeMetric
+		wantDropped bool
+		wantWaiting bool
+	}{
+		{
+			name:        "no baseline",
+			wantDropped: true,
+		},
+		{
+			name:     "wait for the aggregation window after the eviction",
+			baseline: baseline,
+			nodeMetric: newNodeMetric(now.Add(3*time.Minute), map[time.Duration]corev1.ResourceList{
+				5 * time.Minute: {corev1.ResourceCPU: resource.MustParse("9"), corev1.ResourceMemory: resource.MustParse("9Gi")},
+			}),
+			wantWaiting: true,
+		},
+		{
+			name:     "NodeMetric is not aggregated for too long after the eviction",
+			baseline: staleBaseline,
+		},
+		{
+			name:     "aggregated load drops less than the minimum",
+			baseline: baseline,
+			nodeMetric: newNodeMetric(now.Add(6*time.Minute), map[time.Duration]corev1.ResourceList{
+				5 * time.Minute: {corev1.ResourceCPU: resource.MustParse("7600m"), corev1.ResourceMemory: resource.MustParse("8Gi")},
+			}),
+			wantDropped: false,
+		},
+		{
+			name:     "aggregated load dropped",
+			baseline: baseline,
+			nodeMetric: newNodeMetric(now.Add(6*time.Minute), map[time.Duration]corev1.ResourceList{
+				5 * time.Minute:  {corev1.ResourceCPU: resource.MustParse("9"), corev1.ResourceMemory: resource.MustParse("6Gi")},
+				10 * time.Minute: {corev1.ResourceCPU: resource.MustParse("9"), corev1.ResourceMemory: resource.MustParse("9Gi")},
+			}),
+			wantDropped: true,
+		},
+		{
+			name:     "aggregated load does not drop",
+			baseline: baseline,
+			nodeMetric: newNodeMetric(now.Add(6*time.Minute), map[time.Duration]corev1.ResourceList{
+				5 * time.Minute:  {corev1.ResourceCPU: resource.MustParse("9"), corev1.ResourceMemory: resource.MustParse("8Gi")},
+				10 * time.Minute: {corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+			}),
+			wantDropped: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reconciler := newTestVerificationReconciler(now)
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			if tt.nodeMetric != nil {
+				assert.Nil(t, indexer.Add(tt.nodeMetric))
+			}
+			reconciler.nodeMetricLister = koordslolisters.NewNodeMetricLister(indexer)
+			job := &sev1alpha1.PodMigrationJob{ObjectMeta: metav1.ObjectMeta{Name: "test", UID: "test-uid"}}
+			if tt.baseline != nil {
+				data, err := json.Marshal(tt.baseline)
+				assert.NoError(t, err)
+				job.Annotations = map[string]string{AnnotationSourceNodeUsage: string(data)}
+			}
+			dropped, waiting, _ := reconciler.verifySourceNodeLoad(job)
+			assert.Equal(t, tt.wantDropped, dropped)
+			assert.Equal(t, tt.wantWaiting, waiting)
+		})
+	}
+}
+
+func TestRecordSourceNodeUsage(t *testing.T) {
+	now := time.Now()
+	reconciler := newTestVerificationReconciler(now)
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.Nil(t, indexer.Add(&slov1alpha1.NodeMetric{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-node"},
+		Status: slov1alpha1.NodeMetricStatus{
+			UpdateTime: &metav1.Time{Time: now},
+			NodeMetric: &slov1alpha1.NodeMetricInfo{
+				AggregatedNodeUsages: []slov1alpha1.AggregatedUsage{
+					{
+						Usage:    map[extension.AggregationType]slov1alpha1.ResourceMap{extension.P50: {ResourceList: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("6")}}},
+						Duration: metav1.Duration{Duration: 10 * time.Minute},
+					},
+					{
+						Usage:    map[extension.AggregationType]slov1alpha1.ResourceMap{extension.P50: {ResourceList: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8")}}},
+						Duration: metav1.Duration{Duration: 5 * time.Minute},
+					},
+				},
+			},
+		},
+	}))
+	reconciler.nodeMetricLister = koordslolisters.NewNodeMetricLister(indexer)
+	job := &sev1alpha1.PodMigrationJob{ObjectMeta: metav1.ObjectMeta{Name: "test", UID: "test-uid"}}
+	assert.Nil(t, reconciler.Client.Create(context.TODO(), job))
+	pod := &corev1.Pod{Spec: corev1.PodSpec{NodeName: "test-node"}}
+	reconciler.recordSourceNodeUsage(context.TODO(), job, pod)
+
+	got := &sev1alpha1.PodMigrationJob{}
+	assert.Nil(t, reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: job.Name}, got))
+	baseline := getSourceNodeUsage(got)
+	assert.NotNil(t, baseline)
+	assert.Equal(t, "test-node", baseline.NodeName)
+	cpu := baseline.Usage[corev1.ResourceCPU]
+	assert.Equal(t, int64(8000), cpu.MilliValue())
+}
+
+func TestFilterWorkloadBackoff(t *testing.T) {
+	now := time.Now()
+	reconciler := newTestVerificationReconciler(now)
+	fakeClock := reconciler.clock.(*clock.FakeClock)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-pod",
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: "apps/v1",
+					Kind:       "ReplicaSet",
+					Name:       "test-rs",
+					UID:        "test-rs-uid",
+					Controller: func() *bool { b := true; return &b }(),
+				},
+			},
+		},
+	}
+	assert.True(t, reconciler.filterWorkloadBackoff(pod))
+
+	reconciler.backoffWorkload(pod)
+	assert.False(t, reconciler.filterWorkloadBackoff(pod))
+	fakeClock.Step(6 * time.Minute)
+	assert.True(t, reconciler.filterWorkloadBackoff(pod))
+
+	// the backoff is doubled after the verification fails again
+	reconciler.backoffWorkload(pod)
+	fakeClock.Step(6 * time.Minute)
+	assert.False(t, reconciler.filterWorkloadBackoff(pod))
+	fakeClock.Step(5 * time.Minute)
+	assert.True(t, reconciler.filterWorkloadBackoff(pod))
+}
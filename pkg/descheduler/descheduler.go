@@ -32,6 +32,7 @@ import (
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/klog/v2"
 
+	koordinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
 	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config/scheme"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config/v1alpha2"
@@ -70,6 +71,7 @@ type deschedulerOptions struct {
 	deschedulingInterval   time.Duration
 	nodeSelector           *metav1.LabelSelector
 	evictionLimiter        frameworkruntime.EvictionLimiter
+	koordInformerFactory   koordinformers.SharedInformerFactory
 }
 
 // Option configures a Scheduler
@@ -148,6 +150,14 @@ func WithEvictionLimiter(limiter frameworkruntime.EvictionLimiter) Option {
 	}
 }
 
+// WithKoordinatorSharedInformerFactory sets the informer factory of koordinator resources shared by the plugins.
+// The caller is responsible for starting it after the descheduler is created.
+func WithKoordinatorSharedInformerFactory(factory koordinformers.SharedInformerFactory) Option {
+	return func(options *deschedulerOptions) {
+		options.koordInformerFactory = factory
+	}
+}
+
 var defaultDeschedulerOptions = deschedulerOptions{
 	applyDefaultProfile: true,
 }
@@ -214,6 +224,7 @@ func New(client clientset.Interface,
 		frameworkruntime.WithClientSet(client),
 		frameworkruntime.WithKubeConfig(options.kubeConfig),
 		frameworkruntime.WithSharedInformerFactory(informerFactory),
+		frameworkruntime.WithKoordinatorSharedInformerFactory(options.koordInformerFactory),
 		frameworkruntime.WithEvictionLimiter(options.evictionLimiter),
 		frameworkruntime.WithGetPodsAssignedToNodeFunc(podAssignedToNodeAdaptor(options.podAssignedToNodeFn)),
 		frameworkruntime.WithCaptureProfile(frameworkruntime.CaptureProfile(options.frameworkCapturer)),
@@ -0,0 +1,258 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gpufragmentation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	schedulinglisters "github.com/koordinator-sh/koordinator/pkg/client/listers/scheduling/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config/validation"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	nodeutil "github.com/koordinator-sh/koordinator/pkg/descheduler/node"
+	podutil "github.com/koordinator-sh/koordinator/pkg/descheduler/pod"
+)
+
+const (
+	GPUFragmentationName = "GPUFragmentation"
+)
+
+var _ framework.BalancePlugin = &GPUFragmentation{}
+
+// GPUFragmentation migrates the small shared-GPU Pods from the fragmented GPUs to the other partially used GPUs,
+// so that the whole GPUs can be freed for the Pods requesting full GPUs.
+type GPUFragmentation struct {
+	handle       framework.Handle
+	podFilter    framework.FilterFunc
+	nodeSelector labels.Selector
+	deviceLister schedulinglisters.DeviceLister
+	args         *deschedulerconfig.GPUFragmentationArgs
+}
+
+// NewGPUFragmentation builds plugin from its arguments while passing a handle
+func NewGPUFragmentation(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+	gpuFragmentationArgs, ok := args.(*deschedulerconfig.GPUFragmentationArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type GPUFragmentationArgs, got %T", args)
+	}
+	if err := validation.ValidateGPUFragmentationArgs(nil, gpuFragmentationArgs); err != nil {
+		return nil, err
+	}
+
+	nodeSelector := labels.Everything()
+	if gpuFragmentationArgs.NodeSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(gpuFragmentationArgs.NodeSelector)
+		if err != nil {
+			return nil, err
+		}
+		nodeSelector = selector
+	}
+
+	var excludedNamespaces sets.String
+	var includedNamespaces sets.String
+	if gpuFragmentationArgs.EvictableNamespaces != nil {
+		excludedNamespaces = sets.NewString(gpuFragmentationArgs.EvictableNamespaces.Exclude...)
+		includedNamespaces = sets.NewString(gpuFragmentationArgs.EvictableNamespaces.Include...)
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithFilter(handle.Evictor().Filter).
+		WithoutNamespaces(excludedNamespaces).
+		WithNamespaces(includedNamespaces).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	deviceInformer := handle.KoordinatorSharedInformerFactory().Scheduling().V1alpha1().Devices()
+	deviceInformer.Informer()
+
+	return &GPUFragmentation{
+		handle:       handle,
+		podFilter:    podFilter,
+		nodeSelector: nodeSelector,
+		deviceLister: deviceInformer.Lister(),
+		args:         gpuFragmentationArgs,
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (pl *GPUFragmentation) Name() string {
+	return GPUFragmentationName
+}
+
+// Balance extension point implementation for the plugin
+func (pl *GPUFragmentation) Balance(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	if pl.args.Paused {
+		klog.Infof("GPUFragmentation is paused and will do nothing.")
+		return nil
+	}
+
+	nodeGPUInfos, err := pl.getNodeGPUInfos(nodes)
+	if err != nil {
+		return &framework.Status{Err: err}
+	}
+	if len(nodeGPUInfos) == 0 {
+		klog.V(4).InfoS("No GPU nodes to process GPUFragmentation")
+		return nil
+	}
+
+	var partialGPUs, candidates []*GPUInfo
+	targetNodes := sets.NewString()
+	var nodeFitTargets []*corev1.Node
+	for _, nodeGPUInfo := range nodeGPUInfos {
+		fragmentation := nodeGPUInfo.fragmentation()
+		klog.V(4).InfoS("Node GPU fragmentation", "node", klog.KObj(nodeGPUInfo.node), "fragmentation", fragmentation)
+		for _, gpu := range nodeGPUInfo.gpus {
+			if !gpu.isPartial() {
+				continue
+			}
+			partialGPUs = append(partialGPUs, gpu)
+			if !targetNodes.Has(gpu.node.Name) {
+				targetNodes.Insert(gpu.node.Name)
+				nodeFitTargets = append(nodeFitTargets, gpu.node)
+			}
+			if fragmentation >= pl.args.FragmentationThreshold {
+				candidates = append(candidates, gpu)
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		klog.V(4).InfoS("No nodes are above the GPU fragmentation threshold, nothing to do here", "fragmentationThreshold", pl.args.FragmentationThreshold)
+		return nil
+	}
+
+	// the GPU with the least allocated gpu-core is the cheapest to be freed
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].usedCore != candidates[j].usedCore {
+			return candidates[i].usedCore < candidates[j].usedCore
+		}
+		if candidates[i].node.Name != candidates[j].node.Name {
+			return candidates[i].node.Name < candidates[j].node.Name
+		}
+		return candidates[i].minor < candidates[j].minor
+	})
+
+	freed := map[*GPUInfo]bool{}
+	receivers := map[*GPUInfo]bool{}
+	for _, candidate := range candidates {
+		if len(freed) >= int(pl.args.MaxGPUsPerRound) {
+			break
+		}
+		if receivers[candidate] || !pl.isGPUMigratable(candidate, nodeFitTargets) {
+			continue
+		}
+		// the Reservation of the migrated Pod never lands on its source node, so only the GPUs of the other nodes are targets
+		targets := make([]*GPUInfo, 0, len(partialGPUs))
+		for _, gpu := range partialGPUs {
+			if !freed[gpu] && gpu.node.Name != candidate.node.Name {
+				targets = append(targets, gpu)
+			}
+		}
+		placements, ok := simulateMigration(candidate, targets)
+		if !ok {
+			klog.V(4).InfoS("The Pods of GPU can not be placed into the other GPUs", "node", klog.KObj(candidate.node), "minor", candidate.minor)
+			continue
+		}
+		freed[candidate] = true
+		for _, gpu := range placements {
+			receivers[gpu] = true
+		}
+		pl.evictGPUPods(ctx, candidate, placements)
+	}
+	return nil
+}
+
+func (pl *GPUFragmentation) getNodeGPUInfos(nodes []*corev1.Node) ([]*NodeGPUInfo, error) {
+	var nodeGPUInfos []*NodeGPUInfo
+	for _, node := range nodes {
+		if !pl.nodeSelector.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+		device, err := pl.deviceLister.Get(node.Name)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		nodeGPUInfo, err := getNodeGPUInfo(node, device, pl.handle.GetPodsAssignedToNodeFunc())
+		if err != nil {
+			return nil, err
+		}
+		if len(nodeGPUInfo.gpus) > 0 {
+			nodeGPUInfos = append(nodeGPUInfos, nodeGPUInfo)
+		}
+	}
+	return nodeGPUInfos, nil
+}
+
+// isGPUMigratable checks whether all the Pods on the GPU are the small shared-GPU Pods that can be evicted.
+func (pl *GPUFragmentation) isGPUMigratable(gpu *GPUInfo, nodeFitTargets []*corev1.Node) bool {
+	for _, p := range gpu.pods {
+		if !p.shared || p.core > pl.args.MaxPodGPUCore {
+			klog.V(4).InfoS("GPU is not migratable because of the Pod is not a small shared-GPU Pod", "pod", klog.KObj(p.pod), "node", klog.KObj(gpu.node), "minor", gpu.minor)
+			return false
+		}
+		if !pl.podFilter(p.pod) {
+			klog.V(4).InfoS("GPU is not migratable because of the Pod was filtered by filters", "pod", klog.KObj(p.pod), "node", klog.KObj(gpu.node), "minor", gpu.minor)
+			return false
+		}
+		if pl.args.NodeFit && !nodeutil.PodFitsAnyNode(pl.handle.GetPodsAssignedToNodeFunc(), p.pod, nodeFitTargets) {
+			klog.V(4).InfoS("GPU is not migratable because of the Pod does not fit any node", "pod", klog.KObj(p.pod), "node", klog.KObj(gpu.node), "minor", gpu.minor)
+			return false
+		}
+	}
+	return true
+}
+
+// evictGPUPods evicts the Pods of the GPU with the Reservations restricted to the nodes of the planned target GPUs.
+// The GPU within the target node is still chosen by the DeviceShare plugin of the scheduler,
+// since the Reservation cannot be pinned to a specific GPU minor.
+// The eviction stops at the first failure, the GPU can not be freed anyway and the remaining Pods are left in place.
+func (pl *GPUFragmentation) evictGPUPods(ctx context.Context, gpu *GPUInfo, placements map[*gpuPod]*GPUInfo) {
+	reason := fmt.Sprintf("consolidate the fragmented GPU %d on node %s", gpu.minor, gpu.node.Name)
+	for _, p := range gpu.pods {
+		target := placements[p]
+		if pl.args.DryRun {
+			klog.InfoS("Evict pod in dry run mode", "pod", klog.KObj(p.pod), "node", klog.KObj(gpu.node), "minor", gpu.minor, "targetNode", klog.KObj(target.node), "targetMinor", target.minor)
+			continue
+		}
+		// the reservation ensures the Pod is placed into the node of the planned GPU before the Pod is evicted
+		podCtx := migration.WithContext(ctx, &migration.JobContext{
+			Mode:             sev1alpha1.PodMigrationJobModeReservationFirst,
+			DestinationNodes: []string{target.node.Name},
+		})
+		if !pl.handle.Evictor().Evict(podCtx, p.pod, framework.EvictOptions{Reason: reason}) {
+			klog.InfoS("Failed to Evict Pod, stop consolidating the GPU", "pod", klog.KObj(p.pod), "node", klog.KObj(gpu.node), "minor", gpu.minor)
+			return
+		}
+		klog.InfoS("Evicted Pod", "pod", klog.KObj(p.pod), "node", klog.KObj(gpu.node), "minor", gpu.minor, "targetNode", klog.KObj(target.node), "targetMinor", target.minor)
+	}
+}
@@ -0,0 +1,324 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gpufragmentation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	coretesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/pointer"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	koordfake "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
+	koordinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/evictions"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/kubernetes/defaultevictor"
+	frameworkruntime "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/runtime"
+	frameworktesting "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/testing"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/test"
+	"github.com/koordinator-sh/koordinator/pkg/util"
+)
+
+func setupFakeDiscoveryWithPolicyResource(fake *coretesting.Fake) {
+	fake.AddReactor("get", "group", func(action coretesting.Action) (handled bool, ret runtime.Object, err error) {
+		fake.Resources = []*metav1.APIResourceList{
+			{
+				GroupVersion: policy.SchemeGroupVersion.String(),
+				APIResources: []metav1.APIResource{
+					{
+						Name: util.EvictionSubResourceName,
+						Kind: util.EvictionKind,
+					},
+				},
+			},
+		}
+		return true, nil, nil
+	})
+	fake.AddReactor("get", "resource", func(action coretesting.Action) (handled bool, ret runtime.Object, err error) {
+		fake.Resources = []*metav1.APIResourceList{
+			{
+				GroupVersion: "v1",
+				APIResources: []metav1.APIResource{
+					{
+						Name: util.EvictionSubResourceName,
+						Kind: util.EvictionKind,
+					},
+				},
+			},
+		}
+		return true, nil, nil
+	})
+}
+
+func buildTestDevice(nodeName string, numGPUs int32) *schedulingv1alpha1.Device {
+	device := &schedulingv1alpha1.Device{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: nodeName,
+		},
+	}
+	for i := int32(0); i < numGPUs; i++ {
+		device.Spec.Devices = append(device.Spec.Devices, schedulingv1alpha1.DeviceInfo{
+			Type:   schedulingv1alpha1.GPU,
+			Minor:  pointer.Int32(i),
+			Health: true,
+			Resources: corev1.ResourceList{
+				extension.ResourceGPUCore:        *resource.NewQuantity(100, resource.DecimalSI),
+				extension.ResourceGPUMemoryRatio: *resource.NewQuantity(100, resource.DecimalSI),
+			},
+		})
+	}
+	return device
+}
+
+func buildTestGPUPod(t *testing.T, name, nodeName string, gpuCore int64, minors ...int32) *corev1.Pod {
+	return test.BuildTestPod(name, 100, 0, nodeName, func(pod *corev1.Pod) {
+		test.SetRSOwnerRef(pod)
+		var allocations []*extension.DeviceAllocation
+		for _, minor := range minors {
+			allocations = append(allocations, &extension.DeviceAllocation{
+				Minor: minor,
+				Resources: corev1.ResourceList{
+					extension.ResourceGPUCore:        *resource.NewQuantity(gpuCore, resource.DecimalSI),
+					extension.ResourceGPUMemoryRatio: *resource.NewQuantity(gpuCore, resource.DecimalSI),
+				},
+			})
+		}
+		assert.NoError(t, extension.SetDeviceAllocations(pod, extension.DeviceAllocations{schedulingv1alpha1.GPU: allocations}))
+	})
+}
+
+func TestGPUFragmentation(t *testing.T) {
+	testCases := []struct {
+		name                string
+		buildPods           func(t *testing.T) []*corev1.Pod
+		args                deschedulerconfig.GPUFragmentationArgs
+		expectedPodsEvicted uint
+	}{
+		{
+			name: "consolidate the least used GPUs",
+			args: deschedulerconfig.GPUFragmentationArgs{
+				NodeFit:                true,
+				MaxPodGPUCore:          50,
+				FragmentationThreshold: 30,
+				MaxGPUsPerRound:        2,
+			},
+			expectedPodsEvicted: 2,
+		},
+		{
+			name: "limited by maxGPUsPerRound",
+			args: deschedulerconfig.GPUFragmentationArgs{
+				NodeFit:                true,
+				MaxPodGPUCore:          50,
+				FragmentationThreshold: 30,
+				MaxGPUsPerRound:        1,
+			},
+			expectedPodsEvicted: 1,
+		},
+		{
+			name: "nodes under fragmentation threshold",
+			args: deschedulerconfig.GPUFragmentationArgs{
+				NodeFit:                true,
+				MaxPodGPUCore:          50,
+				FragmentationThreshold: 50,
+				MaxGPUsPerRound:        2,
+			},
+			expectedPodsEvicted: 0,
+		},
+		{
+			name: "pods larger than maxPodGPUCore",
+			args: deschedulerconfig.GPUFragmentationArgs{
+				NodeFit:                true,
+				MaxPodGPUCore:          10,
+				FragmentationThreshold: 30,
+				MaxGPUsPerRound:        2,
+			},
+			expectedPodsEvicted: 0,
+		},
+		{
+			name: "dry run",
+			args: deschedulerconfig.GPUFragmentationArgs{
+				DryRun:                 true,
+				NodeFit:                true,
+				MaxPodGPUCore:          50,
+				FragmentationThreshold: 30,
+				MaxGPUsPerRound:        2,
+			},
+			expectedPodsEvicted: 0,
+		},
+		{
+			name: "pods allocated multiple GPUs are not migrated",
+			buildPods: func(t *testing.T) []*corev1.Pod {
+				return []*corev1.Pod{
+					buildTestGPUPod(t, "pod-1", "node-1", 20, 0, 1),
+					buildTestGPUPod(t, "pod-2", "node-2", 60, 0),
+				}
+			},
+			args: deschedulerconfig.GPUFragmentationArgs{
+				NodeFit:                true,
+				MaxPodGPUCore:          50,
+				FragmentationThreshold: 30,
+				MaxGPUsPerRound:        2,
+			},
+			expectedPodsEvicted: 0,
+		},
+		{
+			name: "pods are not migrated to the other GPUs of the same node",
+			buildPods: func(t *testing.T) []*corev1.Pod {
+				// node-1 fragmentation: (80+30)/400=27%, node-2 fragmentation: (5+5)/200=5%
+				return []*corev1.Pod{
+					buildTestGPUPod(t, "pod-1", "node-1", 20, 0),
+					buildTestGPUPod(t, "pod-2", "node-1", 70, 1),
+					buildTestGPUPod(t, "pod-3", "node-2", 95, 0),
+					buildTestGPUPod(t, "pod-4", "node-2", 95, 1),
+				}
+			},
+			args: deschedulerconfig.GPUFragmentationArgs{
+				NodeFit:                true,
+				MaxPodGPUCore:          50,
+				FragmentationThreshold: 20,
+				MaxGPUsPerRound:        2,
+			},
+			expectedPodsEvicted: 0,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			nodes := []*corev1.Node{
+				test.BuildTestNode("node-1", 32000, 64*1024*1024*1024, 100, nil),
+				test.BuildTestNode("node-2", 32000, 64*1024*1024*1024, 100, nil),
+			}
+			var pods []*corev1.Pod
+			if tt.buildPods != nil {
+				pods = tt.buildPods(t)
+			} else {
+				// node-1 fragmentation: (80+70)/400=37%, node-2 fragmentation: (40+50)/200=45%
+				pods = []*corev1.Pod{
+					buildTestGPUPod(t, "pod-1", "node-1", 20, 0),
+					buildTestGPUPod(t, "pod-2", "node-1", 30, 1),
+					buildTestGPUPod(t, "pod-3", "node-2", 60, 0),
+					buildTestGPUPod(t, "pod-4", "node-2", 50, 1),
+				}
+			}
+
+			var objs []runtime.Object
+			for _, node := range nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+			setupFakeDiscoveryWithPolicyResource(&fakeClient.Fake)
+
+			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+			_ = sharedInformerFactory.Core().V1().Nodes().Informer()
+			podInformer := sharedInformerFactory.Core().V1().Pods()
+
+			getPodsAssignedToNode, err := test.BuildGetPodsAssignedToNodeFunc(podInformer)
+			assert.NoError(t, err)
+
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			eventRecorder := &events.FakeRecorder{}
+			evictionLimiter := evictions.NewEvictionLimiter(nil, nil)
+
+			koordClientSet := koordfake.NewSimpleClientset(buildTestDevice("node-1", 4), buildTestDevice("node-2", 2))
+			koordSharedInformerFactory := koordinformers.NewSharedInformerFactory(koordClientSet, 0)
+
+			args := tt.args
+			fh, err := frameworktesting.NewFramework(
+				[]frameworktesting.RegisterPluginFunc{
+					func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+						reg.Register(defaultevictor.PluginName, defaultevictor.New)
+						profile.Plugins.Evict.Enabled = append(profile.Plugins.Evict.Enabled, deschedulerconfig.Plugin{Name: defaultevictor.PluginName})
+						profile.Plugins.Filter.Enabled = append(profile.Plugins.Filter.Enabled, deschedulerconfig.Plugin{Name: defaultevictor.PluginName})
+						profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+							Name: defaultevictor.PluginName,
+							Args: &defaultevictor.DefaultEvictorArgs{},
+						})
+					},
+					func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+						reg.Register(GPUFragmentationName, func(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+							return NewGPUFragmentation(args, handle)
+						})
+						profile.Plugins.Balance.Enabled = append(profile.Plugins.Balance.Enabled, deschedulerconfig.Plugin{Name: GPUFragmentationName})
+						profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+							Name: GPUFragmentationName,
+							Args: &args,
+						})
+					},
+				},
+				"test",
+				frameworkruntime.WithClientSet(fakeClient),
+				frameworkruntime.WithEvictionLimiter(evictionLimiter),
+				frameworkruntime.WithEventRecorder(eventRecorder),
+				frameworkruntime.WithSharedInformerFactory(sharedInformerFactory),
+				frameworkruntime.WithKoordinatorSharedInformerFactory(koordSharedInformerFactory),
+				frameworkruntime.WithGetPodsAssignedToNodeFunc(getPodsAssignedToNode),
+			)
+			assert.NoError(t, err)
+
+			koordSharedInformerFactory.Start(ctx.Done())
+			koordSharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			fh.RunBalancePlugins(ctx, nodes)
+
+			assert.Equal(t, tt.expectedPodsEvicted, evictionLimiter.TotalEvicted())
+		})
+	}
+}
+
+func TestSimulateMigration(t *testing.T) {
+	pod1 := &gpuPod{core: 30, memoryRatio: 30, shared: true}
+	pod2 := &gpuPod{core: 20, memoryRatio: 20, shared: true}
+	source := &GPUInfo{totalCore: 100, totalMemoryRatio: 100, usedCore: 50, usedMemoryRatio: 50,
+		pods: []*gpuPod{pod1, pod2},
+	}
+	target1 := &GPUInfo{minor: 1, totalCore: 100, totalMemoryRatio: 100, usedCore: 60, usedMemoryRatio: 60}
+	target2 := &GPUInfo{minor: 2, totalCore: 100, totalMemoryRatio: 100, usedCore: 75, usedMemoryRatio: 75}
+
+	placements, ok := simulateMigration(source, []*GPUInfo{source, target1, target2})
+	assert.True(t, ok)
+	assert.Equal(t, map[*gpuPod]*GPUInfo{pod1: target1, pod2: target2}, placements)
+	assert.Equal(t, int64(90), target1.usedCore)
+	assert.Equal(t, int64(95), target2.usedCore)
+
+	// no GPU has enough free gpu-core, the targets are left unchanged
+	placements, ok = simulateMigration(source, []*GPUInfo{source, target1, target2})
+	assert.False(t, ok)
+	assert.Nil(t, placements)
+	assert.Equal(t, int64(90), target1.usedCore)
+	assert.Equal(t, int64(95), target2.usedCore)
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gpufragmentation
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	"github.com/koordinator-sh/koordinator/pkg/util"
+)
+
+// gpuPod is a Pod allocated on a GPU with the resources allocated from the GPU.
+type gpuPod struct {
+	pod         *corev1.Pod
+	core        int64
+	memoryRatio int64
+	// shared indicates whether the Pod is allocated exactly one GPU,
+	// only the shared Pods can be migrated to the other GPUs.
+	shared bool
+}
+
+// GPUInfo is the allocation state of a GPU.
+type GPUInfo struct {
+	node             *corev1.Node
+	minor            int32
+	totalCore        int64
+	totalMemoryRatio int64
+	usedCore         int64
+	usedMemoryRatio  int64
+	pods             []*gpuPod
+}
+
+func (g *GPUInfo) isPartial() bool {
+	return g.usedCore > 0 && g.usedCore < g.totalCore
+}
+
+func (g *GPUInfo) freeCore() int64 {
+	return g.totalCore - g.usedCore
+}
+
+func (g *GPUInfo) fits(pod *gpuPod) bool {
+	return g.usedCore+pod.core <= g.totalCore && g.usedMemoryRatio+pod.memoryRatio <= g.totalMemoryRatio
+}
+
+func (g *GPUInfo) assume(pod *gpuPod) {
+	g.usedCore += pod.core
+	g.usedMemoryRatio += pod.memoryRatio
+}
+
+func (g *GPUInfo) forget(pod *gpuPod) {
+	g.usedCore -= pod.core
+	g.usedMemoryRatio -= pod.memoryRatio
+}
+
+// NodeGPUInfo is the allocation state of the GPUs of a node.
+type NodeGPUInfo struct {
+	node *corev1.Node
+	gpus []*GPUInfo
+}
+
+// fragmentation returns the percentage of the free gpu-core on the partially used GPUs to the total gpu-core of the node.
+func (n *NodeGPUInfo) fragmentation() int64 {
+	var total, fragmented int64
+	for _, gpu := range n.gpus {
+		total += gpu.totalCore
+		if gpu.isPartial() {
+			fragmented += gpu.freeCore()
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return fragmented * 100 / total
+}
+
+// getNodeGPUInfo builds the GPU allocation state of the node from its Device and the device-allocated annotations of its Pods.
+func getNodeGPUInfo(node *corev1.Node, device *schedulingv1alpha1.Device, getPodsAssignedToNode framework.GetPodsAssignedToNodeFunc) (*NodeGPUInfo, error) {
+	nodeGPUInfo := &NodeGPUInfo{node: node}
+	gpus := map[int32]*GPUInfo{}
+	for _, info := range device.Spec.Devices {
+		if info.Type != schedulingv1alpha1.GPU || !info.Health || info.Minor == nil {
+			continue
+		}
+		gpuCore := info.Resources[extension.ResourceGPUCore]
+		if gpuCore.Value() <= 0 {
+			continue
+		}
+		gpuMemoryRatio := info.Resources[extension.ResourceGPUMemoryRatio]
+		gpu := &GPUInfo{
+			node:             node,
+			minor:            *info.Minor,
+			totalCore:        gpuCore.Value(),
+			totalMemoryRatio: gpuMemoryRatio.Value(),
+		}
+		gpus[gpu.minor] = gpu
+		nodeGPUInfo.gpus = append(nodeGPUInfo.gpus, gpu)
+	}
+	sort.Slice(nodeGPUInfo.gpus, func(i, j int) bool {
+		return nodeGPUInfo.gpus[i].minor < nodeGPUInfo.gpus[j].minor
+	})
+	if len(gpus) == 0 {
+		return nodeGPUInfo, nil
+	}
+
+	pods, err := getPodsAssignedToNode(node.Name, func(pod *corev1.Pod) bool {
+		return !util.IsPodTerminated(pod)
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, pod := range pods {
+		allocations, err := extension.GetDeviceAllocations(pod.Annotations)
+		if err != nil {
+			klog.V(4).InfoS("Failed to parse device allocations of Pod", "pod", klog.KObj(pod), "err", err)
+			continue
+		}
+		gpuAllocations := allocations[schedulingv1alpha1.GPU]
+		for _, allocation := range gpuAllocations {
+			gpu := gpus[allocation.Minor]
+			if gpu == nil {
+				continue
+			}
+			gpuCore := allocation.Resources[extension.ResourceGPUCore]
+			gpuMemoryRatio := allocation.Resources[extension.ResourceGPUMemoryRatio]
+			p := &gpuPod{
+				pod:         pod,
+				core:        gpuCore.Value(),
+				memoryRatio: gpuMemoryRatio.Value(),
+				shared:      len(gpuAllocations) == 1,
+			}
+			gpu.assume(p)
+			gpu.pods = append(gpu.pods, p)
+		}
+	}
+	return nodeGPUInfo, nil
+}
+
+// simulateMigration tries to place the Pods of the source GPU into the other partially used GPUs by best fit.
+// If all the Pods can be placed, the targets are assumed with the Pods and the planned GPU of each Pod is returned,
+// otherwise the targets are left unchanged.
+func simulateMigration(source *GPUInfo, targets []*GPUInfo) (map[*gpuPod]*GPUInfo, bool) {
+	pods := make([]*gpuPod, len(source.pods))
+	copy(pods, source.pods)
+	sort.SliceStable(pods, func(i, j int) bool {
+		return pods[i].core > pods[j].core
+	})
+
+	placements := make(map[*gpuPod]*GPUInfo, len(pods))
+	for _, pod := range pods {
+		var bestFit *GPUInfo
+		for _, target := range targets {
+			if target == source || !target.fits(pod) {
+				continue
+			}
+			if bestFit == nil || target.freeCore() < bestFit.freeCore() {
+				bestFit = target
+			}
+		}
+		if bestFit == nil {
+			for p, target := range placements {
+				target.forget(p)
+			}
+			return nil, false
+		}
+		bestFit.assume(pod)
+		placements[pod] = bestFit
+	}
+	return placements, true
+}
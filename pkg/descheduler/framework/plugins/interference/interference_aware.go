@@ -0,0 +1,357 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package interference
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	gocache "github.com/patrickmn/go-cache"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	koordslolisters "github.com/koordinator-sh/koordinator/pkg/client/listers/slo/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config/validation"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	nodeutil "github.com/koordinator-sh/koordinator/pkg/descheduler/node"
+	podutil "github.com/koordinator-sh/koordinator/pkg/descheduler/pod"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/utils/anomaly"
+)
+
+const (
+	InterferenceAwareName = "InterferenceAware"
+)
+
+var _ framework.BalancePlugin = &InterferenceAware{}
+
+// InterferenceAware migrates Pods off the interference hotspots, the nodes on which the LS Pods suffer
+// from the resource pressure stalls or the high CPI reported by koordlet in NodeMetric.
+// Depending on the EvictionTarget, either the most affected LS Pods (victims) or
+// the BE/batch Pods consuming the most CPU (aggressors) are evicted.
+type InterferenceAware struct {
+	handle               framework.Handle
+	podFilter            framework.FilterFunc
+	nodeSelector         labels.Selector
+	nodeMetricLister     koordslolisters.NodeMetricLister
+	nodeAnomalyDetectors *gocache.Cache
+	args                 *deschedulerconfig.InterferenceAwareArgs
+}
+
+// NewInterferenceAware builds plugin from its arguments while passing a handle
+func NewInterferenceAware(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+	interferenceArgs, ok := args.(*deschedulerconfig.InterferenceAwareArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type InterferenceAwareArgs, got %T", args)
+	}
+	if err := validation.ValidateInterferenceAwareArgs(nil, interferenceArgs); err != nil {
+		return nil, err
+	}
+
+	nodeSelector := labels.Everything()
+	if interferenceArgs.NodeSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(interferenceArgs.NodeSelector)
+		if err != nil {
+			return nil, err
+		}
+		nodeSelector = selector
+	}
+
+	var excludedNamespaces sets.String
+	var includedNamespaces sets.String
+	if interferenceArgs.EvictableNamespaces != nil {
+		excludedNamespaces = sets.NewString(interferenceArgs.EvictableNamespaces.Exclude...)
+		includedNamespaces = sets.NewString(interferenceArgs.EvictableNamespaces.Include...)
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithFilter(handle.Evictor().Filter).
+		WithoutNamespaces(excludedNamespaces).
+		WithNamespaces(includedNamespaces).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	nodeMetricInformer := handle.KoordinatorSharedInformerFactory().Slo().V1alpha1().NodeMetrics()
+	nodeMetricInformer.Informer()
+
+	return &InterferenceAware{
+		handle:               handle,
+		podFilter:            podFilter,
+		nodeSelector:         nodeSelector,
+		nodeMetricLister:     nodeMetricInformer.Lister(),
+		nodeAnomalyDetectors: gocache.New(5*time.Minute, 5*time.Minute),
+		args:                 interferenceArgs,
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (pl *InterferenceAware) Name() string {
+	return InterferenceAwareName
+}
+
+// Balance extension point implementation for the plugin
+func (pl *InterferenceAware) Balance(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	if pl.args.Paused {
+		klog.Infof("InterferenceAware is paused and will do nothing.")
+		return nil
+	}
+
+	var hotspots []*nodeInterference
+	hotspotNames := sets.NewString()
+	for _, node := range nodes {
+		if !pl.nodeSelector.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+		info := pl.getNodeInterference(node)
+		if info == nil {
+			continue
+		}
+		if len(info.victims) < int(pl.args.MinVictims) {
+			pl.markNodeAsNormal(node.Name)
+			continue
+		}
+		klog.V(4).InfoS("Node is suffering from interference", "node", klog.KObj(node), "victims", len(info.victims))
+		if pl.markNodeAsAbnormal(node.Name) {
+			hotspots = append(hotspots, info)
+			hotspotNames.Insert(node.Name)
+		}
+	}
+	if len(hotspots) == 0 {
+		klog.V(4).InfoS("None of the nodes were detected as interference hotspots, nothing to do here")
+		return nil
+	}
+
+	var nodeFitTargets []*corev1.Node
+	for _, node := range nodes {
+		if !hotspotNames.Has(node.Name) {
+			nodeFitTargets = append(nodeFitTargets, node)
+		}
+	}
+
+	// the node with the most affected LS Pods is processed first
+	sort.SliceStable(hotspots, func(i, j int) bool {
+		if len(hotspots[i].victims) != len(hotspots[j].victims) {
+			return len(hotspots[i].victims) > len(hotspots[j].victims)
+		}
+		return hotspots[i].node.Name < hotspots[j].node.Name
+	})
+	for _, hotspot := range hotspots {
+		pl.evictFromHotspot(ctx, hotspot, nodeFitTargets)
+	}
+	return nil
+}
+
+type podInterference struct {
+	pod *corev1.Pod
+	// score is the max ratio of the interference signals to the thresholds,
+	// the Pod is affected if the score is not less than 1.
+	score    float64
+	cpuUsage int64
+}
+
+type nodeInterference struct {
+	node       *corev1.Node
+	victims    []*podInterference
+	aggressors []*podInterference
+}
+
+func (pl *InterferenceAware) getNodeInterference(node *corev1.Node) *nodeInterference {
+	nodeMetric, err := pl.nodeMetricLister.Get(node.Name)
+	if err != nil {
+		klog.V(4).InfoS("Node will not be processed, failed to get NodeMetric", "node", klog.KObj(node), "err", err)
+		return nil
+	}
+	if len(nodeMetric.Status.PodsMetric) == 0 {
+		return nil
+	}
+	podMetrics := make(map[types.NamespacedName]*slov1alpha1.PodMetricInfo, len(nodeMetric.Status.PodsMetric))
+	for _, podMetric := range nodeMetric.Status.PodsMetric {
+		podMetrics[types.NamespacedName{Namespace: podMetric.Namespace, Name: podMetric.Name}] = podMetric
+	}
+
+	pods, err := podutil.ListPodsOnANode(node.Name, pl.handle.GetPodsAssignedToNodeFunc(), nil)
+	if err != nil {
+		klog.ErrorS(err, "Node will not be processed, error accessing its pods", "node", klog.KObj(node))
+		return nil
+	}
+
+	info := &nodeInterference{node: node}
+	for _, pod := range pods {
+		podMetric := podMetrics[types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}]
+		if podMetric == nil {
+			continue
+		}
+		p := &podInterference{
+			pod:      pod,
+			cpuUsage: podMetric.PodUsage.ResourceList.Cpu().MilliValue(),
+		}
+		qosClass := extension.GetPodQoSClassWithDefault(pod)
+		if isLatencySensitive(qosClass) {
+			if podMetric.Interference != nil {
+				p.score = interferenceScore(podMetric.Interference, pl.args.Thresholds)
+			}
+			if p.score >= 1 {
+				info.victims = append(info.victims, p)
+				continue
+			}
+		}
+		if canBeAggressor(pod, qosClass) {
+			info.aggressors = append(info.aggressors, p)
+		}
+	}
+	return info
+}
+
+// canBeAggressor checks whether the Pod could be evicted as an aggressor.
+// Only the BE or batch Pods are, the LS Pods are the ones to be protected from the interference.
+func canBeAggressor(pod *corev1.Pod, qosClass extension.QoSClass) bool {
+	if qosClass == extension.QoSBE {
+		return true
+	}
+	priorityClass := extension.GetPodPriorityClassWithDefault(pod)
+	return priorityClass == extension.PriorityBatch || priorityClass == extension.PriorityFree
+}
+
+func isLatencySensitive(qosClass extension.QoSClass) bool {
+	return qosClass == extension.QoSLS || qosClass == extension.QoSLSR || qosClass == extension.QoSLSE
+}
+
+// interferenceScore returns the max ratio of the interference signals to the thresholds.
+func interferenceScore(metric *slov1alpha1.PodInterferenceMetric, thresholds *deschedulerconfig.InterferenceThresholds) float64 {
+	if thresholds == nil {
+		return 0
+	}
+	var score float64
+	ratio := func(value float64, threshold int64) {
+		if threshold > 0 && value/float64(threshold) > score {
+			score = value / float64(threshold)
+		}
+	}
+	quantityValue := func(q *resource.Quantity) float64 {
+		if q == nil {
+			return 0
+		}
+		return q.AsApproximateFloat64()
+	}
+	if metric.PSI != nil {
+		ratio(quantityValue(metric.PSI.CPU.Some), thresholds.CPUPressure)
+		ratio(quantityValue(metric.PSI.Memory.Some), thresholds.MemoryPressure)
+		ratio(quantityValue(metric.PSI.IO.Some), thresholds.IOPressure)
+	}
+	if metric.CPI != nil {
+		ratio(float64(metric.CPI.MilliValue()), thresholds.MilliCPI)
+	}
+	return score
+}
+
+func (pl *InterferenceAware) evictFromHotspot(ctx context.Context, hotspot *nodeInterference, nodeFitTargets []*corev1.Node) {
+	var candidates []*podInterference
+	var reason string
+	if pl.args.EvictionTarget == deschedulerconfig.InterferenceEvictionTargetVictim {
+		candidates = hotspot.victims
+		// the most affected Pod is evicted first
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return candidates[i].score > candidates[j].score
+		})
+		reason = fmt.Sprintf("node %s is an interference hotspot, migrate the affected Pod", hotspot.node.Name)
+	} else {
+		candidates = hotspot.aggressors
+		// the Pod consuming the most CPU is evicted first
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return candidates[i].cpuUsage > candidates[j].cpuUsage
+		})
+		reason = fmt.Sprintf("node %s is an interference hotspot, migrate the aggressor Pod", hotspot.node.Name)
+	}
+
+	var evicted int32
+	for _, p := range candidates {
+		if evicted >= pl.args.MaxPodsToEvictPerNode {
+			break
+		}
+		if !pl.podFilter(p.pod) {
+			klog.V(4).InfoS("Pod was filtered by filters", "pod", klog.KObj(p.pod), "node", klog.KObj(hotspot.node))
+			continue
+		}
+		if pl.args.NodeFit && !nodeutil.PodFitsAnyNode(pl.handle.GetPodsAssignedToNodeFunc(), p.pod, nodeFitTargets) {
+			klog.V(4).InfoS("Pod does not fit any node", "pod", klog.KObj(p.pod), "node", klog.KObj(hotspot.node))
+			continue
+		}
+		if pl.args.DryRun {
+			klog.InfoS("Evict pod in dry run mode", "pod", klog.KObj(p.pod), "node", klog.KObj(hotspot.node), "score", p.score, "cpuUsage", p.cpuUsage)
+			evicted++
+			continue
+		}
+		if !pl.handle.Evictor().Evict(ctx, p.pod, framework.EvictOptions{Reason: reason}) {
+			klog.InfoS("Failed to Evict Pod", "pod", klog.KObj(p.pod), "node", klog.KObj(hotspot.node))
+			continue
+		}
+		klog.InfoS("Evicted Pod", "pod", klog.KObj(p.pod), "node", klog.KObj(hotspot.node), "score", p.score, "cpuUsage", p.cpuUsage)
+		evicted++
+	}
+	if evicted > 0 {
+		pl.resetNode(hotspot.node.Name)
+	}
+}
+
+// markNodeAsAbnormal marks the node as abnormal and returns true if the node was abnormal consecutively,
+// so that the spikes of the interference signals are ignored.
+func (pl *InterferenceAware) markNodeAsAbnormal(nodeName string) bool {
+	obj, ok := pl.nodeAnomalyDetectors.Get(nodeName)
+	if !ok {
+		var opts anomaly.Options
+		if anomalyCondition := pl.args.AnomalyCondition; anomalyCondition != nil {
+			opts = anomaly.Options{
+				Timeout: anomalyCondition.Timeout.Duration,
+				NormalConditionFn: func(counter anomaly.Counter) bool {
+					return counter.ConsecutiveNormalities > anomalyCondition.ConsecutiveNormalities
+				},
+				AnomalyConditionFn: func(counter anomaly.Counter) bool {
+					return counter.ConsecutiveAbnormalities > anomalyCondition.ConsecutiveAbnormalities
+				},
+			}
+		}
+		obj = anomaly.NewBasicDetector(nodeName, opts)
+	}
+	anomalyDetector := obj.(anomaly.Detector)
+	state, _ := anomalyDetector.Mark(false)
+	pl.nodeAnomalyDetectors.Set(nodeName, anomalyDetector, gocache.DefaultExpiration)
+	return state == anomaly.StateAnomaly
+}
+
+func (pl *InterferenceAware) markNodeAsNormal(nodeName string) {
+	if obj, ok := pl.nodeAnomalyDetectors.Get(nodeName); ok {
+		obj.(anomaly.Detector).Mark(true)
+	}
+}
+
+func (pl *InterferenceAware) resetNode(nodeName string) {
+	if obj, ok := pl.nodeAnomalyDetectors.Get(nodeName); ok {
+		obj.(anomaly.Detector).Reset()
+	}
+}
@@ -0,0 +1,386 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package interference
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	coretesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/events"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	koordinatorclientset "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned"
+	koordfake "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
+	koordinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/evictions"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/kubernetes/defaultevictor"
+	frameworkruntime "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/runtime"
+	frameworktesting "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/testing"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/test"
+	"github.com/koordinator-sh/koordinator/pkg/util"
+)
+
+func setupFakeDiscoveryWithPolicyResource(fake *coretesting.Fake) {
+	fake.AddReactor("get", "group", func(action coretesting.Action) (handled bool, ret runtime.Object, err error) {
+		fake.Resources = []*metav1.APIResourceList{
+			{
+				GroupVersion: policy.SchemeGroupVersion.String(),
+				APIResources: []metav1.APIResource{
+					{
+						Name: util.EvictionSubResourceName,
+						Kind: util.EvictionKind,
+					},
+				},
+			},
+		}
+		return true, nil, nil
+	})
+	fake.AddReactor("get", "resource", func(action coretesting.Action) (handled bool, ret runtime.Object, err error) {
+		fake.Resources = []*metav1.APIResourceList{
+			{
+				GroupVersion: "v1",
+				APIResources: []metav1.APIResource{
+					{
+						Name: util.EvictionSubResourceName,
+						Kind: util.EvictionKind,
+					},
+				},
+			},
+		}
+		return true, nil, nil
+	})
+}
+
+// setupNodeMetrics reports the requests of the Pods as their usages along with the interference signals.
+func setupNodeMetrics(t *testing.T, koordClientSet koordinatorclientset.Interface, nodes []*corev1.Node, pods []*corev1.Pod, interferences map[string]*slov1alpha1.PodInterferenceMetric) {
+	nodeMetrics := map[string]*slov1alpha1.NodeMetric{}
+	for _, node := range nodes {
+		nodeMetrics[node.Name] = &slov1alpha1.NodeMetric{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: node.Name,
+			},
+			Status: slov1alpha1.NodeMetricStatus{
+				NodeMetric: &slov1alpha1.NodeMetricInfo{},
+			},
+		}
+	}
+	for _, pod := range pods {
+		nm := nodeMetrics[pod.Spec.NodeName]
+		if nm == nil {
+			continue
+		}
+		podMetric := &slov1alpha1.PodMetricInfo{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+			PodUsage: slov1alpha1.ResourceMap{
+				ResourceList: util.GetPodRequest(pod),
+			},
+		}
+		podMetric.Interference = interferences[pod.Name]
+		nm.Status.PodsMetric = append(nm.Status.PodsMetric, podMetric)
+	}
+	for _, nm := range nodeMetrics {
+		_, err := koordClientSet.SloV1alpha1().NodeMetrics().Create(context.TODO(), nm, metav1.CreateOptions{})
+		assert.NoError(t, err)
+	}
+}
+
+func TestInterferenceAware(t *testing.T) {
+	withQoS := func(qosClass extension.QoSClass) func(*corev1.Pod) {
+		return func(pod *corev1.Pod) {
+			pod.Labels = map[string]string{extension.LabelPodQoS: string(qosClass)}
+			test.SetRSOwnerRef(pod)
+		}
+	}
+	withBatchPriority := func(pod *corev1.Pod) {
+		pod.Labels[extension.LabelPodPriorityClass] = string(extension.PriorityBatch)
+	}
+	defaultNodes := func() []*corev1.Node {
+		return []*corev1.Node{
+			test.BuildTestNode("n1", 4000, 3000, 10, nil),
+			test.BuildTestNode("n2", 4000, 3000, 10, nil),
+		}
+	}
+	defaultPods := func() []*corev1.Pod {
+		return []*corev1.Pod{
+			test.BuildTestPod("n1-ls-0", 500, 100, "n1", withQoS(extension.QoSLS)),
+			test.BuildTestPod("n1-ls-1", 500, 100, "n1", withQoS(extension.QoSLS)),
+			test.BuildTestPod("n1-be-0", 1000, 100, "n1", withQoS(extension.QoSBE)),
+			test.BuildTestPod("n1-be-1", 800, 100, "n1", withQoS(extension.QoSBE)),
+			test.BuildTestPod("n1-batch-0", 200, 100, "n1", func(pod *corev1.Pod) {
+				withQoS(extension.QoSLS)(pod)
+				withBatchPriority(pod)
+			}),
+			test.BuildTestPod("n2-ls-0", 500, 100, "n2", withQoS(extension.QoSLS)),
+		}
+	}
+	stalled := func(cpu, memory int64) *slov1alpha1.PodInterferenceMetric {
+		return &slov1alpha1.PodInterferenceMetric{
+			PSI: &slov1alpha1.PodPSIMetric{
+				CPU:    slov1alpha1.PSIStats{Some: resource.NewQuantity(cpu, resource.DecimalSI)},
+				Memory: slov1alpha1.PSIStats{Some: resource.NewQuantity(memory, resource.DecimalSI)},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name                  string
+		interferences         map[string]*slov1alpha1.PodInterferenceMetric
+		evictionTarget        deschedulerconfig.InterferenceEvictionTarget
+		minVictims            int32
+		maxPodsToEvictPerNode int32
+		anomalyCondition      *deschedulerconfig.LoadAnomalyCondition
+		dryRun                bool
+		expectedEvicted       []string
+	}{
+		{
+			name: "no interference",
+			interferences: map[string]*slov1alpha1.PodInterferenceMetric{
+				"n1-ls-0": stalled(5, 1),
+			},
+		},
+		{
+			name: "evict the aggressor consuming the most cpu",
+			interferences: map[string]*slov1alpha1.PodInterferenceMetric{
+				"n1-ls-0": stalled(30, 1),
+			},
+			expectedEvicted: []string{"n1-be-0"},
+		},
+		{
+			name: "evict the most affected victim",
+			interferences: map[string]*slov1alpha1.PodInterferenceMetric{
+				"n1-ls-0": stalled(30, 1),
+				"n1-ls-1": stalled(5, 60),
+			},
+			evictionTarget:  deschedulerconfig.InterferenceEvictionTargetVictim,
+			minVictims:      2,
+			expectedEvicted: []string{"n1-ls-1"},
+		},
+		{
+			name: "victims are less than minVictims",
+			interferences: map[string]*slov1alpha1.PodInterferenceMetric{
+				"n1-ls-0": stalled(30, 1),
+			},
+			minVictims: 2,
+		},
+		{
+			name: "evict multiple aggressors",
+			interferences: map[string]*slov1alpha1.PodInterferenceMetric{
+				"n1-ls-0": {CPI: resource.NewQuantity(3, resource.DecimalSI)},
+			},
+			maxPodsToEvictPerNode: 3,
+			expectedEvicted:       []string{"n1-be-0", "n1-be-1", "n1-batch-0"},
+		},
+		{
+			name: "LS pods are never evicted as aggressors",
+			interferences: map[string]*slov1alpha1.PodInterferenceMetric{
+				"n1-ls-0": {CPI: resource.NewQuantity(3, resource.DecimalSI)},
+			},
+			maxPodsToEvictPerNode: 5,
+			expectedEvicted:       []string{"n1-be-0", "n1-be-1", "n1-batch-0"},
+		},
+		{
+			name: "ignore the spikes",
+			interferences: map[string]*slov1alpha1.PodInterferenceMetric{
+				"n1-ls-0": stalled(30, 1),
+			},
+			anomalyCondition: &deschedulerconfig.LoadAnomalyCondition{
+				ConsecutiveAbnormalities: 2,
+			},
+		},
+		{
+			name: "dry run",
+			interferences: map[string]*slov1alpha1.PodInterferenceMetric{
+				"n1-ls-0": stalled(30, 1),
+			},
+			dryRun: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			nodes := defaultNodes()
+			pods := defaultPods()
+			var objs []runtime.Object
+			for _, node := range nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+			setupFakeDiscoveryWithPolicyResource(&fakeClient.Fake)
+			evicted := sets.NewString()
+			fakeClient.PrependReactor("create", "pods", func(action coretesting.Action) (handled bool, ret runtime.Object, err error) {
+				if action.GetSubresource() == "eviction" {
+					evicted.Insert(action.(coretesting.CreateAction).GetObject().(metav1.Object).GetName())
+				}
+				return false, nil, nil
+			})
+
+			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+			_ = sharedInformerFactory.Core().V1().Nodes().Informer()
+			podInformer := sharedInformerFactory.Core().V1().Pods()
+
+			getPodsAssignedToNode, err := test.BuildGetPodsAssignedToNodeFunc(podInformer)
+			assert.NoError(t, err)
+
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			koordClientSet := koordfake.NewSimpleClientset()
+			koordSharedInformerFactory := koordinformers.NewSharedInformerFactory(koordClientSet, 0)
+			setupNodeMetrics(t, koordClientSet, nodes, pods, tt.interferences)
+
+			evictionTarget := tt.evictionTarget
+			if evictionTarget == "" {
+				evictionTarget = deschedulerconfig.InterferenceEvictionTargetAggressor
+			}
+			minVictims := tt.minVictims
+			if minVictims == 0 {
+				minVictims = 1
+			}
+			maxPodsToEvictPerNode := tt.maxPodsToEvictPerNode
+			if maxPodsToEvictPerNode == 0 {
+				maxPodsToEvictPerNode = 1
+			}
+			anomalyCondition := tt.anomalyCondition
+			if anomalyCondition == nil {
+				anomalyCondition = &deschedulerconfig.LoadAnomalyCondition{
+					ConsecutiveAbnormalities: 1,
+				}
+			}
+
+			fh, err := frameworktesting.NewFramework(
+				[]frameworktesting.RegisterPluginFunc{
+					func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+						reg.Register(defaultevictor.PluginName, defaultevictor.New)
+						profile.Plugins.Evict.Enabled = append(profile.Plugins.Evict.Enabled, deschedulerconfig.Plugin{Name: defaultevictor.PluginName})
+						profile.Plugins.Filter.Enabled = append(profile.Plugins.Filter.Enabled, deschedulerconfig.Plugin{Name: defaultevictor.PluginName})
+						profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+							Name: defaultevictor.PluginName,
+							Args: &defaultevictor.DefaultEvictorArgs{},
+						})
+					},
+					func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+						reg.Register(InterferenceAwareName, func(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+							return NewInterferenceAware(args, handle)
+						})
+						profile.Plugins.Balance.Enabled = append(profile.Plugins.Balance.Enabled, deschedulerconfig.Plugin{Name: InterferenceAwareName})
+						profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+							Name: InterferenceAwareName,
+							Args: &deschedulerconfig.InterferenceAwareArgs{
+								DryRun:  tt.dryRun,
+								NodeFit: true,
+								Thresholds: &deschedulerconfig.InterferenceThresholds{
+									CPUPressure:    20,
+									MemoryPressure: 20,
+									MilliCPI:       2000,
+								},
+								MinVictims:            minVictims,
+								EvictionTarget:        evictionTarget,
+								MaxPodsToEvictPerNode: maxPodsToEvictPerNode,
+								AnomalyCondition:      anomalyCondition,
+							},
+						})
+					},
+				},
+				"test",
+				frameworkruntime.WithClientSet(fakeClient),
+				frameworkruntime.WithEvictionLimiter(evictions.NewEvictionLimiter(nil, nil)),
+				frameworkruntime.WithEventRecorder(&events.FakeRecorder{}),
+				frameworkruntime.WithSharedInformerFactory(sharedInformerFactory),
+				frameworkruntime.WithKoordinatorSharedInformerFactory(koordSharedInformerFactory),
+				frameworkruntime.WithGetPodsAssignedToNodeFunc(getPodsAssignedToNode),
+			)
+			assert.NoError(t, err)
+
+			koordSharedInformerFactory.Start(ctx.Done())
+			koordSharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			// the node is detected as a hotspot only after it is abnormal more than ConsecutiveAbnormalities times,
+			// so two rounds are needed for the default ConsecutiveAbnormalities 1.
+			for i := 0; i < 2; i++ {
+				fh.RunBalancePlugins(ctx, nodes)
+			}
+
+			assert.Equal(t, sets.NewString(tt.expectedEvicted...), evicted)
+		})
+	}
+}
+
+func TestInterferenceScore(t *testing.T) {
+	thresholds := &deschedulerconfig.InterferenceThresholds{
+		CPUPressure: 20,
+		IOPressure:  10,
+		MilliCPI:    2000,
+	}
+	tests := []struct {
+		name   string
+		metric *slov1alpha1.PodInterferenceMetric
+		want   float64
+	}{
+		{
+			name:   "no signals",
+			metric: &slov1alpha1.PodInterferenceMetric{},
+			want:   0,
+		},
+		{
+			name: "cpu pressure",
+			metric: &slov1alpha1.PodInterferenceMetric{
+				PSI: &slov1alpha1.PodPSIMetric{CPU: slov1alpha1.PSIStats{Some: resource.NewQuantity(30, resource.DecimalSI)}},
+			},
+			want: 1.5,
+		},
+		{
+			name: "memory pressure is not checked without threshold",
+			metric: &slov1alpha1.PodInterferenceMetric{
+				PSI: &slov1alpha1.PodPSIMetric{Memory: slov1alpha1.PSIStats{Some: resource.NewQuantity(90, resource.DecimalSI)}},
+			},
+			want: 0,
+		},
+		{
+			name: "the max ratio of the signals",
+			metric: &slov1alpha1.PodInterferenceMetric{
+				PSI: &slov1alpha1.PodPSIMetric{IO: slov1alpha1.PSIStats{Some: resource.NewQuantity(5, resource.DecimalSI)}},
+				CPI: resource.NewQuantity(4, resource.DecimalSI),
+			},
+			want: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, interferenceScore(tt.metric, thresholds))
+		})
+	}
+}
@@ -0,0 +1,368 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadaware
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	gocache "github.com/patrickmn/go-cache"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	sev1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	koordslolisters "github.com/koordinator-sh/koordinator/pkg/client/listers/slo/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config/validation"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	nodeutil "github.com/koordinator-sh/koordinator/pkg/descheduler/node"
+	podutil "github.com/koordinator-sh/koordinator/pkg/descheduler/pod"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/utils"
+	"github.com/koordinator-sh/koordinator/pkg/util"
+)
+
+const (
+	HighNodeLoadName = "HighNodeLoad"
+)
+
+var _ framework.BalancePlugin = &HighNodeLoad{}
+
+// HighNodeLoad drains the underutilized nodes by cordoning them and migrating all their pods to the other nodes,
+// so that the drained nodes can be scaled in. It is the inverse of LowNodeLoad, and
+// the plugin refers to the actual usage of the node as well.
+type HighNodeLoad struct {
+	handle               framework.Handle
+	podFilter            framework.FilterFunc
+	nodeMetricLister     koordslolisters.NodeMetricLister
+	args                 *deschedulerconfig.HighNodeLoadArgs
+	nodeAnomalyDetectors *gocache.Cache
+}
+
+// NewHighNodeLoad builds plugin from its arguments while passing a handle
+func NewHighNodeLoad(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+	highNodeLoadArgs, ok := args.(*deschedulerconfig.HighNodeLoadArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type HighNodeLoadArgs, got %T", args)
+	}
+	if err := validation.ValidateHighNodeLoadArgs(nil, highNodeLoadArgs); err != nil {
+		return nil, err
+	}
+
+	podSelectorFn, err := filterPods(highNodeLoadArgs.PodSelectors)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod selector filter: %v", err)
+	}
+
+	var excludedNamespaces sets.String
+	var includedNamespaces sets.String
+	if highNodeLoadArgs.EvictableNamespaces != nil {
+		excludedNamespaces = sets.NewString(highNodeLoadArgs.EvictableNamespaces.Exclude...)
+		includedNamespaces = sets.NewString(highNodeLoadArgs.EvictableNamespaces.Include...)
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, podSelectorFn)).
+		WithoutNamespaces(excludedNamespaces).
+		WithNamespaces(includedNamespaces).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	nodeMetricInformer := handle.KoordinatorSharedInformerFactory().Slo().V1alpha1().NodeMetrics()
+	nodeMetricInformer.Informer()
+
+	return &HighNodeLoad{
+		handle:               handle,
+		podFilter:            podFilter,
+		nodeMetricLister:     nodeMetricInformer.Lister(),
+		args:                 highNodeLoadArgs,
+		nodeAnomalyDetectors: gocache.New(5*time.Minute, 5*time.Minute),
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (pl *HighNodeLoad) Name() string {
+	return HighNodeLoadName
+}
+
+// Balance extension point implementation for the plugin
+func (pl *HighNodeLoad) Balance(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	if pl.args.Paused {
+		klog.Infof("HighNodeLoad is paused and will do nothing.")
+		return nil
+	}
+
+	processedNodes := sets.NewString()
+	for _, nodePool := range pl.args.NodePools {
+		klog.V(4).InfoS("try to process nodePool", "nodePool", nodePool.Name)
+		status := pl.processOneNodePool(ctx, &nodePool, nodes, processedNodes)
+		if status != nil && status.Err != nil {
+			klog.ErrorS(status.Err, "Failed to processOneNodePool", "nodePool", nodePool.Name)
+		} else {
+			klog.V(4).InfoS("Successfully processed nodePool", "nodePool", nodePool.Name)
+		}
+	}
+	return nil
+}
+
+func (pl *HighNodeLoad) processOneNodePool(ctx context.Context, nodePool *deschedulerconfig.LowNodeLoadNodePool, nodes []*corev1.Node, processedNodes sets.String) *framework.Status {
+	nodes, err := filterNodes(nodePool.NodeSelector, nodes, processedNodes)
+	if err != nil {
+		return &framework.Status{Err: err}
+	}
+
+	if len(nodes) == 0 {
+		klog.InfoS("No nodes to process HighNodeLoad", "nodePool", nodePool.Name)
+		return nil
+	}
+
+	lowThresholds, highThresholds := newThresholds(nodePool.UseDeviationThresholds, nodePool.LowThresholds, nodePool.HighThresholds)
+	resourceNames := getResourceNames(lowThresholds)
+	nodeUsages := getNodeUsage(nodes, resourceNames, false, nodePool.Aggregated, pl.nodeMetricLister, pl.handle.GetPodsAssignedToNodeFunc())
+	nodeThresholds := getNodeThresholds(nodeUsages, lowThresholds, highThresholds, resourceNames, nodePool.UseDeviationThresholds)
+	lowNodes, highNodes := classifyNodes(nodeUsages, nodeThresholds, lowThresholdFilter, highThresholdFilter)
+
+	logUtilizationCriteria(nodePool.Name, "Criteria for nodes under low thresholds and above high thresholds", lowThresholds, highThresholds, len(lowNodes), len(highNodes), len(nodes))
+
+	if len(lowNodes) == 0 {
+		klog.V(4).InfoS("No nodes are underutilized, nothing to do here, you might tune your thresholds further", "nodePool", nodePool.Name)
+		return nil
+	}
+
+	lowNodeNames := sets.NewString()
+	for _, v := range lowNodes {
+		lowNodeNames.Insert(v.node.Name)
+	}
+	highNodeNames := sets.NewString()
+	for _, v := range highNodes {
+		highNodeNames.Insert(v.node.Name)
+	}
+	var normalNodes, destinationNodes []NodeInfo
+	for _, nodeUsage := range nodeUsages {
+		if lowNodeNames.Has(nodeUsage.node.Name) {
+			continue
+		}
+		nodeInfo := NodeInfo{NodeUsage: nodeUsage, thresholds: nodeThresholds[nodeUsage.node.Name]}
+		normalNodes = append(normalNodes, nodeInfo)
+		if !highNodeNames.Has(nodeUsage.node.Name) && !nodeutil.IsNodeUnschedulable(nodeUsage.node) {
+			destinationNodes = append(destinationNodes, nodeInfo)
+		}
+	}
+	resetNodesAsNormal(normalNodes, pl.nodeAnomalyDetectors)
+
+	if len(lowNodes) <= int(pl.args.NumberOfNodes) {
+		klog.V(4).InfoS("Number of nodes underutilized is less or equal than NumberOfNodes, nothing to do here",
+			"underutilizedNodes", len(lowNodes), "numberOfNodes", pl.args.NumberOfNodes, "nodePool", nodePool.Name)
+		return nil
+	}
+
+	if len(destinationNodes) == 0 {
+		klog.V(4).InfoS("No nodes can receive the pods of the underutilized nodes, nothing to do here", "nodePool", nodePool.Name)
+		return nil
+	}
+
+	underutilizedNodes := filterRealAbnormalNodes(lowNodes, pl.nodeAnomalyDetectors, nodePool.AnomalyCondition)
+	if len(underutilizedNodes) == 0 {
+		klog.V(4).InfoS("None of the nodes were detected as continuously underutilized, nothing to do here", "nodePool", nodePool.Name)
+		return nil
+	}
+
+	// drain the least utilized nodes first, and pack the pods into the most utilized destination nodes
+	sortNodesByUsage(underutilizedNodes, nodePool.ResourceWeights, true)
+	sortNodesByUsage(destinationNodes, nodePool.ResourceWeights, false)
+
+	var targetNodes []*corev1.Node
+	availableUsages := map[string]map[corev1.ResourceName]*resource.Quantity{}
+	for _, v := range destinationNodes {
+		targetNodes = append(targetNodes, v.node)
+		available := map[corev1.ResourceName]*resource.Quantity{}
+		for _, resourceName := range resourceNames {
+			quantity := v.thresholds.highResourceThreshold[resourceName].DeepCopy()
+			quantity.Sub(*v.usage[resourceName])
+			available[resourceName] = &quantity
+		}
+		availableUsages[v.node.Name] = available
+	}
+
+	evictionReason := underUtilizedEvictionReason(lowThresholds)
+	var drainedNodes []NodeInfo
+	for _, srcNode := range underutilizedNodes {
+		if len(drainedNodes) >= int(pl.args.MaxNodesToDrain) {
+			break
+		}
+		pods, ok := pl.getDrainablePods(nodePool.Name, srcNode, targetNodes)
+		if !ok {
+			continue
+		}
+		destinations, ok := assumePodsOnDestinationNodes(pods, srcNode, destinationNodes, availableUsages, resourceNames)
+		if !ok {
+			klog.V(4).InfoS("The pods of the underutilized node can not be placed into the other nodes", "node", klog.KObj(srcNode.node), "nodePool", nodePool.Name)
+			continue
+		}
+		processedNodes.Insert(srcNode.node.Name)
+		if !pl.drainNode(ctx, nodePool.Name, srcNode, pods, destinations, evictionReason(srcNode)) {
+			continue
+		}
+		drainedNodes = append(drainedNodes, srcNode)
+	}
+	tryMarkNodesAsNormal(drainedNodes, pl.nodeAnomalyDetectors)
+	return nil
+}
+
+// getDrainablePods returns the pods to be migrated from the node,
+// and false if there is any pod which prevents the node from being drained.
+func (pl *HighNodeLoad) getDrainablePods(nodePoolName string, srcNode NodeInfo, targetNodes []*corev1.Node) ([]*corev1.Pod, bool) {
+	var pods []*corev1.Pod
+	for _, pod := range srcNode.allPods {
+		// the pods bound to the node leave together with the node
+		if utils.IsDaemonsetPod(pod.OwnerReferences) || utils.IsMirrorPod(pod) || utils.IsStaticPod(pod) {
+			continue
+		}
+		if !pl.podFilter(pod) {
+			klog.V(4).InfoS("Node can not be drained because the pod was filtered by filters", "pod", klog.KObj(pod), "node", klog.KObj(srcNode.node), "nodePool", nodePoolName)
+			return nil, false
+		}
+		if pl.args.NodeFit && !nodeutil.PodFitsAnyNode(pl.handle.GetPodsAssignedToNodeFunc(), pod, targetNodes) {
+			klog.V(4).InfoS("Node can not be drained because the pod does not fit any node", "pod", klog.KObj(pod), "node", klog.KObj(srcNode.node), "nodePool", nodePoolName)
+			return nil, false
+		}
+		pods = append(pods, pod)
+	}
+	return pods, true
+}
+
+// assumePodsOnDestinationNodes places the pods into the first destination node that has enough available usage,
+// and returns the destination node name of each pod.
+// The available usages are deducted only if all the pods can be placed.
+func assumePodsOnDestinationNodes(pods []*corev1.Pod, srcNode NodeInfo, destinationNodes []NodeInfo, availableUsages map[string]map[corev1.ResourceName]*resource.Quantity, resourceNames []corev1.ResourceName) ([]string, bool) {
+	assumed := map[string]map[corev1.ResourceName]*resource.Quantity{}
+	for nodeName, available := range availableUsages {
+		copied := map[corev1.ResourceName]*resource.Quantity{}
+		for resourceName, quantity := range available {
+			q := quantity.DeepCopy()
+			copied[resourceName] = &q
+		}
+		assumed[nodeName] = copied
+	}
+
+	destinations := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		podUsage := util.GetPodRequest(pod, resourceNames...)
+		if podMetric := srcNode.podMetrics[types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}]; podMetric != nil {
+			podUsage = podMetric.ResourceList
+		}
+		placed := false
+		for _, destinationNode := range destinationNodes {
+			available := assumed[destinationNode.node.Name]
+			fits := true
+			for _, resourceName := range resourceNames {
+				if quantity := podUsage[resourceName]; available[resourceName].Cmp(quantity) < 0 {
+					fits = false
+					break
+				}
+			}
+			if !fits {
+				continue
+			}
+			for _, resourceName := range resourceNames {
+				available[resourceName].Sub(podUsage[resourceName])
+			}
+			destinations = append(destinations, destinationNode.node.Name)
+			placed = true
+			break
+		}
+		if !placed {
+			return nil, false
+		}
+	}
+
+	for nodeName, available := range assumed {
+		availableUsages[nodeName] = available
+	}
+	return destinations, true
+}
+
+// drainNode cordons the node and migrates the pods to their planned destination nodes. It stops at the first
+// failed eviction and uncordons the node, so that the node is not left unschedulable without being drained.
+func (pl *HighNodeLoad) drainNode(ctx context.Context, nodePoolName string, srcNode NodeInfo, pods []*corev1.Pod, destinations []string, reason string) bool {
+	if pl.args.DryRun {
+		for i, pod := range pods {
+			klog.InfoS("Evict pod in dry run mode", "pod", klog.KObj(pod), "node", klog.KObj(srcNode.node), "destination", destinations[i], "nodePool", nodePoolName)
+		}
+		return true
+	}
+
+	// the node is cordoned so that the evicted pods and the other pods are not scheduled back to the node
+	cordoned := false
+	if !nodeutil.IsNodeUnschedulable(srcNode.node) {
+		if err := pl.setNodeUnschedulable(ctx, srcNode.node, true); err != nil {
+			klog.ErrorS(err, "Failed to cordon the node before draining", "node", klog.KObj(srcNode.node), "nodePool", nodePoolName)
+			return false
+		}
+		cordoned = true
+	}
+
+	for i, pod := range pods {
+		// the reservation ensures the pod is placed into the planned destination node before the pod is evicted
+		podCtx := migration.WithContext(ctx, &migration.JobContext{
+			Mode:             sev1alpha1.PodMigrationJobModeReservationFirst,
+			DestinationNodes: []string{destinations[i]},
+		})
+		if !pl.handle.Evictor().Evict(podCtx, pod, framework.EvictOptions{Reason: reason}) {
+			klog.InfoS("Failed to Evict Pod, stop draining the node", "pod", klog.KObj(pod), "node", klog.KObj(srcNode.node), "nodePool", nodePoolName)
+			if cordoned {
+				if err := pl.setNodeUnschedulable(ctx, srcNode.node, false); err != nil {
+					klog.ErrorS(err, "Failed to uncordon the node after the drain stopped", "node", klog.KObj(srcNode.node), "nodePool", nodePoolName)
+				}
+			}
+			return false
+		}
+		klog.InfoS("Evicted Pod", "pod", klog.KObj(pod), "node", klog.KObj(srcNode.node), "destination", destinations[i], "nodePool", nodePoolName)
+	}
+	return true
+}
+
+func (pl *HighNodeLoad) setNodeUnschedulable(ctx context.Context, node *corev1.Node, unschedulable bool) error {
+	patch := []byte(fmt.Sprintf(`{"spec":{"unschedulable":%t}}`, unschedulable))
+	_, err := pl.handle.ClientSet().CoreV1().Nodes().Patch(ctx, node.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+func underUtilizedEvictionReason(lowThresholds deschedulerconfig.ResourceThresholds) evictionReasonGeneratorFn {
+	resourceNames := getResourceNames(lowThresholds)
+	sort.Slice(resourceNames, func(i, j int) bool {
+		return resourceNames[i] < resourceNames[j]
+	})
+	return func(nodeInfo NodeInfo) string {
+		usagePercentages := resourceUsagePercentages(nodeInfo.NodeUsage)
+		var infos []string
+		for _, resourceName := range resourceNames {
+			infos = append(infos, fmt.Sprintf("%s usage(%.2f%%)<threshold(%.2f%%)", resourceName, usagePercentages[resourceName], lowThresholds[resourceName]))
+		}
+		return fmt.Sprintf("node is underutilized, %s", strings.Join(infos, ", "))
+	}
+}
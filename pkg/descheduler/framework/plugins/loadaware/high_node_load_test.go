@@ -0,0 +1,299 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadaware
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/pointer"
+
+	koordfake "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
+	koordinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/evictions"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/kubernetes/defaultevictor"
+	frameworkruntime "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/runtime"
+	frameworktesting "github.com/koordinator-sh/koordinator/pkg/descheduler/framework/testing"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/test"
+)
+
+func TestHighNodeLoad(t *testing.T) {
+	buildPods := func(nodeName string, num int, cpu int64, apply func(*corev1.Pod)) []*corev1.Pod {
+		var pods []*corev1.Pod
+		for i := 0; i < num; i++ {
+			pods = append(pods, test.BuildTestPod(fmt.Sprintf("%s-pod-%d", nodeName, i), cpu, 100, nodeName, apply))
+		}
+		return pods
+	}
+	defaultNodes := func() []*corev1.Node {
+		return []*corev1.Node{
+			test.BuildTestNode("n1", 4000, 3000, 10, nil),
+			test.BuildTestNode("n2", 4000, 3000, 10, nil),
+			test.BuildTestNode("n3", 4000, 3000, 10, nil),
+		}
+	}
+	defaultPods := func() []*corev1.Pod {
+		var pods []*corev1.Pod
+		pods = append(pods, buildPods("n1", 2, 400, test.SetRSOwnerRef)...)
+		pods = append(pods, buildPods("n2", 4, 500, test.SetRSOwnerRef)...)
+		pods = append(pods, buildPods("n3", 3, 500, test.SetRSOwnerRef)...)
+		return pods
+	}
+
+	testCases := []struct {
+		name                  string
+		nodes                 []*corev1.Node
+		pods                  []*corev1.Pod
+		highThresholds        ResourceThresholds
+		dryRun                bool
+		maxNodesToDrain       int32
+		maxPodsToEvictPerNode *uint
+		expectedPodsEvicted   uint
+		expectedCordonedNodes []string
+	}{
+		{
+			name:                  "drain the underutilized node",
+			nodes:                 defaultNodes(),
+			pods:                  defaultPods(),
+			expectedPodsEvicted:   2,
+			expectedCordonedNodes: []string{"n1"},
+		},
+		{
+			name:                  "stop draining and uncordon the node when failing to evict",
+			nodes:                 defaultNodes(),
+			pods:                  defaultPods(),
+			maxPodsToEvictPerNode: pointer.Uint(1),
+			expectedPodsEvicted:   1,
+		},
+		{
+			name:  "pods can not be placed into the destination nodes",
+			nodes: defaultNodes(),
+			pods:  defaultPods(),
+			highThresholds: ResourceThresholds{
+				corev1.ResourceCPU:    55,
+				corev1.ResourceMemory: 80,
+			},
+			expectedPodsEvicted: 0,
+		},
+		{
+			name:  "non-evictable pod prevents the node from being drained",
+			nodes: defaultNodes(),
+			pods: append(defaultPods(), test.BuildTestPod("n1-local-storage-pod", 100, 100, "n1", func(pod *corev1.Pod) {
+				pod.Spec.Volumes = []corev1.Volume{
+					{
+						Name: "sample",
+						VolumeSource: corev1.VolumeSource{
+							EmptyDir: &corev1.EmptyDirVolumeSource{
+								SizeLimit: resource.NewQuantity(int64(10), resource.BinarySI),
+							},
+						},
+					},
+				}
+				test.SetRSOwnerRef(pod)
+			})),
+			expectedPodsEvicted: 0,
+		},
+		{
+			name:                  "daemonset pods are ignored",
+			nodes:                 defaultNodes(),
+			pods:                  append(defaultPods(), test.BuildTestPod("n1-ds-pod", 100, 100, "n1", test.SetDSOwnerRef)),
+			expectedPodsEvicted:   2,
+			expectedCordonedNodes: []string{"n1"},
+		},
+		{
+			name:                "dry run",
+			nodes:               defaultNodes(),
+			pods:                defaultPods(),
+			dryRun:              true,
+			expectedPodsEvicted: 0,
+		},
+		{
+			name:  "drain the least utilized node first",
+			nodes: append(defaultNodes(), test.BuildTestNode("n4", 4000, 3000, 10, nil)),
+			pods: func() []*corev1.Pod {
+				pods := defaultPods()
+				pods = append(pods, buildPods("n4", 1, 200, test.SetRSOwnerRef)...)
+				return pods
+			}(),
+			maxNodesToDrain:       1,
+			expectedPodsEvicted:   1,
+			expectedCordonedNodes: []string{"n4"},
+		},
+		{
+			name:  "drain multiple nodes",
+			nodes: append(defaultNodes(), test.BuildTestNode("n4", 4000, 3000, 10, nil)),
+			pods: func() []*corev1.Pod {
+				pods := defaultPods()
+				pods = append(pods, buildPods("n4", 1, 200, test.SetRSOwnerRef)...)
+				return pods
+			}(),
+			maxNodesToDrain:       2,
+			expectedPodsEvicted:   3,
+			expectedCordonedNodes: []string{"n1", "n4"},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objs []runtime.Object
+			for _, node := range tt.nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range tt.pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+			setupFakeDiscoveryWithPolicyResource(&fakeClient.Fake)
+
+			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+			_ = sharedInformerFactory.Core().V1().Nodes().Informer()
+			podInformer := sharedInformerFactory.Core().V1().Pods()
+
+			getPodsAssignedToNode, err := test.BuildGetPodsAssignedToNodeFunc(podInformer)
+			assert.NoError(t, err)
+
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			eventRecorder := &events.FakeRecorder{}
+			evictionLimiter := evictions.NewEvictionLimiter(tt.maxPodsToEvictPerNode, nil)
+
+			koordClientSet := koordfake.NewSimpleClientset()
+			koordSharedInformerFactory := koordinformers.NewSharedInformerFactory(koordClientSet, 0)
+			setupNodeMetrics(t, koordClientSet, tt.nodes, tt.pods, nil)
+
+			highThresholds := tt.highThresholds
+			if highThresholds == nil {
+				highThresholds = ResourceThresholds{
+					corev1.ResourceCPU:    80,
+					corev1.ResourceMemory: 80,
+				}
+			}
+			maxNodesToDrain := tt.maxNodesToDrain
+			if maxNodesToDrain == 0 {
+				maxNodesToDrain = 1
+			}
+
+			fh, err := frameworktesting.NewFramework(
+				[]frameworktesting.RegisterPluginFunc{
+					func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+						reg.Register(defaultevictor.PluginName, defaultevictor.New)
+						profile.Plugins.Evict.Enabled = append(profile.Plugins.Evict.Enabled, deschedulerconfig.Plugin{Name: defaultevictor.PluginName})
+						profile.Plugins.Filter.Enabled = append(profile.Plugins.Filter.Enabled, deschedulerconfig.Plugin{Name: defaultevictor.PluginName})
+						profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+							Name: defaultevictor.PluginName,
+							Args: &defaultevictor.DefaultEvictorArgs{},
+						})
+					},
+					func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
+						reg.Register(HighNodeLoadName, func(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+							return NewHighNodeLoad(args, handle)
+						})
+						profile.Plugins.Balance.Enabled = append(profile.Plugins.Balance.Enabled, deschedulerconfig.Plugin{Name: HighNodeLoadName})
+						profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
+							Name: HighNodeLoadName,
+							Args: &deschedulerconfig.HighNodeLoadArgs{
+								DryRun:          tt.dryRun,
+								NodeFit:         true,
+								MaxNodesToDrain: maxNodesToDrain,
+								NodePools: []deschedulerconfig.LowNodeLoadNodePool{
+									{
+										LowThresholds: ResourceThresholds{
+											corev1.ResourceCPU:    30,
+											corev1.ResourceMemory: 30,
+										},
+										HighThresholds: highThresholds,
+										ResourceWeights: map[corev1.ResourceName]int64{
+											corev1.ResourceCPU:    1,
+											corev1.ResourceMemory: 1,
+										},
+										AnomalyCondition: &deschedulerconfig.LoadAnomalyCondition{
+											ConsecutiveAbnormalities: 1,
+										},
+									},
+								},
+							},
+						})
+					},
+				},
+				"test",
+				frameworkruntime.WithClientSet(fakeClient),
+				frameworkruntime.WithEvictionLimiter(evictionLimiter),
+				frameworkruntime.WithEventRecorder(eventRecorder),
+				frameworkruntime.WithSharedInformerFactory(sharedInformerFactory),
+				frameworkruntime.WithKoordinatorSharedInformerFactory(koordSharedInformerFactory),
+				frameworkruntime.WithGetPodsAssignedToNodeFunc(getPodsAssignedToNode),
+			)
+			assert.NoError(t, err)
+
+			koordSharedInformerFactory.Start(ctx.Done())
+			koordSharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			fh.RunBalancePlugins(ctx, tt.nodes)
+
+			assert.Equal(t, tt.expectedPodsEvicted, evictionLimiter.TotalEvicted())
+			nodeList, err := fakeClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+			assert.NoError(t, err)
+			var cordonedNodes []string
+			for _, node := range nodeList.Items {
+				if node.Spec.Unschedulable {
+					cordonedNodes = append(cordonedNodes, node.Name)
+				}
+			}
+			assert.Equal(t, tt.expectedCordonedNodes, cordonedNodes)
+		})
+	}
+}
+
+func TestUnderUtilizedEvictionReason(t *testing.T) {
+	nodeUsage := &NodeUsage{
+		node: &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-node",
+			},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("96"),
+					corev1.ResourceMemory: resource.MustParse("512Gi"),
+				},
+			},
+		},
+		usage: map[corev1.ResourceName]*resource.Quantity{
+			corev1.ResourceCPU:    resource.NewMilliQuantity(12*1000, resource.DecimalSI),
+			corev1.ResourceMemory: resource.NewQuantity(32*1024*1024*1024, resource.BinarySI),
+		},
+	}
+	got := underUtilizedEvictionReason(deschedulerconfig.ResourceThresholds{
+		corev1.ResourceCPU:    20,
+		corev1.ResourceMemory: 20,
+	})(NodeInfo{NodeUsage: nodeUsage})
+	assert.Equal(t, "node is underutilized, cpu usage(12.50%)<threshold(20.00%), memory usage(6.25%)<threshold(20.00%)", got)
+}
@@ -32,15 +32,18 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 
-	koordclientset "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned"
-	koordinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
+	"github.com/koordinator-sh/koordinator/apis/extension"
 	koordslolisters "github.com/koordinator-sh/koordinator/pkg/client/listers/slo/v1alpha1"
 	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config/validation"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/migration/controllerfinder"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/controllers/options"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
 	nodeutil "github.com/koordinator-sh/koordinator/pkg/descheduler/node"
 	podutil "github.com/koordinator-sh/koordinator/pkg/descheduler/pod"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/utils/anomaly"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/utils/report"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/utils/sorter"
 )
 
 const (
@@ -53,10 +56,12 @@ var _ framework.BalancePlugin = &LowNodeLoad{}
 // Note that the plugin refers to the actual usage of the node.
 type LowNodeLoad struct {
 	handle               framework.Handle
-	podFilter            framework.FilterFunc
+	podRejectReason      podRejectReasonFn
 	nodeMetricLister     koordslolisters.NodeMetricLister
 	args                 *deschedulerconfig.LowNodeLoadArgs
 	nodeAnomalyDetectors *gocache.Cache
+	costModel            *sorter.EvictionCostModel
+	reports              *report.History
 }
 
 // NewLowNodeLoad builds plugin from its arguments while passing a handle
@@ -81,40 +86,45 @@ func NewLowNodeLoad(args runtime.Object, handle framework.Handle) (framework.Plu
 		includedNamespaces = sets.NewString(loadLoadUtilizationArgs.EvictableNamespaces.Include...)
 	}
 
-	podFilter, err := podutil.NewOptions().
-		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, podSelectorFn)).
+	namespaceFilter, err := podutil.NewOptions().
 		WithoutNamespaces(excludedNamespaces).
 		WithNamespaces(includedNamespaces).
 		BuildFilterFunc()
 	if err != nil {
 		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
 	}
+	podRejectReason := newPodRejectReasonFn(
+		podRejectFilter{filter: namespaceFilter, reason: rejectedReasonNotEvictableNamespace},
+		podRejectFilter{filter: handle.Evictor().Filter, reason: rejectedReasonEvictorFiltered},
+		podRejectFilter{filter: podSelectorFn, reason: rejectedReasonNotSelected},
+	)
 
-	koordClientSet, ok := handle.(koordclientset.Interface)
-	if !ok {
-		kubeConfig := *handle.KubeConfig()
-		kubeConfig.ContentType = runtime.ContentTypeJSON
-		kubeConfig.AcceptContentTypes = runtime.ContentTypeJSON
-		var err error
-		koordClientSet, err = koordclientset.NewForConfig(&kubeConfig)
-		if err != nil {
-			return nil, err
-		}
-	}
-	koordSharedInformerFactory := koordinformers.NewSharedInformerFactory(koordClientSet, 0)
-	nodeMetricInformer := koordSharedInformerFactory.Slo().V1alpha1().NodeMetrics()
+	nodeMetricInformer := handle.KoordinatorSharedInformerFactory().Slo().V1alpha1().NodeMetrics()
 	nodeMetricInformer.Informer()
-	koordSharedInformerFactory.Start(context.TODO().Done())
-	koordSharedInformerFactory.WaitForCacheSync(context.TODO().Done())
 
 	nodeAnomalyDetectors := gocache.New(5*time.Minute, 5*time.Minute)
 
+	var costModel *sorter.EvictionCostModel
+	if loadLoadUtilizationArgs.EvictionCostModel != nil {
+		var workloadUnavailable sorter.WorkloadUnavailableFn
+		if options.Manager != nil {
+			finder, err := controllerfinder.New(options.Manager, nil)
+			if err != nil {
+				return nil, err
+			}
+			workloadUnavailable = controllerfinder.WorkloadUnavailable(finder)
+		}
+		costModel = sorter.NewEvictionCostModel(loadLoadUtilizationArgs.EvictionCostModel, workloadUnavailable)
+	}
+
 	return &LowNodeLoad{
 		handle:               handle,
 		nodeMetricLister:     nodeMetricInformer.Lister(),
 		args:                 loadLoadUtilizationArgs,
-		podFilter:            podFilter,
+		podRejectReason:      podRejectReason,
 		nodeAnomalyDetectors: nodeAnomalyDetectors,
+		costModel:            costModel,
+		reports:              report.DefaultHistory,
 	}, nil
 }
 
@@ -152,25 +162,49 @@ func (pl *LowNodeLoad) Balance(ctx context.Context, nodes []*corev1.Node) *frame
 }
 
 func (pl *LowNodeLoad) processOneNodePool(ctx context.Context, nodePool *deschedulerconfig.LowNodeLoadNodePool, nodes []*corev1.Node, processedNodes sets.String) *framework.Status {
+	rep := report.NewReport(LowNodeLoadName, nodePool.Name, pl.args.DryRun)
+	defer pl.reports.Add(rep)
+
 	nodes, err := filterNodes(nodePool.NodeSelector, nodes, processedNodes)
 	if err != nil {
+		rep.SetMessage(fmt.Sprintf("Failed to filter nodes: %v", err))
 		return &framework.Status{Err: err}
 	}
 
 	if len(nodes) == 0 {
+		rep.SetMessage("No nodes to process")
 		klog.InfoS("No nodes to process LowNodeLoad", "nodePool", nodePool.Name)
 		return nil
 	}
 
-	lowThresholds, highThresholds := newThresholds(nodePool.UseDeviationThresholds, nodePool.LowThresholds, nodePool.HighThresholds)
+	podRejectReason := pl.podRejectReason
+	lowThresholds, highThresholds := nodePool.LowThresholds, nodePool.HighThresholds
+	prodUsage := len(nodePool.ProdLowThresholds) > 0 || len(nodePool.ProdHighThresholds) > 0
+	if prodUsage {
+		// only evicting the Prod Pods could reduce the usage of Prod Pods
+		podRejectReason = func(pod *corev1.Pod) string {
+			if reason := pl.podRejectReason(pod); reason != "" {
+				return reason
+			}
+			if extension.GetPodPriorityClassWithDefault(pod) != extension.PriorityProd {
+				return rejectedReasonNonProdPod
+			}
+			return ""
+		}
+		lowThresholds, highThresholds = nodePool.ProdLowThresholds, nodePool.ProdHighThresholds
+	}
+	lowThresholds, highThresholds = newThresholds(nodePool.UseDeviationThresholds, lowThresholds, highThresholds)
 	resourceNames := getResourceNames(lowThresholds)
-	nodeUsages := getNodeUsage(nodes, resourceNames, pl.nodeMetricLister, pl.handle.GetPodsAssignedToNodeFunc())
+	nodeUsages := getNodeUsage(nodes, resourceNames, prodUsage, nodePool.Aggregated, pl.nodeMetricLister, pl.handle.GetPodsAssignedToNodeFunc())
 	nodeThresholds := getNodeThresholds(nodeUsages, lowThresholds, highThresholds, resourceNames, nodePool.UseDeviationThresholds)
 	lowNodes, sourceNodes := classifyNodes(nodeUsages, nodeThresholds, lowThresholdFilter, highThresholdFilter)
+	addNodesToReport(rep, lowNodes, report.NodeClassificationLow)
+	addNodesToReport(rep, sourceNodes, report.NodeClassificationHigh)
 
 	logUtilizationCriteria(nodePool.Name, "Criteria for nodes under low thresholds and above high thresholds", lowThresholds, highThresholds, len(lowNodes), len(sourceNodes), len(nodes))
 
 	if len(lowNodes) == 0 {
+		rep.SetMessage("No nodes are underutilized")
 		klog.V(4).InfoS("No nodes are underutilized, nothing to do here, you might tune your thresholds further", "nodePool", nodePool.Name)
 		return nil
 	}
@@ -178,40 +212,45 @@ func (pl *LowNodeLoad) processOneNodePool(ctx context.Context, nodePool *desched
 	resetNodesAsNormal(lowNodes, pl.nodeAnomalyDetectors)
 
 	if len(lowNodes) <= int(pl.args.NumberOfNodes) {
+		rep.SetMessage(fmt.Sprintf("Number of nodes underutilized %d is less or equal than NumberOfNodes %d", len(lowNodes), pl.args.NumberOfNodes))
 		klog.V(4).InfoS("Number of nodes underutilized is less or equal than NumberOfNodes, nothing to do here",
 			"underutilizedNodes", len(lowNodes), "numberOfNodes", pl.args.NumberOfNodes, "nodePool", nodePool.Name)
 		return nil
 	}
 
 	if len(lowNodes) == len(nodes) {
+		rep.SetMessage("All nodes are underutilized")
 		klog.V(4).InfoS("All nodes are underutilized, nothing to do here", "nodePool", nodePool.Name)
 		return nil
 	}
 
 	if len(sourceNodes) == 0 {
+		rep.SetMessage("All nodes are under target utilization")
 		klog.V(4).InfoS("All nodes are under target utilization, nothing to do here", "nodePool", nodePool.Name)
 		return nil
 	}
 
 	abnormalNodes := filterRealAbnormalNodes(sourceNodes, pl.nodeAnomalyDetectors, nodePool.AnomalyCondition)
+	addNodesToReport(rep, abnormalNodes, report.NodeClassificationAbnormal)
 	if len(abnormalNodes) == 0 {
+		rep.SetMessage("None of the nodes were detected as anomalous")
 		klog.V(4).InfoS("None of the nodes were detected as anomalous, nothing to do here", "nodePool", nodePool.Name)
 		return nil
 	}
 
-	continueEvictionCond := func(nodeInfo NodeInfo, totalAvailableUsages map[corev1.ResourceName]*resource.Quantity) bool {
+	continueEvictionCond := func(nodeInfo NodeInfo, totalAvailableUsages map[corev1.ResourceName]*resource.Quantity) string {
 		if _, overutilized := isNodeOverutilized(nodeInfo.NodeUsage.usage, nodeInfo.thresholds.highResourceThreshold); !overutilized {
 			resetNodesAsNormal([]NodeInfo{nodeInfo}, pl.nodeAnomalyDetectors)
-			return false
+			return rejectedReasonNodeNotOverutilized
 		}
 		for _, resourceName := range resourceNames {
 			if quantity, ok := totalAvailableUsages[resourceName]; ok {
 				if quantity.CmpInt64(0) < 1 {
-					return false
+					return rejectedReasonNoCapacityLeft
 				}
 			}
 		}
-		return true
+		return ""
 	}
 
 	sortNodesByUsage(abnormalNodes, nodePool.ResourceWeights, false)
@@ -224,13 +263,16 @@ func (pl *LowNodeLoad) processOneNodePool(ctx context.Context, nodePool *desched
 		pl.args.DryRun,
 		pl.args.NodeFit,
 		nodePool.ResourceWeights,
+		pl.costModel,
+		rep,
 		pl.handle.Evictor(),
-		pl.podFilter,
+		podRejectReason,
 		pl.handle.GetPodsAssignedToNodeFunc(),
 		resourceNames,
 		continueEvictionCond,
 		overUtilizedEvictionReason(highThresholds),
 	)
+	pl.recordNodeEvents(rep, abnormalNodes)
 	tryMarkNodesAsNormal(sourceNodes, pl.nodeAnomalyDetectors)
 	for _, v := range sourceNodes {
 		processedNodes.Insert(v.node.Name)
@@ -238,6 +280,29 @@ func (pl *LowNodeLoad) processOneNodePool(ctx context.Context, nodePool *desched
 	return nil
 }
 
+// recordNodeEvents summarizes the descheduling on the abnormal nodes as events.
+func (pl *LowNodeLoad) recordNodeEvents(rep *report.Report, abnormalNodes []NodeInfo) {
+	eventRecorder := pl.handle.EventRecorder()
+	if eventRecorder == nil {
+		return
+	}
+	action := "Descheduling"
+	if rep.DryRun {
+		action = "DeschedulingDryRun"
+	}
+	for _, v := range abnormalNodes {
+		victims, rejected := rep.NodeSummary(v.node.Name)
+		eventRecorder.Eventf(v.node, nil, corev1.EventTypeNormal, "Overutilized", action,
+			"Node is overutilized in nodePool %q, %d pods selected to evict and %d candidates rejected", rep.NodePool, victims, rejected)
+	}
+}
+
+func addNodesToReport(rep *report.Report, nodes []NodeInfo, classification report.NodeClassification) {
+	for _, v := range nodes {
+		rep.AddNode(v.node.Name, classification, resourceUsagePercentages(v.NodeUsage))
+	}
+}
+
 func resetNodesAsNormal(lowNodes []NodeInfo, nodeAnomalyDetectors *gocache.Cache) {
 	for _, v := range lowNodes {
 		if obj, ok := nodeAnomalyDetectors.Get(v.node.Name); ok {
@@ -38,6 +38,7 @@ import (
 	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
 	koordinatorclientset "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned"
 	koordfake "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
+	koordinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
 	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/evictions"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
@@ -47,14 +48,10 @@ import (
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/test"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/utils"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/utils/anomaly"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/utils/report"
 	"github.com/koordinator-sh/koordinator/pkg/util"
 )
 
-type fakeFrameworkHandle struct {
-	framework.Handle
-	koordinatorclientset.Interface
-}
-
 func setupFakeDiscoveryWithPolicyResource(fake *coretesting.Fake) {
 	fake.AddReactor("get", "group", func(action coretesting.Action) (handled bool, ret runtime.Object, err error) {
 		fake.Resources = []*metav1.APIResourceList{
@@ -995,6 +992,7 @@ func TestLowNodeLoad(t *testing.T) {
 			evictionLimiter := evictions.NewEvictionLimiter(nil, nil)
 
 			koordClientSet := koordfake.NewSimpleClientset()
+			koordSharedInformerFactory := koordinformers.NewSharedInformerFactory(koordClientSet, 0)
 			setupNodeMetrics(t, koordClientSet, tt.nodes, tt.pods, tt.podMetrics)
 
 			fh, err := frameworktesting.NewFramework(
@@ -1010,10 +1008,7 @@ func TestLowNodeLoad(t *testing.T) {
 					},
 					func(reg *frameworkruntime.Registry, profile *deschedulerconfig.DeschedulerProfile) {
 						reg.Register(LowNodeLoadName, func(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
-							return NewLowNodeLoad(args, &fakeFrameworkHandle{
-								Handle:    handle,
-								Interface: koordClientSet,
-							})
+							return NewLowNodeLoad(args, handle)
 						})
 						profile.Plugins.Balance.Enabled = append(profile.Plugins.Balance.Enabled, deschedulerconfig.Plugin{Name: LowNodeLoadName})
 						profile.PluginConfig = append(profile.PluginConfig, deschedulerconfig.PluginConfig{
@@ -1040,16 +1035,24 @@ func TestLowNodeLoad(t *testing.T) {
 				frameworkruntime.WithEvictionLimiter(evictionLimiter),
 				frameworkruntime.WithEventRecorder(eventRecorder),
 				frameworkruntime.WithSharedInformerFactory(sharedInformerFactory),
+				frameworkruntime.WithKoordinatorSharedInformerFactory(koordSharedInformerFactory),
 				frameworkruntime.WithGetPodsAssignedToNodeFunc(getPodsAssignedToNode),
 			)
 			assert.NoError(t, err)
 
+			koordSharedInformerFactory.Start(ctx.Done())
+			koordSharedInformerFactory.WaitForCacheSync(ctx.Done())
+
 			fh.RunBalancePlugins(ctx, tt.nodes)
 
 			podsEvicted := evictionLimiter.TotalEvicted()
 			if tt.expectedPodsEvicted != podsEvicted {
 				t.Errorf("Expected %v pods to be evicted but %v got evicted", tt.expectedPodsEvicted, podsEvicted)
 			}
+
+			reports := report.DefaultHistory.List(report.Filter{Plugin: LowNodeLoadName, Limit: 1})
+			assert.Len(t, reports, 1)
+			assert.Equal(t, int(tt.expectedPodsEvicted), len(reports[0].Victims))
 		})
 	}
 }
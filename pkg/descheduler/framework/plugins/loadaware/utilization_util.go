@@ -24,15 +24,19 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 
+	"github.com/koordinator-sh/koordinator/apis/extension"
 	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
 	slolisters "github.com/koordinator-sh/koordinator/pkg/client/listers/slo/v1alpha1"
 	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
 	nodeutil "github.com/koordinator-sh/koordinator/pkg/descheduler/node"
 	podutil "github.com/koordinator-sh/koordinator/pkg/descheduler/pod"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/utils/report"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/utils/sorter"
+	"github.com/koordinator-sh/koordinator/pkg/util"
 )
 
 type Percentage = deschedulerconfig.Percentage
@@ -55,7 +59,29 @@ type NodeInfo struct {
 	thresholds NodeThresholds
 }
 
-type continueEvictionCond func(nodeInfo NodeInfo, totalAvailableUsages map[corev1.ResourceName]*resource.Quantity) bool
+// continueEvictionCond returns an empty string if the eviction on the node should go on,
+// otherwise the reason why the remaining pods are rejected.
+type continueEvictionCond func(nodeInfo NodeInfo, totalAvailableUsages map[corev1.ResourceName]*resource.Quantity) string
+
+// podRejectReasonFn returns an empty string if the pod is evictable, otherwise the reason why it is rejected.
+type podRejectReasonFn func(pod *corev1.Pod) string
+
+// podRejectFilter rejects the pods not passing the filter with the reason.
+type podRejectFilter struct {
+	filter framework.FilterFunc
+	reason string
+}
+
+func newPodRejectReasonFn(filters ...podRejectFilter) podRejectReasonFn {
+	return func(pod *corev1.Pod) string {
+		for _, f := range filters {
+			if f.filter != nil && !f.filter(pod) {
+				return f.reason
+			}
+		}
+		return ""
+	}
+}
 
 type evictionReasonGeneratorFn func(nodeInfo NodeInfo) string
 
@@ -64,6 +90,17 @@ const (
 	MaxResourcePercentage = 100
 )
 
+const (
+	rejectedReasonNotEvictableNamespace = "pod is not in the evictable namespaces"
+	rejectedReasonEvictorFiltered       = "pod is filtered by the evictor"
+	rejectedReasonNotSelected           = "pod is not selected by the pod selectors"
+	rejectedReasonNonProdPod            = "pod is not a Prod pod"
+	rejectedReasonNoFitNode             = "no underutilized node fits the pod"
+	rejectedReasonEvictionFailed        = "failed to evict the pod"
+	rejectedReasonNodeNotOverutilized   = "node is no longer overutilized"
+	rejectedReasonNoCapacityLeft        = "underutilized nodes have no capacity left"
+)
+
 func normalizePercentage(percent Percentage) Percentage {
 	if percent > MaxResourcePercentage {
 		return MaxResourcePercentage
@@ -127,7 +164,14 @@ func resourceThreshold(nodeCapacity corev1.ResourceList, resourceName corev1.Res
 	return resource.NewQuantity(resourceCapacityFraction(resourceCapacityQuantity.Value()), resourceCapacityQuantity.Format)
 }
 
-func getNodeUsage(nodes []*corev1.Node, resourceNames []corev1.ResourceName, nodeMetricLister slolisters.NodeMetricLister, getPodsAssignedToNode podutil.GetPodsAssignedToNodeFunc) map[string]*NodeUsage {
+func getNodeUsage(
+	nodes []*corev1.Node,
+	resourceNames []corev1.ResourceName,
+	prodUsage bool,
+	aggregated *deschedulerconfig.LoadAggregatedUsage,
+	nodeMetricLister slolisters.NodeMetricLister,
+	getPodsAssignedToNode podutil.GetPodsAssignedToNodeFunc,
+) map[string]*NodeUsage {
 	nodeUsages := map[string]*NodeUsage{}
 	for _, v := range nodes {
 		pods, err := podutil.ListPodsOnANode(v.Name, getPodsAssignedToNode, nil)
@@ -146,16 +190,32 @@ func getNodeUsage(nodes []*corev1.Node, resourceNames []corev1.ResourceName, nod
 			continue
 		}
 
+		var usageList corev1.ResourceList
+		if aggregated != nil {
+			aggregatedUsage := util.GetTargetAggregatedUsage(nodeMetric, &aggregated.UsageAggregatedDuration, aggregated.UsageAggregationType)
+			if aggregatedUsage == nil {
+				klog.V(4).InfoS("Node will not be processed, aggregated usage is not reported", "node", klog.KObj(v),
+					"aggregationType", aggregated.UsageAggregationType, "aggregatedDuration", aggregated.UsageAggregatedDuration.Duration)
+				continue
+			}
+			usageList = aggregatedUsage.ResourceList
+		} else if prodUsage {
+			usageList = sumProdPodsUsage(nodeMetric, pods)
+		} else {
+			usageList = corev1.ResourceList{}
+			for _, resourceName := range resourceNames {
+				quantity := nodeMetric.Status.NodeMetric.SystemUsage.ResourceList[resourceName].DeepCopy()
+				for _, podMetricInfo := range nodeMetric.Status.PodsMetric {
+					quantity.Add(podMetricInfo.PodUsage.ResourceList[resourceName])
+				}
+				usageList[resourceName] = quantity
+			}
+		}
+
 		usage := map[corev1.ResourceName]*resource.Quantity{}
 		for _, resourceName := range resourceNames {
-			sysUsage := nodeMetric.Status.NodeMetric.SystemUsage.ResourceList[resourceName]
-			var podUsage resource.Quantity
-			for _, podMetricInfo := range nodeMetric.Status.PodsMetric {
-				podUsage.Add(podMetricInfo.PodUsage.ResourceList[resourceName])
-			}
 			var usageQuantity resource.Quantity
-			usageQuantity.Add(sysUsage)
-			usageQuantity.Add(podUsage)
+			usageQuantity.Add(usageList[resourceName])
 			if usageQuantity.IsZero() {
 				switch resourceName {
 				case corev1.ResourceCPU:
@@ -172,7 +232,15 @@ func getNodeUsage(nodes []*corev1.Node, resourceNames []corev1.ResourceName, nod
 
 		podMetrics := make(map[types.NamespacedName]*slov1alpha1.ResourceMap)
 		for _, podMetric := range nodeMetric.Status.PodsMetric {
-			podMetrics[types.NamespacedName{Namespace: podMetric.Namespace, Name: podMetric.Name}] = podMetric.PodUsage.DeepCopy()
+			podUsage := &podMetric.PodUsage
+			if aggregated != nil {
+				// compare the pods with the node in the same aggregation window, the instantaneous usage is used
+				// only if the aggregated usages of the pods are not reported, see PodMetricPolicy.EnableAggregatedUsages
+				if aggregatedUsage := util.GetPodTargetAggregatedUsage(nodeMetric, podMetric, &aggregated.UsageAggregatedDuration, aggregated.UsageAggregationType); aggregatedUsage != nil {
+					podUsage = aggregatedUsage
+				}
+			}
+			podMetrics[types.NamespacedName{Namespace: podMetric.Namespace, Name: podMetric.Name}] = podUsage.DeepCopy()
 		}
 
 		nodeUsages[v.Name] = &NodeUsage{
@@ -186,6 +254,28 @@ func getNodeUsage(nodes []*corev1.Node, resourceNames []corev1.ResourceName, nod
 	return nodeUsages
 }
 
+// sumProdPodsUsage sums the usage of the Prod Pods on the node, the metrics of the Pods already deleted are ignored.
+func sumProdPodsUsage(nodeMetric *slov1alpha1.NodeMetric, pods []*corev1.Pod) corev1.ResourceList {
+	prodPods := sets.NewString()
+	for _, pod := range pods {
+		if extension.GetPodPriorityClassWithDefault(pod) == extension.PriorityProd {
+			prodPods.Insert(types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}.String())
+		}
+	}
+	usage := corev1.ResourceList{}
+	for _, podMetricInfo := range nodeMetric.Status.PodsMetric {
+		if !prodPods.Has(types.NamespacedName{Namespace: podMetricInfo.Namespace, Name: podMetricInfo.Name}.String()) {
+			continue
+		}
+		for resourceName, quantity := range podMetricInfo.PodUsage.ResourceList {
+			q := usage[resourceName]
+			q.Add(quantity)
+			usage[resourceName] = q
+		}
+	}
+	return usage
+}
+
 // classifyNodes classifies the nodes into low-utilization or high-utilization nodes.
 // If a node lies between low and high thresholds, it is simply ignored.
 func classifyNodes(
@@ -232,8 +322,10 @@ func evictPodsFromSourceNodes(
 	dryRun bool,
 	nodeFit bool,
 	resourceWeights map[corev1.ResourceName]int64,
+	costModel *sorter.EvictionCostModel,
+	rep *report.Report,
 	podEvictor framework.Evictor,
-	podFilter framework.FilterFunc,
+	podRejectReason podRejectReasonFn,
 	nodeIndexer podutil.GetPodsAssignedToNodeFunc,
 	resourceNames []corev1.ResourceName,
 	continueEviction continueEvictionCond,
@@ -271,15 +363,25 @@ func evictPodsFromSourceNodes(
 	klog.V(4).InfoS("Total capacity to be moved", keysAndValues...)
 
 	for _, srcNode := range sourceNodes {
-		nonRemovablePods, removablePods := classifyPods(
-			srcNode.allPods,
-			podutil.WrapFilterFuncs(podFilter, func(pod *corev1.Pod) bool {
-				if !nodeFit {
-					return true
-				}
+		var nonRemovablePods, removablePods []*corev1.Pod
+		for _, pod := range srcNode.allPods {
+			if reason := podRejectReason(pod); reason != "" {
+				rep.AddRejected(pod, reason)
+				nonRemovablePods = append(nonRemovablePods, pod)
+			} else {
+				removablePods = append(removablePods, pod)
+			}
+		}
+		if nodeFit {
+			var nonFitPods []*corev1.Pod
+			nonFitPods, removablePods = classifyPods(removablePods, func(pod *corev1.Pod) bool {
 				return nodeutil.PodFitsAnyNode(nodeIndexer, pod, targetNodes)
-			}),
-		)
+			})
+			for _, pod := range nonFitPods {
+				rep.AddRejected(pod, rejectedReasonNoFitNode)
+			}
+			nonRemovablePods = append(nonRemovablePods, nonFitPods...)
+		}
 		klog.V(4).InfoS("Evicting pods from node",
 			"nodePool", nodePoolName, "node", klog.KObj(srcNode.node), "usage", srcNode.usage,
 			"allPods", len(srcNode.allPods), "nonRemovablePods", len(nonRemovablePods), "removablePods", len(removablePods))
@@ -289,13 +391,13 @@ func evictPodsFromSourceNodes(
 			continue
 		}
 
-		sorter.SortPodsByUsage(
-			removablePods,
-			srcNode.podMetrics,
-			map[string]corev1.ResourceList{srcNode.node.Name: srcNode.node.Status.Allocatable},
-			resourceWeights,
-		)
-		evictPods(ctx, nodePoolName, dryRun, removablePods, srcNode, totalAvailableUsages, podEvictor, podFilter, continueEviction, evictionReasonGenerator)
+		nodeAllocatableMap := map[string]corev1.ResourceList{srcNode.node.Name: srcNode.node.Status.Allocatable}
+		if costModel != nil {
+			sorter.SortPodsByEvictionCost(removablePods, costModel, srcNode.podMetrics, nodeAllocatableMap, resourceWeights)
+		} else {
+			sorter.SortPodsByUsage(removablePods, srcNode.podMetrics, nodeAllocatableMap, resourceWeights)
+		}
+		evictPods(ctx, nodePoolName, dryRun, removablePods, srcNode, totalAvailableUsages, rep, podEvictor, podRejectReason, continueEviction, evictionReasonGenerator)
 	}
 }
 
@@ -306,32 +408,40 @@ func evictPods(
 	inputPods []*corev1.Pod,
 	nodeInfo NodeInfo,
 	totalAvailableUsages map[corev1.ResourceName]*resource.Quantity,
+	rep *report.Report,
 	podEvictor framework.Evictor,
-	podFilter framework.FilterFunc,
+	podRejectReason podRejectReasonFn,
 	continueEviction continueEvictionCond,
 	evictionReasonGenerator evictionReasonGeneratorFn,
 ) {
-	for _, pod := range inputPods {
-		if !continueEviction(nodeInfo, totalAvailableUsages) {
+	for i, pod := range inputPods {
+		if reason := continueEviction(nodeInfo, totalAvailableUsages); reason != "" {
+			for _, p := range inputPods[i:] {
+				rep.AddRejected(p, reason)
+			}
 			return
 		}
 
-		if !podFilter(pod) {
-			klog.V(4).InfoS("Pod aborted eviction because it was filtered by filters", "pod", klog.KObj(pod), "node", klog.KObj(nodeInfo.node), "nodePool", nodePoolName)
+		if reason := podRejectReason(pod); reason != "" {
+			klog.V(4).InfoS("Pod aborted eviction because it was filtered by filters", "pod", klog.KObj(pod), "node", klog.KObj(nodeInfo.node), "nodePool", nodePoolName, "reason", reason)
+			rep.AddRejected(pod, reason)
 			continue
 		}
+		evictionReason := evictionReasonGenerator(nodeInfo)
 		if dryRun {
 			klog.InfoS("Evict pod in dry run mode", "pod", klog.KObj(pod), "node", klog.KObj(nodeInfo.node), "nodePool", nodePoolName)
 		} else {
 			evictionOptions := framework.EvictOptions{
-				Reason: evictionReasonGenerator(nodeInfo),
+				Reason: evictionReason,
 			}
 			if !podEvictor.Evict(ctx, pod, evictionOptions) {
 				klog.InfoS("Failed to Evict Pod", "pod", klog.KObj(pod), "node", klog.KObj(nodeInfo.node), "nodePool", nodePoolName)
+				rep.AddRejected(pod, rejectedReasonEvictionFailed)
 				continue
 			}
 			klog.InfoS("Evicted Pod", "pod", klog.KObj(pod), "node", klog.KObj(nodeInfo.node), "nodePool", nodePoolName)
 		}
+		rep.AddVictim(pod, evictionReason)
 
 		podMetric := nodeInfo.podMetrics[types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}]
 		if podMetric == nil {
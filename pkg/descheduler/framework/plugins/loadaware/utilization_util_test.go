@@ -17,13 +17,24 @@ limitations under the License.
 package loadaware
 
 import (
+	"context"
 	"math"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	slolisters "github.com/koordinator-sh/koordinator/pkg/client/listers/slo/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/utils/report"
 )
 
 var (
@@ -147,3 +158,175 @@ func TestSortNodesByUsageAscendingOrder(t *testing.T) {
 
 	assert.Equal(t, expectedNodeList, nodeList)
 }
+
+func TestEvictPodsReport(t *testing.T) {
+	newPod := func(name string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec:       corev1.PodSpec{NodeName: "node1"},
+		}
+	}
+	pods := []*corev1.Pod{newPod("filtered"), newPod("victim"), newPod("remaining")}
+	rep := report.NewReport(LowNodeLoadName, "test-pool", true)
+	evicted := 0
+	evictPods(
+		context.TODO(),
+		"test-pool",
+		true,
+		pods,
+		testNode1,
+		map[corev1.ResourceName]*resource.Quantity{},
+		rep,
+		nil,
+		newPodRejectReasonFn(podRejectFilter{
+			filter: func(pod *corev1.Pod) bool {
+				return pod.Name != "filtered"
+			},
+			reason: rejectedReasonNotSelected,
+		}),
+		func(nodeInfo NodeInfo, totalAvailableUsages map[corev1.ResourceName]*resource.Quantity) string {
+			evicted++
+			if evicted > 2 {
+				return rejectedReasonNoCapacityLeft
+			}
+			return ""
+		},
+		func(nodeInfo NodeInfo) string {
+			return "node is overutilized"
+		},
+	)
+	assert.Equal(t, []report.PodReport{
+		{Namespace: "default", Name: "victim", Node: "node1", Reason: "node is overutilized"},
+	}, rep.Victims)
+	assert.Equal(t, []report.PodReport{
+		{Namespace: "default", Name: "filtered", Node: "node1", Reason: rejectedReasonNotSelected},
+		{Namespace: "default", Name: "remaining", Node: "node1", Reason: rejectedReasonNoCapacityLeft},
+	}, rep.Rejected)
+	victims, rejected := rep.NodeSummary("node1")
+	assert.Equal(t, 1, victims)
+	assert.Equal(t, 2, rejected)
+}
+
+func TestGetNodeUsage(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+		Status:     corev1.NodeStatus{Allocatable: testNodeAllocatable},
+	}
+	newPod := func(name string, priorityClass extension.PriorityClass) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      name,
+				Labels:    map[string]string{extension.LabelPodPriorityClass: string(priorityClass)},
+			},
+			Spec: corev1.PodSpec{NodeName: "node1"},
+		}
+	}
+	pods := []*corev1.Pod{newPod("prod-pod", extension.PriorityProd), newPod("batch-pod", extension.PriorityBatch)}
+	cpuUsage := func(cpu string) slov1alpha1.ResourceMap {
+		return slov1alpha1.ResourceMap{ResourceList: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)}}
+	}
+	nodeMetric := &slov1alpha1.NodeMetric{
+		ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+		Status: slov1alpha1.NodeMetricStatus{
+			NodeMetric: &slov1alpha1.NodeMetricInfo{
+				NodeUsage:   cpuUsage("11"),
+				SystemUsage: cpuUsage("1"),
+				AggregatedNodeUsages: []slov1alpha1.AggregatedUsage{
+					{
+						Duration: metav1.Duration{Duration: 5 * time.Minute},
+						Usage:    map[extension.AggregationType]slov1alpha1.ResourceMap{extension.P95: cpuUsage("8")},
+					},
+					{
+						Duration: metav1.Duration{Duration: 10 * time.Minute},
+						Usage:    map[extension.AggregationType]slov1alpha1.ResourceMap{extension.P95: cpuUsage("6")},
+					},
+				},
+			},
+			PodsMetric: []*slov1alpha1.PodMetricInfo{
+				{
+					Namespace: "default",
+					Name:      "prod-pod",
+					PodUsage:  cpuUsage("4"),
+					AggregatedPodUsages: []slov1alpha1.AggregatedUsage{
+						{
+							Duration: metav1.Duration{Duration: 5 * time.Minute},
+							Usage:    map[extension.AggregationType]slov1alpha1.ResourceMap{extension.P95: cpuUsage("3")},
+						},
+					},
+				},
+				{Namespace: "default", Name: "batch-pod", PodUsage: cpuUsage("6")},
+				{Namespace: "default", Name: "deleted-pod", PodUsage: cpuUsage("2")},
+			},
+		},
+	}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.NoError(t, indexer.Add(nodeMetric))
+	nodeMetricLister := slolisters.NewNodeMetricLister(indexer)
+	getPodsAssignedToNode := func(nodeName string, filter framework.FilterFunc) ([]*corev1.Pod, error) {
+		var r []*corev1.Pod
+		for _, pod := range pods {
+			if pod.Spec.NodeName == nodeName && filter(pod) {
+				r = append(r, pod)
+			}
+		}
+		return r, nil
+	}
+
+	tests := []struct {
+		name       string
+		prodUsage  bool
+		aggregated *deschedulerconfig.LoadAggregatedUsage
+		wantCPU    string
+		wantPodCPU string
+		wantSkip   bool
+	}{
+		{
+			name:       "node usage",
+			wantCPU:    "13",
+			wantPodCPU: "4",
+		},
+		{
+			name:       "prod usage",
+			prodUsage:  true,
+			wantCPU:    "4",
+			wantPodCPU: "4",
+		},
+		{
+			name:       "aggregated usage with the max duration",
+			aggregated: &deschedulerconfig.LoadAggregatedUsage{UsageAggregationType: extension.P95},
+			wantCPU:    "6",
+			wantPodCPU: "4",
+		},
+		{
+			name: "aggregated usage with the specified duration",
+			aggregated: &deschedulerconfig.LoadAggregatedUsage{
+				UsageAggregationType:    extension.P95,
+				UsageAggregatedDuration: metav1.Duration{Duration: 5 * time.Minute},
+			},
+			wantCPU:    "8",
+			wantPodCPU: "3",
+		},
+		{
+			name:       "aggregated usage not reported",
+			aggregated: &deschedulerconfig.LoadAggregatedUsage{UsageAggregationType: extension.P50},
+			wantSkip:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodeUsages := getNodeUsage([]*corev1.Node{node}, []corev1.ResourceName{corev1.ResourceCPU}, tt.prodUsage, tt.aggregated, nodeMetricLister, getPodsAssignedToNode)
+			if tt.wantSkip {
+				assert.Empty(t, nodeUsages)
+				return
+			}
+			assert.Len(t, nodeUsages, 1)
+			wantCPU := resource.MustParse(tt.wantCPU)
+			assert.Equal(t, 0, wantCPU.Cmp(*nodeUsages["node1"].usage[corev1.ResourceCPU]), "got %v", nodeUsages["node1"].usage[corev1.ResourceCPU])
+			assert.Equal(t, int64(2), nodeUsages["node1"].usage[corev1.ResourcePods].Value())
+			wantPodCPU := resource.MustParse(tt.wantPodCPU)
+			podCPU := nodeUsages["node1"].podMetrics[types.NamespacedName{Namespace: "default", Name: "prod-pod"}].ResourceList[corev1.ResourceCPU]
+			assert.Equal(t, 0, wantPodCPU.Cmp(podCPU), "got %v", podCPU.String())
+		})
+	}
+}
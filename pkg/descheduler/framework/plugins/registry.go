@@ -17,6 +17,8 @@ limitations under the License.
 package plugins
 
 import (
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/gpufragmentation"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/interference"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/kubernetes"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/plugins/loadaware"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework/runtime"
@@ -24,7 +26,10 @@ import (
 
 func NewInTreeRegistry() runtime.Registry {
 	registry := runtime.Registry{
-		loadaware.LowNodeLoadName: loadaware.NewLowNodeLoad,
+		loadaware.LowNodeLoadName:             loadaware.NewLowNodeLoad,
+		loadaware.HighNodeLoadName:            loadaware.NewHighNodeLoad,
+		gpufragmentation.GPUFragmentationName: gpufragmentation.NewGPUFragmentation,
+		interference.InterferenceAwareName:    interference.NewInterferenceAware,
 	}
 	kubernetes.SetupK8sDeschedulerPlugins(registry)
 	return registry
@@ -102,11 +102,27 @@ func (e *evictorProxy) Evict(ctx context.Context, pod *corev1.Pod, opts framewor
 	if !e.AllowEvict(pod) {
 		return false
 	}
+	framework.FillEvictOptionsFromContext(ctx, &opts)
+	now := timeNow()
+	pluginWindows := e.handle.pluginWindows[opts.PluginName]
+	reservation, ok := e.handle.windows.Reserve(now)
+	if !ok {
+		klog.V(4).InfoS("Pod can not be evicted since the eviction budget of the windows is exhausted", "pod", klog.KObj(pod), "plugin", opts.PluginName)
+		return false
+	}
+	pluginReservation, ok := pluginWindows.Reserve(now)
+	if !ok {
+		reservation.Cancel()
+		klog.V(4).InfoS("Pod can not be evicted since the eviction budget of the plugin windows is exhausted", "pod", klog.KObj(pod), "plugin", opts.PluginName)
+		return false
+	}
 	if e.dryRun {
 		klog.V(1).InfoS("Evicted pod in dry run mode", "pod", klog.KObj(pod), "reason", opts.Reason, "strategy", opts.PluginName, "node", pod.Spec.NodeName)
 	} else {
 		succeeded := e.handle.evictPlugins[0].Evict(ctx, pod, opts)
 		if !succeeded {
+			reservation.Cancel()
+			pluginReservation.Cancel()
 			return false
 		}
 	}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -30,11 +31,16 @@ import (
 	clientset "k8s.io/client-go/kubernetes"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/events"
+	"k8s.io/klog/v2"
 
+	koordinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
 	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/utils/window"
 )
 
+var timeNow = time.Now
+
 type frameworkImpl struct {
 	dryRun                    bool
 	clientSet                 clientset.Interface
@@ -42,11 +48,15 @@ type frameworkImpl struct {
 	eventRecorder             events.EventRecorder
 	evictionLimiter           EvictionLimiter
 	sharedInformerFactory     informers.SharedInformerFactory
+	koordInformerFactory      koordinformers.SharedInformerFactory
 	getPodsAssignedToNodeFunc framework.GetPodsAssignedToNodeFunc
 	deschedulePlugins         []framework.DeschedulePlugin
 	balancePlugins            []framework.BalancePlugin
 	evictPlugins              []framework.EvictPlugin
 	filterPlugins             []framework.FilterPlugin
+	// windows and pluginWindows restrict when the profile and the plugins run and how many pods they evict.
+	windows       *window.Windows
+	pluginWindows map[string]*window.Windows
 }
 
 // Option for the frameworkImpl.
@@ -58,6 +68,7 @@ type frameworkOptions struct {
 	kubeConfig                *restclient.Config
 	eventRecorder             events.EventRecorder
 	sharedInformerFactory     informers.SharedInformerFactory
+	koordInformerFactory      koordinformers.SharedInformerFactory
 	getPodsAssignedToNodeFunc framework.GetPodsAssignedToNodeFunc
 	evictionLimiter           EvictionLimiter
 	captureProfile            CaptureProfile
@@ -89,6 +100,13 @@ func WithSharedInformerFactory(sharedInformerFactory informers.SharedInformerFac
 	}
 }
 
+// WithKoordinatorSharedInformerFactory sets the informer factory of koordinator resources shared by the plugins.
+func WithKoordinatorSharedInformerFactory(koordInformerFactory koordinformers.SharedInformerFactory) Option {
+	return func(o *frameworkOptions) {
+		o.koordInformerFactory = koordInformerFactory
+	}
+}
+
 func WithGetPodsAssignedToNodeFunc(fn framework.GetPodsAssignedToNodeFunc) Option {
 	return func(opts *frameworkOptions) {
 		opts.getPodsAssignedToNodeFunc = fn
@@ -131,12 +149,24 @@ func NewFramework(r Registry, profile *deschedulerconfig.DeschedulerProfile, opt
 		eventRecorder:             options.eventRecorder,
 		evictionLimiter:           options.evictionLimiter,
 		sharedInformerFactory:     options.sharedInformerFactory,
+		koordInformerFactory:      options.koordInformerFactory,
 		getPodsAssignedToNodeFunc: options.getPodsAssignedToNodeFunc,
 	}
 
-	if profile == nil || profile.Plugins == nil {
+	if profile == nil {
+		return f, nil
+	}
+	windows, err := window.NewWindows(profile.Windows)
+	if err != nil {
+		return nil, fmt.Errorf("invalid windows of profile %s: %w", profile.Name, err)
+	}
+	f.windows = windows
+	if profile.Plugins == nil {
 		return f, nil
 	}
+	if err := f.initPluginWindows(profile.Plugins); err != nil {
+		return nil, err
+	}
 
 	pluginConfig := make(map[string]runtime.Object, len(profile.PluginConfig))
 	for i := range profile.PluginConfig {
@@ -149,6 +179,7 @@ func NewFramework(r Registry, profile *deschedulerconfig.DeschedulerProfile, opt
 	outputProfile := deschedulerconfig.DeschedulerProfile{
 		Name:    profile.Name,
 		Plugins: profile.Plugins,
+		Windows: profile.Windows,
 	}
 
 	pluginsMap := make(map[string]framework.Plugin)
@@ -181,6 +212,29 @@ func NewFramework(r Registry, profile *deschedulerconfig.DeschedulerProfile, opt
 	return f, nil
 }
 
+func (f *frameworkImpl) initPluginWindows(plugins *deschedulerconfig.Plugins) error {
+	pluginWindows := map[string][]deschedulerconfig.DeschedulingWindow{}
+	for _, pluginSet := range []deschedulerconfig.PluginSet{plugins.Deschedule, plugins.Balance} {
+		for _, pl := range pluginSet.Enabled {
+			pluginWindows[pl.Name] = append(pluginWindows[pl.Name], pl.Windows...)
+		}
+	}
+	for name, windows := range pluginWindows {
+		ws, err := window.NewWindows(windows)
+		if err != nil {
+			return fmt.Errorf("invalid windows of plugin %s: %w", name, err)
+		}
+		if ws == nil {
+			continue
+		}
+		if f.pluginWindows == nil {
+			f.pluginWindows = map[string]*window.Windows{}
+		}
+		f.pluginWindows[name] = ws
+	}
+	return nil
+}
+
 func (f *frameworkImpl) initPlugins(r Registry, pluginConfig map[string]runtime.Object, extensionPoints []extensionPoint, pluginsMap map[string]framework.Plugin) ([]deschedulerconfig.PluginConfig, error) {
 	pg := sets.NewString()
 	pluginsNeeded(pg, extensionPoints)
@@ -307,9 +361,21 @@ func (f *frameworkImpl) SharedInformerFactory() informers.SharedInformerFactory
 	return f.sharedInformerFactory
 }
 
+func (f *frameworkImpl) KoordinatorSharedInformerFactory() koordinformers.SharedInformerFactory {
+	return f.koordInformerFactory
+}
+
 func (f *frameworkImpl) RunDeschedulePlugins(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	if !f.windows.Active(timeNow()) {
+		klog.V(4).InfoS("Skip the deschedule plugins since the profile is out of its windows")
+		return &framework.Status{}
+	}
 	var errs []error
 	for _, pl := range f.deschedulePlugins {
+		if !f.pluginWindows[pl.Name()].Active(timeNow()) {
+			klog.V(4).InfoS("Skip the plugin since it is out of its windows", "plugin", pl.Name())
+			continue
+		}
 		childCtx := framework.PluginNameWithContext(ctx, pl.Name())
 		status := pl.Deschedule(childCtx, nodes)
 		if status != nil && status.Err != nil {
@@ -328,8 +394,16 @@ func (f *frameworkImpl) RunDeschedulePlugins(ctx context.Context, nodes []*corev
 }
 
 func (f *frameworkImpl) RunBalancePlugins(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	if !f.windows.Active(timeNow()) {
+		klog.V(4).InfoS("Skip the balance plugins since the profile is out of its windows")
+		return &framework.Status{}
+	}
 	var errs []error
 	for _, pl := range f.balancePlugins {
+		if !f.pluginWindows[pl.Name()].Active(timeNow()) {
+			klog.V(4).InfoS("Skip the plugin since it is out of its windows", "plugin", pl.Name())
+			continue
+		}
 		childCtx := framework.PluginNameWithContext(ctx, pl.Name())
 		status := pl.Balance(childCtx, nodes)
 		if status != nil && status.Err != nil {
@@ -20,14 +20,17 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/fake"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
 
 	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
 	"github.com/koordinator-sh/koordinator/pkg/descheduler/framework"
@@ -551,3 +554,146 @@ func TestRunBalancePlugins(t *testing.T) {
 		})
 	}
 }
+
+var _ framework.EvictPlugin = &testSucceededEvictorPlugin{}
+
+type testSucceededEvictorPlugin struct{}
+
+func (pl *testSucceededEvictorPlugin) Name() string {
+	return evictorPluginName1
+}
+
+func (pl *testSucceededEvictorPlugin) Evict(ctx context.Context, pod *corev1.Pod, evictOptions framework.EvictOptions) bool {
+	return true
+}
+
+var _ framework.BalancePlugin = &testEvictBalancePlugin{}
+
+type testEvictBalancePlugin struct {
+	handle  framework.Handle
+	pods    []*corev1.Pod
+	runs    int
+	evicted int
+}
+
+func (pl *testEvictBalancePlugin) Name() string {
+	return testPlugin1
+}
+
+func (pl *testEvictBalancePlugin) Balance(ctx context.Context, nodes []*corev1.Node) *framework.Status {
+	pl.runs++
+	for _, pod := range pl.pods {
+		if pl.handle.Evictor().Evict(ctx, pod, framework.EvictOptions{}) {
+			pl.evicted++
+		}
+	}
+	return nil
+}
+
+func TestRunPluginsWithWindows(t *testing.T) {
+	// 2022-10-17 03:00 is a Monday
+	now := time.Date(2022, 10, 17, 3, 0, 0, 0, time.UTC)
+	defer func() {
+		timeNow = time.Now
+	}()
+	timeNow = func() time.Time {
+		return now
+	}
+
+	tests := []struct {
+		name           string
+		profileWindows []deschedulerconfig.DeschedulingWindow
+		pluginWindows  []deschedulerconfig.DeschedulingWindow
+		wantRuns       int
+		wantEvicted    int
+	}{
+		{
+			name:        "no windows",
+			wantRuns:    1,
+			wantEvicted: 3,
+		},
+		{
+			name: "profile in the window",
+			profileWindows: []deschedulerconfig.DeschedulingWindow{
+				{Schedule: "0 2 * * 1-5", Duration: metav1.Duration{Duration: 3 * time.Hour}, TimeZone: "UTC"},
+			},
+			wantRuns:    1,
+			wantEvicted: 3,
+		},
+		{
+			name: "profile out of the window",
+			profileWindows: []deschedulerconfig.DeschedulingWindow{
+				{Schedule: "0 2 * * 6,0", Duration: metav1.Duration{Duration: 3 * time.Hour}, TimeZone: "UTC"},
+			},
+			wantRuns:    0,
+			wantEvicted: 0,
+		},
+		{
+			name: "plugin out of the window",
+			pluginWindows: []deschedulerconfig.DeschedulingWindow{
+				{Schedule: "0 4 * * *", Duration: metav1.Duration{Duration: time.Hour}, TimeZone: "UTC"},
+			},
+			wantRuns:    0,
+			wantEvicted: 0,
+		},
+		{
+			name: "profile window budget exhausted",
+			profileWindows: []deschedulerconfig.DeschedulingWindow{
+				{Schedule: "0 2 * * 1-5", Duration: metav1.Duration{Duration: 3 * time.Hour}, TimeZone: "UTC", MaxEvictions: pointer.Uint(2)},
+			},
+			wantRuns:    1,
+			wantEvicted: 2,
+		},
+		{
+			name: "plugin window budget exhausted",
+			pluginWindows: []deschedulerconfig.DeschedulingWindow{
+				{Schedule: "0 3 * * *", Duration: metav1.Duration{Duration: time.Hour}, TimeZone: "UTC", MaxEvictions: pointer.Uint(1)},
+			},
+			wantRuns:    1,
+			wantEvicted: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pl := &testEvictBalancePlugin{
+				pods: []*corev1.Pod{
+					{ObjectMeta: metav1.ObjectMeta{Name: "pod-1"}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "pod-2"}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "pod-3"}},
+				},
+			}
+			registry := Registry{
+				evictorPluginName1: func(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+					return &testSucceededEvictorPlugin{}, nil
+				},
+				testPlugin1: func(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+					pl.handle = handle
+					return pl, nil
+				},
+			}
+			profile := &deschedulerconfig.DeschedulerProfile{
+				Name:    testProfileName,
+				Windows: tt.profileWindows,
+				Plugins: &deschedulerconfig.Plugins{
+					Evict: deschedulerconfig.PluginSet{
+						Enabled: []deschedulerconfig.Plugin{
+							{Name: evictorPluginName1},
+						},
+					},
+					Balance: deschedulerconfig.PluginSet{
+						Enabled: []deschedulerconfig.Plugin{
+							{Name: testPlugin1, Windows: tt.pluginWindows},
+						},
+					},
+				},
+			}
+			f, err := NewFramework(registry, profile)
+			assert.NoError(t, err)
+
+			status := f.RunBalancePlugins(context.TODO(), nil)
+			assert.NoError(t, status.Err)
+			assert.Equal(t, tt.wantRuns, pl.runs)
+			assert.Equal(t, tt.wantEvicted, pl.evicted)
+		})
+	}
+}
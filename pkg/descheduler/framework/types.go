@@ -27,6 +27,8 @@ import (
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/events"
 	"k8s.io/utils/pointer"
+
+	koordinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
 )
 
 type Handle interface {
@@ -45,6 +47,10 @@ type Handle interface {
 	GetPodsAssignedToNodeFunc() GetPodsAssignedToNodeFunc
 
 	SharedInformerFactory() informers.SharedInformerFactory
+
+	// KoordinatorSharedInformerFactory returns the informer factory of koordinator resources shared by
+	// all plugins. Plugins only register their informers, the descheduler starts the factory.
+	KoordinatorSharedInformerFactory() koordinformers.SharedInformerFactory
 }
 
 type PluginsRunner interface {
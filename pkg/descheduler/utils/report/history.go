@@ -0,0 +1,159 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+const (
+	// DefaultHistoryCapacity is the number of the latest reports kept by DefaultHistory.
+	DefaultHistoryCapacity = 256
+	// DefaultPath is the path of the HTTP endpoint serving the reports.
+	DefaultPath = "/debug/descheduler/reports"
+)
+
+// DefaultHistory keeps the reports of all the plugins in the descheduler.
+var DefaultHistory = NewHistory(DefaultHistoryCapacity)
+
+// History is a ring buffer keeping the latest reports.
+type History struct {
+	lock    sync.RWMutex
+	reports []*Report
+	next    int
+	full    bool
+	lastID  uint64
+}
+
+// NewHistory creates a History keeping at most capacity reports.
+func NewHistory(capacity int) *History {
+	if capacity <= 0 {
+		capacity = DefaultHistoryCapacity
+	}
+	return &History{
+		reports: make([]*Report, capacity),
+	}
+}
+
+// Add completes the report and keeps it, the oldest report is dropped if the History is full.
+func (h *History) Add(r *Report) {
+	if h == nil || r == nil {
+		return
+	}
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if r.EndTime.IsZero() {
+		r.Complete()
+	}
+	h.lastID++
+	r.ID = h.lastID
+	h.reports[h.next] = r
+	h.next = (h.next + 1) % len(h.reports)
+	if h.next == 0 {
+		h.full = true
+	}
+}
+
+// Filter selects the reports listed.
+type Filter struct {
+	Plugin   string
+	NodePool string
+	Node     string
+	// Limit is the max number of the reports listed, 0 means unlimited.
+	Limit int
+}
+
+func (f *Filter) match(r *Report) bool {
+	if f.Plugin != "" && f.Plugin != r.Plugin {
+		return false
+	}
+	if f.NodePool != "" && f.NodePool != r.NodePool {
+		return false
+	}
+	if f.Node != "" && !r.HasNode(f.Node) {
+		return false
+	}
+	return true
+}
+
+// List returns the reports matched by the filter, the latest first.
+func (h *History) List(filter Filter) []*Report {
+	if h == nil {
+		return nil
+	}
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	count := h.next
+	if h.full {
+		count = len(h.reports)
+	}
+	var reports []*Report
+	for i := 1; i <= count; i++ {
+		r := h.reports[(h.next-i+len(h.reports))%len(h.reports)]
+		if !filter.match(r) {
+			continue
+		}
+		reports = append(reports, r)
+		if filter.Limit > 0 && len(reports) >= filter.Limit {
+			break
+		}
+	}
+	return reports
+}
+
+// ServeHTTP lists the reports in JSON, the reports can be filtered by the query parameters plugin, nodePool, node and limit.
+func (h *History) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	filter := Filter{
+		Plugin:   query.Get("plugin"),
+		NodePool: query.Get("nodePool"),
+		Node:     query.Get("node"),
+	}
+	if limit := query.Get("limit"); limit != "" {
+		l, err := strconv.Atoi(limit)
+		if err != nil || l < 0 {
+			http.Error(w, fmt.Sprintf("invalid limit %q", limit), http.StatusBadRequest)
+			return
+		}
+		filter.Limit = l
+	}
+	reports := h.List(filter)
+	if reports == nil {
+		reports = []*Report{}
+	}
+	data, err := json.MarshalIndent(reports, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+type mux interface {
+	Handle(string, http.Handler)
+}
+
+// InstallHandler serves the reports of DefaultHistory on DefaultPath.
+func InstallHandler(m mux) {
+	m.Handle(DefaultPath, DefaultHistory)
+}
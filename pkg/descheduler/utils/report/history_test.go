@@ -0,0 +1,115 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestReport(t *testing.T) {
+	var nilReport *Report
+	nilReport.AddNode("node1", NodeClassificationHigh, nil)
+	nilReport.AddVictim(&corev1.Pod{}, "")
+	assert.False(t, nilReport.HasNode("node1"))
+
+	r := NewReport("LowNodeLoad", "pool", false)
+	r.AddNode("node1", NodeClassificationHigh, map[corev1.ResourceName]float64{corev1.ResourceCPU: 80})
+	r.AddNode("node1", NodeClassificationAbnormal, map[corev1.ResourceName]float64{corev1.ResourceCPU: 80})
+	r.AddNode("node2", NodeClassificationLow, nil)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod1"},
+		Spec:       corev1.PodSpec{NodeName: "node3"},
+	}
+	r.AddVictim(pod, "overutilized")
+	r.AddRejected(pod, "filtered")
+	r.AddRejected(pod, "filtered")
+
+	assert.Len(t, r.Nodes, 2)
+	assert.Equal(t, NodeClassificationAbnormal, r.Nodes[0].Classification)
+	assert.True(t, r.HasNode("node2"))
+	assert.True(t, r.HasNode("node3"))
+	assert.False(t, r.HasNode("node4"))
+	victims, rejected := r.NodeSummary("node3")
+	assert.Equal(t, 1, victims)
+	assert.Equal(t, 2, rejected)
+}
+
+func TestHistory(t *testing.T) {
+	h := NewHistory(3)
+	assert.Empty(t, h.List(Filter{}))
+
+	for _, plugin := range []string{"a", "b", "a", "b"} {
+		h.Add(NewReport(plugin, "", false))
+	}
+	reports := h.List(Filter{})
+	var ids []uint64
+	for _, r := range reports {
+		ids = append(ids, r.ID)
+		assert.False(t, r.EndTime.IsZero())
+	}
+	assert.Equal(t, []uint64{4, 3, 2}, ids)
+
+	reports = h.List(Filter{Plugin: "b"})
+	assert.Len(t, reports, 2)
+	assert.Equal(t, uint64(4), reports[0].ID)
+
+	reports = h.List(Filter{Limit: 1})
+	assert.Len(t, reports, 1)
+	assert.Equal(t, uint64(4), reports[0].ID)
+}
+
+func TestHistoryServeHTTP(t *testing.T) {
+	h := NewHistory(10)
+	r := NewReport("LowNodeLoad", "pool", true)
+	r.AddNode("node1", NodeClassificationHigh, nil)
+	h.Add(r)
+	h.Add(NewReport("LowNodeLoad", "other-pool", true))
+
+	tests := []struct {
+		name       string
+		query      string
+		wantCode   int
+		wantLength int
+	}{
+		{name: "all reports", wantCode: http.StatusOK, wantLength: 2},
+		{name: "filter by node pool", query: "?nodePool=pool", wantCode: http.StatusOK, wantLength: 1},
+		{name: "filter by node", query: "?node=node1", wantCode: http.StatusOK, wantLength: 1},
+		{name: "filter by plugin", query: "?plugin=HighNodeLoad", wantCode: http.StatusOK, wantLength: 0},
+		{name: "limit", query: "?limit=1", wantCode: http.StatusOK, wantLength: 1},
+		{name: "invalid limit", query: "?limit=-1", wantCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			h.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, DefaultPath+tt.query, nil))
+			assert.Equal(t, tt.wantCode, recorder.Code)
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			var reports []*Report
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &reports))
+			assert.Len(t, reports, tt.wantLength)
+		})
+	}
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NodeClassification is the classification of a node in a descheduling cycle.
+type NodeClassification string
+
+const (
+	NodeClassificationLow      NodeClassification = "Low"
+	NodeClassificationHigh     NodeClassification = "High"
+	NodeClassificationAbnormal NodeClassification = "Abnormal"
+)
+
+// NodeReport describes how a node is classified in a descheduling cycle.
+type NodeReport struct {
+	Name             string                          `json:"name"`
+	Classification   NodeClassification              `json:"classification"`
+	UsagePercentages map[corev1.ResourceName]float64 `json:"usagePercentages,omitempty"`
+}
+
+// PodReport describes a candidate Pod selected as victim or rejected in a descheduling cycle.
+type PodReport struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Node      string `json:"node"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// Report is the structured result of a descheduling cycle of a plugin.
+// All methods of Report are safe to be called on nil Report.
+type Report struct {
+	ID        uint64       `json:"id"`
+	Plugin    string       `json:"plugin"`
+	NodePool  string       `json:"nodePool,omitempty"`
+	DryRun    bool         `json:"dryRun,omitempty"`
+	StartTime metav1.Time  `json:"startTime"`
+	EndTime   metav1.Time  `json:"endTime,omitempty"`
+	Message   string       `json:"message,omitempty"`
+	Nodes     []NodeReport `json:"nodes,omitempty"`
+	Victims   []PodReport  `json:"victims,omitempty"`
+	Rejected  []PodReport  `json:"rejected,omitempty"`
+}
+
+// NewReport starts a Report of the plugin for the node pool.
+func NewReport(plugin, nodePool string, dryRun bool) *Report {
+	return &Report{
+		Plugin:    plugin,
+		NodePool:  nodePool,
+		DryRun:    dryRun,
+		StartTime: metav1.Now(),
+	}
+}
+
+// SetMessage records why the cycle ended, e.g. there is nothing to do.
+func (r *Report) SetMessage(message string) {
+	if r == nil {
+		return
+	}
+	r.Message = message
+}
+
+// AddNode records the classification of the node. The node reclassified is updated in place.
+func (r *Report) AddNode(node string, classification NodeClassification, usagePercentages map[corev1.ResourceName]float64) {
+	if r == nil {
+		return
+	}
+	for i := range r.Nodes {
+		if r.Nodes[i].Name == node {
+			r.Nodes[i].Classification = classification
+			r.Nodes[i].UsagePercentages = usagePercentages
+			return
+		}
+	}
+	r.Nodes = append(r.Nodes, NodeReport{
+		Name:             node,
+		Classification:   classification,
+		UsagePercentages: usagePercentages,
+	})
+}
+
+// AddVictim records the Pod selected to be evicted.
+func (r *Report) AddVictim(pod *corev1.Pod, reason string) {
+	if r == nil {
+		return
+	}
+	r.Victims = append(r.Victims, newPodReport(pod, reason))
+}
+
+// AddRejected records the candidate Pod rejected with the reason.
+func (r *Report) AddRejected(pod *corev1.Pod, reason string) {
+	if r == nil {
+		return
+	}
+	r.Rejected = append(r.Rejected, newPodReport(pod, reason))
+}
+
+// Complete marks the end of the cycle.
+func (r *Report) Complete() {
+	if r == nil {
+		return
+	}
+	r.EndTime = metav1.Now()
+}
+
+// HasNode checks whether the node is involved in the cycle.
+func (r *Report) HasNode(node string) bool {
+	if r == nil {
+		return false
+	}
+	for i := range r.Nodes {
+		if r.Nodes[i].Name == node {
+			return true
+		}
+	}
+	for _, reports := range [][]PodReport{r.Victims, r.Rejected} {
+		for i := range reports {
+			if reports[i].Node == node {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// NodeSummary counts the victims and the rejected candidates on the node.
+func (r *Report) NodeSummary(node string) (victims, rejected int) {
+	if r == nil {
+		return 0, 0
+	}
+	for i := range r.Victims {
+		if r.Victims[i].Node == node {
+			victims++
+		}
+	}
+	for i := range r.Rejected {
+		if r.Rejected[i].Node == node {
+			rejected++
+		}
+	}
+	return victims, rejected
+}
+
+func newPodReport(pod *corev1.Pod, reason string) PodReport {
+	return PodReport{
+		Namespace: pod.Namespace,
+		Name:      pod.Name,
+		Node:      pod.Spec.NodeName,
+		Reason:    reason,
+	}
+}
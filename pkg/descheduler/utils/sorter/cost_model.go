@@ -0,0 +1,230 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sorter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+const (
+	maxEvictionCostTermScore = 100
+
+	EvictionCostTermPodAge              = "podAge"
+	EvictionCostTermRestartCount        = "restartCount"
+	EvictionCostTermConnections         = "connections"
+	EvictionCostTermQoSClass            = "qosClass"
+	EvictionCostTermWorkloadUnavailable = "workloadUnavailable"
+	EvictionCostTermAnnotation          = "evictionCostAnnotation"
+)
+
+// WorkloadUnavailableFn returns the percentage of the unavailable Pods in the workload of the Pod.
+type WorkloadUnavailableFn func(pod *corev1.Pod) (int64, error)
+
+// EvictionCostTerm is a weighted term of the eviction cost.
+type EvictionCostTerm struct {
+	Name   string
+	Score  int64
+	Weight int64
+}
+
+// EstimatedEvictionCost is the estimated cost of evicting a Pod with the terms explaining it.
+type EstimatedEvictionCost struct {
+	Score int64
+	Terms []EvictionCostTerm
+}
+
+// String explains the eviction cost, e.g. "eviction cost 40 (podAge=100*1, restartCount=0*1)".
+func (c *EstimatedEvictionCost) String() string {
+	terms := make([]string, 0, len(c.Terms))
+	for _, term := range c.Terms {
+		terms = append(terms, fmt.Sprintf("%s=%d*%d", term.Name, term.Score, term.Weight))
+	}
+	return fmt.Sprintf("eviction cost %d (%s)", c.Score, strings.Join(terms, ", "))
+}
+
+// EvictionCostModel estimates the eviction cost of the Pods by the weighted terms configured.
+type EvictionCostModel struct {
+	args                *deschedulerconfig.EvictionCostModel
+	workloadUnavailable WorkloadUnavailableFn
+	now                 func() time.Time
+}
+
+// NewEvictionCostModel builds the EvictionCostModel, the term of the workload unavailable ratio is
+// always 0 if workloadUnavailable is nil.
+func NewEvictionCostModel(args *deschedulerconfig.EvictionCostModel, workloadUnavailable WorkloadUnavailableFn) *EvictionCostModel {
+	return &EvictionCostModel{
+		args:                args,
+		workloadUnavailable: workloadUnavailable,
+		now:                 time.Now,
+	}
+}
+
+// Cost estimates the eviction cost of the Pod.
+func (m *EvictionCostModel) Cost(pod *corev1.Pod) *EstimatedEvictionCost {
+	cost := &EstimatedEvictionCost{}
+	var totalScore, weightSum int64
+	addTerm := func(name string, weight int64, scoreFn func() int64) {
+		if weight <= 0 {
+			return
+		}
+		score := scoreFn()
+		cost.Terms = append(cost.Terms, EvictionCostTerm{Name: name, Score: score, Weight: weight})
+		totalScore += score * weight
+		weightSum += weight
+	}
+	addTerm(EvictionCostTermPodAge, m.args.PodAgeWeight, func() int64 {
+		return m.podAgeScore(pod)
+	})
+	addTerm(EvictionCostTermRestartCount, m.args.RestartCountWeight, func() int64 {
+		return restartCountScore(pod)
+	})
+	addTerm(EvictionCostTermConnections, m.args.ConnectionsWeight, func() int64 {
+		return connectionsScore(pod, m.args.ConnectionsReadinessGate)
+	})
+	addTerm(EvictionCostTermQoSClass, m.args.QoSClassWeight, func() int64 {
+		return qosClassScore(pod)
+	})
+	addTerm(EvictionCostTermWorkloadUnavailable, m.args.WorkloadUnavailableWeight, func() int64 {
+		return m.workloadUnavailableScore(pod)
+	})
+	addTerm(EvictionCostTermAnnotation, m.args.EvictionCostAnnotationWeight, func() int64 {
+		return annotationScore(pod)
+	})
+	if weightSum > 0 {
+		cost.Score = totalScore / weightSum
+	}
+	return cost
+}
+
+// Compare returns a CompareFn ordering the Pods by the eviction cost,
+// the costs are cached in the CompareFn so that each Pod is estimated only once.
+func (m *EvictionCostModel) Compare() CompareFn {
+	costs := map[types.NamespacedName]int64{}
+	getCost := func(pod *corev1.Pod) int64 {
+		key := types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}
+		if cost, ok := costs[key]; ok {
+			return cost
+		}
+		cost := m.Cost(pod)
+		klog.V(5).InfoS("Estimated the eviction cost of Pod", "pod", klog.KObj(pod), "cost", cost.String())
+		costs[key] = cost.Score
+		return cost.Score
+	}
+	return func(p1, p2 *corev1.Pod) int {
+		cost1, cost2 := getCost(p1), getCost(p2)
+		if cost1 == cost2 {
+			return 0
+		}
+		if cost1 > cost2 {
+			return 1
+		}
+		return -1
+	}
+}
+
+func (m *EvictionCostModel) podAgeScore(pod *corev1.Pod) int64 {
+	maxPodAge := m.args.MaxPodAge.Duration
+	if maxPodAge <= 0 || pod.CreationTimestamp.IsZero() {
+		return 0
+	}
+	age := m.now().Sub(pod.CreationTimestamp.Time)
+	if age <= 0 {
+		return 0
+	}
+	if age >= maxPodAge {
+		return maxEvictionCostTermScore
+	}
+	return int64(age) * maxEvictionCostTermScore / int64(maxPodAge)
+}
+
+func (m *EvictionCostModel) workloadUnavailableScore(pod *corev1.Pod) int64 {
+	if m.workloadUnavailable == nil {
+		return 0
+	}
+	ratio, err := m.workloadUnavailable(pod)
+	if err != nil {
+		klog.V(4).InfoS("Failed to get the unavailable ratio of the workload of Pod", "pod", klog.KObj(pod), "err", err)
+		return 0
+	}
+	return clampScore(ratio, 0)
+}
+
+// restartCountScore decreases by the restarts, the Pod restarting frequently is cheap to evict.
+func restartCountScore(pod *corev1.Pod) int64 {
+	var restarts int64
+	for _, status := range pod.Status.ContainerStatuses {
+		restarts += int64(status.RestartCount)
+	}
+	return maxEvictionCostTermScore / (1 + restarts)
+}
+
+func connectionsScore(pod *corev1.Pod, readinessGate corev1.PodConditionType) int64 {
+	if readinessGate == "" {
+		return 0
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == readinessGate && condition.Status == corev1.ConditionTrue {
+			return maxEvictionCostTermScore
+		}
+	}
+	return 0
+}
+
+func qosClassScore(pod *corev1.Pod) int64 {
+	order := koordQoSClassOrder[extension.GetPodQoSClassWithDefault(pod)]
+	if order <= 0 {
+		return 0
+	}
+	return clampScore(int64(order-1)*maxEvictionCostTermScore/4, 0)
+}
+
+func annotationScore(pod *corev1.Pod) int64 {
+	cost, _ := extension.GetEvictionCost(pod.Annotations)
+	return clampScore(int64(cost), -maxEvictionCostTermScore)
+}
+
+func clampScore(score, min int64) int64 {
+	if score < min {
+		return min
+	}
+	if score > maxEvictionCostTermScore {
+		return maxEvictionCostTermScore
+	}
+	return score
+}
+
+// SortPodsByEvictionCost sorts the Pods by the priority, the eviction cost and the resource usage,
+// the Pods to be evicted first are in the front.
+func SortPodsByEvictionCost(pods []*corev1.Pod, model *EvictionCostModel, podMetrics map[types.NamespacedName]*slov1alpha1.ResourceMap, nodeAllocatableMap map[string]corev1.ResourceList, resourceToWeightMap map[corev1.ResourceName]int64) {
+	OrderedBy(
+		KoordinatorPriorityClass,
+		Priority,
+		model.Compare(),
+		Reverse(PodUsage(podMetrics, nodeAllocatableMap, resourceToWeightMap)),
+		PodCreationTimestamp,
+	).Sort(pods)
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sorter
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	deschedulerconfig "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+func TestEvictionCostModelCost(t *testing.T) {
+	now := time.Now()
+	servingGate := corev1.PodConditionType("example.com/serving")
+	withRestarts := func(restarts int32) podDecoratorFn {
+		return func(pod *corev1.Pod) {
+			pod.Status.ContainerStatuses = []corev1.ContainerStatus{{RestartCount: restarts}}
+		}
+	}
+	withServing := func(pod *corev1.Pod) {
+		pod.Status.Conditions = []corev1.PodCondition{{Type: servingGate, Status: corev1.ConditionTrue}}
+	}
+
+	tests := []struct {
+		name                string
+		args                deschedulerconfig.EvictionCostModel
+		pod                 *corev1.Pod
+		workloadUnavailable WorkloadUnavailableFn
+		wantScore           int64
+		wantExplanation     string
+	}{
+		{
+			name:            "pod age",
+			args:            deschedulerconfig.EvictionCostModel{PodAgeWeight: 1, MaxPodAge: metav1.Duration{Duration: 4 * time.Hour}},
+			pod:             makePod("test", 0, extension.QoSLS, corev1.PodQOSBurstable, now.Add(-time.Hour)),
+			wantScore:       25,
+			wantExplanation: "eviction cost 25 (podAge=25*1)",
+		},
+		{
+			name:            "restart count",
+			args:            deschedulerconfig.EvictionCostModel{RestartCountWeight: 1},
+			pod:             makePod("test", 0, extension.QoSLS, corev1.PodQOSBurstable, now, withRestarts(3)),
+			wantScore:       25,
+			wantExplanation: "eviction cost 25 (restartCount=25*1)",
+		},
+		{
+			name:            "serving connections",
+			args:            deschedulerconfig.EvictionCostModel{ConnectionsWeight: 1, ConnectionsReadinessGate: servingGate},
+			pod:             makePod("test", 0, extension.QoSLS, corev1.PodQOSBurstable, now, withServing),
+			wantScore:       100,
+			wantExplanation: "eviction cost 100 (connections=100*1)",
+		},
+		{
+			name:            "qos class",
+			args:            deschedulerconfig.EvictionCostModel{QoSClassWeight: 1},
+			pod:             makePod("test", 0, extension.QoSLSR, corev1.PodQOSGuaranteed, now),
+			wantScore:       50,
+			wantExplanation: "eviction cost 50 (qosClass=50*1)",
+		},
+		{
+			name: "workload unavailable",
+			args: deschedulerconfig.EvictionCostModel{WorkloadUnavailableWeight: 1},
+			pod:  makePod("test", 0, extension.QoSLS, corev1.PodQOSBurstable, now),
+			workloadUnavailable: func(pod *corev1.Pod) (int64, error) {
+				return 40, nil
+			},
+			wantScore:       40,
+			wantExplanation: "eviction cost 40 (workloadUnavailable=40*1)",
+		},
+		{
+			name: "failed to get workload unavailable",
+			args: deschedulerconfig.EvictionCostModel{WorkloadUnavailableWeight: 1},
+			pod:  makePod("test", 0, extension.QoSLS, corev1.PodQOSBurstable, now),
+			workloadUnavailable: func(pod *corev1.Pod) (int64, error) {
+				return 0, fmt.Errorf("not found")
+			},
+			wantScore:       0,
+			wantExplanation: "eviction cost 0 (workloadUnavailable=0*1)",
+		},
+		{
+			name:            "annotation is clamped",
+			args:            deschedulerconfig.EvictionCostModel{EvictionCostAnnotationWeight: 1},
+			pod:             makePod("test", 0, extension.QoSLS, corev1.PodQOSBurstable, now, withCost(extension.AnnotationEvictionCost, 1000)),
+			wantScore:       100,
+			wantExplanation: "eviction cost 100 (evictionCostAnnotation=100*1)",
+		},
+		{
+			name: "weighted terms",
+			args: deschedulerconfig.EvictionCostModel{
+				RestartCountWeight:           1,
+				QoSClassWeight:               2,
+				EvictionCostAnnotationWeight: 1,
+			},
+			pod:             makePod("test", 0, extension.QoSBE, corev1.PodQOSBestEffort, now, withRestarts(1), withCost(extension.AnnotationEvictionCost, -50)),
+			wantScore:       0,
+			wantExplanation: "eviction cost 0 (restartCount=50*1, qosClass=0*2, evictionCostAnnotation=-50*1)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := NewEvictionCostModel(&tt.args, tt.workloadUnavailable)
+			model.now = func() time.Time {
+				return now
+			}
+			cost := model.Cost(tt.pod)
+			assert.Equal(t, tt.wantScore, cost.Score)
+			assert.Equal(t, tt.wantExplanation, cost.String())
+		})
+	}
+}
+
+func TestSortPodsByEvictionCost(t *testing.T) {
+	now := time.Now()
+	pods := []*corev1.Pod{
+		makePod("old-pod", 0, extension.QoSLS, corev1.PodQOSBurstable, now.Add(-48*time.Hour)),
+		makePod("new-pod", 0, extension.QoSLS, corev1.PodQOSBurstable, now.Add(-time.Hour)),
+		makePod("high-priority-pod", 100, extension.QoSLS, corev1.PodQOSBurstable, now),
+		makePod("middle-age-pod", 0, extension.QoSLS, corev1.PodQOSBurstable, now.Add(-12*time.Hour)),
+	}
+	model := NewEvictionCostModel(&deschedulerconfig.EvictionCostModel{
+		PodAgeWeight: 1,
+		MaxPodAge:    metav1.Duration{Duration: 24 * time.Hour},
+	}, nil)
+	SortPodsByEvictionCost(pods, model, nil, nil, nil)
+	var names []string
+	for _, pod := range pods {
+		names = append(names, pod.Name)
+	}
+	assert.Equal(t, []string{"new-pod", "middle-age-pod", "old-pod", "high-priority-pod"}, names)
+}
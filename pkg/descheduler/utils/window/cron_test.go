@@ -0,0 +1,105 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package window
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSchedule(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr bool
+	}{
+		{name: "every minute", spec: "* * * * *"},
+		{name: "weekdays", spec: "0 2 * * 1-5"},
+		{name: "lists and steps", spec: "*/15 1,3,5-7 1-31/2 * 0,7"},
+		{name: "too few fields", spec: "0 2 * *", wantErr: true},
+		{name: "too many fields", spec: "0 2 * * * *", wantErr: true},
+		{name: "minute out of range", spec: "60 * * * *", wantErr: true},
+		{name: "day of month out of range", spec: "* * 0 * *", wantErr: true},
+		{name: "reversed range", spec: "* 5-1 * * *", wantErr: true},
+		{name: "zero step", spec: "*/0 * * * *", wantErr: true},
+		{name: "invalid value", spec: "a * * * *", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseSchedule(tt.spec)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+		})
+	}
+}
+
+func TestScheduleMatch(t *testing.T) {
+	// 2022-10-17 is a Monday
+	monday := time.Date(2022, 10, 17, 2, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		spec string
+		t    time.Time
+		want bool
+	}{
+		{name: "weekday matched", spec: "0 2 * * 1-5", t: monday, want: true},
+		{name: "weekend not matched", spec: "0 2 * * 1-5", t: monday.AddDate(0, 0, 5), want: false},
+		{name: "minute not matched", spec: "0 2 * * 1-5", t: monday.Add(time.Minute), want: false},
+		{name: "sunday as 7", spec: "0 2 * * 7", t: monday.AddDate(0, 0, 6), want: true},
+		{name: "step matched", spec: "*/15 * * * *", t: monday.Add(45 * time.Minute), want: true},
+		{name: "step not matched", spec: "*/15 * * * *", t: monday.Add(50 * time.Minute), want: false},
+		{name: "start with step", spec: "5/20 * * * *", t: monday.Add(25 * time.Minute), want: true},
+		{name: "day of month or day of week", spec: "0 2 1 * 1", t: monday, want: true},
+		{name: "neither day of month nor day of week", spec: "0 2 1 * 2", t: monday, want: false},
+		{name: "month not matched", spec: "0 2 * 1-9 *", t: monday, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ParseSchedule(tt.spec)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, s.Match(tt.t))
+		})
+	}
+}
+
+func TestSchedulePrev(t *testing.T) {
+	// 2022-10-17 is a Monday
+	monday := time.Date(2022, 10, 17, 2, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		spec string
+		t    time.Time
+		want time.Time
+	}{
+		{name: "matched minute", spec: "0 2 * * 1-5", t: monday.Add(30 * time.Second), want: monday},
+		{name: "earlier in the same hour", spec: "*/15 * * * *", t: monday.Add(50 * time.Minute), want: monday.Add(45 * time.Minute)},
+		{name: "previous day", spec: "0 2 * * *", t: monday.Add(-time.Minute), want: monday.AddDate(0, 0, -1)},
+		{name: "skip the weekend", spec: "30 23 * * 1-5", t: monday.Add(time.Hour), want: time.Date(2022, 10, 14, 23, 30, 0, 0, time.UTC)},
+		{name: "previous month", spec: "0 0 31 * *", t: monday, want: time.Date(2022, 8, 31, 0, 0, 0, 0, time.UTC)},
+		{name: "previous year", spec: "0 0 1 12 *", t: monday, want: time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "never matched", spec: "0 0 31 2 *", t: monday, want: time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ParseSchedule(tt.spec)
+			assert.NoError(t, err)
+			got := s.Prev(tt.t)
+			assert.True(t, tt.want.Equal(got), "want %v, got %v", tt.want, got)
+		})
+	}
+}
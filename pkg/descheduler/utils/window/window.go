@@ -0,0 +1,169 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package window
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+// Window is a recurring time window opened by a cron schedule.
+type Window struct {
+	schedule     *Schedule
+	duration     time.Duration
+	location     *time.Location
+	maxEvictions *uint
+}
+
+// NewWindow builds the Window from its configuration.
+func NewWindow(w *config.DeschedulingWindow) (*Window, error) {
+	schedule, err := ParseSchedule(w.Schedule)
+	if err != nil {
+		return nil, err
+	}
+	if w.Duration.Duration <= 0 {
+		return nil, fmt.Errorf("duration must be positive, got %v", w.Duration.Duration)
+	}
+	location := time.Local
+	if w.TimeZone != "" {
+		location, err = time.LoadLocation(w.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time zone %q: %v", w.TimeZone, err)
+		}
+	}
+	return &Window{
+		schedule:     schedule,
+		duration:     w.Duration.Duration,
+		location:     location,
+		maxEvictions: w.MaxEvictions,
+	}, nil
+}
+
+// ActiveSince returns the start time of the occurrence of the window covering now.
+func (w *Window) ActiveSince(now time.Time) (time.Time, bool) {
+	start := w.schedule.Prev(now.In(w.location))
+	if start.IsZero() || now.Sub(start) >= w.duration {
+		return time.Time{}, false
+	}
+	return start, true
+}
+
+type occurrence struct {
+	start   time.Time
+	evicted uint
+}
+
+// Windows is a set of Windows with the eviction budget of each occurrence.
+// The nil or empty Windows is always active and has unlimited budget.
+type Windows struct {
+	lock        sync.Mutex
+	windows     []*Window
+	occurrences []occurrence
+}
+
+// NewWindows builds the Windows from the configurations, it returns nil if no window is configured.
+func NewWindows(windows []config.DeschedulingWindow) (*Windows, error) {
+	if len(windows) == 0 {
+		return nil, nil
+	}
+	ws := &Windows{
+		windows:     make([]*Window, 0, len(windows)),
+		occurrences: make([]occurrence, len(windows)),
+	}
+	for i := range windows {
+		w, err := NewWindow(&windows[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid window %d: %v", i, err)
+		}
+		ws.windows = append(ws.windows, w)
+	}
+	return ws, nil
+}
+
+// Active checks whether any of the windows is active at now.
+func (ws *Windows) Active(now time.Time) bool {
+	if ws == nil {
+		return true
+	}
+	for _, w := range ws.windows {
+		if _, ok := w.ActiveSince(now); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Reserve checks whether any of the active windows has eviction budget left at now,
+// and if so, reserves one eviction in the active windows which have budget left under the same lock.
+// The exhausted windows are not charged, so that they are not overdrawn by the evictions allowed by the other windows.
+// The returned Reservation should be cancelled if the eviction fails.
+func (ws *Windows) Reserve(now time.Time) (*Reservation, bool) {
+	if ws == nil {
+		return nil, true
+	}
+	ws.lock.Lock()
+	defer ws.lock.Unlock()
+	starts := map[int]time.Time{}
+	for i, w := range ws.windows {
+		start, ok := w.ActiveSince(now)
+		if !ok {
+			continue
+		}
+		if w.maxEvictions == nil || ws.evicted(i, start) < *w.maxEvictions {
+			starts[i] = start
+		}
+	}
+	if len(starts) == 0 {
+		return nil, false
+	}
+	for i, start := range starts {
+		ws.occurrences[i] = occurrence{start: start, evicted: ws.evicted(i, start) + 1}
+	}
+	return &Reservation{windows: ws, starts: starts}, true
+}
+
+// Reservation is one eviction reserved in the occurrences of the active windows.
+type Reservation struct {
+	windows *Windows
+	starts  map[int]time.Time
+}
+
+// Cancel gives the reserved eviction back to the occurrences it was reserved in.
+func (r *Reservation) Cancel() {
+	if r == nil {
+		return
+	}
+	ws := r.windows
+	ws.lock.Lock()
+	defer ws.lock.Unlock()
+	for i, start := range r.starts {
+		if evicted := ws.evicted(i, start); evicted > 0 {
+			ws.occurrences[i].evicted = evicted - 1
+		}
+	}
+}
+
+// evicted returns the number of evictions in the occurrence starting at start, the budget is renewed by every occurrence.
+func (ws *Windows) evicted(i int, start time.Time) uint {
+	if !ws.occurrences[i].start.Equal(start) {
+		return 0
+	}
+	return ws.occurrences[i].evicted
+}
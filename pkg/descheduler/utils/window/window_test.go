@@ -0,0 +1,189 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package window
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config"
+)
+
+func TestNewWindow(t *testing.T) {
+	tests := []struct {
+		name    string
+		window  config.DeschedulingWindow
+		wantErr bool
+	}{
+		{
+			name:   "valid window",
+			window: config.DeschedulingWindow{Schedule: "0 2 * * 1-5", Duration: metav1.Duration{Duration: 3 * time.Hour}, TimeZone: "UTC"},
+		},
+		{
+			name:    "invalid schedule",
+			window:  config.DeschedulingWindow{Schedule: "0 2 * *", Duration: metav1.Duration{Duration: 3 * time.Hour}},
+			wantErr: true,
+		},
+		{
+			name:    "zero duration",
+			window:  config.DeschedulingWindow{Schedule: "0 2 * * 1-5"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid time zone",
+			window:  config.DeschedulingWindow{Schedule: "0 2 * * 1-5", Duration: metav1.Duration{Duration: 3 * time.Hour}, TimeZone: "Invalid/Zone"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewWindow(&tt.window)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+		})
+	}
+}
+
+func TestWindowActiveSince(t *testing.T) {
+	w, err := NewWindow(&config.DeschedulingWindow{
+		Schedule: "0 2 * * 1-5",
+		Duration: metav1.Duration{Duration: 3 * time.Hour},
+		TimeZone: "Asia/Shanghai",
+	})
+	assert.NoError(t, err)
+	location, err := time.LoadLocation("Asia/Shanghai")
+	assert.NoError(t, err)
+
+	// 2022-10-17 is a Monday
+	start := time.Date(2022, 10, 17, 2, 0, 0, 0, location)
+	tests := []struct {
+		name      string
+		now       time.Time
+		wantStart time.Time
+		want      bool
+	}{
+		{name: "window opened", now: start, wantStart: start, want: true},
+		{name: "in the window", now: start.Add(2*time.Hour + 30*time.Second), wantStart: start, want: true},
+		{name: "in the window of other time zone", now: start.Add(time.Hour).UTC(), wantStart: start, want: true},
+		{name: "before the window", now: start.Add(-time.Second), want: false},
+		{name: "window closed", now: start.Add(3 * time.Hour), want: false},
+		{name: "weekend", now: start.AddDate(0, 0, 5).Add(time.Hour), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := w.ActiveSince(tt.now)
+			assert.Equal(t, tt.want, ok)
+			if tt.want {
+				assert.True(t, tt.wantStart.Equal(got), "want %v, got %v", tt.wantStart, got)
+			}
+		})
+	}
+}
+
+func TestWindows(t *testing.T) {
+	var nilWindows *Windows
+	now := time.Date(2022, 10, 17, 2, 0, 0, 0, time.UTC)
+	assert.True(t, nilWindows.Active(now))
+	r, ok := nilWindows.Reserve(now)
+	assert.True(t, ok)
+	r.Cancel()
+
+	ws, err := NewWindows(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, ws)
+
+	ws, err = NewWindows([]config.DeschedulingWindow{
+		{
+			Schedule:     "0 2 * * *",
+			Duration:     metav1.Duration{Duration: time.Hour},
+			TimeZone:     "UTC",
+			MaxEvictions: pointer.Uint(2),
+		},
+	})
+	assert.NoError(t, err)
+	assert.True(t, ws.Active(now))
+	assert.False(t, ws.Active(now.Add(time.Hour)))
+	_, ok = ws.Reserve(now.Add(time.Hour))
+	assert.False(t, ok)
+
+	_, ok = ws.Reserve(now)
+	assert.True(t, ok)
+	r, ok = ws.Reserve(now.Add(time.Minute))
+	assert.True(t, ok)
+	_, ok = ws.Reserve(now.Add(30 * time.Minute))
+	assert.False(t, ok)
+
+	// the cancelled reservation gives the budget back
+	r.Cancel()
+	_, ok = ws.Reserve(now.Add(30 * time.Minute))
+	assert.True(t, ok)
+	_, ok = ws.Reserve(now.Add(30 * time.Minute))
+	assert.False(t, ok)
+
+	// the budget is renewed by the next occurrence
+	tomorrow := now.AddDate(0, 0, 1)
+	_, ok = ws.Reserve(tomorrow)
+	assert.True(t, ok)
+	r, ok = ws.Reserve(tomorrow)
+	assert.True(t, ok)
+	// cancelling the reservation of a closed occurrence has no effect on the current one
+	dayAfterTomorrow := tomorrow.AddDate(0, 0, 1)
+	_, ok = ws.Reserve(dayAfterTomorrow)
+	assert.True(t, ok)
+	r.Cancel()
+	_, ok = ws.Reserve(dayAfterTomorrow)
+	assert.True(t, ok)
+	_, ok = ws.Reserve(dayAfterTomorrow)
+	assert.False(t, ok)
+
+	_, err = NewWindows([]config.DeschedulingWindow{{Schedule: "invalid"}})
+	assert.Error(t, err)
+}
+
+func TestWindowsReserveOverlapped(t *testing.T) {
+	now := time.Date(2022, 10, 17, 2, 0, 0, 0, time.UTC)
+	ws, err := NewWindows([]config.DeschedulingWindow{
+		{
+			Schedule:     "0 2 * * *",
+			Duration:     metav1.Duration{Duration: time.Hour},
+			TimeZone:     "UTC",
+			MaxEvictions: pointer.Uint(1),
+		},
+		{
+			Schedule:     "0 2 * * *",
+			Duration:     metav1.Duration{Duration: 30 * time.Minute},
+			TimeZone:     "UTC",
+			MaxEvictions: pointer.Uint(3),
+		},
+	})
+	assert.NoError(t, err)
+
+	r, ok := ws.Reserve(now)
+	assert.True(t, ok)
+	// the exhausted window is not charged by the eviction allowed by the other window
+	_, ok = ws.Reserve(now)
+	assert.True(t, ok)
+	r.Cancel()
+	// the first window gets its budget back after the only eviction charged to it is cancelled
+	_, ok = ws.Reserve(now.Add(45 * time.Minute))
+	assert.True(t, ok)
+	_, ok = ws.Reserve(now.Add(45 * time.Minute))
+	assert.False(t, ok)
+}
@@ -42,6 +42,25 @@ const (
 	// ConfigMapValidatingWebhook enables validating webhook for configmap Creation or updates
 	ConfigMapValidatingWebhook featuregate.Feature = "ConfigMapValidatingWebhook"
 
+	// ReservationMutatingWebhook enables mutating webhook for Reservation creations
+	ReservationMutatingWebhook featuregate.Feature = "ReservationMutatingWebhook"
+
+	// ReservationValidatingWebhook enables validating webhook for Reservation creations or updates
+	ReservationValidatingWebhook featuregate.Feature = "ReservationValidatingWebhook"
+
+	// PodMigrationJobMutatingWebhook enables mutating webhook for PodMigrationJob creations
+	PodMigrationJobMutatingWebhook featuregate.Feature = "PodMigrationJobMutatingWebhook"
+
+	// PodMigrationJobValidatingWebhook enables validating webhook for PodMigrationJob creations or updates
+	PodMigrationJobValidatingWebhook featuregate.Feature = "PodMigrationJobValidatingWebhook"
+
+	// DeviceValidatingWebhook enables validating webhook for Device creations or updates
+	DeviceValidatingWebhook featuregate.Feature = "DeviceValidatingWebhook"
+
+	// WorkloadMutatingWebhook enables mutating webhook for the pod templates of Deployments, StatefulSets, Jobs
+	// and CronJobs creations by ClusterColocationProfiles.
+	WorkloadMutatingWebhook featuregate.Feature = "WorkloadMutatingWebhook"
+
 	// ColocationProfileSkipMutatingResources config whether to update resourceName according to priority by default
 	ColocationProfileSkipMutatingResources featuregate.Feature = "ColocationProfileSkipMutatingResources"
 
@@ -56,6 +75,12 @@ var defaultFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	ElasticQuotaValidatingWebhook:          {Default: true, PreRelease: featuregate.Beta},
 	NodeValidatingWebhook:                  {Default: false, PreRelease: featuregate.Alpha},
 	ConfigMapValidatingWebhook:             {Default: false, PreRelease: featuregate.Alpha},
+	ReservationMutatingWebhook:             {Default: false, PreRelease: featuregate.Alpha},
+	ReservationValidatingWebhook:           {Default: false, PreRelease: featuregate.Alpha},
+	PodMigrationJobMutatingWebhook:         {Default: false, PreRelease: featuregate.Alpha},
+	PodMigrationJobValidatingWebhook:       {Default: false, PreRelease: featuregate.Alpha},
+	DeviceValidatingWebhook:                {Default: false, PreRelease: featuregate.Alpha},
+	WorkloadMutatingWebhook:                {Default: false, PreRelease: featuregate.Alpha},
 	WebhookFramework:                       {Default: true, PreRelease: featuregate.Beta},
 	ColocationProfileSkipMutatingResources: {Default: false, PreRelease: featuregate.Alpha},
 }
@@ -18,6 +18,7 @@ package features
 
 import (
 	"fmt"
+	"reflect"
 
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/component-base/featuregate"
@@ -114,6 +115,13 @@ const (
 	//
 	// BlkIOReconcile enables block I/O QoS feature of koordlet.
 	BlkIOReconcile featuregate.Feature = "BlkIOReconcile"
+
+	// owner: @zwzhang0107 @saintube
+	// alpha: v1.3
+	//
+	// BatchStorageResource enables collecting node ephemeral-storage and hugepages usage, and limiting the
+	// batch-ephemeral-storage and batch-hugepages of BE pods via disk quota and hugetlb cgroups.
+	BatchStorageResource featuregate.Feature = "BatchStorageResource"
 )
 
 func init() {
@@ -139,12 +147,13 @@ var (
 		CPICollector:           {Default: false, PreRelease: featuregate.Alpha},
 		PSICollector:           {Default: false, PreRelease: featuregate.Alpha},
 		BlkIOReconcile:         {Default: false, PreRelease: featuregate.Alpha},
+		BatchStorageResource:   {Default: false, PreRelease: featuregate.Alpha},
 	}
 )
 
 // IsFeatureDisabled returns whether the featuregate is disabled by nodeSLO config
 func IsFeatureDisabled(nodeSLO *slov1alpha1.NodeSLO, feature featuregate.Feature) (bool, error) {
-	if nodeSLO == nil || nodeSLO.Spec == (slov1alpha1.NodeSLOSpec{}) {
+	if nodeSLO == nil || reflect.DeepEqual(nodeSLO.Spec, slov1alpha1.NodeSLOSpec{}) {
 		return true, fmt.Errorf("cannot parse feature config for invalid nodeSLO %v", nodeSLO)
 	}
 
@@ -26,6 +26,9 @@ var (
 	NodeGPUMemUsageMetric  = defaultMetricFactory.New(NodeMetricGPUMemUsage).withPropertySchema(MetricPropertyGPUMinor, MetricPropertyGPUDeviceUUID)
 	NodeGPUMemTotalMetric  = defaultMetricFactory.New(NodeMetricGPUMemTotal).withPropertySchema(MetricPropertyGPUMinor, MetricPropertyGPUDeviceUUID)
 
+	NodeEphemeralStorageUsageMetric = defaultMetricFactory.New(NodeMetricEphemeralStorageUsage)
+	NodeHugePagesUsageMetric        = defaultMetricFactory.New(NodeMetricHugePagesUsage).withPropertySchema(MetricPropertyHugePageSize)
+
 	// define system resource usage as independent metric, although this can be calculate by node-sum(pod), but the time series are
 	// unaligned across different type of metric, which makes it hard to aggregate.
 	SystemCPUUsageMetric    = defaultMetricFactory.New(SysMetricCPUUsage)
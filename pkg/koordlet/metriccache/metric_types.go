@@ -42,6 +42,9 @@ const (
 	NodeMetricGPUMemUsage  MetricKind = "node_gpu_memory_usage"
 	NodeMetricGPUMemTotal  MetricKind = "node_gpu_memory_total"
 
+	NodeMetricEphemeralStorageUsage MetricKind = "node_ephemeral_storage_usage"
+	NodeMetricHugePagesUsage        MetricKind = "node_hugepages_usage"
+
 	SysMetricCPUUsage    MetricKind = "sys_cpu_usage"
 	SysMetricMemoryUsage MetricKind = "sys_memory_usage"
 
@@ -95,6 +98,8 @@ const (
 
 	MetricPropertyBEResource   MetricProperty = "be_resource"
 	MetricPropertyBEAllocation MetricProperty = "be_allocation"
+
+	MetricPropertyHugePageSize MetricProperty = "hugepage_size"
 )
 
 // MetricPropertyValue is the property value
@@ -131,6 +136,7 @@ var MetricPropertiesFunc = struct {
 	PodGPU              func(string, string, string) map[MetricProperty]string
 	ContainerGPU        func(string, string, string) map[MetricProperty]string
 	NodeBE              func(string, string) map[MetricProperty]string
+	HugePage            func(string) map[MetricProperty]string
 }{
 	Pod: func(podUID string) map[MetricProperty]string {
 		return map[MetricProperty]string{MetricPropertyPodUID: podUID}
@@ -162,6 +168,9 @@ var MetricPropertiesFunc = struct {
 	NodeBE: func(beResource, beResourceAllocation string) map[MetricProperty]string {
 		return map[MetricProperty]string{MetricPropertyBEResource: beResource, MetricPropertyBEAllocation: beResourceAllocation}
 	},
+	HugePage: func(pageSize string) map[MetricProperty]string {
+		return map[MetricProperty]string{MetricPropertyHugePageSize: pageSize}
+	},
 }
 
 // point is the struct to describe metric
@@ -17,6 +17,8 @@ limitations under the License.
 package metrics
 
 import (
+	"sync/atomic"
+
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/koordinator-sh/koordinator/pkg/util/metrics"
@@ -120,6 +122,7 @@ func RecordCollectNodeLocalStorageInfoStatus(err error) {
 }
 
 func RecordPodEviction(namespace, podName, reasonType string) {
+	atomic.AddInt64(&podsEvictedTotal, 1)
 	labels := genNodeLabels()
 	if labels == nil {
 		return
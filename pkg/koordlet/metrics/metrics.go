@@ -32,6 +32,7 @@ func init() {
 	prometheus.MustRegister(CPUSuppressCollector...)
 	prometheus.MustRegister(CPUBurstCollector...)
 	prometheus.MustRegister(PredictionCollectors...)
+	prometheus.MustRegister(NodeSLOCollectors...)
 }
 
 const (
@@ -58,6 +59,8 @@ const (
 
 	ResourceKey = "resource"
 
+	RuleKey = "rule"
+
 	UnitKey     = "unit"
 	UnitCore    = "core"
 	UnitByte    = "byte"
@@ -141,6 +141,7 @@ func TestCommonCollectors(t *testing.T) {
 		RecordContainerScaledCFSBurstUS(testingPod.Namespace, testingPod.Name, testingContainer.ContainerID, testingContainer.Name, 1000000)
 		RecordContainerScaledCFSQuotaUS(testingPod.Namespace, testingPod.Name, testingContainer.ContainerID, testingContainer.Name, 1000000)
 		RecordPodEviction(testingPod.Namespace, testingPod.Name, "evictByCPU")
+		RecordNodeSLOApplyError("testRule")
 		ResetContainerCPI()
 		RecordContainerCPI(testingContainer, testingPod, 1, 1)
 		ResetContainerPSI()
@@ -0,0 +1,56 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	NodeSLOApplyErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: KoordletSubsystem,
+		Name:      "node_slo_apply_errors",
+		Help:      "Number of errors when koordlet applies the NodeSLO",
+	}, []string{NodeKey, RuleKey})
+
+	NodeSLOCollectors = []prometheus.Collector{
+		NodeSLOApplyErrors,
+	}
+
+	// podsEvictedTotal and applyErrorsTotal are the health counters of the NodeSLO reported to the NodeSLO status,
+	// which are independent of the node labels of the prometheus metrics.
+	podsEvictedTotal int64
+	applyErrorsTotal int64
+)
+
+func RecordNodeSLOApplyError(rule string) {
+	atomic.AddInt64(&applyErrorsTotal, 1)
+	labels := genNodeLabels()
+	if labels == nil {
+		return
+	}
+	labels[RuleKey] = rule
+	NodeSLOApplyErrors.With(labels).Inc()
+}
+
+// GetNodeSLOHealthCounters returns the total number of pods evicted and the errors of applying the NodeSLO since
+// koordlet starts.
+func GetNodeSLOHealthCounters() (podsEvicted int64, applyErrors int64) {
+	return atomic.LoadInt64(&podsEvictedTotal), atomic.LoadInt64(&applyErrorsTotal)
+}
@@ -20,6 +20,8 @@ import (
 	"time"
 
 	"go.uber.org/atomic"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog/v2"
 
@@ -28,34 +30,72 @@ import (
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/metrics"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/metricsadvisor/framework"
 	koordletutil "github.com/koordinator-sh/koordinator/pkg/koordlet/util"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
 )
 
 const (
 	CollectorName = "NodeStorageInfoCollector"
 )
 
+var (
+	timeNow = time.Now
+
+	// kubeletRootDir is the root dir of the kubelet, whose filesystem is used as the node ephemeral-storage.
+	// It is always mounted to the same path inside the koordlet.
+	kubeletRootDir = "/var/lib/kubelet"
+
+	isProjectQuotaSupported = system.IsProjectQuotaSupported
+)
+
 type nodeInfoCollector struct {
 	collectInterval time.Duration
 	storage         metriccache.KVStorage
+	appendableDB    metriccache.Appendable
 	started         *atomic.Bool
+	// diskQuotaSupported indicates if the batch-ephemeral-storage can be limited via the xfs project quota on the
+	// kubelet root dir. The node ephemeral-storage usage is not reported if unsupported, so that the batch resource
+	// is reset instead of being allocated without enforcement.
+	diskQuotaSupported bool
 }
 
 func New(opt *framework.Options) framework.Collector {
 	return &nodeInfoCollector{
 		collectInterval: opt.Config.CollectNodeStorageInfoInterval,
 		storage:         opt.MetricCache,
+		appendableDB:    opt.MetricCache,
 		started:         atomic.NewBool(false),
 	}
 }
 
 func (n *nodeInfoCollector) Enabled() bool {
-	return features.DefaultKoordletFeatureGate.Enabled(features.BlkIOReconcile)
+	return features.DefaultKoordletFeatureGate.Enabled(features.BlkIOReconcile) ||
+		features.DefaultKoordletFeatureGate.Enabled(features.BatchStorageResource)
 }
 
-func (n *nodeInfoCollector) Setup(s *framework.Context) {}
+func (n *nodeInfoCollector) Setup(s *framework.Context) {
+	if !features.DefaultKoordletFeatureGate.Enabled(features.BatchStorageResource) {
+		return
+	}
+	if supported, err := isProjectQuotaSupported(kubeletRootDir); !supported {
+		klog.Warningf("xfs project quota is unsupported on %s, skip reporting the node ephemeral-storage usage for the batch resource, err: %v",
+			kubeletRootDir, err)
+		return
+	}
+	n.diskQuotaSupported = true
+}
 
 func (n *nodeInfoCollector) Run(stopCh <-chan struct{}) {
-	go wait.Until(n.collectNodeLocalStorageInfo, n.collectInterval, stopCh)
+	go wait.Until(n.collect, n.collectInterval, stopCh)
+}
+
+func (n *nodeInfoCollector) collect() {
+	if features.DefaultKoordletFeatureGate.Enabled(features.BlkIOReconcile) {
+		n.collectNodeLocalStorageInfo()
+	}
+	// the storage usage is used for calculating the batch-ephemeral-storage and batch-hugepages
+	if features.DefaultKoordletFeatureGate.Enabled(features.BatchStorageResource) {
+		n.collectNodeStorageUsage()
+	}
 }
 
 func (n *nodeInfoCollector) Started() bool {
@@ -85,3 +125,58 @@ func (n *nodeInfoCollector) collectNodeLocalStorageInfo() {
 	n.started.Store(true)
 	metrics.RecordCollectNodeLocalStorageInfoStatus(nil)
 }
+
+func (n *nodeInfoCollector) collectNodeStorageUsage() {
+	klog.V(6).Info("start collect node storage usage")
+	nodeMetrics := make([]metriccache.MetricSample, 0)
+	collectTime := timeNow()
+
+	var storageUsed uint64
+	if n.diskQuotaSupported {
+		var err error
+		storageUsed, err = system.GetFilesystemUsage(kubeletRootDir)
+		if err != nil {
+			klog.Warningf("failed to collect node ephemeral-storage usage, err: %s", err)
+			return
+		}
+		storageUsageMetric, err := metriccache.NodeEphemeralStorageUsageMetric.GenerateSample(nil, collectTime, float64(storageUsed))
+		if err != nil {
+			klog.Warningf("generate node ephemeral-storage metrics failed, err %v", err)
+			return
+		}
+		nodeMetrics = append(nodeMetrics, storageUsageMetric)
+	}
+
+	hugePagesInfos, err := koordletutil.GetHugePagesInfo()
+	if err != nil {
+		klog.Warningf("failed to collect node hugepages usage, err: %s", err)
+		return
+	}
+	for i := range hugePagesInfos {
+		info := &hugePagesInfos[i]
+		if info.Total <= 0 {
+			continue
+		}
+		pageSize := resource.NewQuantity(int64(info.PageSize), resource.BinarySI).String()
+		hugePagesUsageMetric, err := metriccache.NodeHugePagesUsageMetric.GenerateSample(
+			metriccache.MetricPropertiesFunc.HugePage(pageSize), collectTime, float64(info.UsageBytes()))
+		if err != nil {
+			klog.Warningf("generate node %s%s metrics failed, err %v", corev1.ResourceHugePagesPrefix, pageSize, err)
+			return
+		}
+		nodeMetrics = append(nodeMetrics, hugePagesUsageMetric)
+	}
+
+	appender := n.appendableDB.Appender()
+	if err := appender.Append(nodeMetrics); err != nil {
+		klog.ErrorS(err, "Append node storage metrics error")
+		return
+	}
+	if err := appender.Commit(); err != nil {
+		klog.Warningf("Commit node storage metrics failed, reason: %v", err)
+		return
+	}
+
+	n.started.Store(true)
+	klog.V(4).Infof("collect node storage usage finished, count %v, ephemeral-storage[%v]", len(nodeMetrics), storageUsed)
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodestorageinfo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
+
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/metricsadvisor/framework"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
+)
+
+func Test_nodeInfoCollector(t *testing.T) {
+	metricCache, err := metriccache.NewMetricCache(&metriccache.Config{
+		TSDBPath:              t.TempDir(),
+		TSDBEnablePromMetrics: false,
+	})
+	assert.NoError(t, err)
+	defer func() {
+		err = metricCache.Close()
+		assert.NoError(t, err)
+	}()
+
+	c := New(&framework.Options{
+		Config: &framework.Config{
+			CollectNodeStorageInfoInterval: 1 * time.Second,
+		},
+		MetricCache: metricCache,
+	})
+	assert.NotNil(t, c)
+	assert.False(t, c.Enabled())
+	assert.NotPanics(t, func() {
+		c.Setup(&framework.Context{})
+	})
+}
+
+func Test_nodeInfoCollector_collectNodeStorageUsage(t *testing.T) {
+	helper := system.NewFileTestUtil(t)
+	defer helper.Cleanup()
+	metricCache, err := metriccache.NewMetricCache(&metriccache.Config{
+		TSDBPath:              t.TempDir(),
+		TSDBEnablePromMetrics: false,
+	})
+	assert.NoError(t, err)
+	defer func() {
+		err = metricCache.Close()
+		assert.NoError(t, err)
+	}()
+	testNow := time.Now()
+	oldTimeNow, oldKubeletRootDir := timeNow, kubeletRootDir
+	defer func() {
+		timeNow, kubeletRootDir = oldTimeNow, oldKubeletRootDir
+	}()
+	timeNow = func() time.Time {
+		return testNow
+	}
+	kubeletRootDir = helper.TempDir
+	helper.WriteFileContents("kernel/mm/hugepages/hugepages-2048kB/nr_hugepages", "1024")
+	helper.WriteFileContents("kernel/mm/hugepages/hugepages-2048kB/free_hugepages", "512")
+	helper.WriteFileContents("kernel/mm/hugepages/hugepages-1048576kB/nr_hugepages", "0")
+	helper.WriteFileContents("kernel/mm/hugepages/hugepages-1048576kB/free_hugepages", "0")
+
+	c := &nodeInfoCollector{
+		storage:            metricCache,
+		appendableDB:       metricCache,
+		started:            atomic.NewBool(false),
+		diskQuotaSupported: true,
+	}
+	assert.NotPanics(t, func() {
+		c.collectNodeStorageUsage()
+	})
+	assert.True(t, c.Started())
+
+	querier, err := metricCache.Querier(testNow.Add(-5*time.Second), testNow.Add(5*time.Second))
+	assert.NoError(t, err)
+	storageUsed, err := testQueryLatest(querier, metriccache.NodeEphemeralStorageUsageMetric, nil)
+	assert.NoError(t, err)
+	assert.Greater(t, storageUsed, float64(0))
+	hugePagesUsed, err := testQueryLatest(querier, metriccache.NodeHugePagesUsageMetric, metriccache.MetricPropertiesFunc.HugePage("2Mi"))
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1<<30), hugePagesUsed)
+	_, err = testQueryLatest(querier, metriccache.NodeHugePagesUsageMetric, metriccache.MetricPropertiesFunc.HugePage("1Gi"))
+	assert.Error(t, err)
+
+	// the ephemeral-storage usage is not reported when the disk quota is unsupported
+	c.diskQuotaSupported = false
+	testNow = testNow.Add(time.Minute)
+	assert.NotPanics(t, func() {
+		c.collectNodeStorageUsage()
+	})
+	querier, err = metricCache.Querier(testNow.Add(-5*time.Second), testNow.Add(5*time.Second))
+	assert.NoError(t, err)
+	_, err = testQueryLatest(querier, metriccache.NodeEphemeralStorageUsageMetric, nil)
+	assert.Error(t, err)
+	hugePagesUsed, err = testQueryLatest(querier, metriccache.NodeHugePagesUsageMetric, metriccache.MetricPropertiesFunc.HugePage("2Mi"))
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1<<30), hugePagesUsed)
+
+	// test collect failed
+	c.diskQuotaSupported = true
+	kubeletRootDir = "/path/not/exist"
+	c.started = atomic.NewBool(false)
+	assert.NotPanics(t, func() {
+		c.collectNodeStorageUsage()
+	})
+	assert.False(t, c.Started())
+}
+
+func testQueryLatest(querier metriccache.Querier, resource metriccache.MetricResource, properties map[metriccache.MetricProperty]string) (float64, error) {
+	queryMeta, err := resource.BuildQueryMeta(properties)
+	if err != nil {
+		return 0, err
+	}
+	aggregateResult := metriccache.DefaultAggregateResultFactory.New(queryMeta)
+	if err = querier.Query(queryMeta, nil, aggregateResult); err != nil {
+		return 0, err
+	}
+	return aggregateResult.Value(metriccache.AggregationTypeLast)
+}
@@ -0,0 +1,286 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batchstorage
+
+import (
+	"path/filepath"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
+	"github.com/koordinator-sh/koordinator/pkg/features"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/audit"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/qosmanager/framework"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/resourceexecutor"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/statesinformer"
+	koordletutil "github.com/koordinator-sh/koordinator/pkg/koordlet/util"
+	sysutil "github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
+	"github.com/koordinator-sh/koordinator/pkg/util"
+)
+
+const (
+	BatchStorageReconcileName = "BatchStorageReconcile"
+
+	// diskQuotaRetryInterval is the interval to retry setting or clearing the disk quota of a pod after a failure.
+	diskQuotaRetryInterval = time.Minute
+)
+
+var (
+	// kubeletPodsDir is the dir of the pods' volumes managed by the kubelet.
+	// It is always mounted to the same path inside the koordlet.
+	kubeletPodsDir = "/var/lib/kubelet/pods"
+
+	isProjectQuotaSupported = sysutil.IsProjectQuotaSupported
+
+	// hugetlbResources maps the batch hugepages to the hugetlb cgroup resources.
+	hugetlbResources = map[corev1.ResourceName]sysutil.ResourceType{
+		apiext.BatchHugePages2Mi: sysutil.HugetlbLimit2MName,
+		apiext.BatchHugePages1Gi: sysutil.HugetlbLimit1GName,
+	}
+)
+
+// batchStorageReconcile limits the batch-hugepages of the BE pods via the hugetlb cgroups, and limits the
+// batch-ephemeral-storage of the BE pods via the xfs project quota on the pod dirs, so that the batch pods cannot
+// use the node storage more than their requests.
+// NOTE: The disk quota only covers the pod dir under the kubelet root (i.e. the emptyDir volumes). The writable
+// layers of the containers are managed by the container runtime and the container logs are under /var/log/pods,
+// which are not mounted into the koordlet, so they are still limited by the kubelet eviction of ephemeral-storage.
+type batchStorageReconcile struct {
+	reconcileInterval time.Duration
+	statesInformer    statesinformer.StatesInformer
+	executor          resourceexecutor.ResourceUpdateExecutor
+	projectFile       string
+	// diskQuotaSupported indicates if the xfs project quota can be enforced on the pod dirs, which is detected once
+	// when running. The disk quotas are neither set nor cleared if it is unsupported.
+	diskQuotaSupported bool
+	// projects records the xfs projects allocated to the pods, and it is loaded from the project file when running
+	projects *projectTable
+	// diskQuotaFailures records the last time failing to set or clear the disk quota of the pods, pod uid -> time
+	diskQuotaFailures map[types.UID]time.Time
+}
+
+var _ framework.QOSStrategy = &batchStorageReconcile{}
+
+func New(opt *framework.Options) framework.QOSStrategy {
+	return &batchStorageReconcile{
+		reconcileInterval: time.Duration(opt.Config.ReconcileIntervalSeconds) * time.Second,
+		statesInformer:    opt.StatesInformer,
+		executor:          resourceexecutor.NewResourceUpdateExecutor(),
+		projectFile:       opt.Config.BatchStorageProjectFile,
+		diskQuotaFailures: map[types.UID]time.Time{},
+	}
+}
+
+func (b *batchStorageReconcile) Enabled() bool {
+	return features.DefaultKoordletFeatureGate.Enabled(features.BatchStorageResource) && b.reconcileInterval > 0
+}
+
+func (b *batchStorageReconcile) Setup(context *framework.Context) {
+}
+
+func (b *batchStorageReconcile) Run(stopCh <-chan struct{}) {
+	b.projects = loadProjectTable(b.projectFile)
+	if supported, err := isProjectQuotaSupported(kubeletPodsDir); !supported {
+		klog.Warningf("xfs project quota is unsupported on %s, the batch-ephemeral-storage of the pods cannot be limited, err: %v",
+			kubeletPodsDir, err)
+	} else {
+		b.diskQuotaSupported = true
+	}
+	b.executor.Run(stopCh)
+	go wait.Until(b.reconcile, b.reconcileInterval, stopCh)
+}
+
+func (b *batchStorageReconcile) reconcile() {
+	podMetas := b.statesInformer.GetAllPods()
+
+	var podResources, containerResources []resourceexecutor.ResourceUpdater
+	projectsChanged := false
+	alivePods := map[types.UID]struct{}{}
+	for _, podMeta := range podMetas {
+		pod := podMeta.Pod
+		if pod == nil {
+			continue
+		}
+		alivePods[pod.UID] = struct{}{}
+		// ignore non-running pods
+		if pod.Status.Phase != corev1.PodRunning && pod.Status.Phase != corev1.PodPending {
+			continue
+		}
+		if apiext.GetPodQoSClassRaw(pod) != apiext.QoSBE {
+			continue
+		}
+
+		curPodResources, curContainerResources := calculateHugetlbResources(podMeta)
+		podResources = append(podResources, curPodResources...)
+		containerResources = append(containerResources, curContainerResources...)
+
+		if b.diskQuotaSupported && b.reconcileDiskQuota(pod) {
+			projectsChanged = true
+		}
+	}
+
+	// the batch hugepages only increase the hugetlb limits, so update from the pod level to the container level
+	b.executor.UpdateBatch(true, append(podResources, containerResources...)...)
+
+	if b.diskQuotaSupported && b.cleanupDiskQuotas(alivePods) {
+		projectsChanged = true
+	}
+	if projectsChanged {
+		if err := b.projects.save(); err != nil {
+			klog.Warningf("failed to save batch storage project file %s, err: %v", b.projectFile, err)
+		}
+	}
+	klog.V(5).Infof("finish reconciling batch storage resources for %v pods", len(podMetas))
+}
+
+// reconcileDiskQuota sets the disk quota of the pod if it is not applied, and returns if the projects are changed.
+func (b *batchStorageReconcile) reconcileDiskQuota(pod *corev1.Pod) bool {
+	limit := getPodBatchResource(pod, apiext.BatchEphemeralStorage)
+	if limit <= 0 {
+		return false
+	}
+	project, ok := b.projects.projects[pod.UID]
+	if ok && project.LimitBytes == limit {
+		return false
+	}
+	if failedTime, failed := b.diskQuotaFailures[pod.UID]; failed && time.Since(failedTime) < diskQuotaRetryInterval {
+		return false
+	}
+
+	changed := false
+	if !ok {
+		var err error
+		project, err = b.projects.allocate(pod.UID, filepath.Join(kubeletPodsDir, string(pod.UID)))
+		if err != nil {
+			klog.Warningf("failed to allocate disk quota project for pod %s, err: %v", util.GetPodKey(pod), err)
+			b.diskQuotaFailures[pod.UID] = time.Now()
+			return false
+		}
+		changed = true
+	}
+	if err := sysutil.SetDirProjectQuota(project.Dir, project.ID, limit); err != nil {
+		klog.Warningf("failed to set disk quota for pod %s, limit %v, err: %v", util.GetPodKey(pod), limit, err)
+		b.diskQuotaFailures[pod.UID] = time.Now()
+		return changed
+	}
+	delete(b.diskQuotaFailures, pod.UID)
+	project.LimitBytes = limit
+	klog.V(5).Infof("set disk quota for pod %s to %v bytes, project %v", util.GetPodKey(pod), limit, project.ID)
+	return true
+}
+
+// cleanupDiskQuotas clears the disk quotas of the deleted pods and releases their projects, and returns if the
+// projects are changed.
+func (b *batchStorageReconcile) cleanupDiskQuotas(alivePods map[types.UID]struct{}) bool {
+	changed := false
+	for uid, project := range b.projects.projects {
+		if _, ok := alivePods[uid]; ok {
+			continue
+		}
+		if failedTime, failed := b.diskQuotaFailures[uid]; failed && time.Since(failedTime) < diskQuotaRetryInterval {
+			continue
+		}
+		if err := sysutil.ClearDirProjectQuota(project.Dir, project.ID); err != nil {
+			klog.Warningf("failed to clear disk quota for deleted pod %s, project %v, err: %v", uid, project.ID, err)
+			b.diskQuotaFailures[uid] = time.Now()
+			continue
+		}
+		b.projects.release(uid)
+		changed = true
+		klog.V(5).Infof("clear disk quota for deleted pod %s, project %v", uid, project.ID)
+	}
+	for uid := range b.diskQuotaFailures {
+		_, isAlive := alivePods[uid]
+		_, hasProject := b.projects.projects[uid]
+		if !isAlive && !hasProject {
+			delete(b.diskQuotaFailures, uid)
+		}
+	}
+	return changed
+}
+
+func calculateHugetlbResources(podMeta *statesinformer.PodMeta) (podResources, containerResources []resourceexecutor.ResourceUpdater) {
+	pod := podMeta.Pod
+	for batchResourceName, resourceType := range hugetlbResources {
+		podLimit := getPodBatchResource(pod, batchResourceName)
+		if podLimit <= 0 {
+			continue
+		}
+		eventHelper := audit.V(3).Pod(pod.Namespace, pod.Name).Reason(BatchStorageReconcileName).Message("set pod %v to %v", resourceType, podLimit)
+		updater, err := resourceexecutor.DefaultCgroupUpdaterFactory.New(resourceType, podMeta.CgroupDir, strconv.FormatInt(podLimit, 10), eventHelper)
+		if err != nil {
+			klog.V(4).Infof("failed to get pod %v updater for pod %s, err: %v", resourceType, util.GetPodKey(pod), err)
+			continue
+		}
+		podResources = append(podResources, updater)
+
+		for i := range pod.Spec.Containers {
+			container := &pod.Spec.Containers[i]
+			containerLimit := getContainerBatchResource(container, batchResourceName)
+			if containerLimit <= 0 {
+				continue
+			}
+			_, containerStatus, err := util.FindContainerIdAndStatusByName(&pod.Status, container.Name)
+			if err != nil {
+				klog.V(5).Infof("failed to find containerStatus, pod %s, container %s, err: %v",
+					util.GetPodKey(pod), container.Name, err)
+				continue
+			}
+			containerDir, err := koordletutil.GetContainerCgroupParentDir(podMeta.CgroupDir, containerStatus)
+			if err != nil {
+				klog.V(5).Infof("failed to get container dir, pod %s, container %s, err: %v",
+					util.GetPodKey(pod), container.Name, err)
+				continue
+			}
+			eventHelper := audit.V(3).Pod(pod.Namespace, pod.Name).Container(container.Name).Reason(BatchStorageReconcileName).Message("set container %v to %v", resourceType, containerLimit)
+			updater, err := resourceexecutor.DefaultCgroupUpdaterFactory.New(resourceType, containerDir, strconv.FormatInt(containerLimit, 10), eventHelper)
+			if err != nil {
+				klog.V(4).Infof("failed to get container %v updater for pod %s, container %s, err: %v",
+					resourceType, util.GetPodKey(pod), container.Name, err)
+				continue
+			}
+			containerResources = append(containerResources, updater)
+		}
+	}
+	return podResources, containerResources
+}
+
+// getPodBatchResource returns the sum of the containers' batch resource limits in bytes.
+func getPodBatchResource(pod *corev1.Pod, resourceName corev1.ResourceName) int64 {
+	podLimit := int64(0)
+	for i := range pod.Spec.Containers {
+		podLimit += getContainerBatchResource(&pod.Spec.Containers[i], resourceName)
+	}
+	return podLimit
+}
+
+// getContainerBatchResource returns the batch resource limit of the container, and the request is used if the limit
+// is not specified.
+func getContainerBatchResource(container *corev1.Container, resourceName corev1.ResourceName) int64 {
+	if q, ok := container.Resources.Limits[resourceName]; ok {
+		return q.Value()
+	}
+	if q, ok := container.Resources.Requests[resourceName]; ok {
+		return q.Value()
+	}
+	return 0
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batchstorage
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/qosmanager/framework"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/resourceexecutor"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/statesinformer"
+	mock_statesinformer "github.com/koordinator-sh/koordinator/pkg/koordlet/statesinformer/mockstatesinformer"
+	koordletutil "github.com/koordinator-sh/koordinator/pkg/koordlet/util"
+	sysutil "github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
+	"github.com/koordinator-sh/koordinator/pkg/util/cache"
+)
+
+func TestNew(t *testing.T) {
+	s := New(&framework.Options{
+		Config: &framework.Config{
+			ReconcileIntervalSeconds: 1,
+		},
+	})
+	assert.NotNil(t, s)
+	assert.False(t, s.Enabled())
+	assert.NotPanics(t, func() {
+		s.Setup(&framework.Context{})
+	})
+}
+
+func Test_batchStorageReconcile_reconcile(t *testing.T) {
+	helper := sysutil.NewFileTestUtil(t)
+	defer helper.Cleanup()
+	oldKubeletPodsDir, oldExecXFSQuota := kubeletPodsDir, sysutil.ExecXFSQuota
+	defer func() {
+		kubeletPodsDir, sysutil.ExecXFSQuota = oldKubeletPodsDir, oldExecXFSQuota
+	}()
+	kubeletPodsDir = filepath.Join(helper.TempDir, "pods")
+
+	bePod := &statesinformer.PodMeta{
+		Pod: &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "be-pod",
+				UID:       "be-pod-uid",
+				Labels: map[string]string{
+					apiext.LabelPodQoS: string(apiext.QoSBE),
+				},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name: "main",
+						Resources: corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{
+								apiext.BatchHugePages2Mi:     resource.MustParse("1Gi"),
+								apiext.BatchEphemeralStorage: resource.MustParse("10Gi"),
+							},
+						},
+					},
+					{
+						Name: "sidecar",
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								apiext.BatchHugePages2Mi: resource.MustParse("512Mi"),
+							},
+						},
+					},
+				},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{
+					{
+						Name:        "main",
+						ContainerID: "containerd://main-id",
+					},
+					{
+						Name:        "sidecar",
+						ContainerID: "containerd://sidecar-id",
+					},
+				},
+			},
+		},
+		CgroupDir: "kubepods.slice/kubepods-besteffort.slice/kubepods-besteffort-podbe_pod_uid.slice",
+	}
+	lsPod := &statesinformer.PodMeta{
+		Pod: &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "ls-pod",
+				UID:       "ls-pod-uid",
+				Labels: map[string]string{
+					apiext.LabelPodQoS: string(apiext.QoSLS),
+				},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name: "main",
+						Resources: corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{
+								apiext.BatchEphemeralStorage: resource.MustParse("10Gi"),
+							},
+						},
+					},
+				},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+		CgroupDir: "kubepods.slice/kubepods-burstable.slice/kubepods-burstable-podls_pod_uid.slice",
+	}
+	mainContainerDir, err := koordletutil.GetContainerCgroupParentDir(bePod.CgroupDir, &bePod.Pod.Status.ContainerStatuses[0])
+	assert.NoError(t, err)
+	sidecarContainerDir, err := koordletutil.GetContainerCgroupParentDir(bePod.CgroupDir, &bePod.Pod.Status.ContainerStatuses[1])
+	assert.NoError(t, err)
+	helper.SetResourcesSupported(true, sysutil.HugetlbLimit2M)
+	for _, dir := range []string{bePod.CgroupDir, mainContainerDir, sidecarContainerDir} {
+		helper.WriteCgroupFileContents(dir, sysutil.HugetlbLimit2M, "0")
+	}
+	helper.MkDirAll(filepath.Join("pods", string(bePod.Pod.UID)))
+
+	var xfsQuotaCommands []string
+	sysutil.ExecXFSQuota = func(mountPoint, command string) ([]byte, error) {
+		xfsQuotaCommands = append(xfsQuotaCommands, command)
+		return nil, nil
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	si := mock_statesinformer.NewMockStatesInformer(ctrl)
+	pods := []*statesinformer.PodMeta{bePod, lsPod}
+	si.EXPECT().GetAllPods().DoAndReturn(func() []*statesinformer.PodMeta {
+		return pods
+	}).AnyTimes()
+
+	// the project of the deleted pod is allocated before restarting
+	projectFile := filepath.Join(helper.TempDir, "projects.json")
+	deletedPodDir := filepath.Join(kubeletPodsDir, "deleted-pod-uid")
+	oldProjects := &projectTable{
+		path: projectFile,
+		projects: map[types.UID]*podProject{
+			"deleted-pod-uid": {ID: minProjectID, Dir: deletedPodDir, LimitBytes: 1024},
+		},
+	}
+	assert.NoError(t, oldProjects.save())
+
+	b := &batchStorageReconcile{
+		reconcileInterval: time.Second,
+		statesInformer:    si,
+		executor: &resourceexecutor.ResourceUpdateExecutorImpl{
+			Config:        resourceexecutor.NewDefaultConfig(),
+			ResourceCache: cache.NewCacheDefault(),
+		},
+		projectFile:        projectFile,
+		diskQuotaSupported: true,
+		projects:           loadProjectTable(projectFile),
+		diskQuotaFailures:  map[types.UID]time.Time{},
+	}
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	b.executor.Run(stopCh)
+
+	b.reconcile()
+	assert.Equal(t, "1610612736", helper.ReadCgroupFileContents(bePod.CgroupDir, sysutil.HugetlbLimit2M))
+	assert.Equal(t, "1073741824", helper.ReadCgroupFileContents(mainContainerDir, sysutil.HugetlbLimit2M))
+	assert.Equal(t, "536870912", helper.ReadCgroupFileContents(sidecarContainerDir, sysutil.HugetlbLimit2M))
+	// the project id of the deleted pod is not reused before it is released
+	bePodDir := filepath.Join(kubeletPodsDir, string(bePod.Pod.UID))
+	projectID := minProjectID + 1
+	assert.Equal(t, []string{
+		fmt.Sprintf("project -s -p %s %d", bePodDir, projectID),
+		fmt.Sprintf("limit -p bhard=10737418240 %d", projectID),
+		fmt.Sprintf("limit -p bhard=0 %d", minProjectID),
+	}, xfsQuotaCommands)
+	expectProjects := map[types.UID]*podProject{
+		bePod.Pod.UID: {ID: projectID, Dir: bePodDir, LimitBytes: 10 << 30},
+	}
+	assert.Equal(t, expectProjects, b.projects.projects)
+	assert.Equal(t, expectProjects, loadProjectTable(projectFile).projects)
+
+	// the applied disk quota is not set again
+	xfsQuotaCommands = nil
+	b.reconcile()
+	assert.Nil(t, xfsQuotaCommands)
+
+	// the failed disk quota is not retried until the retry interval passes
+	sysutil.ExecXFSQuota = func(mountPoint, command string) ([]byte, error) {
+		xfsQuotaCommands = append(xfsQuotaCommands, command)
+		return []byte("not supported"), fmt.Errorf("exit status 1")
+	}
+	pods = []*statesinformer.PodMeta{lsPod}
+	b.reconcile()
+	assert.Equal(t, []string{fmt.Sprintf("limit -p bhard=0 %d", projectID)}, xfsQuotaCommands)
+	xfsQuotaCommands = nil
+	b.reconcile()
+	assert.Nil(t, xfsQuotaCommands)
+	assert.Equal(t, expectProjects, b.projects.projects)
+
+	// the project of the deleted pod is released
+	sysutil.ExecXFSQuota = func(mountPoint, command string) ([]byte, error) {
+		xfsQuotaCommands = append(xfsQuotaCommands, command)
+		return nil, nil
+	}
+	b.diskQuotaFailures[bePod.Pod.UID] = time.Now().Add(-diskQuotaRetryInterval)
+	b.reconcile()
+	assert.Equal(t, []string{
+		fmt.Sprintf("limit -p bhard=0 %d", projectID),
+		fmt.Sprintf("project -C -p %s %d", bePodDir, projectID),
+	}, xfsQuotaCommands)
+	assert.Equal(t, map[types.UID]*podProject{}, b.projects.projects)
+	assert.Equal(t, map[types.UID]*podProject{}, loadProjectTable(projectFile).projects)
+	assert.Equal(t, map[types.UID]time.Time{}, b.diskQuotaFailures)
+
+	// the disk quota is not set when the project quota is unsupported
+	b.diskQuotaSupported = false
+	pods = []*statesinformer.PodMeta{bePod, lsPod}
+	xfsQuotaCommands = nil
+	b.reconcile()
+	assert.Nil(t, xfsQuotaCommands)
+	assert.Equal(t, map[types.UID]*podProject{}, b.projects.projects)
+}
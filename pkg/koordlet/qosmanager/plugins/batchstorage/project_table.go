@@ -0,0 +1,107 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batchstorage
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// minProjectID is the minimum xfs project id used for the pods, to avoid conflicting with the projects
+	// configured by the administrator.
+	minProjectID uint32 = 1 << 20
+
+	projectTableTmpFileSuffix = ".tmp"
+)
+
+// podProject is the xfs project allocated to a pod.
+type podProject struct {
+	ID uint32 `json:"id"`
+	// Dir is the directory attached to the project.
+	Dir string `json:"dir"`
+	// LimitBytes is the block hard limit applied to the project, 0 if not applied yet.
+	LimitBytes int64 `json:"limitBytes,omitempty"`
+}
+
+// projectTable records the xfs projects allocated to the pods, pod uid -> project.
+// It is persisted in a file so that the project ids are neither reused nor leaked after the koordlet restarts.
+type projectTable struct {
+	path     string
+	projects map[types.UID]*podProject
+}
+
+// loadProjectTable loads the project table from the file. An empty table is returned if the file does not exist.
+func loadProjectTable(path string) *projectTable {
+	t := &projectTable{
+		path:     path,
+		projects: map[types.UID]*podProject{},
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			klog.Warningf("failed to read batch storage project file %s, err: %v", path, err)
+		}
+		return t
+	}
+	projects := map[types.UID]*podProject{}
+	if err := json.Unmarshal(data, &projects); err != nil {
+		klog.Warningf("failed to parse batch storage project file %s, err: %v", path, err)
+		return t
+	}
+	t.projects = projects
+	return t
+}
+
+// allocate allocates the smallest unused project id for the pod dir.
+func (t *projectTable) allocate(uid types.UID, dir string) (*podProject, error) {
+	usedIDs := make(map[uint32]struct{}, len(t.projects))
+	for _, p := range t.projects {
+		usedIDs[p.ID] = struct{}{}
+	}
+	for id := minProjectID; id < math.MaxUint32; id++ {
+		if _, ok := usedIDs[id]; ok {
+			continue
+		}
+		p := &podProject{ID: id, Dir: dir}
+		t.projects[uid] = p
+		return p, nil
+	}
+	return nil, fmt.Errorf("no available project id")
+}
+
+func (t *projectTable) release(uid types.UID) {
+	delete(t.projects, uid)
+}
+
+// save writes the table into a temporary file and renames it, so the file is never partially written.
+func (t *projectTable) save() error {
+	data, err := json.Marshal(t.projects)
+	if err != nil {
+		return err
+	}
+	tmpPath := t.path + projectTableTmpFileSuffix
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, t.path)
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batchstorage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func Test_projectTable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "projects.json")
+
+	// no file
+	table := loadProjectTable(path)
+	assert.Equal(t, map[types.UID]*podProject{}, table.projects)
+
+	p0, err := table.allocate("pod-0", "/pods/pod-0")
+	assert.NoError(t, err)
+	assert.Equal(t, &podProject{ID: minProjectID, Dir: "/pods/pod-0"}, p0)
+	p1, err := table.allocate("pod-1", "/pods/pod-1")
+	assert.NoError(t, err)
+	assert.Equal(t, minProjectID+1, p1.ID)
+	p1.LimitBytes = 1024
+	assert.NoError(t, table.save())
+
+	// the released id is reused
+	table = loadProjectTable(path)
+	assert.Equal(t, map[types.UID]*podProject{
+		"pod-0": {ID: minProjectID, Dir: "/pods/pod-0"},
+		"pod-1": {ID: minProjectID + 1, Dir: "/pods/pod-1", LimitBytes: 1024},
+	}, table.projects)
+	table.release("pod-0")
+	p2, err := table.allocate("pod-2", "/pods/pod-2")
+	assert.NoError(t, err)
+	assert.Equal(t, minProjectID, p2.ID)
+
+	// invalid file
+	assert.NoError(t, os.WriteFile(path, []byte("invalid"), 0644))
+	table = loadProjectTable(path)
+	assert.Equal(t, map[types.UID]*podProject{}, table.projects)
+}
@@ -128,7 +128,7 @@ func (m *cgroupResourcesReconcile) calculateAndUpdateResources(nodeSLO *slov1alp
 	podMetas := m.statesInformer.GetAllPods()
 
 	// calculate qos-level, pod-level and container-level resources
-	qosResources, podResources, containerResources := m.calculateResources(nodeSLO.Spec.ResourceQOSStrategy, node, podMetas)
+	qosResources, podResources, containerResources := m.calculateResources(nodeSLO.Spec.ResourceQOSStrategy, nodeSLO.Spec.PodStrategies, node, podMetas)
 
 	// to make sure the hierarchical cgroup resources are correctly updated, we simply update the resources by
 	// cgroup-level order.
@@ -137,15 +137,16 @@ func (m *cgroupResourcesReconcile) calculateAndUpdateResources(nodeSLO *slov1alp
 	m.executor.LeveledUpdateBatch(leveledResources)
 }
 
-// calculateResources calculates qos-level, pod-level and container-level resources with nodeCfg and podMetas
-func (m *cgroupResourcesReconcile) calculateResources(nodeCfg *slov1alpha1.ResourceQOSStrategy, node *corev1.Node,
-	podMetas []*statesinformer.PodMeta) (qosLevelResources, podLevelResources, containerLevelResources []resourceexecutor.ResourceUpdater) {
+// calculateResources calculates qos-level, pod-level and container-level resources with nodeCfg, podStrategies and podMetas
+func (m *cgroupResourcesReconcile) calculateResources(nodeCfg *slov1alpha1.ResourceQOSStrategy, podStrategies []slov1alpha1.PodStrategy,
+	node *corev1.Node, podMetas []*statesinformer.PodMeta) (qosLevelResources, podLevelResources, containerLevelResources []resourceexecutor.ResourceUpdater) {
 	// TODO: check anolis os version
 	qosSummary := map[corev1.PodQOSClass]*cgroupResourceSummary{
 		corev1.PodQOSGuaranteed: {},
 		corev1.PodQOSBurstable:  {},
 		corev1.PodQOSBestEffort: {},
 	}
+	podStrategyMatcher := koordletutil.NewPodStrategyMatcher(podStrategies)
 
 	for _, podMeta := range podMetas {
 		pod := podMeta.Pod
@@ -158,7 +159,7 @@ func (m *cgroupResourcesReconcile) calculateResources(nodeCfg *slov1alpha1.Resou
 		// retrieve pod-level config
 		kubeQoS := apiext.GetKubeQosClass(pod) // assert kubeQoS belongs to {Guaranteed, Burstable, Besteffort}
 		podQoSCfg := helpers.GetPodResourceQoSByQoSClass(pod, nodeCfg)
-		mergedPodCfg, err := m.getMergedPodResourceQoS(pod, podQoSCfg)
+		mergedPodCfg, err := m.getMergedPodResourceQoS(pod, podQoSCfg, podStrategyMatcher)
 		if err != nil {
 			klog.Errorf("failed to retrieve pod resourceQoS, err: %v", err)
 			continue
@@ -351,22 +352,26 @@ func (m *cgroupResourcesReconcile) calculateContainerResources(container *corev1
 }
 
 // getMergedPodResourceQoS returns a merged ResourceQOS for the pod (i.e. a pod-level qos config).
-// 1. merge pod-level cfg with node-level cfg if pod annotation of advanced qos config exists;
+// 1. merge pod-level cfg with node-level cfg if pod annotation of advanced qos config or a matched pod strategy exists;
 // 2. calculates and finally returns the pod-level cfg with each feature cfg (e.g. pod-level memory qos config).
-func (m *cgroupResourcesReconcile) getMergedPodResourceQoS(pod *corev1.Pod, cfg *slov1alpha1.ResourceQOS) (*slov1alpha1.ResourceQOS, error) {
+func (m *cgroupResourcesReconcile) getMergedPodResourceQoS(pod *corev1.Pod, cfg *slov1alpha1.ResourceQOS,
+	podStrategyMatcher *koordletutil.PodStrategyMatcher) (*slov1alpha1.ResourceQOS, error) {
 	// deep-copy node config into pod config; assert cfg == NoneResourceQOS when node disables
 	mergedCfg := cfg.DeepCopy()
 
+	podStrategy := podStrategyMatcher.Match(pod.Namespace, pod.Labels)
+
 	// update with memory qos config
-	m.mergePodResourceQoSForMemoryQoS(pod, mergedCfg)
+	m.mergePodResourceQoSForMemoryQoS(pod, mergedCfg, podStrategy)
 
 	klog.V(5).Infof("get merged pod ResourceQOS %v for pod %s", util.DumpJSON(mergedCfg), util.GetPodKey(pod))
 	return mergedCfg, nil
 }
 
 // mergePodResourceQoSForMemoryQoS merges pod-level memory qos config with node-level resource qos config
-// config overwrite: pod-level config > pod policy template > node-level config
-func (m *cgroupResourcesReconcile) mergePodResourceQoSForMemoryQoS(pod *corev1.Pod, cfg *slov1alpha1.ResourceQOS) {
+// config overwrite: pod-level config > pod strategy of NodeSLO > pod policy template > node-level config
+func (m *cgroupResourcesReconcile) mergePodResourceQoSForMemoryQoS(pod *corev1.Pod, cfg *slov1alpha1.ResourceQOS,
+	podStrategy *slov1alpha1.PodStrategy) {
 	// get the pod-level config and determine if the pod is allowed
 	if cfg.MemoryQOS == nil {
 		cfg.MemoryQOS = &slov1alpha1.MemoryQOSCfg{}
@@ -380,6 +385,11 @@ func (m *cgroupResourcesReconcile) mergePodResourceQoSForMemoryQoS(pod *corev1.P
 		podCfg = nil
 	}
 
+	if podCfg == nil && podStrategy != nil && podStrategy.MemoryQOS != nil {
+		klog.V(6).Infof("memory qos of pod %s is overridden by pod strategy %s", util.GetPodKey(pod), podStrategy.Name)
+		podCfg = podStrategy.MemoryQOS.DeepCopy()
+	}
+
 	if podCfg == nil {
 		var greyCtlMemoryQOSCfgIf interface{} = &slov1alpha1.PodMemoryQOSConfig{}
 		injected := framework.InjectQOSGreyCtrlPlugins(pod, framework.QOSPolicyMemoryQOS, &greyCtlMemoryQOSCfgIf)
@@ -899,7 +899,7 @@ func TestCgroupResourceReconcile_calculateResources(t *testing.T) {
 			})
 			defer func() { stop <- struct{}{} }()
 
-			got, got1, got2 := m.calculateResources(tt.args.nodeCfg, tt.args.node, tt.args.podMetas)
+			got, got1, got2 := m.calculateResources(tt.args.nodeCfg, nil, tt.args.node, tt.args.podMetas)
 			assertCgroupResourceEqual(t, tt.want, got)
 			assertCgroupResourceEqual(t, tt.want1, got1)
 			assertCgroupResourceEqual(t, tt.want2, got2)
@@ -925,9 +925,31 @@ func TestCgroupResourcesReconcile_getMergedPodResourceQoS(t *testing.T) {
 		},
 	}
 	testingMemoryQoSAutoResourceQoS2.MemoryQOS.ThrottlingPercent = pointer.Int64(90)
+	testingPodStrategies := []slov1alpha1.PodStrategy{
+		{
+			Name:       "other-namespace",
+			Namespaces: []string{"other"},
+			MemoryQOS: &slov1alpha1.PodMemoryQOSConfig{
+				Policy: slov1alpha1.PodMemoryQOSPolicyNone,
+			},
+		},
+		{
+			Name:       "tenant-auto",
+			Namespaces: []string{"default"},
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"tenant": "a",
+				},
+			},
+			MemoryQOS: &slov1alpha1.PodMemoryQOSConfig{
+				Policy: slov1alpha1.PodMemoryQOSPolicyAuto,
+			},
+		},
+	}
 	type args struct {
-		pod *corev1.Pod
-		cfg *slov1alpha1.ResourceQOS
+		pod           *corev1.Pod
+		cfg           *slov1alpha1.ResourceQOS
+		podStrategies []slov1alpha1.PodStrategy
 	}
 	type fields struct {
 		opt *framework.Options
@@ -1059,12 +1081,87 @@ func TestCgroupResourcesReconcile_getMergedPodResourceQoS(t *testing.T) {
 			},
 			want: testingMemoryQoSAutoResourceQoS2,
 		},
+		{
+			name: "pod strategy policy is Auto, use strategy config even if node disabled",
+			fields: fields{
+				opt: &framework.Options{Config: framework.NewDefaultConfig()},
+			},
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-pod",
+						Namespace: "default",
+						Labels: map[string]string{
+							apiext.LabelPodQoS: string(apiext.QoSBE),
+							"tenant":           "a",
+						},
+					},
+					Status: corev1.PodStatus{
+						Phase: corev1.PodRunning,
+					},
+				},
+				cfg:           testingNodeNoneResourceQoS,
+				podStrategies: testingPodStrategies,
+			},
+			want: testingMemoryQoSAutoResourceQoS,
+		},
+		{
+			name: "pod strategy not matched, use node config",
+			fields: fields{
+				opt: &framework.Options{Config: framework.NewDefaultConfig()},
+			},
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-pod",
+						Namespace: "default",
+						Labels: map[string]string{
+							apiext.LabelPodQoS: string(apiext.QoSBE),
+							"tenant":           "b",
+						},
+					},
+					Status: corev1.PodStatus{
+						Phase: corev1.PodRunning,
+					},
+				},
+				cfg:           testutil.DefaultQOSStrategy().BEClass,
+				podStrategies: testingPodStrategies,
+			},
+			want: testutil.DefaultQOSStrategy().BEClass,
+		},
+		{
+			name: "pod annotation overrides pod strategy",
+			fields: fields{
+				opt: &framework.Options{Config: framework.NewDefaultConfig()},
+			},
+			args: args{
+				pod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-pod",
+						Namespace: "default",
+						Labels: map[string]string{
+							apiext.LabelPodQoS: string(apiext.QoSBE),
+							"tenant":           "a",
+						},
+						Annotations: map[string]string{
+							slov1alpha1.AnnotationPodMemoryQoS: `{"policy":"none"}`,
+						},
+					},
+					Status: corev1.PodStatus{
+						Phase: corev1.PodRunning,
+					},
+				},
+				cfg:           sloconfig.DefaultResourceQOSStrategy().BEClass,
+				podStrategies: testingPodStrategies,
+			},
+			want: testingMemoryQoSNoneResourceQoS1,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ci := New(tt.fields.opt)
 			c := ci.(*cgroupResourcesReconcile)
-			got, gotErr := c.getMergedPodResourceQoS(tt.args.pod, tt.args.cfg)
+			got, gotErr := c.getMergedPodResourceQoS(tt.args.pod, tt.args.cfg, koordletutil.NewPodStrategyMatcher(tt.args.podStrategies))
 			assert.Equal(t, tt.want, got)
 			assert.Equal(t, tt.wantErr, gotErr != nil)
 		})
@@ -1475,7 +1572,7 @@ func TestCgroupResourcesReconcile_mergePodResourceQoSForMemoryQoS(t *testing.T)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			m := &cgroupResourcesReconcile{}
-			m.mergePodResourceQoSForMemoryQoS(tt.args.pod, tt.args.cfg)
+			m.mergePodResourceQoSForMemoryQoS(tt.args.pod, tt.args.cfg, nil)
 			assert.Equal(t, tt.wants.memoryQOSCfg, tt.args.cfg.MemoryQOS)
 		})
 	}
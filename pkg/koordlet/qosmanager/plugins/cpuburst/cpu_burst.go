@@ -221,6 +221,7 @@ func (b *cpuBurst) start() {
 	nodeState := b.getNodeStateForBurst(*b.nodeCPUBurstStrategy.SharePoolThresholdPercent, podsMeta)
 	klog.V(5).Infof("get node state %v for cpu burst", nodeState)
 
+	podStrategyMatcher := koordletutil.NewPodStrategyMatcher(nodeSLO.Spec.PodStrategies)
 	for _, podMeta := range podsMeta {
 		if podMeta == nil || podMeta.Pod == nil {
 			klog.Warningf("podMeta is illegal, detail %v", podMeta)
@@ -239,7 +240,7 @@ func (b *cpuBurst) start() {
 		}
 
 		// merge burst config from pod and node
-		cpuBurstCfg := genPodBurstConfig(podMeta.Pod, &b.nodeCPUBurstStrategy.CPUBurstConfig)
+		cpuBurstCfg := genPodBurstConfig(podMeta.Pod, &b.nodeCPUBurstStrategy.CPUBurstConfig, podStrategyMatcher)
 		if cpuBurstCfg == nil {
 			klog.Warningf("pod %v/%v burst config illegal, burst config %v",
 				podMeta.Pod.Namespace, podMeta.Pod.Name, cpuBurstCfg)
@@ -661,14 +662,23 @@ func calcStaticCPUBurstVal(container *corev1.Container, burstCfg *slov1alpha1.CP
 	return containerCFSBurstVal
 }
 
-// use node config by default, overlap if pod specify config
-func genPodBurstConfig(pod *corev1.Pod, nodeCfg *slov1alpha1.CPUBurstConfig) *slov1alpha1.CPUBurstConfig {
+// use node config by default, overlap if pod specify config or the pod matches a pod strategy of NodeSLO
+// config overwrite: pod-level config > pod strategy of NodeSLO > qos grey control plugins > node-level config
+func genPodBurstConfig(pod *corev1.Pod, nodeCfg *slov1alpha1.CPUBurstConfig, podStrategyMatcher *koordletutil.PodStrategyMatcher) *slov1alpha1.CPUBurstConfig {
 	podCPUBurstCfg, err := slov1alpha1.GetPodCPUBurstConfig(pod)
 	if err != nil {
 		klog.Infof("parse pod %s/%s cpu burst config failed, reason %v", pod.Namespace, pod.Name, err)
 		return nodeCfg
 	}
 
+	if podCPUBurstCfg == nil {
+		podStrategy := podStrategyMatcher.Match(pod.Namespace, pod.Labels)
+		if podStrategy != nil && podStrategy.CPUBurst != nil {
+			klog.V(6).Infof("cpu burst of pod %s/%s is overridden by pod strategy %s", pod.Namespace, pod.Name, podStrategy.Name)
+			podCPUBurstCfg = podStrategy.CPUBurst.DeepCopy()
+		}
+	}
+
 	if podCPUBurstCfg == nil {
 		var greyCtlCPUBurstCfgIf interface{} = &slov1alpha1.CPUBurstConfig{}
 		injected := framework.InjectQOSGreyCtrlPlugins(pod, framework.QOSPolicyCPUBurst, &greyCtlCPUBurstCfgIf)
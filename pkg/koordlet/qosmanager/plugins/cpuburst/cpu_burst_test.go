@@ -415,10 +415,21 @@ func TestCPUBurst_getNodeStateForBurst(t *testing.T) {
 
 func Test_genPodBurstConfig(t *testing.T) {
 
+	testingPodStrategies := []slov1alpha1.PodStrategy{
+		{
+			Name:       "tenant-a",
+			Namespaces: []string{"tenant-a"},
+			CPUBurst: &slov1alpha1.CPUBurstConfig{
+				Policy: slov1alpha1.CPUBurstNone,
+			},
+		},
+	}
+
 	type args struct {
-		podNamespace string
-		podCfg       *slov1alpha1.CPUBurstConfig
-		nodeCfg      *slov1alpha1.CPUBurstConfig
+		podNamespace  string
+		podCfg        *slov1alpha1.CPUBurstConfig
+		nodeCfg       *slov1alpha1.CPUBurstConfig
+		podStrategies []slov1alpha1.PodStrategy
 	}
 
 	tests := []struct {
@@ -483,6 +494,62 @@ func Test_genPodBurstConfig(t *testing.T) {
 				CFSQuotaBurstPeriodSeconds: pointer.Int64(600),
 			},
 		},
+		{
+			name: "merge-pod-strategy-config",
+			args: args{
+				podNamespace: "tenant-a",
+				nodeCfg: &slov1alpha1.CPUBurstConfig{
+					Policy:                     slov1alpha1.CPUBurstAuto,
+					CPUBurstPercent:            pointer.Int64(1000),
+					CFSQuotaBurstPercent:       pointer.Int64(300),
+					CFSQuotaBurstPeriodSeconds: pointer.Int64(600),
+				},
+				podStrategies: testingPodStrategies,
+			},
+			want: &slov1alpha1.CPUBurstConfig{
+				Policy:                     slov1alpha1.CPUBurstNone,
+				CPUBurstPercent:            pointer.Int64(1000),
+				CFSQuotaBurstPercent:       pointer.Int64(300),
+				CFSQuotaBurstPeriodSeconds: pointer.Int64(600),
+			},
+		},
+		{
+			name: "pod-config-overrides-pod-strategy-config",
+			args: args{
+				podNamespace: "tenant-a",
+				podCfg: &slov1alpha1.CPUBurstConfig{
+					Policy: slov1alpha1.CPUBurstOnly,
+				},
+				nodeCfg: &slov1alpha1.CPUBurstConfig{
+					Policy:                     slov1alpha1.CPUBurstAuto,
+					CPUBurstPercent:            pointer.Int64(1000),
+					CFSQuotaBurstPercent:       pointer.Int64(300),
+					CFSQuotaBurstPeriodSeconds: pointer.Int64(600),
+				},
+				podStrategies: testingPodStrategies,
+			},
+			want: &slov1alpha1.CPUBurstConfig{
+				Policy:                     slov1alpha1.CPUBurstOnly,
+				CPUBurstPercent:            pointer.Int64(1000),
+				CFSQuotaBurstPercent:       pointer.Int64(300),
+				CFSQuotaBurstPeriodSeconds: pointer.Int64(600),
+			},
+		},
+		{
+			name: "pod-strategy-not-matched",
+			args: args{
+				podNamespace: "tenant-b",
+				nodeCfg: &slov1alpha1.CPUBurstConfig{
+					Policy:          slov1alpha1.CPUBurstAuto,
+					CPUBurstPercent: pointer.Int64(1000),
+				},
+				podStrategies: testingPodStrategies,
+			},
+			want: &slov1alpha1.CPUBurstConfig{
+				Policy:          slov1alpha1.CPUBurstAuto,
+				CPUBurstPercent: pointer.Int64(1000),
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -498,7 +565,7 @@ func Test_genPodBurstConfig(t *testing.T) {
 				annoStr, _ := json.Marshal(tt.args.podCfg)
 				pod.Annotations[slov1alpha1.AnnotationPodCPUBurst] = string(annoStr)
 			}
-			if got := genPodBurstConfig(pod, tt.args.nodeCfg); !reflect.DeepEqual(got, tt.want) {
+			if got := genPodBurstConfig(pod, tt.args.nodeCfg, util.NewPodStrategyMatcher(tt.args.podStrategies)); !reflect.DeepEqual(got, tt.want) {
 				gotStr, _ := json.Marshal(got)
 				wantStr, _ := json.Marshal(tt.want)
 				t.Errorf("genPodBurstConfig() =\n%v\nwant =\n%v", string(gotStr), string(wantStr))
@@ -1786,7 +1853,7 @@ func Test_genPodBurstConfigWithPlugin(t *testing.T) {
 					slov1alpha1.AnnotationPodCPUBurst: string(podCPUBurstCfgStr),
 				}
 			}
-			gotCfg := genPodBurstConfig(tt.args.pod, &tt.args.nodeCfg)
+			gotCfg := genPodBurstConfig(tt.args.pod, &tt.args.nodeCfg, nil)
 			assert.Equal(t, tt.want, gotCfg)
 		})
 	}
@@ -18,6 +18,7 @@ package plugins
 
 import (
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/qosmanager/framework"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/qosmanager/plugins/batchstorage"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/qosmanager/plugins/blkio"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/qosmanager/plugins/cgreconcile"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/qosmanager/plugins/cpuburst"
@@ -30,6 +31,7 @@ import (
 
 var (
 	StrategyPlugins = map[string]framework.QOSStrategyFactory{
+		batchstorage.BatchStorageReconcileName: batchstorage.New,
 		blkio.BlkIOReconcileName:               blkio.New,
 		cgreconcile.CgroupReconcileName:        cgreconcile.New,
 		cpuburst.CPUBurstName:                  cpuburst.New,
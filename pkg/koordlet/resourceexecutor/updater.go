@@ -55,6 +55,8 @@ func init() {
 		sysutil.MemoryPriorityName,
 		sysutil.MemoryUsePriorityOomName,
 		sysutil.MemoryOomGroupName,
+		sysutil.HugetlbLimit2MName,
+		sysutil.HugetlbLimit1GName,
 	)
 	// special cases
 	DefaultCgroupUpdaterFactory.Register(NewCPUSharesCgroupUpdater, sysutil.CPUSharesName)
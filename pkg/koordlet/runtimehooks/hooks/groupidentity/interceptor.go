@@ -45,7 +45,7 @@ func (b *bvtPlugin) SetPodBvtValue(p protocol.HooksProtocol) error {
 	req := podCtx.Request
 	podQOS := ext.GetQoSClassByAttrs(req.Labels, req.Annotations)
 	podKubeQOS := util.GetKubeQoSByCgroupParent(req.CgroupParent)
-	podBvt := r.getPodBvtValue(podQOS, podKubeQOS)
+	podBvt := r.getPodBvtValue(req.PodMeta.Namespace, req.Labels, podQOS, podKubeQOS)
 	podCtx.Response.Resources.CPUBvt = pointer.Int64(podBvt)
 	return nil
 }
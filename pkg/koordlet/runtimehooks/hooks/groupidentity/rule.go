@@ -39,6 +39,9 @@ type bvtRule struct {
 	podQOSParams     map[ext.QoSClass]int64
 	kubeQOSDirParams map[corev1.PodQOSClass]int64
 	kubeQOSPodParams map[corev1.PodQOSClass]int64
+	// podStrategyMatcher matches the pod strategies of NodeSLO, the first matched strategy overrides the group identity
+	// of the pod if it specifies one
+	podStrategyMatcher *koordletutil.PodStrategyMatcher
 }
 
 func (r *bvtRule) getEnable() bool {
@@ -48,7 +51,15 @@ func (r *bvtRule) getEnable() bool {
 	return r.enable
 }
 
-func (r *bvtRule) getPodBvtValue(podQoSClass ext.QoSClass, podKubeQOS corev1.PodQOSClass) int64 {
+func (r *bvtRule) getPodBvtValue(podNamespace string, podLabels map[string]string, podQoSClass ext.QoSClass, podKubeQOS corev1.PodQOSClass) int64 {
+	// pod strategy only takes effect when the cpu qos is enabled on the node, and only the first matched strategy
+	// takes effect as the other qos features
+	if r.enable {
+		podStrategy := r.podStrategyMatcher.Match(podNamespace, podLabels)
+		if podStrategy != nil && podStrategy.CPUQOS != nil && podStrategy.CPUQOS.GroupIdentity != nil {
+			return *podStrategy.CPUQOS.GroupIdentity
+		}
+	}
 	if val, exist := r.podQOSParams[podQoSClass]; exist {
 		return val
 	}
@@ -113,6 +124,7 @@ func (b *bvtPlugin) parseRule(mergedNodeSLOIf interface{}) (bool, error) {
 			corev1.PodQOSBurstable:  burstablePodVal,
 			corev1.PodQOSBestEffort: besteffortPodVal,
 		},
+		podStrategyMatcher: koordletutil.NewPodStrategyMatcher(mergedNodeSLO.PodStrategies),
 	}
 
 	updated := b.updateRule(newRule)
@@ -146,7 +158,7 @@ func (b *bvtPlugin) ruleUpdateCb(pods []*statesinformer.PodMeta) error {
 	for _, podMeta := range pods {
 		podQOS := ext.GetPodQoSClassRaw(podMeta.Pod)
 		podKubeQOS := podMeta.Pod.Status.QOSClass
-		podBvt := r.getPodBvtValue(podQOS, podKubeQOS)
+		podBvt := r.getPodBvtValue(podMeta.Pod.Namespace, podMeta.Pod.Labels, podQOS, podKubeQOS)
 		podCgroupPath := podMeta.CgroupDir
 		e := audit.V(3).Pod(podMeta.Pod.Namespace, podMeta.Pod.Name).Reason(name).Message("set bvt to %v", podBvt)
 		bvtUpdater, err := resourceexecutor.DefaultCgroupUpdaterFactory.New(sysutil.CPUBVTWarpNsName, podCgroupPath, strconv.FormatInt(podBvt, 10), e)
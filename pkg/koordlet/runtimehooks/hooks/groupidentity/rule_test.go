@@ -35,14 +35,27 @@ import (
 )
 
 func Test_bvtRule_getPodBvtValue(t *testing.T) {
+	testingPodStrategies := []slov1alpha1.PodStrategy{
+		{
+			Name:       "tenant-a",
+			Namespaces: []string{"tenant-a"},
+			CPUQOS: &slov1alpha1.CPUQOS{
+				GroupIdentity: pointer.Int64(-1),
+			},
+		},
+	}
 	type fields struct {
+		enable           bool
 		podQOSParams     map[ext.QoSClass]int64
 		kubeQOSDirParams map[corev1.PodQOSClass]int64
 		kubeQOSPodParams map[corev1.PodQOSClass]int64
+		podStrategies    []slov1alpha1.PodStrategy
 	}
 	type args struct {
-		podQoSClass ext.QoSClass
-		podKubeQoS  corev1.PodQOSClass
+		podNamespace string
+		podLabels    map[string]string
+		podQoSClass  ext.QoSClass
+		podKubeQoS   corev1.PodQOSClass
 	}
 	tests := []struct {
 		name   string
@@ -82,15 +95,87 @@ func Test_bvtRule_getPodBvtValue(t *testing.T) {
 			},
 			want: 1,
 		},
+		{
+			name: "use pod strategy",
+			fields: fields{
+				enable: true,
+				podQOSParams: map[ext.QoSClass]int64{
+					ext.QoSLS: 2,
+				},
+				podStrategies: testingPodStrategies,
+			},
+			args: args{
+				podNamespace: "tenant-a",
+				podQoSClass:  ext.QoSLS,
+				podKubeQoS:   corev1.PodQOSBurstable,
+			},
+			want: -1,
+		},
+		{
+			name: "pod strategy not matched",
+			fields: fields{
+				enable: true,
+				podQOSParams: map[ext.QoSClass]int64{
+					ext.QoSLS: 2,
+				},
+				podStrategies: testingPodStrategies,
+			},
+			args: args{
+				podNamespace: "tenant-b",
+				podQoSClass:  ext.QoSLS,
+				podKubeQoS:   corev1.PodQOSBurstable,
+			},
+			want: 2,
+		},
+		{
+			name: "first matched pod strategy without group identity",
+			fields: fields{
+				enable: true,
+				podQOSParams: map[ext.QoSClass]int64{
+					ext.QoSLS: 2,
+				},
+				podStrategies: append([]slov1alpha1.PodStrategy{
+					{
+						Name:       "tenant-a-memory",
+						Namespaces: []string{"tenant-a"},
+						MemoryQOS:  &slov1alpha1.PodMemoryQOSConfig{},
+					},
+				}, testingPodStrategies...),
+			},
+			args: args{
+				podNamespace: "tenant-a",
+				podQoSClass:  ext.QoSLS,
+				podKubeQoS:   corev1.PodQOSBurstable,
+			},
+			want: 2,
+		},
+		{
+			name: "ignore pod strategy when rule disabled",
+			fields: fields{
+				enable: false,
+				podQOSParams: map[ext.QoSClass]int64{
+					ext.QoSLS: 0,
+				},
+				podStrategies: testingPodStrategies,
+			},
+			args: args{
+				podNamespace: "tenant-a",
+				podQoSClass:  ext.QoSLS,
+				podKubeQoS:   corev1.PodQOSBurstable,
+			},
+			want: 0,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &bvtRule{
-				podQOSParams:     tt.fields.podQOSParams,
-				kubeQOSDirParams: tt.fields.kubeQOSDirParams,
-				kubeQOSPodParams: tt.fields.kubeQOSPodParams,
+				enable:             tt.fields.enable,
+				podQOSParams:       tt.fields.podQOSParams,
+				kubeQOSDirParams:   tt.fields.kubeQOSDirParams,
+				kubeQOSPodParams:   tt.fields.kubeQOSPodParams,
+				podStrategyMatcher: util.NewPodStrategyMatcher(tt.fields.podStrategies),
 			}
-			if got := r.getPodBvtValue(tt.args.podQoSClass, tt.args.podKubeQoS); got != tt.want {
+			if got := r.getPodBvtValue(tt.args.podNamespace, tt.args.podLabels, tt.args.podQoSClass, tt.args.podKubeQoS); got != tt.want {
 				t.Errorf("getPodBvtValue() = %v, want %v", got, tt.want)
 			}
 		})
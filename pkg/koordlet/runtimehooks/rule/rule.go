@@ -23,6 +23,7 @@ import (
 
 	"k8s.io/klog/v2"
 
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/metrics"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/statesinformer"
 	"github.com/koordinator-sh/koordinator/pkg/util"
 )
@@ -60,7 +61,8 @@ func (r *Rule) runUpdateCallbacks(pods []*statesinformer.PodMeta) {
 	for _, callbackFn := range r.callbacks {
 		if err := callbackFn(pods); err != nil {
 			cbName := runtime.FuncForPC(reflect.ValueOf(callbackFn).Pointer()).Name()
-			klog.Warningf("executing %s callback function %s failed, error %v", r.name, cbName)
+			klog.Warningf("executing %s callback function %s failed, error %v", r.name, cbName, err)
+			metrics.RecordNodeSLOApplyError(r.name)
 		}
 	}
 }
@@ -93,6 +95,7 @@ func UpdateRules(ruleType statesinformer.RegisterType, ruleObj interface{}, pods
 		updated, err := r.parseRuleFn(ruleObj)
 		if err != nil {
 			klog.Warningf("parse rule %s from nodeSLO failed, error: %v", r.name, err)
+			metrics.RecordNodeSLOApplyError(r.name)
 			continue
 		}
 		if updated {
@@ -33,7 +33,9 @@ type UpdateCbCtx struct{}
 type callbackRunner struct {
 	callbackChans        map[statesinformer.RegisterType]chan UpdateCbCtx
 	stateUpdateCallbacks map[statesinformer.RegisterType][]updateCallback
-	statesInformer       StatesInformer
+	// callbacksDoneFns are invoked with the object after all callbacks of the type have run
+	callbacksDoneFns map[statesinformer.RegisterType]func(obj interface{})
+	statesInformer   StatesInformer
 }
 
 func NewCallbackRunner() *callbackRunner {
@@ -48,6 +50,7 @@ func NewCallbackRunner() *callbackRunner {
 		statesinformer.RegisterTypeAllPods:      {},
 		statesinformer.RegisterTypeNodeTopology: {},
 	}
+	c.callbacksDoneFns = map[statesinformer.RegisterType]func(obj interface{}){}
 	return c
 }
 
@@ -74,6 +77,11 @@ func (s *callbackRunner) RegisterCallbacks(rType statesinformer.RegisterType, na
 	klog.V(1).Infof("states informer callback %s has registered for type %v", name, rType.String())
 }
 
+// setCallbacksDoneFn sets the function to invoke after all callbacks of the type have run.
+func (s *callbackRunner) setCallbacksDoneFn(rType statesinformer.RegisterType, fn func(obj interface{})) {
+	s.callbacksDoneFns[rType] = fn
+}
+
 func (s *callbackRunner) SendCallback(objType statesinformer.RegisterType) {
 	if _, exist := s.callbackChans[objType]; exist {
 		select {
@@ -98,6 +106,9 @@ func (s *callbackRunner) runCallbacks(objType statesinformer.RegisterType, obj i
 		klog.V(5).Infof("start running callback function %v for type %v", c.name, objType.String())
 		c.fn(objType, obj, pods)
 	}
+	if doneFn := s.callbacksDoneFns[objType]; doneFn != nil {
+		doneFn(obj)
+	}
 }
 
 func (s *callbackRunner) Start(stopCh <-chan struct{}) {
@@ -33,6 +33,7 @@ type Config struct {
 	DisableQueryKubeletConfig   bool
 	EnableNodeMetricReport      bool
 	MetricReportInterval        time.Duration // Deprecated
+	NodeSLOStatusReportInterval time.Duration
 }
 
 func NewDefaultConfig() *Config {
@@ -45,6 +46,7 @@ func NewDefaultConfig() *Config {
 		NodeTopologySyncInterval:    3 * time.Second,
 		DisableQueryKubeletConfig:   false,
 		EnableNodeMetricReport:      true,
+		NodeSLOStatusReportInterval: 30 * time.Second,
 	}
 }
 
@@ -58,4 +60,5 @@ func (c *Config) InitFlags(fs *flag.FlagSet) {
 	fs.BoolVar(&c.DisableQueryKubeletConfig, "disable-query-kubelet-config", c.DisableQueryKubeletConfig, "Disables querying the kubelet configuration from kubelet. Flag must be set to true if kubelet-insecure-tls=true is configured")
 	fs.DurationVar(&c.MetricReportInterval, "report-interval", c.MetricReportInterval, "Deprecated since v1.1, use ColocationStrategy.MetricReportIntervalSeconds in config map of slo-controller")
 	fs.BoolVar(&c.EnableNodeMetricReport, "enable-node-metric-report", c.EnableNodeMetricReport, "Enable status update of node metric crd.")
+	fs.DurationVar(&c.NodeSLOStatusReportInterval, "nodeslo-status-report-interval", c.NodeSLOStatusReportInterval, "The interval which Koordlet will report the applied config generation and health status to NodeSLO. Non-positive value disables the report.")
 }
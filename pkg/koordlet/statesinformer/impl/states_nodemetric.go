@@ -45,6 +45,7 @@ import (
 	clientset "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned"
 	clientsetv1alpha1 "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/typed/slo/v1alpha1"
 	listerv1alpha1 "github.com/koordinator-sh/koordinator/pkg/client/listers/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/features"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/metrics"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/prediction"
@@ -71,6 +72,9 @@ const (
 var (
 	scheme = runtime.NewScheme()
 
+	// hugePageSizes are the huge page sizes whose usage are reported in the node metric.
+	hugePageSizes = []string{"2Mi", "1Gi"}
+
 	defaultNodeMetricSpec = slov1alpha1.NodeMetricSpec{
 		CollectPolicy: &slov1alpha1.NodeMetricCollectPolicy{
 			AggregateDurationSeconds: pointer.Int64(defaultAggregateDurationSeconds),
@@ -342,6 +346,7 @@ func (r *nodeMetricInformer) collectMetric() (*slov1alpha1.NodeMetricInfo, []*sl
 
 	podsMeta := r.podsInformer.GetAllPods()
 	podsMetricInfo := make([]*slov1alpha1.PodMetricInfo, 0, len(podsMeta))
+	collectedPodsMeta := make([]*statesinformer.PodMeta, 0, len(podsMeta))
 	podQueryParam := metriccache.QueryParam{
 		Aggregate: metriccache.AggregationTypeAVG,
 		Start:     &startTime,
@@ -365,7 +370,11 @@ func (r *nodeMetricInformer) collectMetric() (*slov1alpha1.NodeMetricInfo, []*sl
 			r.fillGPUMetrics(podQueryParam, podMetric, string(podMeta.Pod.UID), gpus)
 		}
 		podsMetricInfo = append(podsMetricInfo, podMetric)
+		collectedPodsMeta = append(collectedPodsMeta, podMeta)
 	}
+	r.fillPodMetricsByPolicy(podQueryParam, spec.CollectPolicy.PodMetricPolicy, collectedPodsMeta, podsMetricInfo, gpus)
+	r.fillPodAggregatedUsages(endTime, spec.CollectPolicy.PodMetricPolicy, spec.CollectPolicy.NodeAggregatePolicy, collectedPodsMeta, podsMetricInfo)
+
 	prodReclaimable := &slov1alpha1.ReclaimableMetric{}
 	if p, err := prodPredictor.GetResult(); err != nil {
 		klog.Errorf("failed to get prediction, err %v", err)
@@ -402,6 +411,11 @@ func (r *nodeMetricInformer) queryNodeMetric(start time.Time, end time.Time, agg
 	}
 
 	rm.ResourceList = cpuAndMem
+	if features.DefaultKoordletFeatureGate.Enabled(features.BatchStorageResource) {
+		for resourceName, quantity := range r.collectNodeStorageMetric(queryParam) {
+			rm.ResourceList[resourceName] = quantity
+		}
+	}
 
 	value, exist := r.metricCache.Get(koordletutil.GPUDeviceType)
 	if !exist {
@@ -460,6 +474,38 @@ func (r *nodeMetricInformer) collectNodeMetric(queryparam metriccache.QueryParam
 	return rl, cpuAggregateResult.TimeRangeDuration(), nil
 }
 
+// collectNodeStorageMetric collects the node ephemeral-storage and hugepages usage. The resource whose usage is not
+// collected is ignored, since the storage metrics are optional for the node.
+func (r *nodeMetricInformer) collectNodeStorageMetric(queryparam metriccache.QueryParam) corev1.ResourceList {
+	rl := corev1.ResourceList{}
+	querier, err := r.metricCache.Querier(*queryparam.Start, *queryparam.End)
+	if err != nil {
+		klog.V(5).Infof("get node storage metric querier failed, error %v", err)
+		return rl
+	}
+
+	storageAggregateResult, err := doQuery(querier, metriccache.NodeEphemeralStorageUsageMetric, nil)
+	if err == nil {
+		if storageUsed, err := storageAggregateResult.Value(queryparam.Aggregate); err == nil {
+			rl[corev1.ResourceEphemeralStorage] = *resource.NewQuantity(int64(storageUsed), resource.BinarySI)
+		}
+	}
+
+	for _, pageSize := range hugePageSizes {
+		hugePagesAggregateResult, err := doQuery(querier, metriccache.NodeHugePagesUsageMetric, metriccache.MetricPropertiesFunc.HugePage(pageSize))
+		if err != nil {
+			continue
+		}
+		hugePagesUsed, err := hugePagesAggregateResult.Value(queryparam.Aggregate)
+		if err != nil {
+			continue
+		}
+		rl[corev1.ResourceName(corev1.ResourceHugePagesPrefix+pageSize)] = *resource.NewQuantity(int64(hugePagesUsed), resource.BinarySI)
+	}
+	klog.V(6).Infof("collect node storage metric finished, %v", rl)
+	return rl
+}
+
 func (r *nodeMetricInformer) collectNodeGPUMetric(queryparam metriccache.QueryParam, gpus koordletutil.GPUDevices) ([]schedulingv1alpha1.DeviceInfo, error) {
 	result := make([]schedulingv1alpha1.DeviceInfo, 0)
 	querier, err := r.metricCache.Querier(*queryparam.Start, *queryparam.End)
@@ -652,33 +698,41 @@ func (r *nodeMetricInformer) collectPodMetric(podMeta *statesinformer.PodMeta, q
 }
 
 func (r *nodeMetricInformer) collectPodGPUMetric(queryparam metriccache.QueryParam, uid string, gpus koordletutil.GPUDevices) ([]schedulingv1alpha1.DeviceInfo, error) {
-	result := make([]schedulingv1alpha1.DeviceInfo, 0)
 	querier, err := r.metricCache.Querier(*queryparam.Start, *queryparam.End)
 	if err != nil {
 		klog.V(5).Infof("get pod gpu metric querier failed, error %v", err)
 		return nil, err
 	}
+	return collectGPUMetric(querier, queryparam.Aggregate, metriccache.PodGPUCoreUsageMetric, metriccache.PodGPUMemUsageMetric,
+		func(minor, uuid string) map[metriccache.MetricProperty]string {
+			return metriccache.MetricPropertiesFunc.PodGPU(uid, minor, uuid)
+		}, gpus)
+}
+
+func collectGPUMetric(querier metriccache.Querier, aggregate metriccache.AggregationType, coreResource, memResource metriccache.MetricResource,
+	propertiesFn func(minor, uuid string) map[metriccache.MetricProperty]string, gpus koordletutil.GPUDevices) ([]schedulingv1alpha1.DeviceInfo, error) {
+	result := make([]schedulingv1alpha1.DeviceInfo, 0)
 	for _, gpu := range gpus {
-		properties := metriccache.MetricPropertiesFunc.PodGPU(uid, fmt.Sprintf("%d", gpu.Minor), gpu.UUID)
-		gpuCoreUsageAggregateResult, err := doQuery(querier, metriccache.PodGPUCoreUsageMetric, properties)
+		properties := propertiesFn(fmt.Sprintf("%d", gpu.Minor), gpu.UUID)
+		gpuCoreUsageAggregateResult, err := doQuery(querier, coreResource, properties)
 		if err != nil {
 			return result, err
 		}
 		if gpuCoreUsageAggregateResult.Count() == 0 {
 			continue
 		}
-		coreUsage, err := gpuCoreUsageAggregateResult.Value(queryparam.Aggregate)
+		coreUsage, err := gpuCoreUsageAggregateResult.Value(aggregate)
 		if err != nil {
 			return result, err
 		}
-		gpuMemUsedAggregateResult, err := doQuery(querier, metriccache.PodGPUMemUsageMetric, properties)
+		gpuMemUsedAggregateResult, err := doQuery(querier, memResource, properties)
 		if err != nil {
 			return result, err
 		}
 		if gpuMemUsedAggregateResult.Count() == 0 {
 			continue
 		}
-		memUsage, err := gpuMemUsedAggregateResult.Value(queryparam.Aggregate)
+		memUsage, err := gpuMemUsedAggregateResult.Value(aggregate)
 		if err != nil {
 			return result, err
 		}
@@ -0,0 +1,328 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impl
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/klog/v2"
+
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/statesinformer"
+	koordletutil "github.com/koordinator-sh/koordinator/pkg/koordlet/util"
+)
+
+const (
+	// defaultMaxPodsWithExtraMetrics is the default number of pods reported with the container metrics and the extra
+	// metric families when the PodMetricPolicy does not specify.
+	defaultMaxPodsWithExtraMetrics = 50
+	// defaultMaxContainersPerPod is the default number of containers reported for each pod when the PodMetricPolicy
+	// does not specify.
+	defaultMaxContainersPerPod = 10
+)
+
+// fillPodMetricsByPolicy fills the container metrics and the extra metric families of the pods according to the
+// PodMetricPolicy. To bound the size of the NodeMetric, only the pods with the highest cpu usage are filled.
+// The podsMeta and podsMetric must be in the same order.
+func (r *nodeMetricInformer) fillPodMetricsByPolicy(queryParam metriccache.QueryParam, policy *slov1alpha1.PodMetricPolicy,
+	podsMeta []*statesinformer.PodMeta, podsMetric []*slov1alpha1.PodMetricInfo, gpus koordletutil.GPUDevices) {
+	if policy == nil || len(podsMetric) <= 0 {
+		return
+	}
+	enableContainerMetrics := policy.EnableContainerMetrics != nil && *policy.EnableContainerMetrics
+	families := map[slov1alpha1.PodMetricFamily]bool{}
+	for _, family := range policy.MetricFamilies {
+		families[family] = true
+	}
+	if !enableContainerMetrics && !families[slov1alpha1.PodMetricFamilyCPUThrottled] &&
+		!families[slov1alpha1.PodMetricFamilyPSI] && !families[slov1alpha1.PodMetricFamilyCPI] {
+		return
+	}
+	maxPods := int64(defaultMaxPodsWithExtraMetrics)
+	if policy.MaxPods != nil {
+		maxPods = *policy.MaxPods
+	}
+	maxContainers := int64(defaultMaxContainersPerPod)
+	if policy.MaxContainersPerPod != nil {
+		maxContainers = *policy.MaxContainersPerPod
+	}
+
+	querier, err := r.metricCache.Querier(*queryParam.Start, *queryParam.End)
+	if err != nil {
+		klog.V(4).Infof("failed to get querier for pod metric policy, error %v", err)
+		return
+	}
+
+	// prefer the pods with higher cpu usage
+	indexes := make([]int, len(podsMetric))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		cpuI := podsMetric[indexes[i]].PodUsage.ResourceList.Cpu()
+		cpuJ := podsMetric[indexes[j]].PodUsage.ResourceList.Cpu()
+		return cpuI.Cmp(*cpuJ) > 0
+	})
+	for i, idx := range indexes {
+		if int64(i) >= maxPods {
+			break
+		}
+		pod, podMetric := podsMeta[idx].Pod, podsMetric[idx]
+		podMetric.Interference = queryPodInterferenceMetric(querier, queryParam.Aggregate, pod, families)
+		if enableContainerMetrics {
+			podMetric.ContainersMetric = queryContainersMetric(querier, queryParam.Aggregate, pod, families, maxContainers, gpus)
+		}
+	}
+}
+
+// queryPodInterferenceMetric returns the interference signals of the enabled metric families for the pod,
+// or nil if none of them has samples. The query failures are ignored since the extra metric families are optional.
+func queryPodInterferenceMetric(querier metriccache.Querier, aggregate metriccache.AggregationType, pod *corev1.Pod,
+	families map[slov1alpha1.PodMetricFamily]bool) *slov1alpha1.PodInterferenceMetric {
+	podUID := string(pod.UID)
+	metric := &slov1alpha1.PodInterferenceMetric{}
+	reported := false
+	if families[slov1alpha1.PodMetricFamilyCPUThrottled] {
+		if ratio, ok := queryMetricValue(querier, aggregate, metriccache.PodCPUThrottledMetric, metriccache.MetricPropertiesFunc.Pod(podUID)); ok {
+			metric.CPUThrottledRatio = newRatioQuantity(ratio)
+			reported = true
+		}
+	}
+	if families[slov1alpha1.PodMetricFamilyPSI] {
+		psi, ok := queryPSIMetric(querier, aggregate, metriccache.PodPSIMetric, func(psiResource, psiDegree string) map[metriccache.MetricProperty]string {
+			return metriccache.MetricPropertiesFunc.PodPSI(podUID, psiResource, string(metriccache.PSIPrecision10), psiDegree)
+		})
+		if ok {
+			metric.PSI = psi
+			reported = true
+		}
+	}
+	if families[slov1alpha1.PodMetricFamilyCPI] {
+		var podCycles, podInstructions float64
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			cycles, instructions, ok := queryContainerCPI(querier, aggregate, podUID, containerStatus.ContainerID)
+			if ok {
+				podCycles += cycles
+				podInstructions += instructions
+			}
+		}
+		if podInstructions > 0 {
+			metric.CPI = newRatioQuantity(podCycles / podInstructions)
+			reported = true
+		}
+	}
+	if !reported {
+		return nil
+	}
+	return metric
+}
+
+// queryContainersMetric returns the usages and the enabled metric families of at most maxContainers containers of the pod.
+func queryContainersMetric(querier metriccache.Querier, aggregate metriccache.AggregationType, pod *corev1.Pod,
+	families map[slov1alpha1.PodMetricFamily]bool, maxContainers int64, gpus koordletutil.GPUDevices) []*slov1alpha1.ContainerMetricInfo {
+	podUID := string(pod.UID)
+	var containersMetric []*slov1alpha1.ContainerMetricInfo
+	for i := range pod.Status.ContainerStatuses {
+		if int64(len(containersMetric)) >= maxContainers {
+			break
+		}
+		containerStatus := &pod.Status.ContainerStatuses[i]
+		containerID := containerStatus.ContainerID
+		if containerID == "" {
+			continue
+		}
+		cpuUsed, cpuOK := queryMetricValue(querier, aggregate, metriccache.ContainerCPUUsageMetric, metriccache.MetricPropertiesFunc.Container(containerID))
+		memUsed, memOK := queryMetricValue(querier, aggregate, metriccache.ContainerMemUsageMetric, metriccache.MetricPropertiesFunc.Container(containerID))
+		if !cpuOK || !memOK {
+			klog.V(5).Infof("skip container %s/%s/%s without usage metrics", pod.Namespace, pod.Name, containerStatus.Name)
+			continue
+		}
+		containerMetric := &slov1alpha1.ContainerMetricInfo{
+			Name: containerStatus.Name,
+			ContainerUsage: slov1alpha1.ResourceMap{
+				ResourceList: corev1.ResourceList{
+					corev1.ResourceCPU:    *resource.NewMilliQuantity(int64(cpuUsed*1000), resource.DecimalSI),
+					corev1.ResourceMemory: *resource.NewQuantity(int64(memUsed), resource.BinarySI),
+				},
+			},
+		}
+
+		if families[slov1alpha1.PodMetricFamilyGPU] && len(gpus) > 0 {
+			devices, err := collectGPUMetric(querier, aggregate, metriccache.ContainerGPUCoreUsageMetric, metriccache.ContainerGPUMemUsageMetric,
+				func(minor, uuid string) map[metriccache.MetricProperty]string {
+					return metriccache.MetricPropertiesFunc.ContainerGPU(containerID, minor, uuid)
+				}, gpus)
+			if err != nil {
+				klog.V(5).Infof("failed to collect gpu metric for container %s/%s/%s, error %v", pod.Namespace, pod.Name, containerStatus.Name, err)
+			} else if len(devices) > 0 {
+				containerMetric.ContainerUsage.Devices = devices
+			}
+		}
+
+		interference := &slov1alpha1.PodInterferenceMetric{}
+		reported := false
+		if families[slov1alpha1.PodMetricFamilyCPUThrottled] {
+			if ratio, ok := queryMetricValue(querier, aggregate, metriccache.ContainerCPUThrottledMetric, metriccache.MetricPropertiesFunc.Container(containerID)); ok {
+				interference.CPUThrottledRatio = newRatioQuantity(ratio)
+				reported = true
+			}
+		}
+		if families[slov1alpha1.PodMetricFamilyPSI] {
+			psi, ok := queryPSIMetric(querier, aggregate, metriccache.ContainerPSIMetric, func(psiResource, psiDegree string) map[metriccache.MetricProperty]string {
+				return metriccache.MetricPropertiesFunc.ContainerPSI(podUID, containerID, psiResource, string(metriccache.PSIPrecision10), psiDegree)
+			})
+			if ok {
+				interference.PSI = psi
+				reported = true
+			}
+		}
+		if families[slov1alpha1.PodMetricFamilyCPI] {
+			if cycles, instructions, ok := queryContainerCPI(querier, aggregate, podUID, containerID); ok && instructions > 0 {
+				interference.CPI = newRatioQuantity(cycles / instructions)
+				reported = true
+			}
+		}
+		if reported {
+			containerMetric.Interference = interference
+		}
+
+		containersMetric = append(containersMetric, containerMetric)
+	}
+	return containersMetric
+}
+
+// queryPSIMetric returns the avg10 pressure of cpu, memory and io. It reports false if none of them has samples.
+func queryPSIMetric(querier metriccache.Querier, aggregate metriccache.AggregationType, psiResource metriccache.MetricResource,
+	propertiesFn func(psiResource, psiDegree string) map[metriccache.MetricProperty]string) (*slov1alpha1.PodPSIMetric, bool) {
+	psi := &slov1alpha1.PodPSIMetric{}
+	reported := false
+	for _, r := range []struct {
+		resource metriccache.MetricPropertyValue
+		stats    *slov1alpha1.PSIStats
+	}{
+		{resource: metriccache.PSIResourceCPU, stats: &psi.CPU},
+		{resource: metriccache.PSIResourceMem, stats: &psi.Memory},
+		{resource: metriccache.PSIResourceIO, stats: &psi.IO},
+	} {
+		if some, ok := queryMetricValue(querier, aggregate, psiResource, propertiesFn(string(r.resource), string(metriccache.PSIDegreeSome))); ok {
+			r.stats.Some = newRatioQuantity(some)
+			reported = true
+		}
+		if full, ok := queryMetricValue(querier, aggregate, psiResource, propertiesFn(string(r.resource), string(metriccache.PSIDegreeFull))); ok {
+			r.stats.Full = newRatioQuantity(full)
+			reported = true
+		}
+	}
+	return psi, reported
+}
+
+func queryContainerCPI(querier metriccache.Querier, aggregate metriccache.AggregationType, podUID, containerID string) (float64, float64, bool) {
+	if containerID == "" {
+		return 0, 0, false
+	}
+	cycles, ok := queryMetricValue(querier, aggregate, metriccache.ContainerCPI,
+		metriccache.MetricPropertiesFunc.ContainerCPI(podUID, containerID, string(metriccache.CPIResourceCycle)))
+	if !ok {
+		return 0, 0, false
+	}
+	instructions, ok := queryMetricValue(querier, aggregate, metriccache.ContainerCPI,
+		metriccache.MetricPropertiesFunc.ContainerCPI(podUID, containerID, string(metriccache.CPIResourceInstruction)))
+	if !ok {
+		return 0, 0, false
+	}
+	return cycles, instructions, true
+}
+
+// newRatioQuantity converts the ratio value to a quantity in the milli precision.
+func newRatioQuantity(value float64) *resource.Quantity {
+	return resource.NewMilliQuantity(int64(math.Round(value*1000)), resource.DecimalSI)
+}
+
+// queryMetricValue returns the aggregated value of the metric. It reports false if the query fails or has no samples.
+func queryMetricValue(querier metriccache.Querier, aggregate metriccache.AggregationType, metricResource metriccache.MetricResource,
+	properties map[metriccache.MetricProperty]string) (float64, bool) {
+	result, err := doQuery(querier, metricResource, properties)
+	if err != nil || result.Count() <= 0 {
+		return 0, false
+	}
+	value, err := result.Value(aggregate)
+	if err != nil {
+		return 0, false
+	}
+	return value, true
+}
+
+var podAggregationTypes = map[apiext.AggregationType]metriccache.AggregationType{
+	apiext.P50: metriccache.AggregationTypeP50,
+	apiext.P90: metriccache.AggregationTypeP90,
+	apiext.P95: metriccache.AggregationTypeP95,
+	apiext.P99: metriccache.AggregationTypeP99,
+}
+
+// fillPodAggregatedUsages fills the aggregated usages of all pods in the durations of the NodeAggregatePolicy if enabled
+// by the PodMetricPolicy, so that the consumers can compare the pod usages with the aggregated node usages of the same
+// window. Like the node, the durations in the cold start are not reported.
+func (r *nodeMetricInformer) fillPodAggregatedUsages(endTime time.Time, policy *slov1alpha1.PodMetricPolicy,
+	aggregatePolicy *slov1alpha1.AggregatePolicy, podsMeta []*statesinformer.PodMeta, podsMetric []*slov1alpha1.PodMetricInfo) {
+	if policy == nil || policy.EnableAggregatedUsages == nil || !*policy.EnableAggregatedUsages ||
+		aggregatePolicy == nil || len(podsMetric) <= 0 {
+		return
+	}
+	for _, d := range aggregatePolicy.Durations {
+		start := endTime.Add(-d.Duration)
+		querier, err := r.metricCache.Querier(start, endTime)
+		if err != nil {
+			klog.V(4).Infof("failed to get querier for pod aggregated usages, duration %v, error %v", d.Duration, err)
+			continue
+		}
+		for i, podMeta := range podsMeta {
+			podUID := string(podMeta.Pod.UID)
+			cpuResult, err := doQuery(querier, metriccache.PodCPUUsageMetric, metriccache.MetricPropertiesFunc.Pod(podUID))
+			if err != nil || cpuResult.Count() <= 0 || metricsInColdStart(start, endTime, cpuResult.TimeRangeDuration()) {
+				continue
+			}
+			memResult, err := doQuery(querier, metriccache.PodMemUsageMetric, metriccache.MetricPropertiesFunc.Pod(podUID))
+			if err != nil || memResult.Count() <= 0 {
+				continue
+			}
+			aggregatedUsage := slov1alpha1.AggregatedUsage{
+				Usage:    map[apiext.AggregationType]slov1alpha1.ResourceMap{},
+				Duration: d,
+			}
+			for aggregationType, aggregate := range podAggregationTypes {
+				cpuUsed, cpuErr := cpuResult.Value(aggregate)
+				memUsed, memErr := memResult.Value(aggregate)
+				if cpuErr != nil || memErr != nil {
+					continue
+				}
+				aggregatedUsage.Usage[aggregationType] = slov1alpha1.ResourceMap{
+					ResourceList: corev1.ResourceList{
+						corev1.ResourceCPU:    *resource.NewMilliQuantity(int64(cpuUsed*1000), resource.DecimalSI),
+						corev1.ResourceMemory: *resource.NewQuantity(int64(memUsed), resource.BinarySI),
+					},
+				}
+			}
+			podsMetric[i].AggregatedPodUsages = append(podsMetric[i].AggregatedPodUsages, aggregatedUsage)
+		}
+	}
+}
@@ -0,0 +1,304 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impl
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache"
+	mockmetriccache "github.com/koordinator-sh/koordinator/pkg/koordlet/metriccache/mockmetriccache"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/statesinformer"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/util"
+)
+
+func Test_nodeMetricInformer_fillPodMetricsByPolicy(t *testing.T) {
+	end := time.Now()
+	start := end.Add(-defaultAggregateDurationSeconds * time.Second)
+	gpus := util.GPUDevices{
+		{UUID: "gpu-0", Minor: 0, MemoryTotal: 100},
+	}
+	newPodsMeta := func() []*statesinformer.PodMeta {
+		return []*statesinformer.PodMeta{
+			{
+				Pod: &v1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: "pod-small", Namespace: "default", UID: "uid-small"},
+					Status: v1.PodStatus{
+						ContainerStatuses: []v1.ContainerStatus{
+							{Name: "main", ContainerID: "containerd://small-main"},
+						},
+					},
+				},
+			},
+			{
+				Pod: &v1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: "pod-large", Namespace: "default", UID: "uid-large"},
+					Status: v1.PodStatus{
+						ContainerStatuses: []v1.ContainerStatus{
+							{Name: "main", ContainerID: "containerd://large-main"},
+							{Name: "sidecar", ContainerID: "containerd://large-sidecar"},
+						},
+					},
+				},
+			},
+		}
+	}
+	newPodsMetric := func() []*slov1alpha1.PodMetricInfo {
+		return []*slov1alpha1.PodMetricInfo{
+			{
+				Name:      "pod-small",
+				Namespace: "default",
+				PodUsage: slov1alpha1.ResourceMap{
+					ResourceList: v1.ResourceList{
+						v1.ResourceCPU:    *resource.NewMilliQuantity(500, resource.DecimalSI),
+						v1.ResourceMemory: *resource.NewQuantity(1024*1024*1024, resource.BinarySI),
+					},
+				},
+			},
+			{
+				Name:      "pod-large",
+				Namespace: "default",
+				PodUsage: slov1alpha1.ResourceMap{
+					ResourceList: v1.ResourceList{
+						v1.ResourceCPU:    *resource.NewMilliQuantity(2000, resource.DecimalSI),
+						v1.ResourceMemory: *resource.NewQuantity(2*1024*1024*1024, resource.BinarySI),
+					},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name                 string
+		policy               *slov1alpha1.PodMetricPolicy
+		wantPodInterference  []*slov1alpha1.PodInterferenceMetric
+		wantContainersMetric [][]*slov1alpha1.ContainerMetricInfo
+	}{
+		{
+			name:                 "no policy",
+			policy:               nil,
+			wantPodInterference:  []*slov1alpha1.PodInterferenceMetric{nil, nil},
+			wantContainersMetric: [][]*slov1alpha1.ContainerMetricInfo{nil, nil},
+		},
+		{
+			name: "policy without families and container metrics",
+			policy: &slov1alpha1.PodMetricPolicy{
+				EnableContainerMetrics: pointer.Bool(false),
+			},
+			wantPodInterference:  []*slov1alpha1.PodInterferenceMetric{nil, nil},
+			wantContainersMetric: [][]*slov1alpha1.ContainerMetricInfo{nil, nil},
+		},
+		{
+			name: "report extra families of the pod with the highest cpu usage",
+			policy: &slov1alpha1.PodMetricPolicy{
+				MetricFamilies: []slov1alpha1.PodMetricFamily{
+					slov1alpha1.PodMetricFamilyCPUThrottled,
+					slov1alpha1.PodMetricFamilyPSI,
+					slov1alpha1.PodMetricFamilyCPI,
+				},
+				MaxPods: pointer.Int64(1),
+			},
+			wantPodInterference: []*slov1alpha1.PodInterferenceMetric{
+				nil,
+				{
+					CPUThrottledRatio: resource.NewMilliQuantity(500, resource.DecimalSI),
+					PSI: &slov1alpha1.PodPSIMetric{
+						CPU: slov1alpha1.PSIStats{
+							Some: resource.NewMilliQuantity(10000, resource.DecimalSI),
+							Full: resource.NewMilliQuantity(5000, resource.DecimalSI),
+						},
+					},
+					CPI: resource.NewMilliQuantity(2000, resource.DecimalSI),
+				},
+			},
+			wantContainersMetric: [][]*slov1alpha1.ContainerMetricInfo{nil, nil},
+		},
+		{
+			name: "report container metrics with limited containers",
+			policy: &slov1alpha1.PodMetricPolicy{
+				EnableContainerMetrics: pointer.Bool(true),
+				MetricFamilies: []slov1alpha1.PodMetricFamily{
+					slov1alpha1.PodMetricFamilyCPI,
+					slov1alpha1.PodMetricFamilyGPU,
+				},
+				MaxPods:             pointer.Int64(1),
+				MaxContainersPerPod: pointer.Int64(1),
+			},
+			wantPodInterference: []*slov1alpha1.PodInterferenceMetric{
+				nil,
+				{
+					CPI: resource.NewMilliQuantity(2000, resource.DecimalSI),
+				},
+			},
+			wantContainersMetric: [][]*slov1alpha1.ContainerMetricInfo{
+				nil,
+				{
+					{
+						Name: "main",
+						ContainerUsage: slov1alpha1.ResourceMap{
+							ResourceList: v1.ResourceList{
+								v1.ResourceCPU:    *resource.NewMilliQuantity(1500, resource.DecimalSI),
+								v1.ResourceMemory: *resource.NewQuantity(1024*1024*1024, resource.BinarySI),
+							},
+							Devices: []schedulingv1alpha1.DeviceInfo{
+								{
+									UUID:  "gpu-0",
+									Minor: pointer.Int32(0),
+									Type:  schedulingv1alpha1.GPU,
+									Resources: map[v1.ResourceName]resource.Quantity{
+										apiext.ResourceGPUCore:        *resource.NewQuantity(50, resource.DecimalSI),
+										apiext.ResourceGPUMemory:      *resource.NewQuantity(20, resource.BinarySI),
+										apiext.ResourceGPUMemoryRatio: *resource.NewQuantity(20, resource.DecimalSI),
+									},
+								},
+							},
+						},
+						Interference: &slov1alpha1.PodInterferenceMetric{
+							CPI: resource.NewMilliQuantity(3000, resource.DecimalSI),
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockMetricCache := mockmetriccache.NewMockMetricCache(ctrl)
+			mockResultFactory := mockmetriccache.NewMockAggregateResultFactory(ctrl)
+			oldFactory := metriccache.DefaultAggregateResultFactory
+			metriccache.DefaultAggregateResultFactory = mockResultFactory
+			defer func() {
+				metriccache.DefaultAggregateResultFactory = oldFactory
+			}()
+			mockQuerier := mockmetriccache.NewMockQuerier(ctrl)
+			mockMetricCache.EXPECT().Querier(gomock.Any(), gomock.Any()).Return(mockQuerier, nil).AnyTimes()
+
+			buildQueryResult := func(metricResource metriccache.MetricResource, properties map[metriccache.MetricProperty]string, value float64) {
+				queryMeta, err := metricResource.BuildQueryMeta(properties)
+				assert.NoError(t, err)
+				buildMockQueryResult(ctrl, mockQuerier, mockResultFactory, queryMeta, value, end.Sub(start))
+			}
+			buildQueryResult(metriccache.PodCPUThrottledMetric, metriccache.MetricPropertiesFunc.Pod("uid-large"), 0.5)
+			buildQueryResult(metriccache.PodPSIMetric, metriccache.MetricPropertiesFunc.PodPSI("uid-large",
+				string(metriccache.PSIResourceCPU), string(metriccache.PSIPrecision10), string(metriccache.PSIDegreeSome)), 10)
+			buildQueryResult(metriccache.PodPSIMetric, metriccache.MetricPropertiesFunc.PodPSI("uid-large",
+				string(metriccache.PSIResourceCPU), string(metriccache.PSIPrecision10), string(metriccache.PSIDegreeFull)), 5)
+			buildQueryResult(metriccache.ContainerCPI, metriccache.MetricPropertiesFunc.ContainerCPI("uid-large",
+				"containerd://large-main", string(metriccache.CPIResourceCycle)), 300)
+			buildQueryResult(metriccache.ContainerCPI, metriccache.MetricPropertiesFunc.ContainerCPI("uid-large",
+				"containerd://large-main", string(metriccache.CPIResourceInstruction)), 100)
+			buildQueryResult(metriccache.ContainerCPI, metriccache.MetricPropertiesFunc.ContainerCPI("uid-large",
+				"containerd://large-sidecar", string(metriccache.CPIResourceCycle)), 100)
+			buildQueryResult(metriccache.ContainerCPI, metriccache.MetricPropertiesFunc.ContainerCPI("uid-large",
+				"containerd://large-sidecar", string(metriccache.CPIResourceInstruction)), 100)
+			buildQueryResult(metriccache.ContainerCPUUsageMetric, metriccache.MetricPropertiesFunc.Container("containerd://large-main"), 1.5)
+			buildQueryResult(metriccache.ContainerMemUsageMetric, metriccache.MetricPropertiesFunc.Container("containerd://large-main"), 1024*1024*1024)
+			buildQueryResult(metriccache.ContainerGPUCoreUsageMetric, metriccache.MetricPropertiesFunc.ContainerGPU("containerd://large-main", "0", "gpu-0"), 50)
+			buildQueryResult(metriccache.ContainerGPUMemUsageMetric, metriccache.MetricPropertiesFunc.ContainerGPU("containerd://large-main", "0", "gpu-0"), 20)
+			// the other metrics have no samples
+			emptyResult := mockmetriccache.NewMockAggregateResult(ctrl)
+			mockResultFactory.EXPECT().New(gomock.Any()).Return(emptyResult).AnyTimes()
+			mockQuerier.EXPECT().Query(gomock.Any(), gomock.Any(), emptyResult).Return(fmt.Errorf("no samples")).AnyTimes()
+
+			r := &nodeMetricInformer{metricCache: mockMetricCache}
+			podsMetric := newPodsMetric()
+			r.fillPodMetricsByPolicy(metriccache.QueryParam{
+				Start:     &start,
+				End:       &end,
+				Aggregate: metriccache.AggregationTypeAVG,
+			}, tt.policy, newPodsMeta(), podsMetric, gpus)
+
+			for i, podMetric := range podsMetric {
+				assert.Equal(t, tt.wantPodInterference[i], podMetric.Interference, podMetric.Name)
+				assert.Equal(t, tt.wantContainersMetric[i], podMetric.ContainersMetric, podMetric.Name)
+			}
+		})
+	}
+}
+
+func Test_nodeMetricInformer_fillPodAggregatedUsages(t *testing.T) {
+	end := time.Now()
+	durations := []metav1.Duration{{Duration: 5 * time.Minute}}
+	tests := []struct {
+		name      string
+		policy    *slov1alpha1.PodMetricPolicy
+		wantUsage []slov1alpha1.AggregatedUsage
+	}{
+		{
+			name:   "disabled by policy",
+			policy: &slov1alpha1.PodMetricPolicy{},
+		},
+		{
+			name:   "report aggregated usages",
+			policy: &slov1alpha1.PodMetricPolicy{EnableAggregatedUsages: pointer.Bool(true)},
+			wantUsage: []slov1alpha1.AggregatedUsage{
+				{
+					Usage: map[apiext.AggregationType]slov1alpha1.ResourceMap{
+						apiext.P50: {ResourceList: v1.ResourceList{v1.ResourceCPU: *resource.NewMilliQuantity(1500, resource.DecimalSI), v1.ResourceMemory: *resource.NewQuantity(1024, resource.BinarySI)}},
+						apiext.P90: {ResourceList: v1.ResourceList{v1.ResourceCPU: *resource.NewMilliQuantity(1500, resource.DecimalSI), v1.ResourceMemory: *resource.NewQuantity(1024, resource.BinarySI)}},
+						apiext.P95: {ResourceList: v1.ResourceList{v1.ResourceCPU: *resource.NewMilliQuantity(1500, resource.DecimalSI), v1.ResourceMemory: *resource.NewQuantity(1024, resource.BinarySI)}},
+						apiext.P99: {ResourceList: v1.ResourceList{v1.ResourceCPU: *resource.NewMilliQuantity(1500, resource.DecimalSI), v1.ResourceMemory: *resource.NewQuantity(1024, resource.BinarySI)}},
+					},
+					Duration: durations[0],
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockMetricCache := mockmetriccache.NewMockMetricCache(ctrl)
+			mockResultFactory := mockmetriccache.NewMockAggregateResultFactory(ctrl)
+			oldFactory := metriccache.DefaultAggregateResultFactory
+			metriccache.DefaultAggregateResultFactory = mockResultFactory
+			defer func() {
+				metriccache.DefaultAggregateResultFactory = oldFactory
+			}()
+			mockQuerier := mockmetriccache.NewMockQuerier(ctrl)
+			mockMetricCache.EXPECT().Querier(gomock.Any(), gomock.Any()).Return(mockQuerier, nil).AnyTimes()
+
+			buildQueryResult := func(metricResource metriccache.MetricResource, properties map[metriccache.MetricProperty]string, value float64) {
+				queryMeta, err := metricResource.BuildQueryMeta(properties)
+				assert.NoError(t, err)
+				buildMockQueryResult(ctrl, mockQuerier, mockResultFactory, queryMeta, value, durations[0].Duration)
+			}
+			buildQueryResult(metriccache.PodCPUUsageMetric, metriccache.MetricPropertiesFunc.Pod("uid-test"), 1.5)
+			buildQueryResult(metriccache.PodMemUsageMetric, metriccache.MetricPropertiesFunc.Pod("uid-test"), 1024)
+
+			r := &nodeMetricInformer{metricCache: mockMetricCache}
+			podsMeta := []*statesinformer.PodMeta{
+				{Pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", UID: "uid-test"}}},
+			}
+			podsMetric := []*slov1alpha1.PodMetricInfo{{Name: "test", Namespace: "default"}}
+			r.fillPodAggregatedUsages(end, tt.policy, &slov1alpha1.AggregatePolicy{Durations: durations}, podsMeta, podsMetric)
+			assert.Equal(t, tt.wantUsage, podsMetric[0].AggregatedPodUsages)
+		})
+	}
+}
@@ -891,6 +891,47 @@ func buildMockQueryResult(ctrl *gomock.Controller, querier *mockmetriccache.Mock
 	querier.EXPECT().Query(queryMeta, gomock.Any(), result).SetArg(2, *result).Return(nil).AnyTimes()
 }
 
+func Test_nodeMetricInformer_collectNodeStorageMetric(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	end := time.Now()
+	start := end.Add(-defaultAggregateDurationSeconds * time.Second)
+
+	mockMetricCache := mockmetriccache.NewMockMetricCache(ctrl)
+	mockResultFactory := mockmetriccache.NewMockAggregateResultFactory(ctrl)
+	oldFactory := metriccache.DefaultAggregateResultFactory
+	metriccache.DefaultAggregateResultFactory = mockResultFactory
+	defer func() {
+		metriccache.DefaultAggregateResultFactory = oldFactory
+	}()
+	mockQuerier := mockmetriccache.NewMockQuerier(ctrl)
+	mockMetricCache.EXPECT().Querier(gomock.Any(), gomock.Any()).Return(mockQuerier, nil).AnyTimes()
+
+	storageQueryMeta, err := metriccache.NodeEphemeralStorageUsageMetric.BuildQueryMeta(nil)
+	assert.NoError(t, err)
+	buildMockQueryResult(ctrl, mockQuerier, mockResultFactory, storageQueryMeta, 100*1024*1024*1024, end.Sub(start))
+	hugePages2MiQueryMeta, err := metriccache.NodeHugePagesUsageMetric.BuildQueryMeta(metriccache.MetricPropertiesFunc.HugePage("2Mi"))
+	assert.NoError(t, err)
+	buildMockQueryResult(ctrl, mockQuerier, mockResultFactory, hugePages2MiQueryMeta, 1024*1024*1024, end.Sub(start))
+	hugePages1GiQueryMeta, err := metriccache.NodeHugePagesUsageMetric.BuildQueryMeta(metriccache.MetricPropertiesFunc.HugePage("1Gi"))
+	assert.NoError(t, err)
+	hugePages1GiResult := mockmetriccache.NewMockAggregateResult(ctrl)
+	mockResultFactory.EXPECT().New(hugePages1GiQueryMeta).Return(hugePages1GiResult).AnyTimes()
+	mockQuerier.EXPECT().Query(hugePages1GiQueryMeta, gomock.Any(), hugePages1GiResult).Return(fmt.Errorf("no samples")).AnyTimes()
+
+	r := &nodeMetricInformer{metricCache: mockMetricCache}
+	got := r.collectNodeStorageMetric(metriccache.QueryParam{
+		Start:     &start,
+		End:       &end,
+		Aggregate: metriccache.AggregationTypeAVG,
+	})
+	want := v1.ResourceList{
+		v1.ResourceEphemeralStorage:        *resource.NewQuantity(100*1024*1024*1024, resource.BinarySI),
+		v1.ResourceHugePagesPrefix + "2Mi": *resource.NewQuantity(1024*1024*1024, resource.BinarySI),
+	}
+	assert.Equal(t, want, got)
+}
+
 func Test_nodeMetricInformer_collectSystemAggregateMetric(t *testing.T) {
 	end := time.Now()
 	start := end.Add(-defaultAggregateDurationSeconds * time.Second)
@@ -25,12 +25,15 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apiruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 
 	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
 	koordclientset "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/metrics"
 	"github.com/koordinator-sh/koordinator/pkg/koordlet/statesinformer"
 	"github.com/koordinator-sh/koordinator/pkg/util"
 	"github.com/koordinator-sh/koordinator/pkg/util/sloconfig"
@@ -45,7 +48,21 @@ type nodeSLOInformer struct {
 	nodeSLORWMutex  sync.RWMutex
 	nodeSLO         *slov1alpha1.NodeSLO
 
-	callbackRunner *callbackRunner
+	koordClient          koordclientset.Interface
+	nodeName             string
+	statusReportInterval time.Duration
+	// specGeneration is the generation of the slo-controller config which the current NodeSLO spec is generated from,
+	// and specApplied indicates whether the callbacks of the current spec have run.
+	specGeneration string
+	specApplied    bool
+	// configGeneration is the generation which the koordlet has applied, i.e. the callbacks of its NodeSLO spec
+	// (e.g. the runtime hooks rules) have run. The health counters are reported as the deltas since it is applied.
+	configGeneration     string
+	configGenerationTime metav1.Time
+	podsEvictedBase      int64
+	applyErrorsBase      int64
+	lastReportedStatus   *slov1alpha1.NodeSLOStatus
+	callbackRunner       *callbackRunner
 }
 
 func NewNodeSLOInformer() *nodeSLOInformer {
@@ -59,12 +76,16 @@ func (s *nodeSLOInformer) GetNodeSLO() *slov1alpha1.NodeSLO {
 }
 
 func (s *nodeSLOInformer) Setup(ctx *PluginOption, state *PluginState) {
+	s.koordClient = ctx.KoordClient
+	s.nodeName = ctx.NodeName
+	s.statusReportInterval = ctx.config.NodeSLOStatusReportInterval
 	s.nodeSLOInformer = newNodeSLOInformer(ctx.KoordClient, ctx.NodeName)
 	s.nodeSLOInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			nodeSLO, ok := obj.(*slov1alpha1.NodeSLO)
 			if ok {
 				s.updateNodeSLOSpec(nodeSLO)
+				s.updateConfigGeneration(nodeSLO)
 				klog.Infof("create NodeSLO %v", util.DumpJSON(nodeSLO))
 			} else {
 				klog.Errorf("node slo informer add func parse nodeSLO failed")
@@ -79,18 +100,24 @@ func (s *nodeSLOInformer) Setup(ctx *PluginOption, state *PluginState) {
 			}
 			if reflect.DeepEqual(oldNodeSLO.Spec, newNodeSLO.Spec) {
 				klog.V(5).Infof("find NodeSLO spec %s has not changed", newNodeSLO.Name)
-				return
+			} else {
+				klog.Infof("update NodeSLO spec %v", util.DumpJSON(newNodeSLO.Spec))
+				s.updateNodeSLOSpec(newNodeSLO)
 			}
-			klog.Infof("update NodeSLO spec %v", util.DumpJSON(newNodeSLO.Spec))
-			s.updateNodeSLOSpec(newNodeSLO)
+			// the generation is updated after the spec, so it is applied only when the new spec is applied
+			s.updateConfigGeneration(newNodeSLO)
 		},
 	})
 	s.callbackRunner = state.callbackRunner
+	s.callbackRunner.setCallbacksDoneFn(statesinformer.RegisterTypeNodeSLOSpec, s.onNodeSLOSpecApplied)
 }
 
 func (s *nodeSLOInformer) Start(stopCh <-chan struct{}) {
 	klog.V(2).Infof("starting node slo informer")
 	go s.nodeSLOInformer.Run(stopCh)
+	if s.statusReportInterval > 0 {
+		go wait.Until(s.reportStatus, s.statusReportInterval, stopCh)
+	}
 	klog.V(2).Infof("node slo informer started")
 }
 
@@ -103,6 +130,97 @@ func (s *nodeSLOInformer) HasSynced() bool {
 	return synced
 }
 
+// updateConfigGeneration records the config generation of the NodeSLO spec. The generation is applied at once if
+// the spec has been applied, e.g. only the generation changes, otherwise it is applied after the callbacks of the spec.
+func (s *nodeSLOInformer) updateConfigGeneration(nodeSLO *slov1alpha1.NodeSLO) {
+	generation := nodeSLO.Annotations[slov1alpha1.AnnotationNodeSLOConfigGeneration]
+	s.nodeSLORWMutex.Lock()
+	defer s.nodeSLORWMutex.Unlock()
+	if nodeSLO.Status.ConfigGeneration != s.configGeneration {
+		// report again if the status is lost, e.g. the NodeSLO is recreated
+		s.lastReportedStatus = nil
+	}
+	s.specGeneration = generation
+	if s.specApplied {
+		s.applyConfigGenerationNoLock(generation)
+	}
+}
+
+// onNodeSLOSpecApplied is invoked after the callbacks of the NodeSLO spec have run, and applies the config generation
+// if the spec is not changed during the callbacks.
+func (s *nodeSLOInformer) onNodeSLOSpecApplied(obj interface{}) {
+	spec, ok := obj.(*slov1alpha1.NodeSLOSpec)
+	if !ok {
+		return
+	}
+	s.nodeSLORWMutex.Lock()
+	defer s.nodeSLORWMutex.Unlock()
+	if s.nodeSLO == nil || !reflect.DeepEqual(*spec, s.nodeSLO.Spec) {
+		klog.V(5).Infof("NodeSLO spec changes during the callbacks, wait for the next callbacks")
+		return
+	}
+	s.specApplied = true
+	s.applyConfigGenerationNoLock(s.specGeneration)
+}
+
+// applyConfigGenerationNoLock records the applied config generation, and resets the base of the health counters
+// when a new generation is applied.
+func (s *nodeSLOInformer) applyConfigGenerationNoLock(generation string) {
+	if generation == s.configGeneration {
+		return
+	}
+	klog.V(4).Infof("NodeSLO config generation applied, changed from %s to %s", s.configGeneration, generation)
+	s.configGeneration = generation
+	s.configGenerationTime = metav1.Now()
+	s.podsEvictedBase, s.applyErrorsBase = metrics.GetNodeSLOHealthCounters()
+}
+
+// reportStatus reports the applied config generation and the health counters since then to the NodeSLO status,
+// which the slo-controller uses to decide whether to continue the config rollout.
+func (s *nodeSLOInformer) reportStatus() {
+	s.nodeSLORWMutex.RLock()
+	generation, generationTime := s.configGeneration, s.configGenerationTime
+	podsEvictedBase, applyErrorsBase := s.podsEvictedBase, s.applyErrorsBase
+	lastReportedStatus := s.lastReportedStatus
+	s.nodeSLORWMutex.RUnlock()
+	if len(generation) <= 0 {
+		klog.V(5).Infof("skip reporting NodeSLO status since config generation is not set")
+		return
+	}
+
+	podsEvicted, applyErrors := metrics.GetNodeSLOHealthCounters()
+	status := &slov1alpha1.NodeSLOStatus{
+		ConfigGeneration:     generation,
+		ConfigGenerationTime: &generationTime,
+		PodsEvicted:          podsEvicted - podsEvictedBase,
+		ApplyErrors:          applyErrors - applyErrorsBase,
+	}
+	if lastReportedStatus != nil && lastReportedStatus.ConfigGeneration == status.ConfigGeneration &&
+		lastReportedStatus.PodsEvicted == status.PodsEvicted && lastReportedStatus.ApplyErrors == status.ApplyErrors {
+		klog.V(6).Infof("skip reporting NodeSLO status since it has not changed")
+		return
+	}
+	updateTime := metav1.Now()
+	status.UpdateTime = &updateTime
+
+	patch, err := json.Marshal(map[string]interface{}{"status": status})
+	if err != nil {
+		klog.Warningf("failed to marshal NodeSLO status patch, err: %v", err)
+		return
+	}
+	_, err = s.koordClient.SloV1alpha1().NodeSLOs().Patch(context.TODO(), s.nodeName, types.MergePatchType, patch,
+		metav1.PatchOptions{}, "status")
+	if err != nil {
+		klog.Warningf("failed to report NodeSLO %s status, err: %v", s.nodeName, err)
+		return
+	}
+	klog.V(4).Infof("report NodeSLO %s status %v", s.nodeName, util.DumpJSON(status))
+
+	s.nodeSLORWMutex.Lock()
+	defer s.nodeSLORWMutex.Unlock()
+	s.lastReportedStatus = status
+}
+
 func (s *nodeSLOInformer) updateNodeSLOSpec(nodeSLO *slov1alpha1.NodeSLO) {
 	s.setNodeSLOSpec(nodeSLO)
 	s.callbackRunner.SendCallback(statesinformer.RegisterTypeNodeSLOSpec)
@@ -113,6 +231,8 @@ func (s *nodeSLOInformer) setNodeSLOSpec(nodeSLO *slov1alpha1.NodeSLO) {
 	defer s.nodeSLORWMutex.Unlock()
 
 	oldNodeSLOStr := util.DumpJSON(s.nodeSLO)
+	// the new spec is applied after its callbacks
+	s.specApplied = false
 
 	if s.nodeSLO == nil {
 		s.nodeSLO = nodeSLO.DeepCopy()
@@ -17,13 +17,17 @@ limitations under the License.
 package impl
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 
 	apiext "github.com/koordinator-sh/koordinator/apis/extension"
 	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	fakekoordclientset "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/metrics"
 	"github.com/koordinator-sh/koordinator/pkg/util"
 	"github.com/koordinator-sh/koordinator/pkg/util/sloconfig"
 )
@@ -459,3 +463,71 @@ func Test_mergeNoneResourceQOSIfDisabled(t *testing.T) {
 		})
 	}
 }
+
+func Test_reportNodeSLOStatus(t *testing.T) {
+	testingNodeSLO := &slov1alpha1.NodeSLO{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-node",
+			Annotations: map[string]string{
+				slov1alpha1.AnnotationNodeSLOConfigGeneration: "gen-1",
+			},
+		},
+	}
+	koordClient := fakekoordclientset.NewSimpleClientset(testingNodeSLO)
+	r := nodeSLOInformer{
+		koordClient:    koordClient,
+		nodeName:       testingNodeSLO.Name,
+		callbackRunner: NewCallbackRunner(),
+	}
+
+	// the generation is not applied until the callbacks of the spec have run
+	r.updateNodeSLOSpec(testingNodeSLO)
+	r.updateConfigGeneration(testingNodeSLO)
+	r.reportStatus()
+	got, err := koordClient.SloV1alpha1().NodeSLOs().Get(context.TODO(), testingNodeSLO.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "", got.Status.ConfigGeneration)
+
+	r.onNodeSLOSpecApplied(&r.GetNodeSLO().Spec)
+	metrics.RecordNodeSLOApplyError("test-rule")
+	r.reportStatus()
+
+	got, err = koordClient.SloV1alpha1().NodeSLOs().Get(context.TODO(), testingNodeSLO.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "gen-1", got.Status.ConfigGeneration)
+	assert.NotNil(t, got.Status.ConfigGenerationTime)
+	assert.Equal(t, int64(1), got.Status.ApplyErrors)
+	assert.Equal(t, int64(0), got.Status.PodsEvicted)
+	assert.NotNil(t, got.Status.UpdateTime)
+
+	// the new generation is applied at once if the spec is not changed, and the counters are reset
+	testingNodeSLO.Annotations[slov1alpha1.AnnotationNodeSLOConfigGeneration] = "gen-2"
+	r.updateConfigGeneration(testingNodeSLO)
+	r.reportStatus()
+
+	got, err = koordClient.SloV1alpha1().NodeSLOs().Get(context.TODO(), testingNodeSLO.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "gen-2", got.Status.ConfigGeneration)
+	assert.Equal(t, int64(0), got.Status.ApplyErrors)
+
+	// the new spec is not applied if it changes again during the callbacks
+	appliedSpec := r.GetNodeSLO().Spec
+	newNodeSLO := testingNodeSLO.DeepCopy()
+	newNodeSLO.Annotations[slov1alpha1.AnnotationNodeSLOConfigGeneration] = "gen-3"
+	newNodeSLO.Spec.ResourceUsedThresholdWithBE = &slov1alpha1.ResourceThresholdStrategy{
+		Enable: pointer.Bool(true),
+	}
+	r.updateNodeSLOSpec(newNodeSLO)
+	r.updateConfigGeneration(newNodeSLO)
+	r.onNodeSLOSpecApplied(&appliedSpec)
+	r.reportStatus()
+	got, err = koordClient.SloV1alpha1().NodeSLOs().Get(context.TODO(), testingNodeSLO.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "gen-2", got.Status.ConfigGeneration)
+
+	r.onNodeSLOSpecApplied(&r.GetNodeSLO().Spec)
+	r.reportStatus()
+	got, err = koordClient.SloV1alpha1().NodeSLOs().Get(context.TODO(), testingNodeSLO.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "gen-3", got.Status.ConfigGeneration)
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
+)
+
+const (
+	hugePagesSubDir        = "kernel/mm/hugepages"
+	hugePagesDirPrefix     = "hugepages-"
+	hugePagesNrFileName    = "nr_hugepages"
+	hugePagesFreeFileName  = "free_hugepages"
+	hugePagesDirSizeSuffix = "kB"
+)
+
+// HugePagesInfo is the hugepages statistics of one page size on the node.
+type HugePagesInfo struct {
+	// PageSize is the size of a huge page in bytes.
+	PageSize uint64 `json:"pageSize"`
+	// Total is the number of the huge pages.
+	Total uint64 `json:"total"`
+	// Free is the number of the unused huge pages.
+	Free uint64 `json:"free"`
+}
+
+// UsageBytes returns the bytes of the huge pages in use.
+func (h *HugePagesInfo) UsageBytes() uint64 {
+	if h.Free >= h.Total {
+		return 0
+	}
+	return (h.Total - h.Free) * h.PageSize
+}
+
+// GetHugePagesInfo reads the hugepages statistics of all page sizes from the sysfs.
+// e.g. /sys/kernel/mm/hugepages/hugepages-2048kB/{nr_hugepages,free_hugepages}
+// It returns nil if the hugepages is not supported on the node.
+func GetHugePagesInfo() ([]HugePagesInfo, error) {
+	hugePagesDir := filepath.Join(system.GetSysRootDir(), hugePagesSubDir)
+	entries, err := os.ReadDir(hugePagesDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read hugepages dir, err: %w", err)
+	}
+
+	var infos []HugePagesInfo
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() || !strings.HasPrefix(name, hugePagesDirPrefix) || !strings.HasSuffix(name, hugePagesDirSizeSuffix) {
+			continue
+		}
+		sizeKB, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(name, hugePagesDirPrefix), hugePagesDirSizeSuffix), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse hugepages size of %s, err: %w", name, err)
+		}
+		total, err := readUint64File(filepath.Join(hugePagesDir, name, hugePagesNrFileName))
+		if err != nil {
+			return nil, err
+		}
+		free, err := readUint64File(filepath.Join(hugePagesDir, name, hugePagesFreeFileName))
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, HugePagesInfo{
+			PageSize: sizeKB * 1024,
+			Total:    total,
+			Free:     free,
+		})
+	}
+	return infos, nil
+}
+
+func readUint64File(path string) (uint64, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s, err: %w", path, err)
+	}
+	v, err := strconv.ParseUint(strings.TrimSpace(string(content)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %s, err: %w", path, err)
+	}
+	return v, nil
+}
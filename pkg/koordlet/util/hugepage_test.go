@@ -0,0 +1,87 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/koordinator-sh/koordinator/pkg/koordlet/util/system"
+)
+
+func TestGetHugePagesInfo(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		want    []HugePagesInfo
+		wantErr bool
+	}{
+		{
+			name: "hugepages not supported",
+			want: nil,
+		},
+		{
+			name: "get hugepages info correctly",
+			files: map[string]string{
+				"kernel/mm/hugepages/hugepages-2048kB/nr_hugepages":      "1024\n",
+				"kernel/mm/hugepages/hugepages-2048kB/free_hugepages":    "512\n",
+				"kernel/mm/hugepages/hugepages-1048576kB/nr_hugepages":   "2\n",
+				"kernel/mm/hugepages/hugepages-1048576kB/free_hugepages": "2\n",
+			},
+			want: []HugePagesInfo{
+				{
+					PageSize: 1 << 30,
+					Total:    2,
+					Free:     2,
+				},
+				{
+					PageSize: 2 << 20,
+					Total:    1024,
+					Free:     512,
+				},
+			},
+		},
+		{
+			name: "failed to parse hugepages count",
+			files: map[string]string{
+				"kernel/mm/hugepages/hugepages-2048kB/nr_hugepages":   "invalid",
+				"kernel/mm/hugepages/hugepages-2048kB/free_hugepages": "512",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			helper := system.NewFileTestUtil(t)
+			defer helper.Cleanup()
+			for file, content := range tt.files {
+				helper.WriteFileContents(file, content)
+			}
+			got, gotErr := GetHugePagesInfo()
+			assert.Equal(t, tt.wantErr, gotErr != nil, gotErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestHugePagesInfoUsageBytes(t *testing.T) {
+	info := &HugePagesInfo{PageSize: 2 << 20, Total: 1024, Free: 512}
+	assert.Equal(t, uint64(1<<30), info.UsageBytes())
+	info = &HugePagesInfo{PageSize: 2 << 20, Total: 0, Free: 0}
+	assert.Equal(t, uint64(0), info.UsageBytes())
+}
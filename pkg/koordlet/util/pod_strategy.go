@@ -0,0 +1,84 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+)
+
+// PodStrategyMatcher matches the pods with the pod strategies of a NodeSLO.
+// The pod selectors are parsed once when the matcher is created.
+type PodStrategyMatcher struct {
+	strategies []slov1alpha1.PodStrategy
+	// selectors[i] is the parsed pod selector of strategies[i], nil means all pods.
+	selectors []labels.Selector
+}
+
+// NewPodStrategyMatcher creates a matcher for the pod strategies, or returns nil if there is no strategy.
+// Strategies with an invalid pod selector are ignored.
+func NewPodStrategyMatcher(podStrategies []slov1alpha1.PodStrategy) *PodStrategyMatcher {
+	if len(podStrategies) <= 0 {
+		return nil
+	}
+	m := &PodStrategyMatcher{}
+	for i := range podStrategies {
+		strategy := &podStrategies[i]
+		var selector labels.Selector
+		if strategy.PodSelector != nil {
+			var err error
+			selector, err = metav1.LabelSelectorAsSelector(strategy.PodSelector)
+			if err != nil {
+				klog.Warningf("ignore pod strategy %s since its pod selector is invalid, err: %v", strategy.Name, err)
+				continue
+			}
+		}
+		m.strategies = append(m.strategies, *strategy.DeepCopy())
+		m.selectors = append(m.selectors, selector)
+	}
+	return m
+}
+
+// Match returns the first pod strategy matching the pod's namespace and labels, or nil if none matches.
+func (m *PodStrategyMatcher) Match(namespace string, podLabels map[string]string) *slov1alpha1.PodStrategy {
+	if m == nil {
+		return nil
+	}
+	for i := range m.strategies {
+		strategy := &m.strategies[i]
+		if len(strategy.Namespaces) > 0 && !containsString(strategy.Namespaces, namespace) {
+			continue
+		}
+		if m.selectors[i] != nil && !m.selectors[i].Matches(labels.Set(podLabels)) {
+			continue
+		}
+		return strategy
+	}
+	return nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+)
+
+func TestPodStrategyMatcher(t *testing.T) {
+	testStrategies := []slov1alpha1.PodStrategy{
+		{
+			Name: "invalid-selector",
+			PodSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "tenant", Operator: "Unknown"},
+				},
+			},
+		},
+		{
+			Name:       "tenant-a",
+			Namespaces: []string{"default"},
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"tenant": "a"},
+			},
+		},
+		{
+			Name:       "namespace-other",
+			Namespaces: []string{"other"},
+		},
+	}
+	tests := []struct {
+		name       string
+		strategies []slov1alpha1.PodStrategy
+		namespace  string
+		podLabels  map[string]string
+		want       string
+	}{
+		{
+			name:      "no strategy",
+			namespace: "default",
+			podLabels: map[string]string{"tenant": "a"},
+		},
+		{
+			name:       "match namespace and labels",
+			strategies: testStrategies,
+			namespace:  "default",
+			podLabels:  map[string]string{"tenant": "a"},
+			want:       "tenant-a",
+		},
+		{
+			name:       "labels not matched",
+			strategies: testStrategies,
+			namespace:  "default",
+			podLabels:  map[string]string{"tenant": "b"},
+		},
+		{
+			name:       "match namespace only",
+			strategies: testStrategies,
+			namespace:  "other",
+			want:       "namespace-other",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewPodStrategyMatcher(tt.strategies)
+			got := m.Match(tt.namespace, tt.podLabels)
+			if tt.want == "" {
+				assert.Nil(t, got)
+			} else {
+				assert.NotNil(t, got)
+				assert.Equal(t, tt.want, got.Name)
+			}
+		})
+	}
+}
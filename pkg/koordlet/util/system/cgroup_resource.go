@@ -111,6 +111,7 @@ const ( // subsystems
 	CgroupCPUAcctDir string = "cpuacct/"
 	CgroupMemDir     string = "memory/"
 	CgroupBlkioDir   string = "blkio/"
+	CgroupHugetlbDir string = "hugetlb/"
 
 	CgroupV2Dir = ""
 )
@@ -167,6 +168,15 @@ const (
 	BlkioTWBpsName    = "blkio.throttle.write_bps_device"
 	BlkioIOWeightName = "blkio.cost.weight"
 	BlkioIOQoSName    = "blkio.cost.qos"
+
+	HugetlbLimit2MName   = "hugetlb.2MB.limit_in_bytes"
+	HugetlbLimit1GName   = "hugetlb.1GB.limit_in_bytes"
+	HugetlbMax2MName     = "hugetlb.2MB.max"
+	HugetlbMax1GName     = "hugetlb.1GB.max"
+	HugetlbUsage2MName   = "hugetlb.2MB.usage_in_bytes"
+	HugetlbUsage1GName   = "hugetlb.1GB.usage_in_bytes"
+	HugetlbCurrent2MName = "hugetlb.2MB.current"
+	HugetlbCurrent1GName = "hugetlb.1GB.current"
 )
 
 var (
@@ -235,6 +245,11 @@ var (
 	BlkioIOWeight  = DefaultFactory.New(BlkioIOWeightName, CgroupBlkioDir).WithValidator(BlkioIOWeightValidator).WithCheckSupported(SupportedIfFileExistsInKubepods).WithCheckOnce(true)
 	BlkioIOQoS     = DefaultFactory.New(BlkioIOQoSName, CgroupBlkioDir).WithValidator(BlkioIOQoSValidator).WithSupported(SupportedIfFileExistsInRootCgroup(BlkioIOQoSName, CgroupBlkioDir))
 
+	HugetlbLimit2M = DefaultFactory.New(HugetlbLimit2MName, CgroupHugetlbDir).WithValidator(NaturalInt64Validator).WithCheckSupported(SupportedIfFileExistsInKubepods).WithCheckOnce(true)
+	HugetlbLimit1G = DefaultFactory.New(HugetlbLimit1GName, CgroupHugetlbDir).WithValidator(NaturalInt64Validator).WithCheckSupported(SupportedIfFileExistsInKubepods).WithCheckOnce(true)
+	HugetlbUsage2M = DefaultFactory.New(HugetlbUsage2MName, CgroupHugetlbDir).WithCheckSupported(SupportedIfFileExistsInKubepods).WithCheckOnce(true)
+	HugetlbUsage1G = DefaultFactory.New(HugetlbUsage1GName, CgroupHugetlbDir).WithCheckSupported(SupportedIfFileExistsInKubepods).WithCheckOnce(true)
+
 	knownCgroupResources = []Resource{
 		CPUStat,
 		CPUShares,
@@ -269,6 +284,10 @@ var (
 		BlkioWriteBps,
 		BlkioIOWeight,
 		BlkioIOQoS,
+		HugetlbLimit2M,
+		HugetlbLimit1G,
+		HugetlbUsage2M,
+		HugetlbUsage1G,
 	}
 
 	CPUCFSQuotaV2  = DefaultFactory.NewV2(CPUCFSQuotaName, CPUMaxName)
@@ -300,6 +319,10 @@ var (
 	MemoryPriorityV2         = DefaultFactory.NewV2(MemoryPriorityName, MemoryPriorityName).WithValidator(MemoryPriorityValidator).WithCheckSupported(SupportedIfFileExists)
 	MemoryUsePriorityOomV2   = DefaultFactory.NewV2(MemoryUsePriorityOomName, MemoryUsePriorityOomName).WithValidator(MemoryUsePriorityOomValidator).WithCheckSupported(SupportedIfFileExists)
 	MemoryOomGroupV2         = DefaultFactory.NewV2(MemoryOomGroupName, MemoryOomGroupName).WithValidator(MemoryOomGroupValidator).WithCheckSupported(SupportedIfFileExists)
+	HugetlbLimit2MV2         = DefaultFactory.NewV2(HugetlbLimit2MName, HugetlbMax2MName).WithValidator(NaturalInt64Validator).WithCheckSupported(SupportedIfFileExistsInKubepods).WithCheckOnce(true)
+	HugetlbLimit1GV2         = DefaultFactory.NewV2(HugetlbLimit1GName, HugetlbMax1GName).WithValidator(NaturalInt64Validator).WithCheckSupported(SupportedIfFileExistsInKubepods).WithCheckOnce(true)
+	HugetlbUsage2MV2         = DefaultFactory.NewV2(HugetlbUsage2MName, HugetlbCurrent2MName).WithCheckSupported(SupportedIfFileExistsInKubepods).WithCheckOnce(true)
+	HugetlbUsage1GV2         = DefaultFactory.NewV2(HugetlbUsage1GName, HugetlbCurrent1GName).WithCheckSupported(SupportedIfFileExistsInKubepods).WithCheckOnce(true)
 
 	knownCgroupV2Resources = []Resource{
 		CPUCFSQuotaV2,
@@ -331,6 +354,10 @@ var (
 		MemoryOomGroupV2,
 		BlkioIOWeight,
 		BlkioIOQoS,
+		HugetlbLimit2MV2,
+		HugetlbLimit1GV2,
+		HugetlbUsage2MV2,
+		HugetlbUsage1GV2,
 	}
 )
 
@@ -0,0 +1,181 @@
+//go:build linux
+// +build linux
+
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package system
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// GetFilesystemUsage returns the used bytes of the filesystem where the path locates.
+func GetFilesystemUsage(path string) (uint64, error) {
+	var st unix.Statfs_t
+	if err := unix.Statfs(path, &st); err != nil {
+		return 0, fmt.Errorf("failed to statfs %s, err: %w", path, err)
+	}
+	return (st.Blocks - st.Bfree) * uint64(st.Bsize), nil
+}
+
+// GetMountPoint returns the mount point of the filesystem where the path locates.
+func GetMountPoint(path string) (string, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	var st unix.Stat_t
+	if err = unix.Stat(absPath, &st); err != nil {
+		return "", fmt.Errorf("failed to stat %s, err: %w", absPath, err)
+	}
+	for absPath != "/" {
+		parent := filepath.Dir(absPath)
+		var parentSt unix.Stat_t
+		if err = unix.Stat(parent, &parentSt); err != nil {
+			return "", fmt.Errorf("failed to stat %s, err: %w", parent, err)
+		}
+		if parentSt.Dev != st.Dev {
+			return absPath, nil
+		}
+		absPath = parent
+	}
+	return "/", nil
+}
+
+var (
+	// mountInfoPath is the mountinfo of the koordlet, where the filesystems of the mounted host paths are listed.
+	mountInfoPath = "/proc/self/mountinfo"
+	// lookPath is used to check if the xfs_quota binary is installed.
+	lookPath = exec.LookPath
+)
+
+// IsProjectQuotaSupported checks if the xfs project quota can be enforced on the filesystem where the path locates,
+// which requires the xfs_quota binary, and the filesystem is xfs mounted with the prjquota (pquota) option.
+// It returns false with the reason if the project quota is unsupported.
+func IsProjectQuotaSupported(path string) (bool, error) {
+	if _, err := lookPath("xfs_quota"); err != nil {
+		return false, fmt.Errorf("xfs_quota is not installed, err: %w", err)
+	}
+	mountPoint, err := GetMountPoint(path)
+	if err != nil {
+		return false, err
+	}
+	fsType, superOptions, err := getMountInfo(mountPoint)
+	if err != nil {
+		return false, err
+	}
+	if fsType != "xfs" {
+		return false, fmt.Errorf("filesystem of %s is %s, not xfs", mountPoint, fsType)
+	}
+	for _, option := range strings.Split(superOptions, ",") {
+		if option == "prjquota" || option == "pquota" {
+			return true, nil
+		}
+	}
+	return false, fmt.Errorf("xfs %s is not mounted with prjquota, options: %s", mountPoint, superOptions)
+}
+
+// getMountInfo returns the filesystem type and the super options of the mount point in the mountinfo.
+// e.g. "36 35 98:0 / /var/lib/kubelet rw,noatime shared:1 - xfs /dev/vda1 rw,attr2,inode64,prjquota"
+func getMountInfo(mountPoint string) (string, string, error) {
+	f, err := os.Open(mountInfoPath)
+	if err != nil {
+		return "", "", err
+	}
+	defer f.Close()
+
+	found := false
+	var fsType, superOptions string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 || fields[4] != mountPoint {
+			continue
+		}
+		for i := 5; i+3 < len(fields); i++ {
+			if fields[i] != "-" {
+				continue
+			}
+			// the later mount overrides the earlier one on the same mount point
+			found, fsType, superOptions = true, fields[i+1], fields[i+3]
+			break
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return "", "", err
+	}
+	if !found {
+		return "", "", fmt.Errorf("mount point %s is not found in %s", mountPoint, mountInfoPath)
+	}
+	return fsType, superOptions, nil
+}
+
+// ExecXFSQuota runs the xfs_quota expert command on the filesystem of the mount point.
+var ExecXFSQuota = func(mountPoint, command string) ([]byte, error) {
+	return exec.Command("xfs_quota", "-x", "-c", command, mountPoint).CombinedOutput()
+}
+
+// SetDirProjectQuota limits the disk usage of the directory via the xfs project quota.
+// The directory and its descendants are attached to the project, and the limit is set as the block hard limit.
+func SetDirProjectQuota(dir string, projectID uint32, limitBytes int64) error {
+	mountPoint, err := GetMountPoint(dir)
+	if err != nil {
+		return err
+	}
+	if out, err := ExecXFSQuota(mountPoint, fmt.Sprintf("project -s -p %s %d", dir, projectID)); err != nil {
+		return fmt.Errorf("failed to set project %d for dir %s, output: %s, err: %w", projectID, dir, string(out), err)
+	}
+	if out, err := ExecXFSQuota(mountPoint, fmt.Sprintf("limit -p bhard=%d %d", limitBytes, projectID)); err != nil {
+		return fmt.Errorf("failed to limit project %d to %d bytes, output: %s, err: %w", projectID, limitBytes, string(out), err)
+	}
+	return nil
+}
+
+// ClearDirProjectQuota removes the block hard limit of the xfs project, and detaches the directory from the project
+// if the directory still exists, so that the project id can be reused by other directories.
+func ClearDirProjectQuota(dir string, projectID uint32) error {
+	dirExists := true
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		dirExists = false
+	}
+	// the parent dir is used to find the filesystem when the directory has been removed
+	mountPath := dir
+	if !dirExists {
+		mountPath = filepath.Dir(dir)
+	}
+	mountPoint, err := GetMountPoint(mountPath)
+	if err != nil {
+		return err
+	}
+	if out, err := ExecXFSQuota(mountPoint, fmt.Sprintf("limit -p bhard=0 %d", projectID)); err != nil {
+		return fmt.Errorf("failed to clear the limit of project %d, output: %s, err: %w", projectID, string(out), err)
+	}
+	if !dirExists {
+		return nil
+	}
+	if out, err := ExecXFSQuota(mountPoint, fmt.Sprintf("project -C -p %s %d", dir, projectID)); err != nil {
+		return fmt.Errorf("failed to clear project %d for dir %s, output: %s, err: %w", projectID, dir, string(out), err)
+	}
+	return nil
+}
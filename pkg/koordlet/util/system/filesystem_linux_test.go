@@ -0,0 +1,182 @@
+//go:build linux
+// +build linux
+
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package system
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFilesystemUsage(t *testing.T) {
+	_, err := GetFilesystemUsage(t.TempDir())
+	assert.NoError(t, err)
+	_, err = GetFilesystemUsage("/path/not/exist")
+	assert.Error(t, err)
+}
+
+func TestGetMountPoint(t *testing.T) {
+	got, err := GetMountPoint("/")
+	assert.NoError(t, err)
+	assert.Equal(t, "/", got)
+	got, err = GetMountPoint(t.TempDir())
+	assert.NoError(t, err)
+	assert.NotEqual(t, "", got)
+	_, err = GetMountPoint("/path/not/exist")
+	assert.Error(t, err)
+}
+
+func TestSetDirProjectQuota(t *testing.T) {
+	oldExecXFSQuota := ExecXFSQuota
+	defer func() {
+		ExecXFSQuota = oldExecXFSQuota
+	}()
+	dir := t.TempDir()
+	mountPoint, err := GetMountPoint(dir)
+	assert.NoError(t, err)
+
+	var gotCommands []string
+	ExecXFSQuota = func(m, command string) ([]byte, error) {
+		assert.Equal(t, mountPoint, m)
+		gotCommands = append(gotCommands, command)
+		return nil, nil
+	}
+	assert.NoError(t, SetDirProjectQuota(dir, 1000, 1<<30))
+	assert.Equal(t, []string{
+		fmt.Sprintf("project -s -p %s 1000", dir),
+		"limit -p bhard=1073741824 1000",
+	}, gotCommands)
+
+	ExecXFSQuota = func(m, command string) ([]byte, error) {
+		return []byte("not supported"), fmt.Errorf("exit status 1")
+	}
+	assert.Error(t, SetDirProjectQuota(dir, 1000, 1<<30))
+}
+
+func TestClearDirProjectQuota(t *testing.T) {
+	oldExecXFSQuota := ExecXFSQuota
+	defer func() {
+		ExecXFSQuota = oldExecXFSQuota
+	}()
+	dir := t.TempDir()
+	mountPoint, err := GetMountPoint(dir)
+	assert.NoError(t, err)
+
+	var gotCommands []string
+	ExecXFSQuota = func(m, command string) ([]byte, error) {
+		assert.Equal(t, mountPoint, m)
+		gotCommands = append(gotCommands, command)
+		return nil, nil
+	}
+	assert.NoError(t, ClearDirProjectQuota(dir, 1000))
+	assert.Equal(t, []string{
+		"limit -p bhard=0 1000",
+		fmt.Sprintf("project -C -p %s 1000", dir),
+	}, gotCommands)
+
+	// the dir is removed
+	gotCommands = nil
+	assert.NoError(t, ClearDirProjectQuota(filepath.Join(dir, "removed"), 1000))
+	assert.Equal(t, []string{"limit -p bhard=0 1000"}, gotCommands)
+
+	ExecXFSQuota = func(m, command string) ([]byte, error) {
+		return []byte("not supported"), fmt.Errorf("exit status 1")
+	}
+	assert.Error(t, ClearDirProjectQuota(dir, 1000))
+}
+
+func TestIsProjectQuotaSupported(t *testing.T) {
+	oldMountInfoPath, oldLookPath := mountInfoPath, lookPath
+	defer func() {
+		mountInfoPath, lookPath = oldMountInfoPath, oldLookPath
+	}()
+	dir := t.TempDir()
+	mountPoint, err := GetMountPoint(dir)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		mountInfo     string
+		xfsQuotaFound bool
+		want          bool
+	}{
+		{
+			name:          "xfs with prjquota",
+			mountInfo:     fmt.Sprintf("36 35 98:0 / %s rw,noatime shared:1 - xfs /dev/vda1 rw,attr2,inode64,prjquota\n", mountPoint),
+			xfsQuotaFound: true,
+			want:          true,
+		},
+		{
+			name:          "xfs with pquota",
+			mountInfo:     fmt.Sprintf("36 35 98:0 / %s rw,noatime - xfs /dev/vda1 rw,pquota\n", mountPoint),
+			xfsQuotaFound: true,
+			want:          true,
+		},
+		{
+			name:          "xfs_quota is not installed",
+			mountInfo:     fmt.Sprintf("36 35 98:0 / %s rw,noatime shared:1 - xfs /dev/vda1 rw,prjquota\n", mountPoint),
+			xfsQuotaFound: false,
+			want:          false,
+		},
+		{
+			name:          "xfs without prjquota",
+			mountInfo:     fmt.Sprintf("36 35 98:0 / %s rw,noatime shared:1 - xfs /dev/vda1 rw,attr2,noquota\n", mountPoint),
+			xfsQuotaFound: true,
+			want:          false,
+		},
+		{
+			name:          "ext4",
+			mountInfo:     fmt.Sprintf("36 35 98:0 / %s rw,noatime shared:1 - ext4 /dev/vda1 rw,prjquota\n", mountPoint),
+			xfsQuotaFound: true,
+			want:          false,
+		},
+		{
+			name: "overlay mounted over xfs",
+			mountInfo: fmt.Sprintf("36 35 98:0 / %s rw,noatime - xfs /dev/vda1 rw,prjquota\n", mountPoint) +
+				fmt.Sprintf("37 35 0:50 / %s rw - overlay overlay rw,lowerdir=/a,upperdir=/b\n", mountPoint),
+			xfsQuotaFound: true,
+			want:          false,
+		},
+		{
+			name:          "mount point not found",
+			mountInfo:     "36 35 98:0 / /path/not/exist rw,noatime - xfs /dev/vda1 rw,prjquota\n",
+			xfsQuotaFound: true,
+			want:          false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mountInfoPath = filepath.Join(t.TempDir(), "mountinfo")
+			assert.NoError(t, os.WriteFile(mountInfoPath, []byte(tt.mountInfo), 0644))
+			lookPath = func(file string) (string, error) {
+				if !tt.xfsQuotaFound {
+					return "", fmt.Errorf("executable file not found in $PATH")
+				}
+				return "/usr/sbin/" + file, nil
+			}
+			got, err := IsProjectQuotaSupported(dir)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, !tt.want, err != nil)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+//go:build !linux
+// +build !linux
+
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package system
+
+import (
+	"fmt"
+)
+
+func GetFilesystemUsage(path string) (uint64, error) {
+	return 0, fmt.Errorf("only support linux")
+}
+
+func GetMountPoint(path string) (string, error) {
+	return "", fmt.Errorf("only support linux")
+}
+
+func IsProjectQuotaSupported(path string) (bool, error) {
+	return false, fmt.Errorf("only support linux")
+}
+
+var ExecXFSQuota = func(mountPoint, command string) ([]byte, error) {
+	return nil, fmt.Errorf("only support linux")
+}
+
+func SetDirProjectQuota(dir string, projectID uint32, limitBytes int64) error {
+	return fmt.Errorf("only support linux")
+}
+
+func ClearDirProjectQuota(dir string, projectID uint32) error {
+	return fmt.Errorf("only support linux")
+}
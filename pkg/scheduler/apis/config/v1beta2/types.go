@@ -49,6 +49,9 @@ type LoadAwareSchedulingArgs struct {
 	ScoreAccordingProdUsage *bool `json:"scoreAccordingProdUsage,omitempty"`
 	// Estimator indicates the expected Estimator to use
 	Estimator string `json:"estimator,omitempty"`
+	// WorkloadProfileNamespace indicates the namespace of the workload profile ConfigMap used by the profileEstimator,
+	// which must be the same as the config namespace of the koord-manager. Default is koordinator-system.
+	WorkloadProfileNamespace string `json:"workloadProfileNamespace,omitempty"`
 	// EstimatedScalingFactors indicates the factor when estimating resource usage.
 	// The default value of CPU is 85%, and the default value of Memory is 70%.
 	EstimatedScalingFactors map[corev1.ResourceName]int64 `json:"estimatedScalingFactors,omitempty"`
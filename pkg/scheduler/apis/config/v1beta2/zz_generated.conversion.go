@@ -251,6 +251,7 @@ func autoConvert_v1beta2_LoadAwareSchedulingArgs_To_config_LoadAwareSchedulingAr
 		return err
 	}
 	out.Estimator = in.Estimator
+	out.WorkloadProfileNamespace = in.WorkloadProfileNamespace
 	out.EstimatedScalingFactors = *(*map[corev1.ResourceName]int64)(unsafe.Pointer(&in.EstimatedScalingFactors))
 	if in.Aggregated != nil {
 		in, out := &in.Aggregated, &out.Aggregated
@@ -279,6 +280,7 @@ func autoConvert_config_LoadAwareSchedulingArgs_To_v1beta2_LoadAwareSchedulingAr
 		return err
 	}
 	out.Estimator = in.Estimator
+	out.WorkloadProfileNamespace = in.WorkloadProfileNamespace
 	out.EstimatedScalingFactors = *(*map[corev1.ResourceName]int64)(unsafe.Pointer(&in.EstimatedScalingFactors))
 	if in.Aggregated != nil {
 		in, out := &in.Aggregated, &out.Aggregated
@@ -336,6 +336,11 @@ func updateReservationInSchedulerCache(sched frameworkext.Scheduler, oldObj, new
 	// Forces priority to be set to maximum to prevent preemption.
 	oldReservePod.Spec.Priority = pointer.Int32(math.MaxInt32)
 	newReservePod.Spec.Priority = pointer.Int32(math.MaxInt32)
+	// The reserve pod in the cache may be already resized by the reservation controller before the status is updated,
+	// so the cached one must be removed to keep the node resources consistent.
+	if cachedReservePod, err := sched.GetCache().GetPod(oldReservePod); err == nil && cachedReservePod != nil {
+		oldReservePod = cachedReservePod
+	}
 	if err := sched.GetCache().UpdatePod(oldReservePod, newReservePod); err != nil {
 		klog.ErrorS(err, "Failed to update reservation into SchedulerCache", "reservation", klog.KObj(newR))
 	} else {
@@ -377,7 +382,11 @@ func deleteReservationFromSchedulerCache(sched frameworkext.Scheduler, obj inter
 	reservePod := reservationutil.NewReservePod(r)
 	// Forces priority to be set to maximum to prevent preemption.
 	reservePod.Spec.Priority = pointer.Int32(math.MaxInt32)
-	if _, err = sched.GetCache().GetPod(reservePod); err == nil {
+	if cachedReservePod, err := sched.GetCache().GetPod(reservePod); err == nil {
+		// The reserve pod in the cache may be resized, so the cached requests must be released.
+		if cachedReservePod != nil {
+			reservePod.Spec.Containers = cachedReservePod.DeepCopy().Spec.Containers
+		}
 		if len(rInfo.AllocatedPorts) > 0 {
 			allocatablePorts := util.RequestedHostPorts(reservePod)
 			util.RemoveHostPorts(allocatablePorts, rInfo.AllocatedPorts)
@@ -32,8 +32,13 @@ type extendedHandleOptions struct {
 	servicesEngine                   *services.Engine
 	koordinatorClientSet             koordinatorclientset.Interface
 	koordinatorSharedInformerFactory koordinatorinformers.SharedInformerFactory
+	pluginHandleWrapper              PluginHandleWrapper
 }
 
+// PluginHandleWrapper wraps the handle passed to the plugin factories, e.g. the offline tools provide the fake clients
+// of the third-party APIs which the plugins look up from the handle.
+type PluginHandleWrapper func(extender FrameworkExtender) framework.Handle
+
 type Option func(*extendedHandleOptions)
 
 func WithServicesEngine(engine *services.Engine) Option {
@@ -54,11 +59,18 @@ func WithKoordinatorSharedInformerFactory(informerFactory koordinatorinformers.S
 	}
 }
 
+func WithPluginHandleWrapper(wrapper PluginHandleWrapper) Option {
+	return func(options *extendedHandleOptions) {
+		options.pluginHandleWrapper = wrapper
+	}
+}
+
 type FrameworkExtenderFactory struct {
 	controllerMaps                   *ControllersMap
 	servicesEngine                   *services.Engine
 	koordinatorClientSet             koordinatorclientset.Interface
 	koordinatorSharedInformerFactory koordinatorinformers.SharedInformerFactory
+	pluginHandleWrapper              PluginHandleWrapper
 	profiles                         map[string]FrameworkExtender
 	scheduler                        Scheduler
 	*errorHandlerDispatcher
@@ -79,6 +91,7 @@ func NewFrameworkExtenderFactory(options ...Option) (*FrameworkExtenderFactory,
 		servicesEngine:                   handleOptions.servicesEngine,
 		koordinatorClientSet:             handleOptions.koordinatorClientSet,
 		koordinatorSharedInformerFactory: handleOptions.koordinatorSharedInformerFactory,
+		pluginHandleWrapper:              handleOptions.pluginHandleWrapper,
 		profiles:                         map[string]FrameworkExtender{},
 		errorHandlerDispatcher:           newErrorHandlerDispatcher(),
 	}, nil
@@ -143,7 +156,11 @@ func PluginFactoryProxy(extenderFactory *FrameworkExtenderFactory, factoryFn fra
 	return func(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
 		fw := handle.(framework.Framework)
 		frameworkExtender := extenderFactory.NewFrameworkExtender(fw)
-		plugin, err := factoryFn(args, frameworkExtender)
+		var pluginHandle framework.Handle = frameworkExtender
+		if extenderFactory.pluginHandleWrapper != nil {
+			pluginHandle = extenderFactory.pluginHandleWrapper(frameworkExtender)
+		}
+		plugin, err := factoryFn(args, pluginHandle)
 		if err != nil {
 			return nil, err
 		}
@@ -28,6 +28,7 @@ import (
 	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
 	koordinatorclientset "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned"
 	koordinatorinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
+	schedulinglister "github.com/koordinator-sh/koordinator/pkg/client/listers/scheduling/v1alpha1"
 )
 
 // ExtendedHandle extends the k8s scheduling framework Handle interface
@@ -182,6 +183,42 @@ func GetNominatedReservation(cycleState *framework.CycleState, nodeName string)
 	return nominatedState.reservationInfos[nodeName]
 }
 
+var (
+	simulatedReservationsKey framework.StateKey = "koordinator.sh/simulated-reservations"
+)
+
+// simulatedReservationsState saves the Reservations simulated in the scheduling cycle, which are not in the informer cache
+type simulatedReservationsState struct {
+	reservations map[string]*schedulingv1alpha1.Reservation
+}
+
+func (s *simulatedReservationsState) Clone() framework.StateData {
+	return s
+}
+
+func SetSimulatedReservations(cycleState *framework.CycleState, reservations []*schedulingv1alpha1.Reservation) {
+	if len(reservations) == 0 {
+		return
+	}
+	state := &simulatedReservationsState{
+		reservations: make(map[string]*schedulingv1alpha1.Reservation, len(reservations)),
+	}
+	for _, r := range reservations {
+		state.reservations[r.Name] = r
+	}
+	cycleState.Write(simulatedReservationsKey, state)
+}
+
+// GetReservation returns the Reservation simulated in the scheduling cycle if present, otherwise gets it from the lister.
+func GetReservation(cycleState *framework.CycleState, lister schedulinglister.ReservationLister, name string) (*schedulingv1alpha1.Reservation, error) {
+	if state, err := cycleState.Read(simulatedReservationsKey); err == nil {
+		if r := state.(*simulatedReservationsState).reservations[name]; r != nil {
+			return r, nil
+		}
+	}
+	return lister.Get(name)
+}
+
 // ReservationPreBindPlugin performs special binding logic specifically for Reservation in the PreBind phase.
 // Similar to the built-in VolumeBinding plugin of kube-scheduler, it does not support Reservation,
 // and how Reservation itself uses PVC reserved resources also needs special handling.
@@ -75,7 +75,7 @@ func (p *PodRequirement) Clone() *PodRequirement {
 }
 
 func NewReservationInfo(r *schedulingv1alpha1.Reservation) *ReservationInfo {
-	allocatable := reservationutil.ReservationRequests(r)
+	allocatable := reservationutil.ReservedResources(r)
 	resourceNames := quotav1.ResourceNames(allocatable)
 	reservedPod := reservationutil.NewReservePod(r)
 
@@ -255,7 +255,7 @@ func (ri *ReservationInfo) Clone() *ReservationInfo {
 func (ri *ReservationInfo) UpdateReservation(r *schedulingv1alpha1.Reservation) {
 	ri.Reservation = r.DeepCopy()
 	ri.Pod = reservationutil.NewReservePod(r)
-	ri.Allocatable = reservationutil.ReservationRequests(r)
+	ri.Allocatable = reservationutil.ReservedResources(r)
 	ri.AllocatablePorts = util.RequestedHostPorts(ri.Pod)
 	ri.ResourceNames = quotav1.ResourceNames(ri.Allocatable)
 	ri.Allocated = quotav1.Mask(ri.Allocated, ri.ResourceNames)
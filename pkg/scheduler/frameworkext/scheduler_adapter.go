@@ -17,6 +17,12 @@ limitations under the License.
 package frameworkext
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/uuid"
@@ -26,10 +32,16 @@ import (
 
 var AssignedPodDelete = framework.ClusterEvent{Resource: framework.Pod, ActionType: framework.Delete, Label: "AssignedPodDelete"}
 
+var ErrNotScheduling = errors.New("the scheduler is not scheduling on this replica, please retry on the leader")
+
 // Scheduler exports scheduler internal cache and queue interface for testability.
 type Scheduler interface {
 	GetCache() SchedulerCache
 	GetSchedulingQueue() SchedulingQueue
+	// RunBetweenSchedulingCycles waits for the running scheduling cycle and runs fn before the next cycle starts,
+	// so that no pods are assumed and the node snapshot is not read by the scheduler while fn is running.
+	// It returns ErrNotScheduling if the scheduler is not getting the pods to schedule, i.e. the replica is not the leader.
+	RunBetweenSchedulingCycles(ctx context.Context, fn func() error) error
 }
 
 type SchedulerCache interface {
@@ -41,6 +53,9 @@ type SchedulerCache interface {
 	GetPod(pod *corev1.Pod) (*corev1.Pod, error)
 	ForgetPod(pod *corev1.Pod) error
 	InvalidNodeInfo(nodeName string) error
+	// GetNodeInfo returns a copy of the NodeInfo in the scheduler cache, which includes the assumed pods.
+	// It must be called in RunBetweenSchedulingCycles.
+	GetNodeInfo(nodeName string) (*framework.NodeInfo, error)
 }
 
 type SchedulingQueue interface {
@@ -56,8 +71,51 @@ type SchedulingQueue interface {
 
 var _ Scheduler = &SchedulerAdapter{}
 
+// SchedulerAdapter adapts the scheduler to the Scheduler interface.
+//
+// A scheduling cycle starts after getting the next pod from the queue and ends when getting the next pod again.
+// The cycle lock is held by the scheduling cycle, and released while the scheduler is waiting for the next pod.
 type SchedulerAdapter struct {
 	Scheduler *scheduler.Scheduler
+	// cycleLock is a semaphore held by either the scheduling cycle or RunBetweenSchedulingCycles, a buffered token means
+	// the scheduler is waiting for the next pod and the lock can be acquired
+	cycleLock chan struct{}
+	// scheduling is set once the scheduler starts getting the pods to schedule, i.e. the replica is the leader
+	scheduling int32
+}
+
+// NewSchedulerAdapter hooks the NextPod of the scheduler to hold the cycle lock, so it must be called before the
+// scheduler runs.
+func NewSchedulerAdapter(sched *scheduler.Scheduler) *SchedulerAdapter {
+	s := &SchedulerAdapter{
+		Scheduler: sched,
+		cycleLock: make(chan struct{}, 1),
+	}
+	// the lock is held by the scheduler initially, and released while the scheduler is waiting for the next pod
+	nextPod := sched.NextPod
+	sched.NextPod = func() *framework.QueuedPodInfo {
+		atomic.StoreInt32(&s.scheduling, 1)
+		s.cycleLock <- struct{}{}
+		podInfo := nextPod()
+		<-s.cycleLock
+		return podInfo
+	}
+	return s
+}
+
+func (s *SchedulerAdapter) RunBetweenSchedulingCycles(ctx context.Context, fn func() error) error {
+	if atomic.LoadInt32(&s.scheduling) == 0 {
+		return ErrNotScheduling
+	}
+	select {
+	case <-s.cycleLock:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() {
+		s.cycleLock <- struct{}{}
+	}()
+	return fn()
 }
 
 func (s *SchedulerAdapter) GetCache() SchedulerCache {
@@ -105,6 +163,39 @@ func (c *cacheAdapter) ForgetPod(pod *corev1.Pod) error {
 	return c.scheduler.Cache.ForgetPod(pod)
 }
 
+// GetNodeInfo refreshes the node snapshot of the scheduler as at the start of a scheduling cycle, which only clones
+// the NodeInfos changed since the last refresh, and returns a copy of the NodeInfo in the snapshot.
+func (c *cacheAdapter) GetNodeInfo(nodeName string) (*framework.NodeInfo, error) {
+	if err := c.updateSnapshot(); err != nil {
+		return nil, err
+	}
+	for _, fwk := range c.scheduler.Profiles {
+		nodeInfo, err := fwk.SnapshotSharedLister().NodeInfos().Get(nodeName)
+		if err != nil || nodeInfo.Node() == nil {
+			return nil, fmt.Errorf("node %s not found in scheduler cache", nodeName)
+		}
+		return nodeInfo.Clone(), nil
+	}
+	return nil, fmt.Errorf("no profiles in scheduler")
+}
+
+// updateSnapshot updates the snapshot shared by the profiles from the cache. The type of the snapshot is internal to
+// the scheduler, so the UpdateSnapshot of the cache is called by reflection.
+func (c *cacheAdapter) updateSnapshot() error {
+	for _, fwk := range c.scheduler.Profiles {
+		snapshot := reflect.ValueOf(fwk.SnapshotSharedLister())
+		updateSnapshot := reflect.ValueOf(c.scheduler.Cache.UpdateSnapshot)
+		if !snapshot.IsValid() || !snapshot.Type().AssignableTo(updateSnapshot.Type().In(0)) {
+			return fmt.Errorf("unexpected snapshot type %T", fwk.SnapshotSharedLister())
+		}
+		if err, _ := updateSnapshot.Call([]reflect.Value{snapshot})[0].Interface().(error); err != nil {
+			return err
+		}
+		return nil
+	}
+	return fmt.Errorf("no profiles in scheduler")
+}
+
 func (c *cacheAdapter) InvalidNodeInfo(nodeName string) error {
 	uid := uuid.NewUUID()
 	pod := &corev1.Pod{
@@ -166,6 +257,7 @@ var _ Scheduler = &FakeScheduler{}
 var _ SchedulingQueue = &FakeQueue{}
 
 type FakeScheduler struct {
+	Nodes      map[string]*corev1.Node
 	Pods       map[string]*corev1.Pod
 	AssumedPod map[string]*corev1.Pod
 	Queue      *FakeQueue
@@ -173,6 +265,7 @@ type FakeScheduler struct {
 
 func NewFakeScheduler() *FakeScheduler {
 	return &FakeScheduler{
+		Nodes:      map[string]*corev1.Node{},
 		Pods:       map[string]*corev1.Pod{},
 		AssumedPod: map[string]*corev1.Pod{},
 		Queue: &FakeQueue{
@@ -199,6 +292,10 @@ func (f *FakeScheduler) GetSchedulingQueue() SchedulingQueue {
 	return f.Queue
 }
 
+func (f *FakeScheduler) RunBetweenSchedulingCycles(ctx context.Context, fn func() error) error {
+	return fn()
+}
+
 func (f *FakeScheduler) AddPod(pod *corev1.Pod) error {
 	key, _ := framework.GetPodKey(pod)
 	f.Pods[key] = pod
@@ -246,6 +343,23 @@ func (f *FakeScheduler) InvalidNodeInfo(nodeName string) error {
 	return nil
 }
 
+func (f *FakeScheduler) GetNodeInfo(nodeName string) (*framework.NodeInfo, error) {
+	node := f.Nodes[nodeName]
+	if node == nil {
+		return nil, fmt.Errorf("node %s not found in scheduler cache", nodeName)
+	}
+	nodeInfo := framework.NewNodeInfo()
+	nodeInfo.SetNode(node)
+	for _, pods := range []map[string]*corev1.Pod{f.Pods, f.AssumedPod} {
+		for _, pod := range pods {
+			if pod.Spec.NodeName == nodeName {
+				nodeInfo.AddPod(pod)
+			}
+		}
+	}
+	return nodeInfo, nil
+}
+
 func (f *FakeQueue) Add(pod *corev1.Pod) error {
 	key, _ := framework.GetPodKey(pod)
 	f.Pods[key] = pod
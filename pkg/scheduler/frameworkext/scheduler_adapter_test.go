@@ -0,0 +1,124 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package frameworkext
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/kubernetes/pkg/scheduler"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+	"k8s.io/kubernetes/pkg/scheduler/profile"
+)
+
+func TestSchedulerAdapterRunBetweenSchedulingCycles(t *testing.T) {
+	nextPodCh := make(chan *framework.QueuedPodInfo)
+	sched := &scheduler.Scheduler{
+		NextPod: func() *framework.QueuedPodInfo {
+			return <-nextPodCh
+		},
+	}
+	adapter := NewSchedulerAdapter(sched)
+
+	// the scheduler is not scheduling on the follower
+	err := adapter.RunBetweenSchedulingCycles(context.TODO(), func() error { return nil })
+	assert.Equal(t, ErrNotScheduling, err)
+
+	// the scheduler is waiting for the next pod
+	go sched.NextPod()
+	assert.Eventually(t, func() bool {
+		return adapter.RunBetweenSchedulingCycles(context.TODO(), func() error { return nil }) == nil
+	}, time.Second, time.Millisecond)
+
+	// the scheduling cycle waits for the running fn
+	ran := make(chan struct{})
+	release := make(chan struct{})
+	go func() {
+		_ = adapter.RunBetweenSchedulingCycles(context.TODO(), func() error {
+			close(ran)
+			<-release
+			return nil
+		})
+	}()
+	<-ran
+	nextPodCh <- &framework.QueuedPodInfo{}
+	close(release)
+
+	// fn waits for the running scheduling cycle
+	assert.Eventually(t, func() bool {
+		ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+		defer cancel()
+		return adapter.RunBetweenSchedulingCycles(ctx, func() error { return nil }) == context.DeadlineExceeded
+	}, time.Second, time.Millisecond)
+}
+
+func TestCacheAdapterGetNodeInfo(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-node-1"},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("32"),
+				corev1.ResourceMemory: resource.MustParse("64Gi"),
+			},
+		},
+	}
+	client := kubefake.NewSimpleClientset()
+	informerFactory := scheduler.NewInformerFactory(client, 0)
+	eventBroadcaster := events.NewBroadcaster(&events.EventSinkImpl{Interface: client.EventsV1()})
+	sched, err := scheduler.New(client, informerFactory, nil, profile.NewRecorderFactory(eventBroadcaster), context.TODO().Done())
+	assert.NoError(t, err)
+
+	sched.Cache.AddNode(node)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-pod-1", UID: "test-pod-1"},
+		Spec: corev1.PodSpec{
+			NodeName: node.Name,
+			Containers: []corev1.Container{
+				{
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("4"),
+						},
+					},
+				},
+			},
+		},
+	}
+	assert.NoError(t, sched.Cache.AssumePod(pod))
+
+	schedulerCache := NewSchedulerAdapter(sched).GetCache()
+	nodeInfo, err := schedulerCache.GetNodeInfo(node.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, node.Name, nodeInfo.Node().Name)
+	assert.Equal(t, int64(4000), nodeInfo.Requested.MilliCPU)
+
+	// the returned NodeInfo is a copy
+	nodeInfo.RemovePod(pod)
+	nodeInfo, err = schedulerCache.GetNodeInfo(node.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4000), nodeInfo.Requested.MilliCPU)
+
+	_, err = schedulerCache.GetNodeInfo("test-node-2")
+	assert.Error(t, err)
+}
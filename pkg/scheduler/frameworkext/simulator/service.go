@@ -0,0 +1,54 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/services"
+)
+
+const simulateTimeout = 30 * time.Second
+
+var _ services.APIServiceProvider = &Simulator{}
+
+func (s *Simulator) RegisterEndpoints(group *gin.RouterGroup) {
+	group.POST("/schedule", func(c *gin.Context) {
+		var request Request
+		if err := c.ShouldBindJSON(&request); err != nil {
+			services.ResponseErrorMessage(c, http.StatusBadRequest, "invalid request: %v", err)
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), simulateTimeout)
+		defer cancel()
+		result, err := s.Simulate(ctx, &request)
+		if err == frameworkext.ErrNotScheduling {
+			services.ResponseErrorMessage(c, http.StatusServiceUnavailable, "failed to simulate: %v", err)
+			return
+		}
+		if err != nil {
+			services.ResponseErrorMessage(c, http.StatusBadRequest, "failed to simulate: %v", err)
+			return
+		}
+		c.JSON(http.StatusOK, result)
+	})
+}
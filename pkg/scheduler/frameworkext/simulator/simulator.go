@@ -0,0 +1,301 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/scheduler"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	koordinatorinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/plugins/coscheduling/util"
+	reservationutil "github.com/koordinator-sh/koordinator/pkg/util/reservation"
+)
+
+const Name = "simulator"
+
+// Simulator runs the scheduling cycle of the scheduler profiles for the simulated pods without binding.
+//
+// The simulation reads the node snapshot of the scheduler, which is updated at the start of every scheduling cycle,
+// so it runs between the scheduling cycles, and the scheduler waits for the running simulation before starting
+// the next cycle. Since the scheduling cycles only run on the leader, the simulation fails fast on the other replicas.
+//
+// The simulation never changes the shared states of the scheduler, i.e. the pods are not assumed in the scheduler
+// cache and the Reserve plugins are not run. The placements of the previous pods are added to the clones of their
+// nodes, which are passed to the Filter plugins of the following pods instead of the nodes in the snapshot. Thus the
+// following pods see the previous pods in the Filter phase, but not in the PreFilter and Score phases, nor in the
+// plugin caches such as the quotas and the devices.
+// The simulated Reservations are only visible to the simulated cycles through the CycleState, and they are never
+// added to the informer cache.
+//
+// The Permit plugins are not run, since the waiting pods and the rejections of the Permit phase take effect on the
+// real scheduling cycles. Thus the pods of gangs are not supported, whose placements are decided in the Permit phase.
+type Simulator struct {
+	sched                            *scheduler.Scheduler
+	schedAdapter                     frameworkext.Scheduler
+	koordinatorSharedInformerFactory koordinatorinformers.SharedInformerFactory
+}
+
+func New(schedAdapter *frameworkext.SchedulerAdapter, koordinatorSharedInformerFactory koordinatorinformers.SharedInformerFactory) *Simulator {
+	return &Simulator{
+		sched:                            schedAdapter.Scheduler,
+		schedAdapter:                     schedAdapter,
+		koordinatorSharedInformerFactory: koordinatorSharedInformerFactory,
+	}
+}
+
+// Simulate returns the results of the simulated objects. The placements are only kept in the simulated nodes,
+// which are dropped after returning.
+func (s *Simulator) Simulate(ctx context.Context, request *Request) (*Result, error) {
+	pods, reservations, err := s.preparePods(request)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Schedulable: true}
+	err = s.schedAdapter.RunBetweenSchedulingCycles(ctx, func() error {
+		simulatedNodes := map[string]*framework.NodeInfo{}
+		for i, pod := range pods {
+			podResult := s.simulatePod(ctx, pod, reservations, simulatedNodes, i < len(pods)-1)
+			if podResult.SuggestedHost == "" {
+				result.Schedulable = false
+			}
+			result.Pods = append(result.Pods, podResult)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// preparePods returns the pods to be simulated, including the reserve pods, and the Reservations to be simulated
+// which are not in the cache.
+func (s *Simulator) preparePods(request *Request) ([]*corev1.Pod, []*schedulingv1alpha1.Reservation, error) {
+	if len(request.Pods) == 0 && len(request.Reservations) == 0 {
+		return nil, nil, errors.New("no pods or reservations to simulate")
+	}
+
+	var pods []*corev1.Pod
+	for _, pod := range request.Pods {
+		if pod == nil {
+			continue
+		}
+		if gangName := util.GetGangNameByPod(pod); gangName != "" {
+			return nil, nil, fmt.Errorf("pod %s belongs to gang %s, which is not supported since the Permit plugins are not run", pod.Name, gangName)
+		}
+		pod = pod.DeepCopy()
+		if pod.Namespace == "" {
+			pod.Namespace = corev1.NamespaceDefault
+		}
+		if pod.Name == "" {
+			pod.Name = "simulated-" + string(uuid.NewUUID())
+		}
+		pod.UID = uuid.NewUUID()
+		pod.Spec.NodeName = ""
+		if pod.Spec.SchedulerName == "" {
+			pod.Spec.SchedulerName = corev1.DefaultSchedulerName
+		}
+		pods = append(pods, pod)
+	}
+
+	reservationLister := s.koordinatorSharedInformerFactory.Scheduling().V1alpha1().Reservations().Lister()
+	var reservations []*schedulingv1alpha1.Reservation
+	for _, r := range request.Reservations {
+		if r == nil {
+			continue
+		}
+		r = r.DeepCopy()
+		if r.Name == "" {
+			r.Name = "simulated-" + string(uuid.NewUUID())
+		}
+		if _, err := reservationLister.Get(r.Name); err == nil {
+			return nil, nil, fmt.Errorf("reservation %s already exists", r.Name)
+		} else if !apierrors.IsNotFound(err) {
+			return nil, nil, err
+		}
+		r.UID = uuid.NewUUID()
+		r.Status = schedulingv1alpha1.ReservationStatus{Phase: schedulingv1alpha1.ReservationPending}
+		if err := reservationutil.ValidateReservation(r); err != nil {
+			return nil, nil, err
+		}
+		reservations = append(reservations, r)
+		pods = append(pods, reservationutil.NewReservePod(r))
+	}
+	return pods, reservations, nil
+}
+
+// simulatePod runs the scheduling cycle for the pod against the simulated nodes, and adds the pod to the clone of
+// the suggested host if assume is true.
+func (s *Simulator) simulatePod(ctx context.Context, pod *corev1.Pod, reservations []*schedulingv1alpha1.Reservation, simulatedNodes map[string]*framework.NodeInfo, assume bool) *PodResult {
+	podResult := &PodResult{
+		Namespace: pod.Namespace,
+		Name:      pod.Name,
+	}
+	if reservationutil.IsReservePod(pod) {
+		podResult.Reservation = reservationutil.GetReservationNameFromReservePod(pod)
+	}
+
+	fwk, ok := s.sched.Profiles[pod.Spec.SchedulerName]
+	if !ok {
+		podResult.Message = fmt.Sprintf("profile not found for scheduler name %q", pod.Spec.SchedulerName)
+		return podResult
+	}
+
+	recorder := newRecordingFramework(fwk, simulatedNodes)
+	state := framework.NewCycleState()
+	// the reservation plugin looks up the Reservation objects of the reserve pods
+	frameworkext.SetSimulatedReservations(state, reservations)
+	scheduleResult, err := s.sched.SchedulePod(ctx, recorder, state, pod)
+	podResult.Nodes = recorder.nodeResults()
+	if err != nil {
+		podResult.Message = err.Error()
+		return podResult
+	}
+	podResult.SuggestedHost = scheduleResult.SuggestedHost
+	if !assume {
+		return podResult
+	}
+
+	nodeInfo := recorder.evaluatedNodeInfo(scheduleResult.SuggestedHost)
+	if nodeInfo == nil {
+		klog.V(4).InfoS("Failed to find the suggested host of simulated pod", "pod", klog.KObj(pod), "node", scheduleResult.SuggestedHost)
+		return podResult
+	}
+	if _, ok := simulatedNodes[scheduleResult.SuggestedHost]; !ok {
+		nodeInfo = nodeInfo.Clone()
+		simulatedNodes[scheduleResult.SuggestedHost] = nodeInfo
+	}
+	assumedPod := pod.DeepCopy()
+	assumedPod.Spec.NodeName = scheduleResult.SuggestedHost
+	nodeInfo.AddPod(assumedPod)
+	return podResult
+}
+
+// recordingFramework records the filter statuses and scores of the nodes evaluated in the scheduling cycle,
+// and filters the nodes having the simulated pods with their clones.
+type recordingFramework struct {
+	framework.Framework
+	// simulatedNodes are the clones of the nodes having the simulated pods, which are read-only in the cycle
+	simulatedNodes map[string]*framework.NodeInfo
+	lock           sync.Mutex
+	filterStatuses map[string]*framework.Status
+	nodeInfos      map[string]*framework.NodeInfo
+	scores         framework.PluginToNodeScores
+	scoredNodes    []*corev1.Node
+}
+
+func newRecordingFramework(fwk framework.Framework, simulatedNodes map[string]*framework.NodeInfo) *recordingFramework {
+	return &recordingFramework{
+		Framework:      fwk,
+		simulatedNodes: simulatedNodes,
+		filterStatuses: map[string]*framework.Status{},
+		nodeInfos:      map[string]*framework.NodeInfo{},
+	}
+}
+
+func (f *recordingFramework) RunFilterPluginsWithNominatedPods(ctx context.Context, state *framework.CycleState, pod *corev1.Pod, nodeInfo *framework.NodeInfo) *framework.Status {
+	node := nodeInfo.Node()
+	if node == nil {
+		return f.Framework.RunFilterPluginsWithNominatedPods(ctx, state, pod, nodeInfo)
+	}
+	if simulatedNode := f.simulatedNodes[node.Name]; simulatedNode != nil {
+		nodeInfo = simulatedNode
+	}
+	status := f.Framework.RunFilterPluginsWithNominatedPods(ctx, state, pod, nodeInfo)
+	f.lock.Lock()
+	f.filterStatuses[node.Name] = status
+	f.nodeInfos[node.Name] = nodeInfo
+	f.lock.Unlock()
+	return status
+}
+
+func (f *recordingFramework) evaluatedNodeInfo(nodeName string) *framework.NodeInfo {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.nodeInfos[nodeName]
+}
+
+func (f *recordingFramework) RunScorePlugins(ctx context.Context, state *framework.CycleState, pod *corev1.Pod, nodes []*corev1.Node) (framework.PluginToNodeScores, *framework.Status) {
+	scores, status := f.Framework.RunScorePlugins(ctx, state, pod, nodes)
+	if status.IsSuccess() {
+		f.lock.Lock()
+		f.scores = scores
+		f.scoredNodes = nodes
+		f.lock.Unlock()
+	}
+	return scores, status
+}
+
+func (f *recordingFramework) nodeResults() []*NodeResult {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	nodeResults := make(map[string]*NodeResult, len(f.filterStatuses))
+	for nodeName, status := range f.filterStatuses {
+		nodeResult := &NodeResult{
+			Name:     nodeName,
+			Feasible: status.IsSuccess(),
+		}
+		if !status.IsSuccess() {
+			nodeResult.FailedPlugin = status.FailedPlugin()
+			nodeResult.Reasons = status.Reasons()
+		}
+		nodeResults[nodeName] = nodeResult
+	}
+	for i, node := range f.scoredNodes {
+		nodeResult := nodeResults[node.Name]
+		if nodeResult == nil {
+			nodeResult = &NodeResult{Name: node.Name, Feasible: true}
+			nodeResults[node.Name] = nodeResult
+		}
+		nodeResult.PluginScores = map[string]int64{}
+		for pluginName, nodeScores := range f.scores {
+			if i < len(nodeScores) {
+				nodeResult.PluginScores[pluginName] = nodeScores[i].Score
+				nodeResult.Score += nodeScores[i].Score
+			}
+		}
+	}
+
+	results := make([]*NodeResult, 0, len(nodeResults))
+	for _, nodeResult := range nodeResults {
+		results = append(results, nodeResult)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Feasible != results[j].Feasible {
+			return results[i].Feasible
+		}
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Name < results[j].Name
+	})
+	return results
+}
@@ -0,0 +1,277 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubernetes/pkg/scheduler"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+	"k8s.io/kubernetes/pkg/scheduler/profile"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	koordfake "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
+	koordinatorinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
+	schedulinglister "github.com/koordinator-sh/koordinator/pkg/client/listers/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+	reservationutil "github.com/koordinator-sh/koordinator/pkg/util/reservation"
+)
+
+type fakeFramework struct {
+	framework.Framework
+	filterStatuses    map[string]*framework.Status
+	podLimits         map[string]int
+	scores            map[string]int64
+	reservationLister schedulinglister.ReservationLister
+}
+
+func (f *fakeFramework) RunFilterPluginsWithNominatedPods(ctx context.Context, state *framework.CycleState, pod *corev1.Pod, nodeInfo *framework.NodeInfo) *framework.Status {
+	if reservationutil.IsReservePod(pod) {
+		if _, err := frameworkext.GetReservation(state, f.reservationLister, reservationutil.GetReservationNameFromReservePod(pod)); err != nil {
+			return framework.AsStatus(err)
+		}
+	}
+	if limit, ok := f.podLimits[nodeInfo.Node().Name]; ok && len(nodeInfo.Pods) >= limit {
+		return framework.NewStatus(framework.Unschedulable, "Too many pods").WithFailedPlugin("NodeResourcesFit")
+	}
+	return f.filterStatuses[nodeInfo.Node().Name]
+}
+
+// startScheduling starts waiting for the next pod, and returns after the scheduler releases the cycle lock.
+func startScheduling(s *Simulator) {
+	go s.sched.NextPod()
+	for s.schedAdapter.RunBetweenSchedulingCycles(context.TODO(), func() error { return nil }) != nil {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func (f *fakeFramework) RunScorePlugins(ctx context.Context, state *framework.CycleState, pod *corev1.Pod, nodes []*corev1.Node) (framework.PluginToNodeScores, *framework.Status) {
+	var scores framework.NodeScoreList
+	for _, node := range nodes {
+		scores = append(scores, framework.NodeScore{Name: node.Name, Score: f.scores[node.Name]})
+	}
+	return framework.PluginToNodeScores{"FakeScore": scores}, nil
+}
+
+// fakeSchedulePod evaluates all the nodes and selects the node with the highest score.
+func fakeSchedulePod(nodes []*corev1.Node) func(ctx context.Context, fwk framework.Framework, state *framework.CycleState, pod *corev1.Pod) (scheduler.ScheduleResult, error) {
+	return func(ctx context.Context, fwk framework.Framework, state *framework.CycleState, pod *corev1.Pod) (scheduler.ScheduleResult, error) {
+		var feasibleNodes []*corev1.Node
+		diagnosis := framework.Diagnosis{NodeToStatusMap: framework.NodeToStatusMap{}}
+		for _, node := range nodes {
+			nodeInfo := framework.NewNodeInfo()
+			nodeInfo.SetNode(node)
+			if status := fwk.RunFilterPluginsWithNominatedPods(ctx, state, pod, nodeInfo); !status.IsSuccess() {
+				diagnosis.NodeToStatusMap[node.Name] = status
+				continue
+			}
+			feasibleNodes = append(feasibleNodes, node)
+		}
+		if len(feasibleNodes) == 0 {
+			return scheduler.ScheduleResult{}, &framework.FitError{Pod: pod, NumAllNodes: len(nodes), Diagnosis: diagnosis}
+		}
+		scores, _ := fwk.RunScorePlugins(ctx, state, pod, feasibleNodes)
+		var host string
+		var maxScore int64 = -1
+		for _, score := range scores["FakeScore"] {
+			if score.Score > maxScore {
+				host, maxScore = score.Name, score.Score
+			}
+		}
+		return scheduler.ScheduleResult{SuggestedHost: host, FeasibleNodes: len(feasibleNodes)}, nil
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	nodes := []*corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-2"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-3"}},
+	}
+	fwk := &fakeFramework{
+		filterStatuses: map[string]*framework.Status{
+			"node-1": framework.NewStatus(framework.Unschedulable, "Insufficient cpu").WithFailedPlugin("NodeResourcesFit"),
+		},
+		scores: map[string]int64{
+			"node-2": 10,
+			"node-3": 50,
+		},
+	}
+	nextPodCh := make(chan *framework.QueuedPodInfo)
+	sched := &scheduler.Scheduler{
+		Profiles: profile.Map{corev1.DefaultSchedulerName: fwk},
+		NextPod: func() *framework.QueuedPodInfo {
+			return <-nextPodCh
+		},
+		SchedulePod: fakeSchedulePod(nodes),
+	}
+	koordSharedInformerFactory := koordinatorinformers.NewSharedInformerFactory(koordfake.NewSimpleClientset(), 0)
+	s := New(frameworkext.NewSchedulerAdapter(sched), koordSharedInformerFactory)
+	request := &Request{
+		Pods: []*corev1.Pod{
+			{ObjectMeta: metav1.ObjectMeta{Name: "test-pod"}},
+		},
+	}
+
+	// the scheduler is not scheduling on the follower
+	_, err := s.Simulate(context.TODO(), request)
+	assert.Equal(t, frameworkext.ErrNotScheduling, err)
+
+	// the scheduler is waiting for the next pod
+	startScheduling(s)
+	result, err := s.Simulate(context.TODO(), request)
+	assert.NoError(t, err)
+	expected := &Result{
+		Schedulable: true,
+		Pods: []*PodResult{
+			{
+				Namespace:     corev1.NamespaceDefault,
+				Name:          "test-pod",
+				SuggestedHost: "node-3",
+				Nodes: []*NodeResult{
+					{Name: "node-3", Feasible: true, Score: 50, PluginScores: map[string]int64{"FakeScore": 50}},
+					{Name: "node-2", Feasible: true, Score: 10, PluginScores: map[string]int64{"FakeScore": 10}},
+					{Name: "node-1", Feasible: false, FailedPlugin: "NodeResourcesFit", Reasons: []string{"Insufficient cpu"}},
+				},
+			},
+		},
+	}
+	assert.Equal(t, expected, result)
+
+	_, err = s.Simulate(context.TODO(), &Request{})
+	assert.Error(t, err)
+
+	request.Pods[0].Spec.SchedulerName = "unknown-scheduler"
+	result, err = s.Simulate(context.TODO(), request)
+	assert.NoError(t, err)
+	assert.False(t, result.Schedulable)
+	assert.NotEmpty(t, result.Pods[0].Message)
+
+	// the simulation waits for the scheduling cycle
+	nextPodCh <- nil
+	assert.Eventually(t, func() bool {
+		ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+		defer cancel()
+		_, err = s.Simulate(ctx, request)
+		return err == context.DeadlineExceeded
+	}, time.Second, time.Millisecond)
+}
+
+func TestSimulateMultiplePods(t *testing.T) {
+	nodes := []*corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-2"}},
+	}
+	fwk := &fakeFramework{
+		podLimits: map[string]int{"node-2": 1},
+		scores: map[string]int64{
+			"node-1": 10,
+			"node-2": 50,
+		},
+	}
+	nextPodCh := make(chan *framework.QueuedPodInfo)
+	sched := &scheduler.Scheduler{
+		Profiles: profile.Map{corev1.DefaultSchedulerName: fwk},
+		NextPod: func() *framework.QueuedPodInfo {
+			return <-nextPodCh
+		},
+		SchedulePod: fakeSchedulePod(nodes),
+	}
+	s := New(frameworkext.NewSchedulerAdapter(sched), koordinatorinformers.NewSharedInformerFactory(koordfake.NewSimpleClientset(), 0))
+	startScheduling(s)
+
+	// the second pod sees the first pod on the simulated node
+	request := &Request{
+		Pods: []*corev1.Pod{
+			{ObjectMeta: metav1.ObjectMeta{Name: "test-pod-1"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "test-pod-2"}},
+		},
+	}
+	result, err := s.Simulate(context.TODO(), request)
+	assert.NoError(t, err)
+	assert.True(t, result.Schedulable)
+	assert.Equal(t, "node-2", result.Pods[0].SuggestedHost)
+	assert.Equal(t, "node-1", result.Pods[1].SuggestedHost)
+
+	// the simulated nodes are dropped after the simulation
+	result, err = s.Simulate(context.TODO(), &Request{Pods: request.Pods[:1]})
+	assert.NoError(t, err)
+	assert.Equal(t, "node-2", result.Pods[0].SuggestedHost)
+	nextPodCh <- nil
+}
+
+func TestSimulateReservationsAndGangs(t *testing.T) {
+	nodes := []*corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+	}
+	koordSharedInformerFactory := koordinatorinformers.NewSharedInformerFactory(koordfake.NewSimpleClientset(), 0)
+	reservationInformer := koordSharedInformerFactory.Scheduling().V1alpha1().Reservations()
+	fwk := &fakeFramework{
+		scores:            map[string]int64{"node-1": 10},
+		reservationLister: reservationInformer.Lister(),
+	}
+	nextPodCh := make(chan *framework.QueuedPodInfo)
+	sched := &scheduler.Scheduler{
+		Profiles: profile.Map{corev1.DefaultSchedulerName: fwk},
+		NextPod: func() *framework.QueuedPodInfo {
+			return <-nextPodCh
+		},
+		SchedulePod: fakeSchedulePod(nodes),
+	}
+	s := New(frameworkext.NewSchedulerAdapter(sched), koordSharedInformerFactory)
+	startScheduling(s)
+
+	// the simulated reservation is visible to the simulated cycle only
+	result, err := s.Simulate(context.TODO(), &Request{
+		Reservations: []*schedulingv1alpha1.Reservation{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-reservation"},
+				Spec: schedulingv1alpha1.ReservationSpec{
+					Template: &corev1.PodTemplateSpec{},
+					TTL:      &metav1.Duration{Duration: time.Hour},
+					Owners: []schedulingv1alpha1.ReservationOwner{
+						{Object: &corev1.ObjectReference{Name: "test-pod"}},
+					},
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.True(t, result.Schedulable)
+	assert.Equal(t, "node-1", result.Pods[0].SuggestedHost)
+	assert.Empty(t, reservationInformer.Informer().GetIndexer().List())
+
+	// the pods of gangs are not supported
+	_, err = s.Simulate(context.TODO(), &Request{
+		Pods: []*corev1.Pod{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "gang-pod",
+					Annotations: map[string]string{extension.AnnotationGangName: "test-gang"},
+				},
+			},
+		},
+	})
+	assert.Error(t, err)
+	nextPodCh <- nil
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+)
+
+// Request describes the objects to be simulated.
+// Pods are simulated in order and then Reservations, and each one sees the placements of the previous ones when
+// filtering the nodes.
+// The pods of gangs are not supported.
+type Request struct {
+	Pods         []*corev1.Pod                     `json:"pods,omitempty"`
+	Reservations []*schedulingv1alpha1.Reservation `json:"reservations,omitempty"`
+}
+
+type Result struct {
+	// Schedulable is true if all the objects fit.
+	Schedulable bool         `json:"schedulable"`
+	Pods        []*PodResult `json:"pods,omitempty"`
+}
+
+type PodResult struct {
+	Namespace   string `json:"namespace,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Reservation string `json:"reservation,omitempty"`
+	// SuggestedHost is the node selected by the scheduler, empty if the pod doesn't fit.
+	SuggestedHost string `json:"suggestedHost,omitempty"`
+	// Message is the reason why the pod doesn't fit.
+	Message string `json:"message,omitempty"`
+	// Nodes are the nodes evaluated by the scheduler, sorted by feasibility and score.
+	Nodes []*NodeResult `json:"nodes,omitempty"`
+}
+
+type NodeResult struct {
+	Name     string `json:"name"`
+	Feasible bool   `json:"feasible"`
+	// FailedPlugin and Reasons are the filter failure of the infeasible node.
+	FailedPlugin string   `json:"failedPlugin,omitempty"`
+	Reasons      []string `json:"reasons,omitempty"`
+	// Score is the sum of the weighted plugin scores, which is only calculated if there are multiple feasible nodes.
+	Score        int64            `json:"score,omitempty"`
+	PluginScores map[string]int64 `json:"pluginScores,omitempty"`
+}
@@ -288,7 +288,7 @@ func (n *nodeDevice) tryAllocateDevice(
 			return nil, fmt.Errorf("node does not have enough %v", deviceType)
 		}
 
-		if deviceType == schedulingv1alpha1.GPU {
+		if deviceType == schedulingv1alpha1.GPU && !isBatchGPURequest(deviceRequest) {
 			if err := fillGPUTotalMem(nodeDeviceTotal, deviceRequest); err != nil {
 				return nil, err
 			}
@@ -548,6 +548,20 @@ func (n *nodeDeviceCache) updateNodeDevice(nodeName string, device *schedulingv1
 }
 
 func buildDeviceResources(device *schedulingv1alpha1.Device) map[schedulingv1alpha1.DeviceType]deviceResources {
+	// the reclaimable resources of the devices are appended to the device total, so that the Batch requests
+	// are allocated only from them.
+	batchResources, err := apiext.GetDeviceBatchResources(device.Annotations)
+	if err != nil {
+		klog.Errorf("Failed to parse device batch resources, nodeName:%v, err:%v", device.Name, err)
+	}
+	batchResourcesByMinor := map[schedulingv1alpha1.DeviceType]map[int32]corev1.ResourceList{}
+	for deviceType, resources := range batchResources {
+		batchResourcesByMinor[deviceType] = map[int32]corev1.ResourceList{}
+		for _, r := range resources {
+			batchResourcesByMinor[deviceType][r.Minor] = r.Resources
+		}
+	}
+
 	nodeDeviceResource := map[schedulingv1alpha1.DeviceType]deviceResources{}
 	for _, deviceInfo := range device.Spec.Devices {
 		if nodeDeviceResource[deviceInfo.Type] == nil {
@@ -560,6 +574,9 @@ func buildDeviceResources(device *schedulingv1alpha1.Device) map[schedulingv1alp
 			klog.Errorf("Find device unhealthy, nodeName:%v, deviceType:%v, minor:%v", device.Name, deviceInfo.Type, deviceInfo.Minor)
 		} else {
 			resources = deviceInfo.Resources
+			if batch := batchResourcesByMinor[deviceInfo.Type][*deviceInfo.Minor]; len(batch) > 0 {
+				resources = quotav1.Add(resources, batch)
+			}
 			klog.V(5).Infof("Find device resource update, nodeName:%v, deviceType:%v, minor:%v, res:%v", device.Name, deviceInfo.Type, deviceInfo.Minor, resources)
 		}
 		nodeDeviceResource[deviceInfo.Type][int(*deviceInfo.Minor)] = resources
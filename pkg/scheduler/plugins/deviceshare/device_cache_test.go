@@ -572,6 +572,97 @@ func Test_nodeDevice_allocateGPUWithUnhealthyInstance(t *testing.T) {
 	assert.True(t, equality.Semantic.DeepEqual(expectAllocations, allocateResult))
 }
 
+func Test_nodeDevice_allocateBatchGPU(t *testing.T) {
+	device := &schedulingv1alpha1.Device{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-node-1"},
+		Spec: schedulingv1alpha1.DeviceSpec{
+			Devices: []schedulingv1alpha1.DeviceInfo{
+				{
+					Type:   schedulingv1alpha1.GPU,
+					Minor:  pointer.Int32(0),
+					Health: true,
+					Resources: corev1.ResourceList{
+						apiext.ResourceGPUCore:        resource.MustParse("100"),
+						apiext.ResourceGPUMemory:      resource.MustParse("16Gi"),
+						apiext.ResourceGPUMemoryRatio: resource.MustParse("100"),
+					},
+				},
+				{
+					Type:   schedulingv1alpha1.GPU,
+					Minor:  pointer.Int32(1),
+					Health: true,
+					Resources: corev1.ResourceList{
+						apiext.ResourceGPUCore:        resource.MustParse("100"),
+						apiext.ResourceGPUMemory:      resource.MustParse("16Gi"),
+						apiext.ResourceGPUMemoryRatio: resource.MustParse("100"),
+					},
+				},
+			},
+		},
+	}
+	assert.NoError(t, apiext.SetDeviceBatchResources(device, apiext.DeviceBatchResources{
+		schedulingv1alpha1.GPU: {
+			{
+				Minor: 0,
+				Resources: corev1.ResourceList{
+					apiext.BatchGPUCore:   resource.MustParse("20"),
+					apiext.BatchGPUMemory: resource.MustParse("2Gi"),
+				},
+			},
+			{
+				Minor: 1,
+				Resources: corev1.ResourceList{
+					apiext.BatchGPUCore:   resource.MustParse("60"),
+					apiext.BatchGPUMemory: resource.MustParse("8Gi"),
+				},
+			},
+		},
+	}))
+
+	nd := newNodeDevice()
+	nd.resetDeviceTotal(buildDeviceResources(device))
+	expectTotal := deviceResources{
+		0: corev1.ResourceList{
+			apiext.ResourceGPUCore:        resource.MustParse("100"),
+			apiext.ResourceGPUMemory:      resource.MustParse("16Gi"),
+			apiext.ResourceGPUMemoryRatio: resource.MustParse("100"),
+			apiext.BatchGPUCore:           resource.MustParse("20"),
+			apiext.BatchGPUMemory:         resource.MustParse("2Gi"),
+		},
+		1: corev1.ResourceList{
+			apiext.ResourceGPUCore:        resource.MustParse("100"),
+			apiext.ResourceGPUMemory:      resource.MustParse("16Gi"),
+			apiext.ResourceGPUMemoryRatio: resource.MustParse("100"),
+			apiext.BatchGPUCore:           resource.MustParse("60"),
+			apiext.BatchGPUMemory:         resource.MustParse("8Gi"),
+		},
+	}
+	assert.True(t, equality.Semantic.DeepEqual(expectTotal, nd.deviceTotal[schedulingv1alpha1.GPU]))
+
+	podRequests := corev1.ResourceList{
+		apiext.BatchGPUCore:   resource.MustParse("40"),
+		apiext.BatchGPUMemory: resource.MustParse("4Gi"),
+	}
+	allocateResult, err := nd.tryAllocateDevice(podRequests, nil, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	expectAllocations := apiext.DeviceAllocations{
+		schedulingv1alpha1.GPU: {
+			{
+				Minor: 1,
+				Resources: corev1.ResourceList{
+					apiext.BatchGPUCore:   resource.MustParse("40"),
+					apiext.BatchGPUMemory: resource.MustParse("4Gi"),
+				},
+			},
+		},
+	}
+	assert.True(t, equality.Semantic.DeepEqual(expectAllocations, allocateResult))
+
+	nd.updateCacheUsed(allocateResult, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "batch-pod"}}, true)
+	_, err = nd.tryAllocateDevice(podRequests, nil, nil, nil, nil, nil)
+	assert.Error(t, err)
+}
+
 func Test_nodeDevice_allocateRDMA(t *testing.T) {
 	nd := newNodeDevice()
 	nd.resetDeviceTotal(map[schedulingv1alpha1.DeviceType]deviceResources{
@@ -37,6 +37,8 @@ const (
 	GPUMemoryRatio
 	FPGA
 	RDMA
+	BatchGPUCore
+	BatchGPUMemory
 )
 
 var DeviceResourceNames = map[schedulingv1alpha1.DeviceType][]corev1.ResourceName{
@@ -47,6 +49,8 @@ var DeviceResourceNames = map[schedulingv1alpha1.DeviceType][]corev1.ResourceNam
 		apiext.ResourceGPUCore,
 		apiext.ResourceGPUMemory,
 		apiext.ResourceGPUMemoryRatio,
+		apiext.BatchGPUCore,
+		apiext.BatchGPUMemory,
 	},
 	schedulingv1alpha1.RDMA: {apiext.ResourceRDMA},
 	schedulingv1alpha1.FPGA: {apiext.ResourceFPGA},
@@ -61,18 +65,21 @@ var DeviceResourceFlags = map[corev1.ResourceName]uint{
 	apiext.ResourceGPUMemoryRatio: GPUMemoryRatio,
 	apiext.ResourceFPGA:           FPGA,
 	apiext.ResourceRDMA:           RDMA,
+	apiext.BatchGPUCore:           BatchGPUCore,
+	apiext.BatchGPUMemory:         BatchGPUMemory,
 }
 
 var ValidDeviceResourceCombinations = map[uint]bool{
-	NvidiaGPU:                true,
-	HygonDCU:                 true,
-	KoordGPU:                 true,
-	GPUMemory:                true,
-	GPUMemoryRatio:           true,
-	GPUCore | GPUMemory:      true,
-	GPUCore | GPUMemoryRatio: true,
-	FPGA:                     true,
-	RDMA:                     true,
+	NvidiaGPU:                     true,
+	HygonDCU:                      true,
+	KoordGPU:                      true,
+	GPUMemory:                     true,
+	GPUMemoryRatio:                true,
+	GPUCore | GPUMemory:           true,
+	GPUCore | GPUMemoryRatio:      true,
+	FPGA:                          true,
+	RDMA:                          true,
+	BatchGPUCore | BatchGPUMemory: true,
 }
 
 var DeviceResourceValidators = map[corev1.ResourceName]func(q resource.Quantity) bool{
@@ -81,6 +88,7 @@ var DeviceResourceValidators = map[corev1.ResourceName]func(q resource.Quantity)
 	apiext.ResourceGPUMemoryRatio: ValidatePercentageResource,
 	apiext.ResourceFPGA:           ValidatePercentageResource,
 	apiext.ResourceRDMA:           ValidatePercentageResource,
+	apiext.BatchGPUCore:           ValidatePercentageResource,
 }
 
 var ResourceCombinationsMapper = map[uint]func(podRequest corev1.ResourceList) corev1.ResourceList{
@@ -136,6 +144,12 @@ var ResourceCombinationsMapper = map[uint]func(podRequest corev1.ResourceList) c
 			apiext.ResourceRDMA: podRequest[apiext.ResourceRDMA],
 		}
 	},
+	BatchGPUCore | BatchGPUMemory: func(podRequest corev1.ResourceList) corev1.ResourceList {
+		return corev1.ResourceList{
+			apiext.BatchGPUCore:   podRequest[apiext.BatchGPUCore],
+			apiext.BatchGPUMemory: podRequest[apiext.BatchGPUMemory],
+		}
+	},
 }
 
 func ValidatePercentageResource(q resource.Quantity) bool {
@@ -208,6 +222,13 @@ func memoryBytesToRatio(bytes, totalMemory resource.Quantity) resource.Quantity
 	return *resource.NewQuantity(int64(float64(bytes.Value())/float64(totalMemory.Value())*100), resource.DecimalSI)
 }
 
+// isBatchGPURequest checks if the pod requests the Batch GPU resources which are only allocated from the
+// reclaimable resources of the GPUs.
+func isBatchGPURequest(podRequest corev1.ResourceList) bool {
+	batchGPUCore, batchGPUMemory := podRequest[apiext.BatchGPUCore], podRequest[apiext.BatchGPUMemory]
+	return !batchGPUCore.IsZero() || !batchGPUMemory.IsZero()
+}
+
 func fillGPUTotalMem(nodeDeviceTotal deviceResources, podRequest corev1.ResourceList) error {
 	// nodeDeviceTotal uses the minor of GPU as key. However, under certain circumstances,
 	// minor 0 might not exist. We need to iterate the cache once to find the active minor.
@@ -166,6 +166,32 @@ func TestValidateDeviceRequest(t *testing.T) {
 			},
 			want:    RDMA,
 			wantErr: false,
+		}, {
+			name: "invalid batch gpu request",
+			podRequest: corev1.ResourceList{
+				apiext.BatchGPUCore: resource.MustParse("50"),
+			},
+			want:    BatchGPUCore,
+			wantErr: true,
+		},
+		{
+			name: "invalid batch gpu request mixed with gpu request",
+			podRequest: corev1.ResourceList{
+				apiext.BatchGPUCore:      resource.MustParse("50"),
+				apiext.BatchGPUMemory:    resource.MustParse("4Gi"),
+				apiext.ResourceGPUMemory: resource.MustParse("4Gi"),
+			},
+			want:    BatchGPUCore | BatchGPUMemory | GPUMemory,
+			wantErr: true,
+		},
+		{
+			name: "valid batch gpu request",
+			podRequest: corev1.ResourceList{
+				apiext.BatchGPUCore:   resource.MustParse("50"),
+				apiext.BatchGPUMemory: resource.MustParse("4Gi"),
+			},
+			want:    BatchGPUCore | BatchGPUMemory,
+			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
@@ -226,7 +226,7 @@ func (qi *QuotaInfo) GetRuntime() v1.ResourceList {
 	return qi.CalculateInfo.Runtime.DeepCopy()
 }
 
-func (qi *QuotaInfo) getMax() v1.ResourceList {
+func (qi *QuotaInfo) GetMax() v1.ResourceList {
 	qi.lock.Lock()
 	defer qi.lock.Unlock()
 	return qi.CalculateInfo.Max.DeepCopy()
@@ -332,6 +332,17 @@ func (qi *QuotaInfo) GetPodCache() map[string]*v1.Pod {
 	return pods
 }
 
+// GetPod returns the cached pod with the namespace and name, or nil if not present.
+func (qi *QuotaInfo) GetPod(namespace, name string) *v1.Pod {
+	qi.lock.Lock()
+	defer qi.lock.Unlock()
+
+	if podInfo, exist := qi.PodCache[namespace+"/"+name]; exist {
+		return podInfo.pod
+	}
+	return nil
+}
+
 func (qi *QuotaInfo) CheckPodIsAssigned(pod *v1.Pod) bool {
 	qi.lock.Lock()
 	defer qi.lock.Unlock()
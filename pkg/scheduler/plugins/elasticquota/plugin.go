@@ -24,6 +24,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	quotav1 "k8s.io/apiserver/pkg/quota/v1"
 	v1 "k8s.io/client-go/listers/core/v1"
@@ -38,6 +39,9 @@ import (
 	"sigs.k8s.io/scheduler-plugins/pkg/generated/informers/externalversions"
 	"sigs.k8s.io/scheduler-plugins/pkg/generated/listers/scheduling/v1alpha1"
 
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	schedulinglisters "github.com/koordinator-sh/koordinator/pkg/client/listers/scheduling/v1alpha1"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config/validation"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
@@ -57,6 +61,8 @@ type PostFilterState struct {
 	quotaInfo *core.QuotaInfo
 	used      corev1.ResourceList
 	runtime   corev1.ResourceList
+	// reserved is the resources of the pod estimated to be allocated from a reservation in the PreFilter
+	reserved corev1.ResourceList
 }
 
 func (p *PostFilterState) Clone() framework.StateData {
@@ -64,6 +70,7 @@ func (p *PostFilterState) Clone() framework.StateData {
 		quotaInfo: p.quotaInfo,
 		used:      p.used.DeepCopy(),
 		runtime:   p.runtime.DeepCopy(),
+		reserved:  p.reserved.DeepCopy(),
 	}
 }
 
@@ -75,6 +82,14 @@ type Plugin struct {
 	podLister   v1.PodLister
 	pdbLister   policylisters.PodDisruptionBudgetLister
 	nodeLister  v1.NodeLister
+	// reservationLister is nil if the handle doesn't support Reservations
+	reservationLister schedulinglisters.ReservationLister
+	// reservationLock protects the reservationAllocated and the charges of reservations
+	reservationLock sync.Mutex
+	// reservationAllocated records the requests of the owner pods allocated from each reservation
+	reservationAllocated map[types.UID]map[types.UID]corev1.ResourceList
+	// quotaReservations indexes the Available reservations by the quota label, quota name -> reservation uid -> reservation
+	quotaReservations map[string]map[types.UID]*schedulingv1alpha1.Reservation
 	// only used in OnNodeAdd,in case Recover and normal Watch double call OnNodeAdd
 	nodeResourceMapLock sync.Mutex
 	nodeResourceMap     map[string]struct{}
@@ -117,6 +132,9 @@ func New(args runtime.Object, handle framework.Handle) (framework.Plugin, error)
 		nodeLister:        handle.SharedInformerFactory().Core().V1().Nodes().Lister(),
 		groupQuotaManager: core.NewGroupQuotaManager(pluginArgs.SystemQuotaGroupMax, pluginArgs.DefaultQuotaGroupMax),
 		nodeResourceMap:   make(map[string]struct{}),
+
+		reservationAllocated: map[types.UID]map[types.UID]corev1.ResourceList{},
+		quotaReservations:    map[string]map[types.UID]*schedulingv1alpha1.Reservation{},
 	}
 
 	ctx := context.TODO()
@@ -143,6 +161,16 @@ func New(args runtime.Object, handle framework.Handle) (framework.Plugin, error)
 		DeleteFunc: elasticQuota.OnPodDelete,
 	})
 
+	if extendedHandle, ok := handle.(frameworkext.ExtendedHandle); ok {
+		reservationInformer := extendedHandle.KoordinatorSharedInformerFactory().Scheduling().V1alpha1().Reservations()
+		elasticQuota.reservationLister = reservationInformer.Lister()
+		frameworkexthelper.ForceSyncFromInformer(ctx.Done(), extendedHandle.KoordinatorSharedInformerFactory(), reservationInformer.Informer(), cache.ResourceEventHandlerFuncs{
+			AddFunc:    elasticQuota.OnReservationAdd,
+			UpdateFunc: elasticQuota.OnReservationUpdate,
+			DeleteFunc: elasticQuota.OnReservationDelete,
+		})
+	}
+
 	elasticQuota.migrateDefaultQuotaGroupsPod()
 
 	return elasticQuota, nil
@@ -174,7 +202,15 @@ func (g *Plugin) PreFilter(ctx context.Context, cycleState *framework.CycleState
 	state := g.snapshotPostFilterState(quotaInfo, cycleState)
 
 	podRequest, _ := resource.PodRequestsAndLimits(pod)
+	if reservationutil.IsReservePod(pod) && extension.GetQuotaName(pod) != "" {
+		// the reservation pre-books resources of the quota, so it can't exceed the max of the quota
+		return nil, g.checkReservationQuota(quotaName, quotaInfo, podRequest)
+	}
 	used := quotav1.Add(podRequest, state.used)
+	if reserved := g.getReservedQuota(pod, quotaName); len(reserved) > 0 {
+		used = quotav1.SubtractWithNonNegativeResult(used, reserved)
+		state.reserved = reserved
+	}
 
 	if isLessEqual, exceedDimensions := quotav1.LessThanOrEqual(used, state.runtime); !isLessEqual {
 		return nil, framework.NewStatus(framework.Unschedulable, fmt.Sprintf("Insufficient quotas, "+
@@ -255,11 +291,36 @@ func (g *Plugin) PostFilter(ctx context.Context, state *framework.CycleState, po
 
 func (g *Plugin) Reserve(ctx context.Context, state *framework.CycleState, p *corev1.Pod, nodeName string) *framework.Status {
 	quotaName := g.getPodAssociateQuotaName(p)
+	if reservationutil.IsReservePod(p) && extension.GetQuotaName(p) != "" {
+		// assume the charge of the reservation until the reservation becomes Available
+		assumedPod := p.DeepCopy()
+		assumedPod.Spec.NodeName = nodeName
+		g.groupQuotaManager.OnPodAdd(quotaName, assumedPod)
+		return framework.NewStatus(framework.Success, "")
+	}
+	if status := g.checkNominatedReservation(state, p, quotaName, nodeName); !status.IsSuccess() {
+		return status
+	}
 	g.groupQuotaManager.ReservePod(quotaName, p)
+	// the pod consumes the charge of the nominated reservation
+	if rInfo := frameworkext.GetNominatedReservation(state, nodeName); rInfo != nil {
+		g.assignReservationOwner(rInfo.UID(), rInfo.GetName(), p)
+	}
 	return framework.NewStatus(framework.Success, "")
 }
 
 func (g *Plugin) Unreserve(ctx context.Context, state *framework.CycleState, p *corev1.Pod, nodeName string) {
 	quotaName := g.getPodAssociateQuotaName(p)
+	if reservationutil.IsReservePod(p) && extension.GetQuotaName(p) != "" {
+		if quotaInfo := g.groupQuotaManager.GetQuotaInfoByName(quotaName); quotaInfo != nil {
+			if assumedPod := quotaInfo.GetPod(p.Namespace, p.Name); assumedPod != nil {
+				g.groupQuotaManager.OnPodDelete(quotaName, assumedPod)
+			}
+		}
+		return
+	}
 	g.groupQuotaManager.UnreservePod(quotaName, p)
+	if rInfo := frameworkext.GetNominatedReservation(state, nodeName); rInfo != nil {
+		g.unassignReservationOwner(rInfo.UID(), rInfo.GetName(), p)
+	}
 }
@@ -184,6 +184,19 @@ func (g *Plugin) checkQuotaRecursive(curQuotaName string, quotaNameTopo []string
 	return framework.NewStatus(framework.Success, "")
 }
 
+// checkReservationQuota checks whether the used of the quota exceeds the max after charging the reservation.
+func (g *Plugin) checkReservationQuota(quotaName string, quotaInfo *core.QuotaInfo, reservationRequest v1.ResourceList) *framework.Status {
+	quotaUsed := quotaInfo.GetUsed()
+	quotaMax := quotaInfo.GetMax()
+	newUsed := quotav1.Add(reservationRequest, quotaUsed)
+	if isLessEqual, exceedDimensions := quotav1.LessThanOrEqual(newUsed, quotaMax); !isLessEqual {
+		return framework.NewStatus(framework.UnschedulableAndUnresolvable, fmt.Sprintf("Insufficient quotas for reservation, "+
+			"quotaName: %v, max: %v, used: %v, reservation's request: %v, exceedDimensions: %v", quotaName,
+			printResourceList(quotaMax), printResourceList(quotaUsed), printResourceList(reservationRequest), exceedDimensions))
+	}
+	return framework.NewStatus(framework.Success, "")
+}
+
 func printResourceList(rl v1.ResourceList) string {
 	res := make([]string, 0)
 	for k, v := range rl {
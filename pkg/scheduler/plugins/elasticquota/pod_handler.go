@@ -31,6 +31,7 @@ func (g *Plugin) OnPodAdd(obj interface{}) {
 
 	quotaName := g.getPodAssociateQuotaName(pod)
 	g.groupQuotaManager.OnPodAdd(quotaName, pod)
+	g.onReservationOwnerPodUpdate(nil, pod)
 	klog.V(5).Infof("OnPodAddFunc %v.%v add success, quotaName:%v", pod.Namespace, pod.Name, quotaName)
 }
 
@@ -46,6 +47,7 @@ func (g *Plugin) OnPodUpdate(oldObj, newObj interface{}) {
 	oldQuotaName := g.getPodAssociateQuotaName(oldPod)
 	newQuotaName := g.getPodAssociateQuotaName(newPod)
 	g.groupQuotaManager.OnPodUpdate(newQuotaName, oldQuotaName, newPod, oldPod)
+	g.onReservationOwnerPodUpdate(oldPod, newPod)
 	klog.V(5).Infof("OnPodUpdateFunc %v.%v update success, quotaName:%v", newPod.Namespace, newPod.Name, newQuotaName)
 }
 
@@ -57,5 +59,6 @@ func (g *Plugin) OnPodDelete(obj interface{}) {
 
 	quotaName := g.getPodAssociateQuotaName(pod)
 	g.groupQuotaManager.OnPodDelete(quotaName, pod)
+	g.onReservationOwnerPodUpdate(pod, nil)
 	klog.V(5).Infof("OnPodDeleteFunc %v.%v delete success", pod.Namespace, pod.Name)
 }
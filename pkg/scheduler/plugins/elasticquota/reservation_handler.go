@@ -0,0 +1,345 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elasticquota
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/api/v1/resource"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+	"github.com/koordinator-sh/koordinator/pkg/util"
+	reservationutil "github.com/koordinator-sh/koordinator/pkg/util/reservation"
+)
+
+// Reservations with the quota label are charged to the quota once scheduled. Only the unallocated resources of the
+// reservation are charged, since the resources allocated to the owner pods are charged by the pods themselves.
+// The allocated resources are tracked from the owner pods, including the pods assumed in the Reserve phase,
+// so the charge is transferred rather than doubled when the owner pods consume the reservation.
+
+func (g *Plugin) OnReservationAdd(obj interface{}) {
+	r, ok := obj.(*schedulingv1alpha1.Reservation)
+	if !ok {
+		return
+	}
+	g.syncReservationCharge(nil, r)
+}
+
+func (g *Plugin) OnReservationUpdate(oldObj, newObj interface{}) {
+	oldR, ok := oldObj.(*schedulingv1alpha1.Reservation)
+	if !ok {
+		return
+	}
+	newR, ok := newObj.(*schedulingv1alpha1.Reservation)
+	if !ok {
+		return
+	}
+	g.syncReservationCharge(oldR, newR)
+}
+
+func (g *Plugin) OnReservationDelete(obj interface{}) {
+	var r *schedulingv1alpha1.Reservation
+	switch t := obj.(type) {
+	case *schedulingv1alpha1.Reservation:
+		r = t
+	case cache.DeletedFinalStateUnknown:
+		var ok bool
+		r, ok = t.Obj.(*schedulingv1alpha1.Reservation)
+		if !ok {
+			return
+		}
+	default:
+		return
+	}
+	g.syncReservationCharge(r, nil)
+}
+
+// onReservationOwnerPodUpdate records the owner pods allocated from reservations and refreshes the charges of
+// the reservations. The oldPod or newPod can be nil when the pod is added or deleted.
+func (g *Plugin) onReservationOwnerPodUpdate(oldPod, newPod *corev1.Pod) {
+	var oldAllocated, newAllocated *extension.ReservationAllocated
+	if oldPod != nil {
+		oldAllocated, _ = extension.GetReservationAllocated(oldPod)
+	}
+	if newPod != nil && !util.IsPodTerminated(newPod) {
+		newAllocated, _ = extension.GetReservationAllocated(newPod)
+	}
+	if oldAllocated != nil && (newAllocated == nil || newAllocated.UID != oldAllocated.UID) {
+		g.unassignReservationOwner(oldAllocated.UID, oldAllocated.Name, oldPod)
+	}
+	if newAllocated != nil {
+		g.assignReservationOwner(newAllocated.UID, newAllocated.Name, newPod)
+	}
+}
+
+func (g *Plugin) assignReservationOwner(reservationUID types.UID, reservationName string, pod *corev1.Pod) {
+	requests, _ := resource.PodRequestsAndLimits(pod)
+	g.reservationLock.Lock()
+	defer g.reservationLock.Unlock()
+	owners := g.reservationAllocated[reservationUID]
+	if owners == nil {
+		owners = map[types.UID]corev1.ResourceList{}
+		g.reservationAllocated[reservationUID] = owners
+	}
+	if allocated, ok := owners[pod.UID]; ok && quotav1.Equals(allocated, requests) {
+		return
+	}
+	owners[pod.UID] = requests
+	g.refreshReservationChargeNoLock(reservationName)
+}
+
+func (g *Plugin) unassignReservationOwner(reservationUID types.UID, reservationName string, pod *corev1.Pod) {
+	g.reservationLock.Lock()
+	defer g.reservationLock.Unlock()
+	owners := g.reservationAllocated[reservationUID]
+	if _, ok := owners[pod.UID]; !ok {
+		return
+	}
+	delete(owners, pod.UID)
+	if len(owners) == 0 {
+		delete(g.reservationAllocated, reservationUID)
+	}
+	g.refreshReservationChargeNoLock(reservationName)
+}
+
+func (g *Plugin) refreshReservationChargeNoLock(reservationName string) {
+	if g.reservationLister == nil {
+		return
+	}
+	r, err := g.reservationLister.Get(reservationName)
+	if err != nil {
+		return
+	}
+	g.syncReservationChargeNoLock(r, r)
+}
+
+// syncReservationCharge replaces the charge of the old reservation, including the charge assumed in the Reserve
+// phase, with the charge of the new reservation.
+func (g *Plugin) syncReservationCharge(oldR, newR *schedulingv1alpha1.Reservation) {
+	g.reservationLock.Lock()
+	defer g.reservationLock.Unlock()
+	if newR == nil && oldR != nil {
+		delete(g.reservationAllocated, oldR.UID)
+	}
+	g.syncReservationChargeNoLock(oldR, newR)
+	if oldR != nil {
+		g.unindexReservationNoLock(oldR)
+	}
+	if newR != nil {
+		g.indexReservationNoLock(newR)
+	}
+}
+
+// indexReservationNoLock indexes the Available reservation by the quota label, so that the reservations the pods of
+// the quota may consume can be found without listing all reservations.
+func (g *Plugin) indexReservationNoLock(r *schedulingv1alpha1.Reservation) {
+	if !reservationutil.IsReservationAvailable(r) {
+		return
+	}
+	quotaName := extension.GetQuotaName(reservationutil.NewReservePod(r))
+	if quotaName == "" {
+		return
+	}
+	reservations := g.quotaReservations[quotaName]
+	if reservations == nil {
+		reservations = map[types.UID]*schedulingv1alpha1.Reservation{}
+		g.quotaReservations[quotaName] = reservations
+	}
+	reservations[r.UID] = r
+}
+
+func (g *Plugin) unindexReservationNoLock(r *schedulingv1alpha1.Reservation) {
+	for quotaName, reservations := range g.quotaReservations {
+		if _, ok := reservations[r.UID]; !ok {
+			continue
+		}
+		delete(reservations, r.UID)
+		if len(reservations) == 0 {
+			delete(g.quotaReservations, quotaName)
+		}
+	}
+}
+
+func (g *Plugin) syncReservationChargeNoLock(oldR, newR *schedulingv1alpha1.Reservation) {
+	chargedQuotaName, chargedPod := g.getReservationCharge(oldR, newR)
+
+	var quotaName string
+	var pod *corev1.Pod
+	if newR != nil {
+		if pod = g.newReservationChargePodNoLock(newR); pod != nil {
+			quotaName = g.getPodAssociateQuotaName(pod)
+		}
+	}
+
+	if chargedPod != nil {
+		if pod != nil && quotaName == chargedQuotaName && isPodRequestsEqual(pod, chargedPod) {
+			return
+		}
+		g.groupQuotaManager.OnPodDelete(chargedQuotaName, chargedPod)
+	}
+	if pod != nil {
+		g.groupQuotaManager.OnPodAdd(quotaName, pod)
+		klog.V(5).InfoS("Charge reservation to quota", "reservation", klog.KObj(newR), "quotaName", quotaName,
+			"requests", printResourceList(pod.Spec.Containers[0].Resources.Requests))
+	}
+}
+
+// getReservationCharge returns the currently charged pod of the reservations and the quota it is charged to.
+func (g *Plugin) getReservationCharge(reservations ...*schedulingv1alpha1.Reservation) (string, *corev1.Pod) {
+	for _, r := range reservations {
+		if r == nil {
+			continue
+		}
+		reservePod := reservationutil.NewReservePod(r)
+		if extension.GetQuotaName(reservePod) == "" {
+			continue
+		}
+		quotaName := g.getPodAssociateQuotaName(reservePod)
+		quotaInfo := g.groupQuotaManager.GetQuotaInfoByName(quotaName)
+		if quotaInfo == nil {
+			continue
+		}
+		if pod := quotaInfo.GetPod(reservePod.Namespace, reservePod.Name); pod != nil {
+			return quotaName, pod
+		}
+	}
+	return "", nil
+}
+
+// newReservationChargePodNoLock returns a pod requesting the reserved resources of the Available reservation
+// minus the resources already allocated by the owner pods, or nil if the reservation should not be charged.
+func (g *Plugin) newReservationChargePodNoLock(r *schedulingv1alpha1.Reservation) *corev1.Pod {
+	if !reservationutil.IsReservationAvailable(r) {
+		return nil
+	}
+	reservePod := reservationutil.NewReservePod(r)
+	if extension.GetQuotaName(reservePod) == "" {
+		return nil
+	}
+	var allocated corev1.ResourceList
+	for _, requests := range g.reservationAllocated[r.UID] {
+		allocated = quotav1.Add(allocated, requests)
+	}
+	reserved := reservationutil.ReservedResources(r)
+	unallocated := quotav1.SubtractWithNonNegativeResult(reserved, quotav1.Mask(allocated, quotav1.ResourceNames(reserved)))
+	return &corev1.Pod{
+		ObjectMeta: reservePod.ObjectMeta,
+		Spec: corev1.PodSpec{
+			NodeName:      reservePod.Spec.NodeName,
+			SchedulerName: reservePod.Spec.SchedulerName,
+			Priority:      reservePod.Spec.Priority,
+			Containers: []corev1.Container{
+				{
+					Name: "reservation",
+					Resources: corev1.ResourceRequirements{
+						Requests: unallocated,
+					},
+				},
+			},
+		},
+	}
+}
+
+// getReservedQuota returns the resources of the pod which would be allocated from the charge of an Available
+// reservation of the quota. Only the reservation the pod can consume is counted, that is, the pod matches the owners
+// of the reservation and the unallocated resources of the reservation fit the requests of the pod.
+// Since the reservation is not nominated until the pod is reserved, it's only an estimation in the PreFilter, and the
+// Reserve checks the quota again if the nominated reservation can't take the resources.
+func (g *Plugin) getReservedQuota(pod *corev1.Pod, quotaName string) corev1.ResourceList {
+	if reservationutil.IsReservePod(pod) {
+		return nil
+	}
+	podRequests, _ := resource.PodRequestsAndLimits(pod)
+	g.reservationLock.Lock()
+	defer g.reservationLock.Unlock()
+	for _, r := range g.quotaReservations[quotaName] {
+		if reserved := g.getReservedQuotaNoLock(pod, podRequests, quotaName, r); len(reserved) > 0 {
+			return reserved
+		}
+	}
+	return nil
+}
+
+// getNominatedReservedQuota returns the resources of the pod allocated from the charge of the nominated reservation.
+func (g *Plugin) getNominatedReservedQuota(pod *corev1.Pod, quotaName string, reservationUID types.UID) corev1.ResourceList {
+	podRequests, _ := resource.PodRequestsAndLimits(pod)
+	g.reservationLock.Lock()
+	defer g.reservationLock.Unlock()
+	r := g.quotaReservations[quotaName][reservationUID]
+	if r == nil {
+		return nil
+	}
+	return g.getReservedQuotaNoLock(pod, podRequests, quotaName, r)
+}
+
+func (g *Plugin) getReservedQuotaNoLock(pod *corev1.Pod, podRequests corev1.ResourceList, quotaName string, r *schedulingv1alpha1.Reservation) corev1.ResourceList {
+	if !reservationutil.MatchReservationOwners(pod, r.Spec.Owners) {
+		return nil
+	}
+	chargePod := g.newReservationChargePodNoLock(r)
+	if chargePod == nil || g.getPodAssociateQuotaName(chargePod) != quotaName {
+		return nil
+	}
+	unallocated := chargePod.Spec.Containers[0].Resources.Requests
+	reserved := quotav1.Mask(podRequests, quotav1.ResourceNames(reservationutil.ReservedResources(r)))
+	if fits, _ := quotav1.LessThanOrEqual(reserved, unallocated); fits && !quotav1.IsZero(reserved) {
+		return reserved
+	}
+	return nil
+}
+
+// checkNominatedReservation checks the quota again with the full requests of the pod if the resources reserved in
+// the PreFilter can't be allocated from the nominated reservation, e.g. the pod is nominated to another reservation
+// or no reservation, since the pod is charged in full then.
+func (g *Plugin) checkNominatedReservation(state *framework.CycleState, pod *corev1.Pod, quotaName, nodeName string) *framework.Status {
+	postFilterState, err := getPostFilterState(state)
+	if err != nil || len(postFilterState.reserved) == 0 {
+		return nil
+	}
+	if rInfo := frameworkext.GetNominatedReservation(state, nodeName); rInfo != nil {
+		if reserved := g.getNominatedReservedQuota(pod, quotaName, rInfo.UID()); len(reserved) > 0 {
+			return nil
+		}
+	}
+	quotaInfo := g.groupQuotaManager.GetQuotaInfoByName(quotaName)
+	if quotaInfo == nil {
+		return nil
+	}
+	podRequest, _ := resource.PodRequestsAndLimits(pod)
+	used := quotaInfo.GetUsed()
+	runtime := quotaInfo.GetRuntime()
+	if isLessEqual, exceedDimensions := quotav1.LessThanOrEqual(quotav1.Add(podRequest, used), runtime); !isLessEqual {
+		return framework.NewStatus(framework.Unschedulable, fmt.Sprintf("Insufficient quotas without the reservation, "+
+			"quotaName: %v, runtime: %v, used: %v, pod's request: %v, exceedDimensions: %v",
+			quotaName, printResourceList(runtime), printResourceList(used), printResourceList(podRequest), exceedDimensions))
+	}
+	return nil
+}
+
+func isPodRequestsEqual(a, b *corev1.Pod) bool {
+	aRequests, _ := resource.PodRequestsAndLimits(a)
+	bRequests, _ := resource.PodRequestsAndLimits(b)
+	return quotav1.Equals(aRequests, bRequests)
+}
@@ -0,0 +1,321 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elasticquota
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+	reservationutil "github.com/koordinator-sh/koordinator/pkg/util/reservation"
+)
+
+func newTestQuotaReservation(name, quotaName string, cpu, mem int64) *schedulingv1alpha1.Reservation {
+	return &schedulingv1alpha1.Reservation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			UID:  types.UID(name),
+			Labels: map[string]string{
+				extension.LabelQuotaName: quotaName,
+			},
+		},
+		Spec: schedulingv1alpha1.ReservationSpec{
+			Template: &corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Resources: corev1.ResourceRequirements{
+								Requests: createResourceList(cpu, mem),
+							},
+						},
+					},
+				},
+			},
+			Owners: []schedulingv1alpha1.ReservationOwner{
+				{
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "test"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestPlugin_ReservationCharge(t *testing.T) {
+	suit := newPluginTestSuit(t, nil)
+	p, err := suit.proxyNew(suit.elasticQuotaArgs, suit.Handle)
+	assert.NoError(t, err)
+	gp := p.(*Plugin)
+	gp.addQuota("test-quota", extension.RootQuotaName, 10, 100, 0, 0, 10, 100, false, "")
+	getUsed := func() corev1.ResourceList {
+		return gp.groupQuotaManager.GetQuotaInfoByName("test-quota").GetUsed()
+	}
+
+	reservation := newTestQuotaReservation("test-reservation", "test-quota", 4, 40)
+
+	// the reservation is assumed in the Reserve phase
+	reservePod := reservationutil.NewReservePod(reservation)
+	status := gp.Reserve(context.TODO(), framework.NewCycleState(), reservePod, "test-node")
+	assert.True(t, status.IsSuccess())
+	assert.True(t, quotav1.Equals(createResourceList(4, 40), getUsed()))
+
+	// the assumed charge is replaced once the reservation is available
+	available := reservation.DeepCopy()
+	reservationutil.SetReservationAvailable(available, "test-node")
+	available.Status.Allocatable = createResourceList(4, 40)
+	gp.OnReservationUpdate(reservation, available)
+	assert.True(t, quotav1.Equals(createResourceList(4, 40), getUsed()))
+
+	informer := suit.koordinatorSharedInformerFactory.Scheduling().V1alpha1().Reservations()
+	assert.NoError(t, informer.Informer().GetStore().Add(available))
+	gp.reservationLister = informer.Lister()
+
+	// the charge is transferred to the owner pod assumed in the Reserve phase
+	ownerPod := defaultCreatePodWithQuotaName("owner-pod", "test-quota", 0, 1, 10)
+	ownerPod.Spec.NodeName = ""
+	ownerPod.ResourceVersion = "1"
+	gp.OnPodAdd(ownerPod)
+	cycleState := framework.NewCycleState()
+	frameworkext.SetNominatedReservation(cycleState, map[string]*frameworkext.ReservationInfo{
+		"test-node": frameworkext.NewReservationInfo(available),
+	})
+	status = gp.Reserve(context.TODO(), cycleState, ownerPod, "test-node")
+	assert.True(t, status.IsSuccess())
+	assert.True(t, quotav1.Equals(createResourceList(4, 40), getUsed()))
+
+	// the charge keeps transferred when the owner pod is bound and the status of the reservation is updated
+	boundPod := defaultCreatePodWithQuotaName("owner-pod", "test-quota", 0, 1, 10)
+	boundPod.ResourceVersion = "2"
+	extension.SetReservationAllocated(boundPod, available)
+	gp.OnPodUpdate(ownerPod, boundPod)
+	allocated := available.DeepCopy()
+	allocated.ResourceVersion = "2"
+	allocated.Status.Allocated = createResourceList(1, 10)
+	gp.OnReservationUpdate(available, allocated)
+	assert.True(t, quotav1.Equals(createResourceList(4, 40), getUsed()))
+
+	// the unallocated charge is released when the reservation succeeds
+	succeeded := allocated.DeepCopy()
+	reservationutil.SetReservationSucceeded(succeeded)
+	gp.OnReservationUpdate(allocated, succeeded)
+	assert.True(t, quotav1.Equals(createResourceList(1, 10), getUsed()))
+
+	gp.OnReservationDelete(succeeded)
+	assert.True(t, quotav1.Equals(createResourceList(1, 10), getUsed()))
+}
+
+func TestPlugin_ReservationUnreserve(t *testing.T) {
+	suit := newPluginTestSuit(t, nil)
+	p, err := suit.proxyNew(suit.elasticQuotaArgs, suit.Handle)
+	assert.NoError(t, err)
+	gp := p.(*Plugin)
+	gp.addQuota("test-quota", extension.RootQuotaName, 10, 100, 0, 0, 10, 100, false, "")
+
+	reservePod := reservationutil.NewReservePod(newTestQuotaReservation("test-reservation", "test-quota", 4, 40))
+	gp.Reserve(context.TODO(), framework.NewCycleState(), reservePod, "test-node")
+	gp.Unreserve(context.TODO(), framework.NewCycleState(), reservePod, "test-node")
+	assert.True(t, quotav1.IsZero(gp.groupQuotaManager.GetQuotaInfoByName("test-quota").GetUsed()))
+}
+
+func TestPlugin_PreFilterReservation(t *testing.T) {
+	tests := []struct {
+		name        string
+		reservation *schedulingv1alpha1.Reservation
+		wantCode    framework.Code
+	}{
+		{
+			name:        "reservation within max",
+			reservation: newTestQuotaReservation("test-reservation", "test-quota", 6, 60),
+			wantCode:    framework.Success,
+		},
+		{
+			name:        "reservation exceeds max",
+			reservation: newTestQuotaReservation("test-reservation", "test-quota", 8, 60),
+			wantCode:    framework.UnschedulableAndUnresolvable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suit := newPluginTestSuit(t, nil)
+			p, err := suit.proxyNew(suit.elasticQuotaArgs, suit.Handle)
+			assert.NoError(t, err)
+			gp := p.(*Plugin)
+			gp.addQuota("test-quota", extension.RootQuotaName, 10, 100, 0, 0, 10, 100, false, "")
+
+			existing := newTestQuotaReservation("existing-reservation", "test-quota", 4, 40)
+			existing.UID = "existing-reservation"
+			reservationutil.SetReservationAvailable(existing, "test-node")
+			gp.OnReservationAdd(existing)
+
+			_, status := gp.PreFilter(context.TODO(), framework.NewCycleState(), reservationutil.NewReservePod(tt.reservation))
+			assert.Equal(t, tt.wantCode, status.Code())
+		})
+	}
+}
+
+func TestPlugin_getReservedQuota(t *testing.T) {
+	reservation := newTestQuotaReservation("test-reservation", "test-quota", 4, 40)
+	reservationutil.SetReservationAvailable(reservation, "test-node")
+	reservation.Status.Allocatable = createResourceList(4, 40)
+	allocatedPod := defaultCreatePodWithQuotaName("allocated-pod", "test-quota", 0, 1, 10)
+	extension.SetReservationAllocated(allocatedPod, reservation)
+
+	newOwnerPod := func(cpu, mem int64) *corev1.Pod {
+		pod := defaultCreatePodWithQuotaName("owner-pod", "test-quota", 0, cpu, mem)
+		pod.Labels["app"] = "test"
+		return pod
+	}
+	tests := []struct {
+		name string
+		pod  *corev1.Pod
+		want corev1.ResourceList
+	}{
+		{
+			name: "pod fits the unallocated of the reservation",
+			pod:  newOwnerPod(2, 20),
+			want: createResourceList(2, 20),
+		},
+		{
+			name: "pod exceeds the unallocated of the reservation",
+			pod:  newOwnerPod(2, 50),
+			want: nil,
+		},
+		{
+			name: "pod doesn't match the owners of the reservation",
+			pod:  defaultCreatePodWithQuotaName("other-pod", "test-quota", 0, 2, 20),
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suit := newPluginTestSuit(t, nil)
+			p, err := suit.proxyNew(suit.elasticQuotaArgs, suit.Handle)
+			assert.NoError(t, err)
+			gp := p.(*Plugin)
+			gp.addQuota("test-quota", extension.RootQuotaName, 10, 100, 0, 0, 10, 100, false, "")
+			informer := suit.koordinatorSharedInformerFactory.Scheduling().V1alpha1().Reservations()
+			assert.NoError(t, informer.Informer().GetStore().Add(reservation))
+			gp.reservationLister = informer.Lister()
+			gp.OnReservationAdd(reservation)
+			gp.OnPodAdd(allocatedPod)
+
+			assert.True(t, quotav1.Equals(createResourceList(3, 30), gp.groupQuotaManager.GetQuotaInfoByName("test-quota").GetPod("default", reservationutil.GetReservationKey(reservation)).Spec.Containers[0].Resources.Requests))
+			got := gp.getReservedQuota(tt.pod, "test-quota")
+			assert.True(t, quotav1.Equals(tt.want, got), "want %v, got %v", tt.want, got)
+		})
+	}
+}
+
+func TestPlugin_PreFilterWithReservation(t *testing.T) {
+	tests := []struct {
+		name            string
+		matched         bool
+		nominated       bool
+		wantCode        framework.Code
+		wantReserveCode framework.Code
+	}{
+		{
+			name:            "pod consumes the charge of the nominated reservation",
+			matched:         true,
+			nominated:       true,
+			wantCode:        framework.Success,
+			wantReserveCode: framework.Success,
+		},
+		{
+			name:            "pod is not nominated to the reservation",
+			matched:         true,
+			nominated:       false,
+			wantCode:        framework.Success,
+			wantReserveCode: framework.Unschedulable,
+		},
+		{
+			name:     "pod doesn't match the reservation",
+			matched:  false,
+			wantCode: framework.Unschedulable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suit := newPluginTestSuit(t, nil)
+			p, err := suit.proxyNew(suit.elasticQuotaArgs, suit.Handle)
+			assert.NoError(t, err)
+			gp := p.(*Plugin)
+			gp.addQuota("test-quota", extension.RootQuotaName, 10, 100, 10, 100, 10, 100, false, "")
+			gp.groupQuotaManager.UpdateClusterTotalResource(createResourceList(100, 1000))
+
+			reservation := newTestQuotaReservation("test-reservation", "test-quota", 4, 40)
+			reservationutil.SetReservationAvailable(reservation, "test-node")
+			reservation.Status.Allocatable = createResourceList(4, 40)
+			informer := suit.koordinatorSharedInformerFactory.Scheduling().V1alpha1().Reservations()
+			assert.NoError(t, informer.Informer().GetStore().Add(reservation))
+			gp.reservationLister = informer.Lister()
+			gp.OnReservationAdd(reservation)
+			gp.OnPodAdd(defaultCreatePodWithQuotaName("running-pod", "test-quota", 0, 4, 40))
+
+			pod := defaultCreatePodWithQuotaName("pod", "test-quota", 0, 4, 40)
+			pod.Spec.NodeName = ""
+			if tt.matched {
+				pod.Labels["app"] = "test"
+			}
+			cycleState := framework.NewCycleState()
+			_, status := gp.PreFilter(context.TODO(), cycleState, pod)
+			assert.Equal(t, tt.wantCode, status.Code(), status.Message())
+			if !status.IsSuccess() {
+				return
+			}
+
+			if tt.nominated {
+				frameworkext.SetNominatedReservation(cycleState, map[string]*frameworkext.ReservationInfo{
+					"test-node": frameworkext.NewReservationInfo(reservation),
+				})
+			}
+			status = gp.Reserve(context.TODO(), cycleState, pod, "test-node")
+			assert.Equal(t, tt.wantReserveCode, status.Code(), status.Message())
+		})
+	}
+}
+
+func TestPlugin_OnReservationDeleteTombstone(t *testing.T) {
+	suit := newPluginTestSuit(t, nil)
+	p, err := suit.proxyNew(suit.elasticQuotaArgs, suit.Handle)
+	assert.NoError(t, err)
+	gp := p.(*Plugin)
+	gp.addQuota("test-quota", extension.RootQuotaName, 10, 100, 0, 0, 10, 100, false, "")
+
+	reservation := newTestQuotaReservation("test-reservation", "test-quota", 4, 40)
+	reservationutil.SetReservationAvailable(reservation, "test-node")
+	reservation.Status.Allocatable = createResourceList(4, 40)
+	gp.OnReservationAdd(reservation)
+	assert.Len(t, gp.quotaReservations["test-quota"], 1)
+	assert.True(t, quotav1.Equals(createResourceList(4, 40), gp.groupQuotaManager.GetQuotaInfoByName("test-quota").GetUsed()))
+
+	gp.OnReservationDelete(cache.DeletedFinalStateUnknown{Key: "test-reservation", Obj: reservation})
+	assert.Empty(t, gp.quotaReservations)
+	assert.True(t, quotav1.IsZero(gp.groupQuotaManager.GetQuotaInfoByName("test-quota").GetUsed()))
+}
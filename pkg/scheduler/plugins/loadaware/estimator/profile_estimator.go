@@ -0,0 +1,154 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package estimator
+
+import (
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
+)
+
+const (
+	profileEstimatorName = "profileEstimator"
+
+	// minProfileSamples is the minimum number of samples a workload profile must have before it is trusted.
+	minProfileSamples = 10
+)
+
+var profileAggregationType = extension.P95
+
+// ProfileEstimator estimates the usage of Pods by the historical usage profile of the workload owning them,
+// which is aggregated by the slo-controller into the workload profile ConfigMap.
+// Pods without a usable profile fall back to the DefaultEstimator.
+type ProfileEstimator struct {
+	*DefaultEstimator
+	namespace       string
+	configMapLister corelisters.ConfigMapLister
+	lock            sync.Mutex
+	// configMap is the ConfigMap in the informer cache which the profiles are parsed from,
+	// the cached object is replaced rather than modified on updates
+	configMap *corev1.ConfigMap
+	profiles  map[extension.WorkloadReference]*extension.WorkloadProfile
+}
+
+func NewProfileEstimator(args *config.LoadAwareSchedulingArgs, handle framework.Handle) (Estimator, error) {
+	namespace := args.WorkloadProfileNamespace
+	if namespace == "" {
+		namespace = extension.DefaultWorkloadProfileNamespace
+	}
+	// The ConfigMap informer of the shared informer factory only watches the workload profile ConfigMap,
+	// and it is started and synced by the scheduler with the other informers.
+	configMapInformer := handle.SharedInformerFactory().InformerFor(&corev1.ConfigMap{}, func(client kubernetes.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+		return coreinformers.NewFilteredConfigMapInformer(client, namespace, resyncPeriod,
+			cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+			func(options *metav1.ListOptions) {
+				options.FieldSelector = fields.OneTermEqualSelector("metadata.name", extension.WorkloadProfileConfigMapName).String()
+			})
+	})
+	return &ProfileEstimator{
+		DefaultEstimator: &DefaultEstimator{
+			resourceWeights: args.ResourceWeights,
+			scalingFactors:  args.EstimatedScalingFactors,
+		},
+		namespace:       namespace,
+		configMapLister: corelisters.NewConfigMapLister(configMapInformer.GetIndexer()),
+	}, nil
+}
+
+func (e *ProfileEstimator) Name() string {
+	return profileEstimatorName
+}
+
+// getProfiles returns the profiles of the workload profile ConfigMap, which are parsed again only if the ConfigMap is
+// updated. The profiles parsed before are kept if the ConfigMap fails to be parsed.
+func (e *ProfileEstimator) getProfiles() map[extension.WorkloadReference]*extension.WorkloadProfile {
+	configMap, err := e.configMapLister.ConfigMaps(e.namespace).Get(extension.WorkloadProfileConfigMapName)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			klog.Errorf("failed to get workload profile ConfigMap %s/%s, err: %v", e.namespace, extension.WorkloadProfileConfigMapName, err)
+		}
+		return nil
+	}
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if configMap == e.configMap {
+		return e.profiles
+	}
+	e.configMap = configMap
+	profiles, err := extension.GetWorkloadProfiles(configMap)
+	if err != nil {
+		klog.Errorf("failed to parse workload profiles from ConfigMap %s/%s, err: %v",
+			e.namespace, extension.WorkloadProfileConfigMapName, err)
+		return e.profiles
+	}
+	m := make(map[extension.WorkloadReference]*extension.WorkloadProfile, len(profiles))
+	for _, profile := range profiles {
+		if profile != nil {
+			m[profile.WorkloadReference] = profile
+		}
+	}
+	e.profiles = m
+	return e.profiles
+}
+
+func (e *ProfileEstimator) getProfile(pod *corev1.Pod) *extension.WorkloadProfile {
+	ref := extension.GetPodWorkloadReference(pod)
+	if ref == nil {
+		return nil
+	}
+	profile := e.getProfiles()[*ref]
+	if profile == nil || profile.Samples < minProfileSamples {
+		return nil
+	}
+	return profile
+}
+
+func (e *ProfileEstimator) EstimatePod(pod *corev1.Pod) (map[corev1.ResourceName]int64, error) {
+	estimatedUsed := estimatedPodUsed(pod, e.resourceWeights, e.scalingFactors)
+	profile := e.getProfile(pod)
+	if profile == nil {
+		return estimatedUsed, nil
+	}
+	usage := profile.Usage[profileAggregationType]
+	for resourceName := range estimatedUsed {
+		quantity, ok := usage[resourceName]
+		if !ok {
+			continue
+		}
+		switch resourceName {
+		case corev1.ResourceCPU:
+			estimatedUsed[resourceName] = quantity.MilliValue()
+		default:
+			estimatedUsed[resourceName] = quantity.Value()
+		}
+	}
+	return estimatedUsed, nil
+}
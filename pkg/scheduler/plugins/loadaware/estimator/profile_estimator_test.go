@@ -0,0 +1,181 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package estimator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+	"k8s.io/utils/pointer"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/apis/config/v1beta2"
+)
+
+type fakeHandle struct {
+	framework.Handle
+	sharedInformerFactory informers.SharedInformerFactory
+}
+
+func (h *fakeHandle) SharedInformerFactory() informers.SharedInformerFactory {
+	return h.sharedInformerFactory
+}
+
+func TestProfileEstimatorEstimatePod(t *testing.T) {
+	profiles := []*extension.WorkloadProfile{
+		{
+			WorkloadReference: extension.WorkloadReference{Namespace: "default", Kind: "Deployment", Name: "test-deploy"},
+			Usage: map[extension.AggregationType]corev1.ResourceList{
+				extension.P95: {
+					corev1.ResourceCPU:    resource.MustParse("1500m"),
+					corev1.ResourceMemory: resource.MustParse("2Gi"),
+				},
+			},
+			Samples: 100,
+		},
+		{
+			WorkloadReference: extension.WorkloadReference{Namespace: "default", Kind: "StatefulSet", Name: "test-sts"},
+			Usage: map[extension.AggregationType]corev1.ResourceList{
+				extension.P95: {
+					corev1.ResourceCPU: resource.MustParse("500m"),
+				},
+			},
+			Samples: 100,
+		},
+		{
+			WorkloadReference: extension.WorkloadReference{Namespace: "default", Kind: "Job", Name: "test-job"},
+			Usage: map[extension.AggregationType]corev1.ResourceList{
+				extension.P95: {
+					corev1.ResourceCPU: resource.MustParse("500m"),
+				},
+			},
+			Samples: 1,
+		},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "koord-custom",
+			Name:      extension.WorkloadProfileConfigMapName,
+		},
+	}
+	assert.NoError(t, extension.SetWorkloadProfiles(configMap, profiles))
+
+	newPod := func(ownerKind, ownerName string, labels map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test-pod",
+				Labels:    labels,
+				OwnerReferences: []metav1.OwnerReference{
+					{Kind: ownerKind, Name: ownerName, Controller: pointer.Bool(true)},
+				},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name: "main",
+						Resources: corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("4"),
+								corev1.ResourceMemory: resource.MustParse("8Gi"),
+							},
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("4"),
+								corev1.ResourceMemory: resource.MustParse("8Gi"),
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name string
+		pod  *corev1.Pod
+		want map[corev1.ResourceName]int64
+	}{
+		{
+			name: "estimate pod of deployment by profile",
+			pod:  newPod("ReplicaSet", "test-deploy-5f7b9c", map[string]string{appsv1.DefaultDeploymentUniqueLabelKey: "5f7b9c"}),
+			want: map[corev1.ResourceName]int64{
+				corev1.ResourceCPU:    1500,
+				corev1.ResourceMemory: 2 * 1024 * 1024 * 1024,
+			},
+		},
+		{
+			name: "estimate missing resource by default estimator",
+			pod:  newPod("StatefulSet", "test-sts", nil),
+			want: map[corev1.ResourceName]int64{
+				corev1.ResourceCPU:    500,
+				corev1.ResourceMemory: 6012954214, // 5.6Gi
+			},
+		},
+		{
+			name: "profile without enough samples",
+			pod:  newPod("Job", "test-job", nil),
+			want: map[corev1.ResourceName]int64{
+				corev1.ResourceCPU:    3400,
+				corev1.ResourceMemory: 6012954214, // 5.6Gi
+			},
+		},
+		{
+			name: "pod without profile",
+			pod:  newPod("StatefulSet", "unknown-sts", nil),
+			want: map[corev1.ResourceName]int64{
+				corev1.ResourceCPU:    3400,
+				corev1.ResourceMemory: 6012954214, // 5.6Gi
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v1beta2args v1beta2.LoadAwareSchedulingArgs
+			v1beta2.SetDefaults_LoadAwareSchedulingArgs(&v1beta2args)
+			var loadAwareSchedulingArgs config.LoadAwareSchedulingArgs
+			err := v1beta2.Convert_v1beta2_LoadAwareSchedulingArgs_To_config_LoadAwareSchedulingArgs(&v1beta2args, &loadAwareSchedulingArgs, nil)
+			assert.NoError(t, err)
+			loadAwareSchedulingArgs.Estimator = profileEstimatorName
+			loadAwareSchedulingArgs.WorkloadProfileNamespace = "koord-custom"
+
+			otherConfigMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "koord-custom", Name: "other-config"},
+			}
+			sharedInformerFactory := informers.NewSharedInformerFactory(kubefake.NewSimpleClientset(configMap, otherConfigMap), 0)
+			handle := &fakeHandle{sharedInformerFactory: sharedInformerFactory}
+			estimator, err := NewEstimator(&loadAwareSchedulingArgs, handle)
+			assert.NoError(t, err)
+			assert.Equal(t, profileEstimatorName, estimator.Name())
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+			sharedInformerFactory.Start(stopCh)
+			sharedInformerFactory.WaitForCacheSync(stopCh)
+
+			got, err := estimator.EstimatePod(tt.pod)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -27,6 +27,7 @@ type FactoryFn func(args *config.LoadAwareSchedulingArgs, handle framework.Handl
 
 var Estimators = map[string]FactoryFn{
 	defaultEstimatorName: NewDefaultEstimator,
+	profileEstimatorName: NewProfileEstimator,
 }
 
 type Estimator interface {
@@ -55,40 +55,6 @@ func stillInTheReportInterval(assignedTime, updateTime time.Time, reportInterval
 	return assignedTime.Before(updateTime) && updateTime.Sub(assignedTime) < reportInterval
 }
 
-func getTargetAggregatedUsage(nodeMetric *slov1alpha1.NodeMetric, aggregatedDuration *metav1.Duration, aggregationType extension.AggregationType) *slov1alpha1.ResourceMap {
-	if nodeMetric.Status.NodeMetric == nil || len(nodeMetric.Status.NodeMetric.AggregatedNodeUsages) == 0 {
-		return nil
-	}
-
-	// If no specific period is set, the maximum period recorded by NodeMetrics will be used by default.
-	// This is a default policy.
-	if aggregatedDuration == nil || aggregatedDuration.Duration == 0 {
-		var maxDuration time.Duration
-		var maxIndex int
-		for i, v := range nodeMetric.Status.NodeMetric.AggregatedNodeUsages {
-			if v.Duration.Duration > maxDuration {
-				maxDuration = v.Duration.Duration
-				maxIndex = i
-			}
-		}
-		aggregatedNodeUsage := &nodeMetric.Status.NodeMetric.AggregatedNodeUsages[maxIndex]
-		usage := aggregatedNodeUsage.Usage[aggregationType]
-		if len(usage.ResourceList) > 0 {
-			return &usage
-		}
-	} else if aggregatedDuration != nil {
-		for _, v := range nodeMetric.Status.NodeMetric.AggregatedNodeUsages {
-			if v.Duration.Duration == aggregatedDuration.Duration {
-				usage := v.Usage[aggregationType]
-				if len(usage.ResourceList) > 0 {
-					return &usage
-				}
-			}
-		}
-	}
-	return nil
-}
-
 func filterWithAggregation(args *schedulingconfig.LoadAwareSchedulingAggregatedArgs) bool {
 	return args != nil && len(args.UsageThresholds) > 0 && args.UsageAggregationType != ""
 }
@@ -38,6 +38,7 @@ import (
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
 	frameworkexthelper "github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext/helper"
 	"github.com/koordinator-sh/koordinator/pkg/scheduler/plugins/loadaware/estimator"
+	"github.com/koordinator-sh/koordinator/pkg/util"
 )
 
 const (
@@ -200,7 +201,7 @@ func (p *Plugin) filterNodeUsage(node *corev1.Node, nodeMetric *slov1alpha1.Node
 		// TODO(joseph): maybe we should estimate the Pod that just be scheduled that have not reported
 		var nodeUsage *slov1alpha1.ResourceMap
 		if filterProfile.AggregatedUsage != nil {
-			nodeUsage = getTargetAggregatedUsage(
+			nodeUsage = util.GetTargetAggregatedUsage(
 				nodeMetric,
 				filterProfile.AggregatedUsage.UsageAggregatedDuration,
 				filterProfile.AggregatedUsage.UsageAggregationType,
@@ -310,7 +311,7 @@ func (p *Plugin) Score(ctx context.Context, state *framework.CycleState, pod *co
 		if nodeMetric.Status.NodeMetric != nil {
 			var nodeUsage *slov1alpha1.ResourceMap
 			if scoreWithAggregation(p.args.Aggregated) {
-				nodeUsage = getTargetAggregatedUsage(nodeMetric, &p.args.Aggregated.ScoreAggregatedDuration, p.args.Aggregated.ScoreAggregationType)
+				nodeUsage = util.GetTargetAggregatedUsage(nodeMetric, &p.args.Aggregated.ScoreAggregatedDuration, p.args.Aggregated.ScoreAggregationType)
 			} else {
 				nodeUsage = &nodeMetric.Status.NodeMetric.NodeUsage
 			}
@@ -357,7 +358,7 @@ func (p *Plugin) estimatedAssignedPodUsed(nodeName string, nodeMetric *slov1alph
 			missedLatestUpdateTime(assignInfo.timestamp, nodeMetricUpdateTime) ||
 			stillInTheReportInterval(assignInfo.timestamp, nodeMetricUpdateTime, nodeMetricReportInterval) ||
 			(scoreWithAggregation(p.args.Aggregated) &&
-				getTargetAggregatedUsage(nodeMetric, &p.args.Aggregated.ScoreAggregatedDuration, p.args.Aggregated.ScoreAggregationType) == nil) {
+				util.GetTargetAggregatedUsage(nodeMetric, &p.args.Aggregated.ScoreAggregatedDuration, p.args.Aggregated.ScoreAggregationType) == nil) {
 			estimated, err := p.estimator.EstimatePod(assignInfo.pod)
 			if err != nil {
 				continue
@@ -62,6 +62,7 @@ type Controller struct {
 	podLister                  corelister.PodLister
 	reservationLister          schedulinglister.ReservationLister
 	koordClientSet             koordclientset.Interface
+	resizer                    Resizer
 	queue                      workqueue.RateLimitingInterface
 	numWorker                  int
 
@@ -73,6 +74,7 @@ func New(
 	sharedInformerFactory informers.SharedInformerFactory,
 	koordSharedInformerFactory koordinatorinformers.SharedInformerFactory,
 	koordClientSet koordclientset.Interface,
+	resizer Resizer,
 	numWorker int,
 ) *Controller {
 	nodeLister := sharedInformerFactory.Core().V1().Nodes().Lister()
@@ -92,6 +94,7 @@ func New(
 		podLister:                  podLister,
 		reservationLister:          reservationLister,
 		koordClientSet:             koordClientSet,
+		resizer:                    resizer,
 		queue:                      queue,
 		numWorker:                  numWorker,
 		pods:                       map[string]map[types.UID]*corev1.Pod{},
@@ -152,11 +155,14 @@ func (c *Controller) processNextWorkItem() bool {
 	case err != nil:
 		c.queue.AddRateLimited(req)
 		klog.ErrorS(err, "failed to sync Reservation")
+	case result.requeue:
+		c.queue.AddRateLimited(req)
+		if result.requeueAfter > 0 {
+			c.queue.AddAfter(req, result.requeueAfter)
+		}
 	case result.requeueAfter > 0:
 		c.queue.Forget(req)
 		c.queue.AddAfter(req, result.requeueAfter)
-	case result.requeue:
-		c.queue.AddRateLimited(req)
 	default:
 		c.queue.Forget(req)
 	}
@@ -192,11 +198,13 @@ func (c *Controller) sync(reservationName string) (result, error) {
 		return result{}, c.expireReservation(reservation)
 	}
 
-	if err := c.syncStatus(reservation); err != nil {
+	resizeFailed, err := c.syncStatus(reservation)
+	if err != nil {
 		return result{}, err
 	}
 
-	return result{requeueAfter: nextSyncTime(reservation)}, nil
+	// the failed resizing is retried with backoff
+	return result{requeue: resizeFailed, requeueAfter: nextSyncTime(reservation)}, nil
 }
 
 func (c *Controller) expireReservation(reservation *schedulingv1alpha1.Reservation) error {
@@ -205,9 +213,10 @@ func (c *Controller) expireReservation(reservation *schedulingv1alpha1.Reservati
 	return err
 }
 
-func (c *Controller) syncStatus(reservation *schedulingv1alpha1.Reservation) error {
+// syncStatus updates the owners and the allocatable of the reservation, and returns true if the resizing is failed.
+func (c *Controller) syncStatus(reservation *schedulingv1alpha1.Reservation) (bool, error) {
 	if reservation.Status.NodeName == "" {
-		return nil
+		return false, nil
 	}
 	var actualOwners []corev1.ObjectReference
 	var actualAllocated corev1.ResourceList
@@ -235,22 +244,28 @@ func (c *Controller) syncStatus(reservation *schedulingv1alpha1.Reservation) err
 	})
 
 	actualAllocated = quotav1.Mask(actualAllocated, quotav1.ResourceNames(reservation.Status.Allocatable))
-	if reflect.DeepEqual(reservation.Status.CurrentOwners, actualOwners) && quotav1.Equals(actualAllocated, reservation.Status.Allocated) {
-		return nil
-	}
+	ownersChanged := !reflect.DeepEqual(reservation.Status.CurrentOwners, actualOwners) || !quotav1.Equals(actualAllocated, reservation.Status.Allocated)
+	if ownersChanged {
+		reservation.Status.Allocated = actualAllocated
+		reservation.Status.CurrentOwners = actualOwners
 
-	reservation.Status.Allocated = actualAllocated
-	reservation.Status.CurrentOwners = actualOwners
+		if apiext.IsReservationAllocateOnce(reservation) {
+			reservationutil.SetReservationSucceeded(reservation)
+		}
+	}
 
-	if apiext.IsReservationAllocateOnce(reservation) {
-		reservationutil.SetReservationSucceeded(reservation)
+	resized, resizeFailed := c.syncResize(reservation)
+	if !ownersChanged && !resized {
+		return resizeFailed, nil
 	}
 
+	// If the status fails to update after the reserve pod is resized in the scheduler cache, the next sync resizes
+	// it again with the cached reserve pod, and the scheduler cache is kept consistent.
 	_, err := c.koordClientSet.SchedulingV1alpha1().Reservations().UpdateStatus(context.TODO(), reservation, metav1.UpdateOptions{})
 	if err == nil {
 		klog.V(4).InfoS("Successfully sync reservation status", "reservation", klog.KObj(reservation))
 	}
-	return err
+	return resizeFailed, err
 }
 
 func isReservationNeedExpiration(r *schedulingv1alpha1.Reservation) bool {
@@ -69,7 +69,7 @@ func TestFailedOrSucceededReservation(t *testing.T) {
 	_, err = fakeKoordClientSet.SchedulingV1alpha1().Reservations().Create(context.TODO(), succededReservation, metav1.CreateOptions{})
 	assert.NoError(t, err)
 
-	controller := New(sharedInformerFactory, koordSharedInformerFactory, fakeKoordClientSet, 0)
+	controller := New(sharedInformerFactory, koordSharedInformerFactory, fakeKoordClientSet, nil, 0)
 
 	sharedInformerFactory.Start(nil)
 	koordSharedInformerFactory.Start(nil)
@@ -178,7 +178,7 @@ func TestExpireActiveReservation(t *testing.T) {
 	_, err := fakeClientSet.CoreV1().Nodes().Create(context.TODO(), node, metav1.CreateOptions{})
 	assert.NoError(t, err)
 
-	controller := New(sharedInformerFactory, koordSharedInformerFactory, fakeKoordClientSet, 0)
+	controller := New(sharedInformerFactory, koordSharedInformerFactory, fakeKoordClientSet, nil, 0)
 
 	sharedInformerFactory.Start(nil)
 	koordSharedInformerFactory.Start(nil)
@@ -284,7 +284,7 @@ func TestSyncStatus(t *testing.T) {
 		assert.NoError(t, err)
 	}
 
-	controller := New(sharedInformerFactory, koordSharedInformerFactory, fakeKoordClientSet, 0)
+	controller := New(sharedInformerFactory, koordSharedInformerFactory, fakeKoordClientSet, nil, 0)
 	controller.Start()
 
 	time.Sleep(1 * time.Second)
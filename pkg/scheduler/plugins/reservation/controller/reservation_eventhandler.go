@@ -17,6 +17,7 @@ limitations under the License.
 package controller
 
 import (
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
 	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
 )
 
@@ -31,7 +32,8 @@ func (c *Controller) onReservationUpdate(oldObj, newObj interface{}) {
 	oldReservation, _ := oldObj.(*schedulingv1alpha1.Reservation)
 	newReservation, _ := newObj.(*schedulingv1alpha1.Reservation)
 	if oldReservation != nil && newReservation != nil {
-		if oldReservation.Generation != newReservation.Generation {
+		if oldReservation.Generation != newReservation.Generation ||
+			apiext.IsReservationReleaseUnused(oldReservation) != apiext.IsReservationReleaseUnused(newReservation) {
 			c.queue.Add(newReservation.Name)
 		}
 	}
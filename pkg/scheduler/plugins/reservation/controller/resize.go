@@ -0,0 +1,133 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+	"k8s.io/klog/v2"
+
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	reservationutil "github.com/koordinator-sh/koordinator/pkg/util/reservation"
+)
+
+// Resizer resizes the reserve pod of the reservation in the scheduler cache, so that the feasibility of growing
+// is checked with the assumed pods of the scheduler. It returns an error if the node has insufficient resources.
+type Resizer interface {
+	ResizeReservation(origin, resized *schedulingv1alpha1.Reservation) error
+}
+
+// syncResize resizes the allocatable of the Available reservation in-place, and returns whether the status is changed
+// and whether the resizing is failed and should be retried.
+// The expected allocatable is the requests of the template, which is capped by the allocated if the reservation
+// is marked to release unused resources. The allocatable never shrinks below the allocated of current owners.
+// Shrinking is always allowed, and growing must fit in the free resources of the node in the scheduler cache.
+func (c *Controller) syncResize(reservation *schedulingv1alpha1.Reservation) (bool, bool) {
+	if c.resizer == nil || !reservationutil.IsReservationAvailable(reservation) || len(reservation.Status.Allocatable) == 0 {
+		return false, false
+	}
+	requests := reservationutil.ReservationRequests(reservation)
+	if len(requests) == 0 {
+		return false, false
+	}
+
+	current := reservation.Status.Allocatable
+	expected := expectedAllocatable(requests, reservation.Status.Allocated, apiext.IsReservationReleaseUnused(reservation))
+	if quotav1.Equals(expected, current) {
+		// the failed resizing is reverted
+		if isReservationResizeFailed(reservation) {
+			return setReservationResized(reservation, schedulingv1alpha1.ConditionStatusTrue, schedulingv1alpha1.ReasonReservationResized, ""), false
+		}
+		return false, false
+	}
+
+	resized := reservation.DeepCopy()
+	resized.Status.Allocatable = expected
+	if err := c.resizer.ResizeReservation(reservation, resized); err != nil {
+		klog.V(4).InfoS("Failed to resize reservation", "reservation", klog.KObj(reservation), "err", err)
+		return setReservationResized(reservation, schedulingv1alpha1.ConditionStatusFalse, schedulingv1alpha1.ReasonReservationResizeFailed, err.Error()), true
+	}
+
+	klog.V(4).InfoS("Resize reservation", "reservation", klog.KObj(reservation), "old", current, "new", expected)
+	reservation.Status.Allocatable = expected
+	setReservationResized(reservation, schedulingv1alpha1.ConditionStatusTrue, schedulingv1alpha1.ReasonReservationResized, "")
+	return true, false
+}
+
+// expectedAllocatable derives the allocatable from the requests of the template, so the resources added to or removed
+// from the template are followed. The resources still allocated by the owners are always kept.
+func expectedAllocatable(requests, allocated corev1.ResourceList, releaseUnused bool) corev1.ResourceList {
+	expected := corev1.ResourceList{}
+	for resourceName, quantity := range requests {
+		allocatedQuantity, ok := allocated[resourceName]
+		if releaseUnused && quantity.Cmp(allocatedQuantity) > 0 {
+			quantity = allocatedQuantity
+		}
+		if ok && quantity.Cmp(allocatedQuantity) < 0 {
+			quantity = allocatedQuantity
+		}
+		expected[resourceName] = quantity.DeepCopy()
+	}
+	for resourceName, allocatedQuantity := range allocated {
+		if _, ok := expected[resourceName]; !ok && !allocatedQuantity.IsZero() {
+			expected[resourceName] = allocatedQuantity.DeepCopy()
+		}
+	}
+	return expected
+}
+
+func isReservationResizeFailed(r *schedulingv1alpha1.Reservation) bool {
+	for _, condition := range r.Status.Conditions {
+		if condition.Type == schedulingv1alpha1.ReservationConditionResized {
+			return condition.Status == schedulingv1alpha1.ConditionStatusFalse
+		}
+	}
+	return false
+}
+
+// setReservationResized updates the Resized condition, and returns true if the condition is changed.
+func setReservationResized(r *schedulingv1alpha1.Reservation, status schedulingv1alpha1.ConditionStatus, reason, msg string) bool {
+	now := metav1.Now()
+	for i := range r.Status.Conditions {
+		condition := &r.Status.Conditions[i]
+		if condition.Type != schedulingv1alpha1.ReservationConditionResized {
+			continue
+		}
+		if condition.Status == status && condition.Reason == reason && condition.Message == msg {
+			return false
+		}
+		if condition.Status != status {
+			condition.LastTransitionTime = now
+		}
+		condition.Status = status
+		condition.Reason = reason
+		condition.Message = msg
+		condition.LastProbeTime = now
+		return true
+	}
+	r.Status.Conditions = append(r.Status.Conditions, schedulingv1alpha1.ReservationCondition{
+		Type:               schedulingv1alpha1.ReservationConditionResized,
+		Status:             status,
+		Reason:             reason,
+		Message:            msg,
+		LastProbeTime:      now,
+		LastTransitionTime: now,
+	})
+	return true
+}
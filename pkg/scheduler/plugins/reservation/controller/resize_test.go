@@ -0,0 +1,231 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+	"k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
+
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	koordfake "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
+	koordinformers "github.com/koordinator-sh/koordinator/pkg/client/informers/externalversions"
+)
+
+func TestSyncResize(t *testing.T) {
+	newReservation := func(requests corev1.ResourceList, releaseUnused bool) *schedulingv1alpha1.Reservation {
+		r := &schedulingv1alpha1.Reservation{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "test-reservation",
+				UID:         "test-reservation",
+				Annotations: map[string]string{},
+			},
+			Spec: schedulingv1alpha1.ReservationSpec{
+				AllocateOnce: pointer.Bool(false),
+				Template: &corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Resources: corev1.ResourceRequirements{
+									Requests: requests,
+								},
+							},
+						},
+					},
+				},
+			},
+			Status: schedulingv1alpha1.ReservationStatus{
+				Phase:    schedulingv1alpha1.ReservationAvailable,
+				NodeName: "test-node",
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("4"),
+					corev1.ResourceMemory: resource.MustParse("4Gi"),
+				},
+				Allocated: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("1"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+			},
+		}
+		if releaseUnused {
+			r.Annotations[apiext.AnnotationReservationReleaseUnused] = "true"
+		}
+		return r
+	}
+
+	newRequests := func(cpu string) corev1.ResourceList {
+		return corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpu),
+			corev1.ResourceMemory: resource.MustParse("4Gi"),
+		}
+	}
+
+	tests := []struct {
+		name            string
+		reservation     *schedulingv1alpha1.Reservation
+		resizeErr       error
+		wantChanged     bool
+		wantFailed      bool
+		wantResized     bool
+		wantAllocatable corev1.ResourceList
+		wantCondition   schedulingv1alpha1.ConditionStatus
+	}{
+		{
+			name:        "no resize",
+			reservation: newReservation(newRequests("4"), false),
+			wantChanged: false,
+			wantAllocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			},
+		},
+		{
+			name:        "shrink",
+			wantResized: true,
+			reservation: newReservation(newRequests("2"), false),
+			wantChanged: true,
+			wantAllocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			},
+			wantCondition: schedulingv1alpha1.ConditionStatusTrue,
+		},
+		{
+			name:        "shrink below allocated",
+			wantResized: true,
+			reservation: newReservation(newRequests("500m"), false),
+			wantChanged: true,
+			wantAllocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			},
+			wantCondition: schedulingv1alpha1.ConditionStatusTrue,
+		},
+		{
+			name:        "grow with sufficient resources",
+			wantResized: true,
+			reservation: newReservation(newRequests("8"), false),
+			wantChanged: true,
+			wantAllocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("8"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			},
+			wantCondition: schedulingv1alpha1.ConditionStatusTrue,
+		},
+		{
+			name:        "grow with insufficient resources",
+			reservation: newReservation(newRequests("12"), false),
+			resizeErr:   fmt.Errorf("Insufficient cpu"),
+			wantChanged: true,
+			wantFailed:  true,
+			wantResized: true,
+			wantAllocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			},
+			wantCondition: schedulingv1alpha1.ConditionStatusFalse,
+		},
+		{
+			name:        "release unused",
+			wantResized: true,
+			reservation: newReservation(newRequests("4"), true),
+			wantChanged: true,
+			wantAllocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			},
+			wantCondition: schedulingv1alpha1.ConditionStatusTrue,
+		},
+		{
+			name: "add resources from template",
+			reservation: newReservation(corev1.ResourceList{
+				corev1.ResourceCPU:              resource.MustParse("4"),
+				corev1.ResourceMemory:           resource.MustParse("4Gi"),
+				corev1.ResourceEphemeralStorage: resource.MustParse("10Gi"),
+			}, false),
+			wantChanged: true,
+			wantResized: true,
+			wantAllocatable: corev1.ResourceList{
+				corev1.ResourceCPU:              resource.MustParse("4"),
+				corev1.ResourceMemory:           resource.MustParse("4Gi"),
+				corev1.ResourceEphemeralStorage: resource.MustParse("10Gi"),
+			},
+			wantCondition: schedulingv1alpha1.ConditionStatusTrue,
+		},
+		{
+			name: "remove resources from template",
+			reservation: newReservation(corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			}, false),
+			wantChanged: true,
+			wantResized: true,
+			wantAllocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			},
+			wantCondition: schedulingv1alpha1.ConditionStatusTrue,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sharedInformerFactory := informers.NewSharedInformerFactory(kubefake.NewSimpleClientset(), 0)
+			koordSharedInformerFactory := koordinformers.NewSharedInformerFactory(koordfake.NewSimpleClientset(), 0)
+			assert.NoError(t, koordSharedInformerFactory.Scheduling().V1alpha1().Reservations().Informer().GetStore().Add(tt.reservation))
+
+			resizer := &fakeResizer{err: tt.resizeErr}
+			c := New(sharedInformerFactory, koordSharedInformerFactory, koordfake.NewSimpleClientset(), resizer, 1)
+
+			reservation := tt.reservation.DeepCopy()
+			changed, failed := c.syncResize(reservation)
+			assert.Equal(t, tt.wantChanged, changed)
+			assert.Equal(t, tt.wantFailed, failed)
+			assert.Equal(t, tt.wantResized, resizer.resized != nil)
+			assert.True(t, quotav1.Equals(tt.wantAllocatable, reservation.Status.Allocatable))
+			var condition *schedulingv1alpha1.ReservationCondition
+			for i := range reservation.Status.Conditions {
+				if reservation.Status.Conditions[i].Type == schedulingv1alpha1.ReservationConditionResized {
+					condition = &reservation.Status.Conditions[i]
+				}
+			}
+			if tt.wantCondition == "" {
+				assert.Nil(t, condition)
+			} else {
+				assert.NotNil(t, condition)
+				assert.Equal(t, tt.wantCondition, condition.Status)
+			}
+		})
+	}
+}
+
+type fakeResizer struct {
+	err     error
+	resized *schedulingv1alpha1.Reservation
+}
+
+func (f *fakeResizer) ResizeReservation(origin, resized *schedulingv1alpha1.Reservation) error {
+	f.resized = resized
+	return f.err
+}
@@ -126,6 +126,7 @@ func (pl *Plugin) NewControllers() ([]frameworkext.Controller, error) {
 		pl.handle.SharedInformerFactory(),
 		pl.handle.KoordinatorSharedInformerFactory(),
 		pl.handle.KoordinatorClientSet(),
+		pl,
 		1)
 	return []frameworkext.Controller{reservationController}, nil
 }
@@ -217,7 +218,7 @@ func (pl *Plugin) PreFilter(ctx context.Context, cycleState *framework.CycleStat
 		// validate reserve pod and reservation
 		klog.V(4).InfoS("Attempting to pre-filter reserve pod", "pod", klog.KObj(pod))
 		rName := reservationutil.GetReservationNameFromReservePod(pod)
-		r, err := pl.rLister.Get(rName)
+		r, err := frameworkext.GetReservation(cycleState, pl.rLister, rName)
 		if err != nil {
 			if errors.IsNotFound(err) {
 				klog.V(3).InfoS("skip the pre-filter for reservation since the object is not found", "pod", klog.KObj(pod), "reservation", rName)
@@ -339,7 +340,7 @@ func (pl *Plugin) Filter(ctx context.Context, cycleState *framework.CycleState,
 			}
 
 			rName := reservationutil.GetReservationNameFromReservePod(pod)
-			reservation, err := pl.rLister.Get(rName)
+			reservation, err := frameworkext.GetReservation(cycleState, pl.rLister, rName)
 			if err != nil {
 				return framework.NewStatus(framework.Error, "reservation not found")
 			}
@@ -534,7 +535,7 @@ func (pl *Plugin) FilterReservation(ctx context.Context, cycleState *framework.C
 func (pl *Plugin) Reserve(ctx context.Context, cycleState *framework.CycleState, pod *corev1.Pod, nodeName string) *framework.Status {
 	if reservationutil.IsReservePod(pod) {
 		rName := reservationutil.GetReservationNameFromReservePod(pod)
-		assumedReservation, err := pl.rLister.Get(rName)
+		assumedReservation, err := frameworkext.GetReservation(cycleState, pl.rLister, rName)
 		if err != nil {
 			return framework.AsStatus(err)
 		}
@@ -574,7 +575,7 @@ func (pl *Plugin) Reserve(ctx context.Context, cycleState *framework.CycleState,
 func (pl *Plugin) Unreserve(ctx context.Context, cycleState *framework.CycleState, pod *corev1.Pod, nodeName string) {
 	if reservationutil.IsReservePod(pod) {
 		rName := reservationutil.GetReservationNameFromReservePod(pod)
-		assumedReservation, err := pl.rLister.Get(rName)
+		assumedReservation, err := frameworkext.GetReservation(cycleState, pl.rLister, rName)
 		if err != nil {
 			klog.ErrorS(err, "Failed to get reservation in Unreserve phase", "reservation", rName, "nodeName", nodeName)
 			return
@@ -0,0 +1,123 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reservation
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+	"k8s.io/klog/v2"
+	resourceapi "k8s.io/kubernetes/pkg/api/v1/resource"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+	"k8s.io/utils/pointer"
+
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/plugins/reservation/controller"
+	reservationutil "github.com/koordinator-sh/koordinator/pkg/util/reservation"
+)
+
+var _ controller.Resizer = &Plugin{}
+
+// ResizeReservation resizes the reserve pod of the Available reservation in the scheduler cache.
+// The growth is checked against the NodeInfo in the scheduler cache, which includes the assumed pods.
+// Both the check and the update run between the scheduling cycles, so that no pods are assumed meanwhile and
+// the resized reservation never overcommits the node with the pods being scheduled.
+func (pl *Plugin) ResizeReservation(origin, resized *schedulingv1alpha1.Reservation) error {
+	return pl.handle.Scheduler().RunBetweenSchedulingCycles(context.TODO(), func() error {
+		return pl.resizeReservation(origin, resized)
+	})
+}
+
+func (pl *Plugin) resizeReservation(origin, resized *schedulingv1alpha1.Reservation) error {
+	nodeName := reservationutil.GetReservationNodeName(resized)
+	schedulerCache := pl.handle.Scheduler().GetCache()
+	cachedReservePod, err := schedulerCache.GetPod(reservationutil.NewReservePod(origin))
+	if err != nil {
+		return err
+	}
+	if cachedReservePod == nil {
+		return fmt.Errorf("reserve pod not found in scheduler cache")
+	}
+	reservePod := reservationutil.NewReservePod(resized)
+	// Forces priority to be set to maximum to prevent preemption.
+	reservePod.Spec.Priority = pointer.Int32(math.MaxInt32)
+
+	cachedRequests, _ := resourceapi.PodRequestsAndLimits(cachedReservePod)
+	requests, _ := resourceapi.PodRequestsAndLimits(reservePod)
+	var grown []corev1.ResourceName
+	for resourceName, quantity := range requests {
+		if quantity.Cmp(cachedRequests[resourceName]) > 0 {
+			grown = append(grown, resourceName)
+		}
+	}
+	if len(grown) > 0 {
+		nodeInfo, err := schedulerCache.GetNodeInfo(nodeName)
+		if err != nil {
+			return err
+		}
+		free := pl.getNodeFreeResources(nodeInfo)
+		var insufficient []string
+		for _, resourceName := range grown {
+			delta := requests[resourceName].DeepCopy()
+			delta.Sub(cachedRequests[resourceName])
+			if delta.Cmp(free[resourceName]) > 0 {
+				insufficient = append(insufficient, string(resourceName))
+			}
+		}
+		if len(insufficient) > 0 {
+			sort.Strings(insufficient)
+			return fmt.Errorf("Insufficient %s", strings.Join(insufficient, ", "))
+		}
+	}
+
+	if err := schedulerCache.UpdatePod(cachedReservePod, reservePod); err != nil {
+		return err
+	}
+	pl.reservationCache.updateReservationIfExists(resized)
+	klog.V(4).InfoS("Resize reservation in scheduler cache", "reservation", klog.KObj(resized), "node", nodeName,
+		"old", cachedRequests, "new", requests)
+	return nil
+}
+
+// getNodeFreeResources returns the node allocatable subtracting the requested of the NodeInfo.
+// The requests of pods allocated from reservations are counted in both the reserve pods and the owner pods,
+// so the allocated of the reservations is added back.
+func (pl *Plugin) getNodeFreeResources(nodeInfo *framework.NodeInfo) corev1.ResourceList {
+	requested := resourceToResourceList(nodeInfo.Requested)
+	for _, rInfo := range pl.reservationCache.listAvailableReservationInfosOnNode(nodeInfo.Node().Name) {
+		requested = quotav1.SubtractWithNonNegativeResult(requested, quotav1.Mask(rInfo.Allocated, quotav1.ResourceNames(rInfo.Allocatable)))
+	}
+	return quotav1.SubtractWithNonNegativeResult(resourceToResourceList(nodeInfo.Allocatable), requested)
+}
+
+func resourceToResourceList(r *framework.Resource) corev1.ResourceList {
+	resourceList := corev1.ResourceList{
+		corev1.ResourceCPU:              *resource.NewMilliQuantity(r.MilliCPU, resource.DecimalSI),
+		corev1.ResourceMemory:           *resource.NewQuantity(r.Memory, resource.BinarySI),
+		corev1.ResourceEphemeralStorage: *resource.NewQuantity(r.EphemeralStorage, resource.BinarySI),
+	}
+	for resourceName, quantity := range r.ScalarResources {
+		resourceList[resourceName] = *resource.NewQuantity(quantity, resource.DecimalSI)
+	}
+	return resourceList
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reservation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+	resourceapi "k8s.io/kubernetes/pkg/api/v1/resource"
+	"k8s.io/utils/pointer"
+
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+	reservationutil "github.com/koordinator-sh/koordinator/pkg/util/reservation"
+)
+
+func TestResizeReservation(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-node"},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("16"),
+				corev1.ResourceMemory: resource.MustParse("32Gi"),
+			},
+		},
+	}
+	newPod := func(name string, cpu string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, UID: "uid-" + types.UID(name)},
+			Spec: corev1.PodSpec{
+				NodeName: node.Name,
+				Containers: []corev1.Container{
+					{
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse(cpu),
+								corev1.ResourceMemory: resource.MustParse("1Gi"),
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	reservation := &schedulingv1alpha1.Reservation{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-reservation", UID: "test-reservation"},
+		Spec: schedulingv1alpha1.ReservationSpec{
+			Template: &corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse("4"),
+									corev1.ResourceMemory: resource.MustParse("4Gi"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Status: schedulingv1alpha1.ReservationStatus{
+			Phase:    schedulingv1alpha1.ReservationAvailable,
+			NodeName: node.Name,
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		cpu         string
+		wantErr     bool
+		wantRequest string
+	}{
+		{
+			name:        "shrink",
+			cpu:         "2",
+			wantRequest: "2",
+		},
+		{
+			name:        "grow with sufficient resources",
+			cpu:         "8",
+			wantRequest: "8",
+		},
+		{
+			name:        "grow with insufficient resources including assumed pods",
+			cpu:         "9",
+			wantErr:     true,
+			wantRequest: "4",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suit := newPluginTestSuit(t)
+			p, err := suit.pluginFactory()
+			assert.NoError(t, err)
+			pl := p.(*Plugin)
+			sched := pl.handle.Scheduler().(*frameworkext.FakeScheduler)
+			sched.Nodes[node.Name] = node
+
+			reservePod := reservationutil.NewReservePod(reservation)
+			reservePod.Spec.Priority = pointer.Int32(math.MaxInt32)
+			assert.NoError(t, sched.AddPod(reservePod))
+			assert.NoError(t, sched.AddPod(newPod("running-pod", "7")))
+			assert.NoError(t, sched.AssumePod(newPod("assumed-pod", "1")))
+			ownerPod := newPod("owner-pod", "1")
+			assert.NoError(t, sched.AddPod(ownerPod))
+			pl.reservationCache.updateReservation(reservation)
+			assert.NoError(t, pl.reservationCache.addPod(reservation.UID, ownerPod))
+
+			resized := reservation.DeepCopy()
+			resized.Status.Allocatable[corev1.ResourceCPU] = resource.MustParse(tt.cpu)
+			err = pl.ResizeReservation(reservation, resized)
+			assert.Equal(t, tt.wantErr, err != nil)
+
+			cachedReservePod, err := sched.GetPod(reservePod)
+			assert.NoError(t, err)
+			requests, _ := resourceapi.PodRequestsAndLimits(cachedReservePod)
+			assert.True(t, quotav1.Equals(corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(tt.wantRequest),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			}, requests))
+			assert.Equal(t, int32(math.MaxInt32), *cachedReservePod.Spec.Priority)
+			rInfo := pl.reservationCache.getReservationInfoByUID(reservation.UID)
+			assert.True(t, quotav1.Equals(requests, rInfo.Allocatable))
+		})
+	}
+}
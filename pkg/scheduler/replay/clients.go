@@ -0,0 +1,111 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replay
+
+import (
+	nrtclientset "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/clientset/versioned"
+	nrtfake "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/clientset/versioned/fake"
+	nrttopologyv1alpha1 "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/clientset/versioned/typed/topology/v1alpha1"
+	nrttopologyv1alpha2 "github.com/k8stopologyawareschedwg/noderesourcetopology-api/pkg/generated/clientset/versioned/typed/topology/v1alpha2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/discovery"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+	schedclientset "sigs.k8s.io/scheduler-plugins/pkg/generated/clientset/versioned"
+	schedfake "sigs.k8s.io/scheduler-plugins/pkg/generated/clientset/versioned/fake"
+	schedschedulingv1alpha1 "sigs.k8s.io/scheduler-plugins/pkg/generated/clientset/versioned/typed/scheduling/v1alpha1"
+
+	koordfake "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/fake"
+	"github.com/koordinator-sh/koordinator/pkg/scheduler/frameworkext"
+)
+
+// ClientSets are the fake clients serving the snapshot.
+type ClientSets struct {
+	KubeClient    *kubefake.Clientset
+	KoordClient   *koordfake.Clientset
+	SchedClient   *schedfake.Clientset
+	NRTClient     *nrtfake.Clientset
+	DynamicClient *dynamicfake.FakeDynamicClient
+}
+
+func (s *Snapshot) NewClientSets() *ClientSets {
+	var kubeObjects, koordObjects, schedObjects []runtime.Object
+	for _, node := range s.Nodes {
+		kubeObjects = append(kubeObjects, node)
+	}
+	for _, pod := range s.Pods {
+		kubeObjects = append(kubeObjects, pod)
+	}
+	for _, nodeMetric := range s.NodeMetrics {
+		koordObjects = append(koordObjects, nodeMetric)
+	}
+	for _, device := range s.Devices {
+		koordObjects = append(koordObjects, device)
+	}
+	for _, r := range s.Reservations {
+		koordObjects = append(koordObjects, r)
+	}
+	for _, quota := range s.ElasticQuotas {
+		schedObjects = append(schedObjects, quota)
+	}
+	return &ClientSets{
+		KubeClient:  kubefake.NewSimpleClientset(kubeObjects...),
+		KoordClient: koordfake.NewSimpleClientset(koordObjects...),
+		SchedClient: schedfake.NewSimpleClientset(schedObjects...),
+		NRTClient:   nrtfake.NewSimpleClientset(),
+		// the dynamic informers only trigger the requeueing of the unschedulable pods on the custom resource events
+		DynamicClient: dynamicfake.NewSimpleDynamicClient(scheme),
+	}
+}
+
+// WrapPluginHandle provides the fake clients of the third-party APIs for the plugins which look up the clients
+// from the handle, e.g. ElasticQuota, Coscheduling and NodeNUMAResource.
+func (c *ClientSets) WrapPluginHandle(extender frameworkext.FrameworkExtender) framework.Handle {
+	return &pluginHandle{
+		FrameworkExtender: extender,
+		schedClient:       c.SchedClient,
+		nrtClient:         c.NRTClient,
+	}
+}
+
+var (
+	_ schedclientset.Interface = &pluginHandle{}
+	_ nrtclientset.Interface   = &pluginHandle{}
+)
+
+type pluginHandle struct {
+	frameworkext.FrameworkExtender
+	schedClient schedclientset.Interface
+	nrtClient   nrtclientset.Interface
+}
+
+func (h *pluginHandle) Discovery() discovery.DiscoveryInterface {
+	return h.schedClient.Discovery()
+}
+
+func (h *pluginHandle) SchedulingV1alpha1() schedschedulingv1alpha1.SchedulingV1alpha1Interface {
+	return h.schedClient.SchedulingV1alpha1()
+}
+
+func (h *pluginHandle) TopologyV1alpha1() nrttopologyv1alpha1.TopologyV1alpha1Interface {
+	return h.nrtClient.TopologyV1alpha1()
+}
+
+func (h *pluginHandle) TopologyV1alpha2() nrttopologyv1alpha2.TopologyV1alpha2Interface {
+	return h.nrtClient.TopologyV1alpha2()
+}
@@ -0,0 +1,266 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replay
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/apimachinery/pkg/util/wait"
+	listercorev1 "k8s.io/client-go/listers/core/v1"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/klog/v2"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
+	"k8s.io/kubernetes/pkg/scheduler"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+	schedv1alpha1 "sigs.k8s.io/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
+
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+)
+
+// Replayer replays the pending pods one by one through the scheduler running against the fake clients.
+//
+// The pods are fed to the scheduler directly rather than popped from the scheduling queue, so each pod is attempted
+// exactly once and in order, and the outcomes are reproducible across the runs with different args.
+// The next pod is fed after the scheduling cycle of the previous one, and the binding cycles, e.g. the waiting gang
+// members, run in parallel as usual.
+type Replayer struct {
+	sched     *scheduler.Scheduler
+	clients   *ClientSets
+	podLister listercorev1.PodLister
+	podCh     chan *framework.QueuedPodInfo
+
+	lock     sync.Mutex
+	outcomes map[types.UID]*PodOutcome
+	notifyCh chan struct{}
+}
+
+// New hooks the scheduler to replay the pods, it must be called before running the scheduler.
+func New(sched *scheduler.Scheduler, clients *ClientSets, podLister listercorev1.PodLister, stopCh <-chan struct{}) *Replayer {
+	r := &Replayer{
+		sched:     sched,
+		clients:   clients,
+		podLister: podLister,
+		podCh:     make(chan *framework.QueuedPodInfo),
+		outcomes:  map[types.UID]*PodOutcome{},
+		notifyCh:  make(chan struct{}, 1),
+	}
+	sched.NextPod = func() *framework.QueuedPodInfo {
+		select {
+		case podInfo := <-r.podCh:
+			return podInfo
+		case <-stopCh:
+			return nil
+		}
+	}
+	errorFn := sched.Error
+	sched.Error = func(podInfo *framework.QueuedPodInfo, err error) {
+		r.recordOutcome(podInfo.Pod, "", err.Error())
+		// the replayed pods are added to the scheduling queue by the event handlers but never popped,
+		// so remove it to let the error handlers requeue it as usual
+		if err := sched.SchedulingQueue.Delete(podInfo.Pod); err != nil {
+			klog.V(4).InfoS("Failed to delete pod from scheduling queue", "pod", klog.KObj(podInfo.Pod), "err", err)
+		}
+		errorFn(podInfo, err)
+	}
+	clients.KubeClient.PrependReactor("create", "pods", r.bindPod)
+	return r
+}
+
+// Replay schedules the pods in order and waits for the outcomes of all the pods.
+func (r *Replayer) Replay(ctx context.Context, pods []*corev1.Pod) (*Report, error) {
+	var outcomes []*PodOutcome
+	for _, pod := range pods {
+		pod = r.preparePod(pod)
+		outcome := &PodOutcome{Namespace: pod.Namespace, Name: pod.Name, pod: pod}
+		outcomes = append(outcomes, outcome)
+		if _, ok := r.sched.Profiles[pod.Spec.SchedulerName]; !ok {
+			r.recordOutcome(pod, "", fmt.Sprintf("profile not found for scheduler name %q", pod.Spec.SchedulerName))
+			continue
+		}
+		if err := r.schedulePod(ctx, pod); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.waitForOutcomes(ctx, outcomes); err != nil {
+		klog.ErrorS(err, "Failed to wait for the outcomes of all the pods")
+	}
+	r.lock.Lock()
+	for _, outcome := range outcomes {
+		if recorded := r.outcomes[outcome.pod.UID]; recorded != nil {
+			outcome.Scheduled, outcome.NodeName, outcome.Message = recorded.Scheduled, recorded.NodeName, recorded.Message
+		} else {
+			outcome.Message = "timeout waiting for the scheduling outcome"
+		}
+	}
+	r.lock.Unlock()
+
+	return r.buildReport(ctx, outcomes)
+}
+
+func (r *Replayer) buildReport(ctx context.Context, outcomes []*PodOutcome) (*Report, error) {
+	nodeList, err := r.clients.KubeClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]*corev1.Node, 0, len(nodeList.Items))
+	for i := range nodeList.Items {
+		nodes = append(nodes, &nodeList.Items[i])
+	}
+	podList, err := r.clients.KubeClient.CoreV1().Pods(corev1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	pods := make([]*corev1.Pod, 0, len(podList.Items))
+	for i := range podList.Items {
+		pods = append(pods, &podList.Items[i])
+	}
+	reservationList, err := r.clients.KoordClient.SchedulingV1alpha1().Reservations().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	reservations := make([]*schedulingv1alpha1.Reservation, 0, len(reservationList.Items))
+	for i := range reservationList.Items {
+		reservations = append(reservations, &reservationList.Items[i])
+	}
+	quotaList, err := r.clients.SchedClient.SchedulingV1alpha1().ElasticQuotas(corev1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	quotas := make([]*schedv1alpha1.ElasticQuota, 0, len(quotaList.Items))
+	for i := range quotaList.Items {
+		quotas = append(quotas, &quotaList.Items[i])
+	}
+	return BuildReport(nodes, pods, reservations, quotas, outcomes), nil
+}
+
+func (r *Replayer) preparePod(pod *corev1.Pod) *corev1.Pod {
+	pod = pod.DeepCopy()
+	if pod.Namespace == "" {
+		pod.Namespace = corev1.NamespaceDefault
+	}
+	if pod.UID == "" {
+		pod.UID = uuid.NewUUID()
+	}
+	if pod.Spec.SchedulerName == "" {
+		pod.Spec.SchedulerName = corev1.DefaultSchedulerName
+	}
+	pod.Spec.NodeName = ""
+	pod.Status = corev1.PodStatus{Phase: corev1.PodPending}
+	return pod
+}
+
+// schedulePod creates the pod and feeds it to the scheduler once the pod is observed by the informers.
+func (r *Replayer) schedulePod(ctx context.Context, pod *corev1.Pod) error {
+	if _, err := r.clients.KubeClient.CoreV1().Pods(pod.Namespace).Create(ctx, pod, metav1.CreateOptions{}); err != nil {
+		return err
+	}
+	err := wait.PollImmediateUntil(10*time.Millisecond, func() (bool, error) {
+		_, err := r.podLister.Pods(pod.Namespace).Get(pod.Name)
+		return err == nil, nil
+	}, ctx.Done())
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	podInfo := &framework.QueuedPodInfo{
+		PodInfo:                 framework.NewPodInfo(pod),
+		Timestamp:               now,
+		Attempts:                1,
+		InitialAttemptTimestamp: now,
+	}
+	select {
+	case r.podCh <- podInfo:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// bindPod reacts to the binding requests of the binder plugins by assigning the pods in the fake client.
+func (r *Replayer) bindPod(action k8stesting.Action) (bool, runtime.Object, error) {
+	if action.GetSubresource() != "binding" {
+		return false, nil, nil
+	}
+	binding, ok := action.(k8stesting.CreateAction).GetObject().(*corev1.Binding)
+	if !ok {
+		return false, nil, nil
+	}
+	obj, err := r.clients.KubeClient.Tracker().Get(action.GetResource(), action.GetNamespace(), binding.Name)
+	if err != nil {
+		return true, nil, err
+	}
+	pod := obj.(*corev1.Pod).DeepCopy()
+	pod.Spec.NodeName = binding.Target.Name
+	podutil.UpdatePodCondition(&pod.Status, &corev1.PodCondition{
+		Type:               corev1.PodScheduled,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+	})
+	if err := r.clients.KubeClient.Tracker().Update(action.GetResource(), pod, action.GetNamespace()); err != nil {
+		return true, nil, err
+	}
+	r.recordOutcome(pod, binding.Target.Name, "")
+	return true, binding, nil
+}
+
+func (r *Replayer) recordOutcome(pod *corev1.Pod, nodeName, message string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.outcomes[pod.UID] = &PodOutcome{
+		Namespace: pod.Namespace,
+		Name:      pod.Name,
+		Scheduled: nodeName != "",
+		NodeName:  nodeName,
+		Message:   message,
+	}
+	select {
+	case r.notifyCh <- struct{}{}:
+	default:
+	}
+}
+
+func (r *Replayer) waitForOutcomes(ctx context.Context, outcomes []*PodOutcome) error {
+	for {
+		r.lock.Lock()
+		done := true
+		for _, outcome := range outcomes {
+			if r.outcomes[outcome.pod.UID] == nil {
+				done = false
+				break
+			}
+		}
+		r.lock.Unlock()
+		if done {
+			return nil
+		}
+		select {
+		case <-r.notifyCh:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replay
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+	resourceapi "k8s.io/kubernetes/pkg/api/v1/resource"
+	schedv1alpha1 "sigs.k8s.io/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	reservationutil "github.com/koordinator-sh/koordinator/pkg/util/reservation"
+)
+
+type Report struct {
+	Summary Summary       `json:"summary"`
+	Pods    []*PodOutcome `json:"pods,omitempty"`
+	Nodes   []*NodeReport `json:"nodes,omitempty"`
+	// Fragmentation describes how the free resources of the cluster are scattered across the nodes.
+	Fragmentation []*FragmentationReport `json:"fragmentation,omitempty"`
+	Quotas        []*QuotaReport         `json:"quotas,omitempty"`
+}
+
+type Summary struct {
+	Total         int `json:"total"`
+	Scheduled     int `json:"scheduled"`
+	Unschedulable int `json:"unschedulable"`
+	// EmptyNodes is the number of the nodes without any pods or reservations after the replay.
+	EmptyNodes int `json:"emptyNodes"`
+}
+
+// PodOutcome is the placement of a replayed pod.
+type PodOutcome struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Scheduled bool   `json:"scheduled"`
+	NodeName  string `json:"nodeName,omitempty"`
+	// Message is the scheduling failure of the unschedulable pod.
+	Message string `json:"message,omitempty"`
+
+	pod *corev1.Pod
+}
+
+type NodeReport struct {
+	Name        string              `json:"name"`
+	Pods        int                 `json:"pods"`
+	Allocatable corev1.ResourceList `json:"allocatable,omitempty"`
+	// Requested is the sum of the requests of the pods and the unallocated resources of the reservations on the node.
+	Requested corev1.ResourceList `json:"requested,omitempty"`
+	// Utilization is the percentage of the requested resources to the allocatable resources.
+	Utilization map[corev1.ResourceName]float64 `json:"utilization,omitempty"`
+}
+
+type FragmentationReport struct {
+	Resource corev1.ResourceName `json:"resource"`
+	// Free is the sum of the free resources of all the nodes.
+	Free resource.Quantity `json:"free"`
+	// MaxNodeFree is the largest free resources on a single node, which is the largest request that still fits.
+	MaxNodeFree resource.Quantity `json:"maxNodeFree"`
+	// Ratio is 1 - MaxNodeFree / Free, the larger the ratio is, the more fragmented the free resources are.
+	Ratio float64 `json:"ratio"`
+}
+
+type QuotaReport struct {
+	Name      string              `json:"name"`
+	Namespace string              `json:"namespace,omitempty"`
+	Min       corev1.ResourceList `json:"min,omitempty"`
+	Max       corev1.ResourceList `json:"max,omitempty"`
+	// Used is the sum of the requests of the scheduled pods in the quota, including the pods in the snapshot.
+	Used corev1.ResourceList `json:"used,omitempty"`
+	// Scheduled and Unschedulable are the numbers of the replayed pods in the quota.
+	Scheduled     int `json:"scheduled"`
+	Unschedulable int `json:"unschedulable"`
+}
+
+// BuildReport builds the report from the final state of the objects after replaying the pods.
+func BuildReport(nodes []*corev1.Node, pods []*corev1.Pod, reservations []*schedulingv1alpha1.Reservation,
+	quotas []*schedv1alpha1.ElasticQuota, outcomes []*PodOutcome) *Report {
+	report := &Report{Pods: outcomes}
+	for _, outcome := range outcomes {
+		report.Summary.Total++
+		if outcome.Scheduled {
+			report.Summary.Scheduled++
+		} else {
+			report.Summary.Unschedulable++
+		}
+	}
+
+	var assignedPods []*corev1.Pod
+	for _, pod := range pods {
+		if pod.Spec.NodeName != "" && pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+			assignedPods = append(assignedPods, pod)
+		}
+	}
+	report.Nodes = buildNodeReports(nodes, assignedPods, reservations)
+	for _, nodeReport := range report.Nodes {
+		if quotav1.IsZero(nodeReport.Requested) {
+			report.Summary.EmptyNodes++
+		}
+	}
+	report.Fragmentation = buildFragmentationReports(report.Nodes)
+	report.Quotas = buildQuotaReports(quotas, assignedPods, outcomes)
+	return report
+}
+
+func buildNodeReports(nodes []*corev1.Node, assignedPods []*corev1.Pod, reservations []*schedulingv1alpha1.Reservation) []*NodeReport {
+	nodeReports := make(map[string]*NodeReport, len(nodes))
+	for _, node := range nodes {
+		nodeReports[node.Name] = &NodeReport{
+			Name:        node.Name,
+			Allocatable: node.Status.Allocatable.DeepCopy(),
+		}
+	}
+
+	reservationAllocated := map[types.UID]corev1.ResourceList{}
+	for _, pod := range assignedPods {
+		requests, _ := resourceapi.PodRequestsAndLimits(pod)
+		if allocated, err := extension.GetReservationAllocated(pod); err == nil && allocated != nil {
+			reservationAllocated[allocated.UID] = quotav1.Add(reservationAllocated[allocated.UID], requests)
+		}
+		if nodeReport := nodeReports[pod.Spec.NodeName]; nodeReport != nil {
+			nodeReport.Pods++
+			nodeReport.Requested = quotav1.Add(nodeReport.Requested, requests)
+		}
+	}
+	// the owner pods are counted by themselves, so only the unallocated resources of the reservations are counted
+	for _, r := range reservations {
+		if !reservationutil.IsReservationAvailable(r) {
+			continue
+		}
+		if nodeReport := nodeReports[reservationutil.GetReservationNodeName(r)]; nodeReport != nil {
+			unallocated := quotav1.SubtractWithNonNegativeResult(reservationutil.ReservedResources(r), reservationAllocated[r.UID])
+			nodeReport.Requested = quotav1.Add(nodeReport.Requested, unallocated)
+		}
+	}
+
+	results := make([]*NodeReport, 0, len(nodeReports))
+	for _, nodeReport := range nodeReports {
+		nodeReport.Utilization = map[corev1.ResourceName]float64{}
+		for resourceName, allocatable := range nodeReport.Allocatable {
+			if allocatable.IsZero() {
+				continue
+			}
+			requested := nodeReport.Requested[resourceName]
+			nodeReport.Utilization[resourceName] = float64(requested.MilliValue()) * 100 / float64(allocatable.MilliValue())
+		}
+		results = append(results, nodeReport)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Name < results[j].Name
+	})
+	return results
+}
+
+func buildFragmentationReports(nodeReports []*NodeReport) []*FragmentationReport {
+	fragmentations := map[corev1.ResourceName]*FragmentationReport{}
+	for _, nodeReport := range nodeReports {
+		free := quotav1.SubtractWithNonNegativeResult(nodeReport.Allocatable, nodeReport.Requested)
+		for resourceName := range nodeReport.Allocatable {
+			fragmentation := fragmentations[resourceName]
+			if fragmentation == nil {
+				fragmentation = &FragmentationReport{Resource: resourceName}
+				fragmentations[resourceName] = fragmentation
+			}
+			quantity := free[resourceName]
+			fragmentation.Free.Add(quantity)
+			if quantity.Cmp(fragmentation.MaxNodeFree) > 0 {
+				fragmentation.MaxNodeFree = quantity.DeepCopy()
+			}
+		}
+	}
+
+	results := make([]*FragmentationReport, 0, len(fragmentations))
+	for _, fragmentation := range fragmentations {
+		if !fragmentation.Free.IsZero() {
+			fragmentation.Ratio = 1 - float64(fragmentation.MaxNodeFree.MilliValue())/float64(fragmentation.Free.MilliValue())
+		}
+		results = append(results, fragmentation)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Resource < results[j].Resource
+	})
+	return results
+}
+
+func buildQuotaReports(quotas []*schedv1alpha1.ElasticQuota, assignedPods []*corev1.Pod, outcomes []*PodOutcome) []*QuotaReport {
+	if len(quotas) == 0 {
+		return nil
+	}
+	quotaReports := make(map[string]*QuotaReport, len(quotas))
+	// the pods without the quota label belong to the quota with the same name as the namespace
+	namespacedQuotas := map[string]string{}
+	for _, quota := range quotas {
+		quotaReports[quota.Name] = &QuotaReport{
+			Name:      quota.Name,
+			Namespace: quota.Namespace,
+			Min:       quota.Spec.Min.DeepCopy(),
+			Max:       quota.Spec.Max.DeepCopy(),
+		}
+		if quota.Name == quota.Namespace {
+			namespacedQuotas[quota.Namespace] = quota.Name
+		}
+	}
+	getQuotaReport := func(pod *corev1.Pod) *QuotaReport {
+		quotaName := extension.GetQuotaName(pod)
+		if quotaName == "" {
+			quotaName = namespacedQuotas[pod.Namespace]
+		}
+		return quotaReports[quotaName]
+	}
+
+	for _, pod := range assignedPods {
+		if quotaReport := getQuotaReport(pod); quotaReport != nil {
+			requests, _ := resourceapi.PodRequestsAndLimits(pod)
+			quotaReport.Used = quotav1.Add(quotaReport.Used, requests)
+		}
+	}
+	for _, outcome := range outcomes {
+		quotaReport := getQuotaReport(outcome.pod)
+		if quotaReport == nil {
+			continue
+		}
+		if outcome.Scheduled {
+			quotaReport.Scheduled++
+		} else {
+			quotaReport.Unschedulable++
+		}
+	}
+
+	results := make([]*QuotaReport, 0, len(quotaReports))
+	for _, quotaReport := range quotaReports {
+		results = append(results, quotaReport)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Name < results[j].Name
+	})
+	return results
+}
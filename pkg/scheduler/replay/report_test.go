@@ -0,0 +1,154 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replay
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+	schedv1alpha1 "sigs.k8s.io/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	reservationutil "github.com/koordinator-sh/koordinator/pkg/util/reservation"
+)
+
+func newTestResourceList(cpu, memory string) corev1.ResourceList {
+	return corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(cpu),
+		corev1.ResourceMemory: resource.MustParse(memory),
+	}
+}
+
+func newTestPod(name, nodeName, quotaName string, requests corev1.ResourceList) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			UID:       types.UID(name),
+			Labels:    map[string]string{},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: nodeName,
+			Containers: []corev1.Container{
+				{Resources: corev1.ResourceRequirements{Requests: requests}},
+			},
+		},
+	}
+	if quotaName != "" {
+		pod.Labels[extension.LabelQuotaName] = quotaName
+	}
+	return pod
+}
+
+func TestBuildReport(t *testing.T) {
+	nodes := []*corev1.Node{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+			Status:     corev1.NodeStatus{Allocatable: newTestResourceList("8", "16Gi")},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-2"},
+			Status:     corev1.NodeStatus{Allocatable: newTestResourceList("8", "16Gi")},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-3"},
+			Status:     corev1.NodeStatus{Allocatable: newTestResourceList("8", "16Gi")},
+		},
+	}
+	existingPod := newTestPod("existing-pod", "node-1", "", newTestResourceList("2", "4Gi"))
+	scheduledPod := newTestPod("scheduled-pod", "node-1", "test-quota", newTestResourceList("2", "4Gi"))
+	unschedulablePod := newTestPod("unschedulable-pod", "", "test-quota", newTestResourceList("16", "4Gi"))
+	ownerPod := newTestPod("owner-pod", "node-2", "", newTestResourceList("1", "2Gi"))
+	ownerPod.Annotations = map[string]string{
+		extension.AnnotationReservationAllocated: `{"name":"test-reservation","uid":"test-reservation"}`,
+	}
+	reservation := &schedulingv1alpha1.Reservation{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-reservation", UID: "test-reservation"},
+		Spec: schedulingv1alpha1.ReservationSpec{
+			Template: &corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Resources: corev1.ResourceRequirements{Requests: newTestResourceList("4", "8Gi")}},
+					},
+				},
+			},
+		},
+	}
+	reservationutil.SetReservationAvailable(reservation, "node-2")
+	quotas := []*schedv1alpha1.ElasticQuota{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-quota"},
+			Spec: schedv1alpha1.ElasticQuotaSpec{
+				Min: newTestResourceList("4", "8Gi"),
+				Max: newTestResourceList("8", "16Gi"),
+			},
+		},
+	}
+	outcomes := []*PodOutcome{
+		{Namespace: "default", Name: "scheduled-pod", Scheduled: true, NodeName: "node-1", pod: scheduledPod},
+		{Namespace: "default", Name: "unschedulable-pod", Message: "Insufficient cpu", pod: unschedulablePod},
+	}
+
+	report := BuildReport(nodes, []*corev1.Pod{existingPod, scheduledPod, unschedulablePod, ownerPod},
+		[]*schedulingv1alpha1.Reservation{reservation}, quotas, outcomes)
+
+	assert.Equal(t, Summary{Total: 2, Scheduled: 1, Unschedulable: 1, EmptyNodes: 1}, report.Summary)
+	assert.Equal(t, outcomes, report.Pods)
+
+	assert.Len(t, report.Nodes, 3)
+	assert.Equal(t, 2, report.Nodes[0].Pods)
+	assert.True(t, quotav1.Equals(newTestResourceList("4", "8Gi"), report.Nodes[0].Requested))
+	assert.Equal(t, float64(50), report.Nodes[0].Utilization[corev1.ResourceCPU])
+	// the owner pod allocates from the reservation
+	assert.Equal(t, 1, report.Nodes[1].Pods)
+	assert.True(t, quotav1.Equals(newTestResourceList("4", "8Gi"), report.Nodes[1].Requested))
+	assert.True(t, quotav1.IsZero(report.Nodes[2].Requested))
+
+	expectedFragmentation := []*FragmentationReport{
+		{
+			Resource:    corev1.ResourceCPU,
+			Free:        resource.MustParse("16"),
+			MaxNodeFree: resource.MustParse("8"),
+			Ratio:       0.5,
+		},
+		{
+			Resource:    corev1.ResourceMemory,
+			Free:        resource.MustParse("32Gi"),
+			MaxNodeFree: resource.MustParse("16Gi"),
+			Ratio:       0.5,
+		},
+	}
+	assert.Len(t, report.Fragmentation, len(expectedFragmentation))
+	for i, expected := range expectedFragmentation {
+		assert.Equal(t, expected.Resource, report.Fragmentation[i].Resource)
+		assert.True(t, expected.Free.Equal(report.Fragmentation[i].Free))
+		assert.True(t, expected.MaxNodeFree.Equal(report.Fragmentation[i].MaxNodeFree))
+		assert.Equal(t, expected.Ratio, report.Fragmentation[i].Ratio)
+	}
+
+	assert.Len(t, report.Quotas, 1)
+	assert.Equal(t, "test-quota", report.Quotas[0].Name)
+	assert.True(t, quotav1.Equals(newTestResourceList("2", "4Gi"), report.Quotas[0].Used))
+	assert.Equal(t, 1, report.Quotas[0].Scheduled)
+	assert.Equal(t, 1, report.Quotas[0].Unschedulable)
+}
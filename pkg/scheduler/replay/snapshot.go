@@ -0,0 +1,169 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replay
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	kubescheme "k8s.io/client-go/kubernetes/scheme"
+	schedv1alpha1 "sigs.k8s.io/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
+	schedscheme "sigs.k8s.io/scheduler-plugins/pkg/generated/clientset/versioned/scheme"
+
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	koordscheme "github.com/koordinator-sh/koordinator/pkg/client/clientset/versioned/scheme"
+)
+
+var (
+	scheme = runtime.NewScheme()
+	codecs = serializer.NewCodecFactory(scheme)
+)
+
+func init() {
+	utilruntime.Must(kubescheme.AddToScheme(scheme))
+	utilruntime.Must(koordscheme.AddToScheme(scheme))
+	utilruntime.Must(schedscheme.AddToScheme(scheme))
+}
+
+// Snapshot is the captured cluster state which the pending pods are replayed against.
+type Snapshot struct {
+	Nodes         []*corev1.Node
+	Pods          []*corev1.Pod
+	NodeMetrics   []*slov1alpha1.NodeMetric
+	Devices       []*schedulingv1alpha1.Device
+	ElasticQuotas []*schedv1alpha1.ElasticQuota
+	Reservations  []*schedulingv1alpha1.Reservation
+}
+
+// LoadSnapshot loads the snapshot from the YAML or JSON files, each file may contain multiple documents and Lists,
+// e.g. the output of `kubectl get -o yaml`.
+func LoadSnapshot(paths ...string) (*Snapshot, error) {
+	snapshot := &Snapshot{}
+	for _, path := range paths {
+		objects, err := decodeFile(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range objects {
+			if err := snapshot.Add(obj); err != nil {
+				return nil, fmt.Errorf("failed to load %s, err: %w", path, err)
+			}
+		}
+	}
+	return snapshot, nil
+}
+
+// LoadPods loads the pending pods to be replayed in order.
+func LoadPods(path string) ([]*corev1.Pod, error) {
+	objects, err := decodeFile(path)
+	if err != nil {
+		return nil, err
+	}
+	pods := make([]*corev1.Pod, 0, len(objects))
+	for _, obj := range objects {
+		pod, ok := obj.(*corev1.Pod)
+		if !ok {
+			return nil, fmt.Errorf("failed to load %s, unexpected kind %s", path, obj.GetObjectKind().GroupVersionKind().Kind)
+		}
+		pods = append(pods, pod)
+	}
+	return pods, nil
+}
+
+func (s *Snapshot) Add(obj runtime.Object) error {
+	// the objects are indexed by UID in the scheduler cache
+	if metaObj, err := meta.Accessor(obj); err == nil && metaObj.GetUID() == "" {
+		metaObj.SetUID(uuid.NewUUID())
+	}
+	switch t := obj.(type) {
+	case *corev1.Node:
+		s.Nodes = append(s.Nodes, t)
+	case *corev1.Pod:
+		s.Pods = append(s.Pods, t)
+	case *slov1alpha1.NodeMetric:
+		s.NodeMetrics = append(s.NodeMetrics, t)
+	case *schedulingv1alpha1.Device:
+		s.Devices = append(s.Devices, t)
+	case *schedv1alpha1.ElasticQuota:
+		s.ElasticQuotas = append(s.ElasticQuotas, t)
+	case *schedulingv1alpha1.Reservation:
+		s.Reservations = append(s.Reservations, t)
+	default:
+		return fmt.Errorf("unsupported kind %s", obj.GetObjectKind().GroupVersionKind().Kind)
+	}
+	return nil
+}
+
+func decodeFile(path string) ([]runtime.Object, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	objects, err := decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s, err: %w", path, err)
+	}
+	return objects, nil
+}
+
+// decode decodes the YAML or JSON documents, and the items of the Lists are flattened.
+func decode(r io.Reader) ([]runtime.Object, error) {
+	decoder := codecs.UniversalDeserializer()
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(r))
+	var objects []runtime.Object
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return objects, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		obj, _, err := decoder.Decode(doc, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		list, ok := obj.(*corev1.List)
+		if !ok {
+			objects = append(objects, obj)
+			continue
+		}
+		for _, item := range list.Items {
+			itemObj, _, err := decoder.Decode(item.Raw, nil, nil)
+			if err != nil {
+				return nil, err
+			}
+			objects = append(objects, itemObj)
+		}
+	}
+}
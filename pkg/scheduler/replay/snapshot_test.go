@@ -0,0 +1,97 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replay
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testSnapshot = `
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Node
+  metadata:
+    name: node-1
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: pod-1
+    namespace: default
+---
+apiVersion: slo.koordinator.sh/v1alpha1
+kind: NodeMetric
+metadata:
+  name: node-1
+---
+apiVersion: scheduling.koordinator.sh/v1alpha1
+kind: Device
+metadata:
+  name: node-1
+---
+apiVersion: scheduling.koordinator.sh/v1alpha1
+kind: Reservation
+metadata:
+  name: reservation-1
+---
+apiVersion: scheduling.sigs.k8s.io/v1alpha1
+kind: ElasticQuota
+metadata:
+  name: quota-1
+  namespace: default
+`
+
+func TestLoadSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "snapshot.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(testSnapshot), 0644))
+
+	snapshot, err := LoadSnapshot(path)
+	assert.NoError(t, err)
+	assert.Len(t, snapshot.Nodes, 1)
+	assert.Len(t, snapshot.Pods, 1)
+	assert.Len(t, snapshot.NodeMetrics, 1)
+	assert.Len(t, snapshot.Devices, 1)
+	assert.Len(t, snapshot.Reservations, 1)
+	assert.Len(t, snapshot.ElasticQuotas, 1)
+	assert.NotEmpty(t, snapshot.Pods[0].UID)
+
+	_, err = LoadPods(path)
+	assert.Error(t, err)
+
+	unsupported := filepath.Join(dir, "unsupported.yaml")
+	assert.NoError(t, os.WriteFile(unsupported, []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: test\n"), 0644))
+	_, err = LoadSnapshot(unsupported)
+	assert.Error(t, err)
+}
+
+func TestLoadPods(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pods.yaml")
+	data := "apiVersion: v1\nkind: Pod\nmetadata:\n  name: pod-1\n---\napiVersion: v1\nkind: Pod\nmetadata:\n  name: pod-2\n"
+	assert.NoError(t, os.WriteFile(path, []byte(data), 0644))
+
+	pods, err := LoadPods(path)
+	assert.NoError(t, err)
+	assert.Len(t, pods, 2)
+	assert.Equal(t, "pod-1", pods[0].Name)
+	assert.Equal(t, "pod-2", pods[1].Name)
+}
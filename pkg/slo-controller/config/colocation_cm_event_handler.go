@@ -40,6 +40,8 @@ import (
 const (
 	ReasonColocationConfigUnmarshalFailed = "ColocationCfgUnmarshalFailed"
 	ReasonSLOConfigUnmarshalFailed        = "SLOCfgUnmarshalFailed"
+	ReasonSLOConfigRolloutProgressed      = "SLOCfgRolloutProgressed"
+	ReasonSLOConfigRolloutPaused          = "SLOCfgRolloutPaused"
 )
 
 var _ handler.EventHandler = &ColocationHandlerForConfigMapEvent{}
@@ -53,12 +53,19 @@ var (
 		Help:      "the count of parsing NodeSLO spec",
 	}, []string{StatusKey, ReasonKey})
 
+	NodeSLORolloutStep = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: SLOControllerSubsystem,
+		Name:      "nodeslo_rollout_step",
+		Help:      "the current step of the staged rollout of the slo-controller config, -1 for the canary step",
+	}, []string{PhaseKey})
+
 	CommonCollectors = []prometheus.Collector{
 		NodeResourceReconcileCount,
 		NodeMetricReconcileCount,
 		NodeMetricSpecParseCount,
 		NodeSLOReconcileCount,
 		NodeSLOSpecParseCount,
+		NodeSLORolloutStep,
 	}
 )
 
@@ -81,3 +88,8 @@ func RecordNodeSLOReconcileCount(isSucceeded bool, reason string) {
 func RecordNodeSLOSpecParseCount(isSucceeded bool, reason string) {
 	recordNodeCountMetric(NodeSLOSpecParseCount, isSucceeded, reason)
 }
+
+func RecordNodeSLORolloutStep(phase string, step int) {
+	NodeSLORolloutStep.Reset()
+	NodeSLORolloutStep.With(prometheus.Labels{PhaseKey: phase}).Set(float64(step))
+}
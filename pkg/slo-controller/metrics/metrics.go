@@ -34,6 +34,7 @@ const (
 	ReasonKey   = "reason"
 	PluginKey   = "plugin"
 	ResourceKey = "resource"
+	PhaseKey    = "phase"
 
 	UnitKey     = "unit"
 	UnitCore    = "core"
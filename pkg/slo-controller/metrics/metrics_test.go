@@ -47,6 +47,7 @@ func TestCommonCollectors(t *testing.T) {
 	RecordNodeMetricSpecParseCount(true, testReason)
 	RecordNodeSLOReconcileCount(true, testReason)
 	RecordNodeSLOSpecParseCount(true, testReason)
+	RecordNodeSLORolloutStep("Progressing", -1)
 }
 
 func TestNodeResourceCollectors(t *testing.T) {
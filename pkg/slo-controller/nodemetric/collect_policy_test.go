@@ -58,6 +58,28 @@ func Test_getNodeMetricCollectPolicy(t *testing.T) {
 				ReportIntervalSeconds:    pointer.Int64(180),
 			},
 		},
+		{
+			name: "config enabled with pod metric policy",
+			config: &configuration.ColocationStrategy{
+				Enable:                         pointer.Bool(true),
+				MetricAggregateDurationSeconds: pointer.Int64(60),
+				MetricReportIntervalSeconds:    pointer.Int64(180),
+				MetricPodPolicy: &slov1alpha1.PodMetricPolicy{
+					EnableContainerMetrics: pointer.Bool(true),
+					MetricFamilies:         []slov1alpha1.PodMetricFamily{slov1alpha1.PodMetricFamilyPSI},
+					MaxPods:                pointer.Int64(20),
+				},
+			},
+			want: &slov1alpha1.NodeMetricCollectPolicy{
+				AggregateDurationSeconds: pointer.Int64(60),
+				ReportIntervalSeconds:    pointer.Int64(180),
+				PodMetricPolicy: &slov1alpha1.PodMetricPolicy{
+					EnableContainerMetrics: pointer.Bool(true),
+					MetricFamilies:         []slov1alpha1.PodMetricFamily{slov1alpha1.PodMetricFamilyPSI},
+					MaxPods:                pointer.Int64(20),
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
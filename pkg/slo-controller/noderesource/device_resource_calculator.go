@@ -30,11 +30,13 @@ import (
 
 	"github.com/koordinator-sh/koordinator/apis/extension"
 	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/slo-controller/noderesource/framework"
+	"github.com/koordinator-sh/koordinator/pkg/slo-controller/noderesource/plugins/batchgpuresource"
 	"github.com/koordinator-sh/koordinator/pkg/util"
 	"github.com/koordinator-sh/koordinator/pkg/util/sloconfig"
 )
 
-func (r *NodeResourceReconciler) updateDeviceResources(node *corev1.Node) error {
+func (r *NodeResourceReconciler) updateDeviceResources(node *corev1.Node, nr *framework.NodeResource) error {
 	// calculate device resources
 	device := &schedulingv1alpha1.Device{}
 	if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: node.Name, Namespace: node.Namespace}, device); err != nil {
@@ -55,6 +57,12 @@ func (r *NodeResourceReconciler) updateDeviceResources(node *corev1.Node) error
 		return err
 	}
 
+	// update the reclaimable device resources calculated for the node
+	strategy := sloconfig.GetNodeColocationStrategy(r.cfgCache.GetCfgCopy(), node)
+	if err := batchgpuresource.SyncDeviceBatchResources(r.Client, strategy, device, nr); err != nil {
+		return fmt.Errorf("failed to update device batch resources, err: %w", err)
+	}
+
 	return nil
 }
 
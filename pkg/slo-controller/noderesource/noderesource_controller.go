@@ -67,7 +67,7 @@ type NodeResourceReconciler struct {
 // +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch;patch
 // +kubebuilder:rbac:groups=core,resources=nodes/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
-// +kubebuilder:rbac:groups=scheduling.koordinator.sh,resources=devices,verbs=get;list;watch
+// +kubebuilder:rbac:groups=scheduling.koordinator.sh,resources=devices,verbs=get;list;watch;patch;update
 // +kubebuilder:rbac:groups=slo.koordinator.sh,resources=nodemetrics,verbs=get;list;watch
 
 func (r *NodeResourceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -124,7 +124,7 @@ func (r *NodeResourceReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	}
 
 	// do other node updates. e.g. update device resources
-	if err := r.updateNodeExtensions(node, nodeMetric, podList); err != nil {
+	if err := r.updateNodeExtensions(node, nr, nodeMetric, podList); err != nil {
 		klog.ErrorS(err, "failed to update node extensions for node", "node", node.Name)
 		return ctrl.Result{Requeue: true}, err
 	}
@@ -167,6 +167,7 @@ func isPluginEnabled(pluginName string) bool {
 func Add(mgr ctrl.Manager) error {
 	// init plugins for NodeResource
 	addPlugins(isPluginEnabled)
+	addExtendedPlugins(isPluginEnabled)
 
 	// setup plugins
 	opt := framework.NewOption().WithManager(mgr)
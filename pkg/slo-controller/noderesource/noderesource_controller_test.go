@@ -38,6 +38,8 @@ import (
 	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
 	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
 	"github.com/koordinator-sh/koordinator/pkg/slo-controller/noderesource/framework"
+	"github.com/koordinator-sh/koordinator/pkg/slo-controller/noderesource/plugins/batchhugepagesresource"
+	"github.com/koordinator-sh/koordinator/pkg/slo-controller/noderesource/plugins/batchstorageresource"
 )
 
 func Test_NodeResourceController_ConfigNotAvaliable(t *testing.T) {
@@ -210,3 +212,9 @@ func Test_NodeResourceController_ColocationEnabled(t *testing.T) {
 	assert.True(t, isValid)
 	assert.Equal(t, int64(0), batchCPU)
 }
+
+func Test_isPluginEnabled(t *testing.T) {
+	assert.True(t, isPluginEnabled(batchstorageresource.PluginName))
+	assert.False(t, isPluginEnabled(batchhugepagesresource.PluginName), "batch hugepages should be disabled by default")
+	assert.Contains(t, AllPlugins, batchhugepagesresource.PluginName)
+}
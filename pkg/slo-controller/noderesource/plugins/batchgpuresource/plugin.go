@@ -0,0 +1,360 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batchgpuresource
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/koordinator-sh/koordinator/apis/configuration"
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/slo-controller/metrics"
+	"github.com/koordinator-sh/koordinator/pkg/slo-controller/noderesource/framework"
+	"github.com/koordinator-sh/koordinator/pkg/util"
+)
+
+const PluginName = "BatchGPUResource"
+
+// ResourceNames defines the Batch GPU extended resource names to update.
+var ResourceNames = []corev1.ResourceName{extension.BatchGPUCore, extension.BatchGPUMemory}
+
+var (
+	clk        clock.Clock = clock.RealClock{} // for testing
+	kubeClient client.Client
+)
+
+type Plugin struct{}
+
+func (p *Plugin) Name() string {
+	return PluginName
+}
+
+func (p *Plugin) Setup(opt *framework.Option) error {
+	kubeClient = opt.Client
+	return nil
+}
+
+func (p *Plugin) NeedSync(strategy *configuration.ColocationStrategy, oldNode, newNode *corev1.Node) (bool, string) {
+	// batch gpu resource diff is bigger than ResourceDiffThreshold
+	for _, resourceName := range ResourceNames {
+		if util.IsResourceDiff(oldNode.Status.Allocatable, newNode.Status.Allocatable, resourceName,
+			*strategy.ResourceDiffThreshold) {
+			klog.V(4).Infof("node %v batch gpu resource %v diff bigger than %v, need sync",
+				newNode.Name, resourceName, *strategy.ResourceDiffThreshold)
+			return true, "batch gpu resource diff is big than threshold"
+		}
+	}
+
+	return false, ""
+}
+
+// Execute prepares the node Batch GPU resources. The reclaimable resources of each GPU are synced onto the Device
+// CRD by SyncDeviceBatchResources when the node devices are updated.
+func (p *Plugin) Execute(strategy *configuration.ColocationStrategy, node *corev1.Node, nr *framework.NodeResource) error {
+	for _, resourceName := range ResourceNames {
+		prepareNodeForResource(node, nr, resourceName)
+	}
+	return nil
+}
+
+func (p *Plugin) Reset(node *corev1.Node, message string) []framework.ResourceItem {
+	items := make([]framework.ResourceItem, len(ResourceNames))
+	for i := range ResourceNames {
+		items[i].Name = ResourceNames[i]
+		items[i].Message = message
+		items[i].Reset = true
+	}
+
+	return items
+}
+
+// Calculate calculates the reclaimable resources of each GPU using the formula below:
+// GPU(Batch).Alloc := GPU.Total * ThresholdRatio - (GPU.Used - Pod(Batch).Used), which are carried in the annotations
+// of the Batch GPU core item and recorded on the Device CRD by SyncDeviceBatchResources.
+// The node Batch GPU resources are the sums of the GPUs' reclaimable resources.
+func (p *Plugin) Calculate(strategy *configuration.ColocationStrategy, node *corev1.Node, podList *corev1.PodList,
+	resourceMetrics *framework.ResourceMetrics) ([]framework.ResourceItem, error) {
+	if strategy == nil || node == nil || podList == nil || resourceMetrics == nil {
+		return nil, fmt.Errorf("missing essential arguments")
+	}
+	if kubeClient == nil {
+		return nil, fmt.Errorf("plugin %s is not setup", PluginName)
+	}
+
+	device := &schedulingv1alpha1.Device{}
+	if err := kubeClient.Get(context.TODO(), types.NamespacedName{Name: node.Name}, device); err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get device, err: %w", err)
+		}
+		return p.Reset(node, "reset node batch gpu resource since the node has no Device"), nil
+	}
+
+	if strategy.BatchGPUCoreThresholdPercent == nil && strategy.BatchGPUMemoryThresholdPercent == nil {
+		return p.Reset(node, "reset node batch gpu resource since the gpu thresholds are not set"), nil
+	}
+
+	// if the node metric is abnormal, do degraded calculation
+	if p.isDegradeNeeded(strategy, resourceMetrics.NodeMetric) {
+		klog.InfoS("node batch gpu need degradation, reset node resources", "node", node.Name)
+		return p.degradeCalculate(node,
+			"degrade node batch gpu resource because of abnormal nodeMetric, reason: degradedByBatchGPUResource"), nil
+	}
+
+	batchResources := calculateDeviceBatchResources(strategy, device, resourceMetrics.NodeMetric, podList)
+	batchResourcesMeta := &metav1.ObjectMeta{}
+	if err := extension.SetDeviceBatchResources(batchResourcesMeta, batchResources); err != nil {
+		return nil, fmt.Errorf("failed to marshal device batch resources, err: %w", err)
+	}
+
+	batchGPUCore := resource.NewQuantity(0, resource.DecimalSI)
+	batchGPUMemory := resource.NewQuantity(0, resource.BinarySI)
+	for _, r := range batchResources[schedulingv1alpha1.GPU] {
+		batchGPUCore.Add(r.Resources[extension.BatchGPUCore])
+		batchGPUMemory.Add(r.Resources[extension.BatchGPUMemory])
+	}
+
+	metrics.RecordNodeExtendedResourceAllocatableInternal(node, string(extension.BatchGPUCore), metrics.UnitInteger, float64(batchGPUCore.Value()))
+	metrics.RecordNodeExtendedResourceAllocatableInternal(node, string(extension.BatchGPUMemory), metrics.UnitByte, float64(batchGPUMemory.Value()))
+	klog.V(6).Infof("calculated batch gpu allocatable for node %s, gpu-core %v, gpu-memory(byte) %v",
+		node.Name, batchGPUCore.Value(), batchGPUMemory.Value())
+
+	return []framework.ResourceItem{
+		{
+			Name:        extension.BatchGPUCore,
+			Quantity:    batchGPUCore,
+			Annotations: batchResourcesMeta.Annotations,
+			Message: fmt.Sprintf("batchAllocatable[GPUCore]:%v = sum(gpuTotal * thresholdRatio:%v - (gpuUsed - podBatchUsed))",
+				batchGPUCore.Value(), getThresholdRatio(strategy.BatchGPUCoreThresholdPercent)),
+		},
+		{
+			Name:     extension.BatchGPUMemory,
+			Quantity: batchGPUMemory,
+			Message: fmt.Sprintf("batchAllocatable[GPUMemory(byte)]:%v = sum(gpuTotal * thresholdRatio:%v - (gpuUsed - podBatchUsed))",
+				batchGPUMemory.Value(), getThresholdRatio(strategy.BatchGPUMemoryThresholdPercent)),
+		},
+	}, nil
+}
+
+func (p *Plugin) isDegradeNeeded(strategy *configuration.ColocationStrategy, nodeMetric *slov1alpha1.NodeMetric) bool {
+	if nodeMetric == nil || nodeMetric.Status.UpdateTime == nil || nodeMetric.Status.NodeMetric == nil {
+		klog.V(4).Infof("need degradation for batch gpu, err: invalid nodeMetric %v", nodeMetric)
+		return true
+	}
+
+	now := clk.Now()
+	if now.After(nodeMetric.Status.UpdateTime.Add(time.Duration(*strategy.DegradeTimeMinutes) * time.Minute)) {
+		klog.V(4).Infof("need degradation for batch gpu, err: timeout nodeMetric: %v, current timestamp: %v,"+
+			" metric last update timestamp: %v", nodeMetric.Name, now, nodeMetric.Status.UpdateTime)
+		return true
+	}
+
+	return false
+}
+
+func (p *Plugin) degradeCalculate(node *corev1.Node, message string) []framework.ResourceItem {
+	return p.Reset(node, message)
+}
+
+func calculateDeviceBatchResources(strategy *configuration.ColocationStrategy, device *schedulingv1alpha1.Device,
+	nodeMetric *slov1alpha1.NodeMetric, podList *corev1.PodList) extension.DeviceBatchResources {
+	gpuUsed := getGPUUsages(nodeMetric.Status.NodeMetric.NodeUsage.Devices)
+
+	// GPU(HP).Used = GPU.Used - Pod(Batch/Free).Used
+	podMetricMap := make(map[string]*slov1alpha1.PodMetricInfo)
+	for _, podMetric := range nodeMetric.Status.PodsMetric {
+		podMetricMap[util.GetPodMetricKey(podMetric)] = podMetric
+	}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.Status.Phase != corev1.PodRunning && pod.Status.Phase != corev1.PodPending {
+			continue
+		}
+		priorityClass := extension.GetPodPriorityClassWithDefault(pod)
+		if priorityClass != extension.PriorityBatch && priorityClass != extension.PriorityFree {
+			continue
+		}
+		podMetric, ok := podMetricMap[util.GetPodKey(pod)]
+		if !ok {
+			continue
+		}
+		for minor, podUsed := range getGPUUsages(podMetric.PodUsage.Devices) {
+			if used, ok := gpuUsed[minor]; ok {
+				gpuUsed[minor] = quotav1.SubtractWithNonNegativeResult(used, podUsed)
+			}
+		}
+	}
+
+	coreRatio := getThresholdRatio(strategy.BatchGPUCoreThresholdPercent)
+	memoryRatio := getThresholdRatio(strategy.BatchGPUMemoryThresholdPercent)
+	var gpuBatchResources []*extension.DeviceBatchResource
+	for _, deviceInfo := range device.Spec.Devices {
+		if deviceInfo.Type != schedulingv1alpha1.GPU || !deviceInfo.Health || deviceInfo.Minor == nil {
+			continue
+		}
+		used, ok := gpuUsed[*deviceInfo.Minor]
+		if !ok {
+			// the GPU without the usage metric is not reclaimable
+			klog.V(5).Infof("gpu %v of node %s has no usage metric, skip reclaiming", *deviceInfo.Minor, device.Name)
+			continue
+		}
+		totalCore, totalMemory := deviceInfo.Resources[extension.ResourceGPUCore], deviceInfo.Resources[extension.ResourceGPUMemory]
+		usedCore, usedMemory := used[extension.ResourceGPUCore], used[extension.ResourceGPUMemory]
+		batchCore := int64(float64(totalCore.Value())*coreRatio) - usedCore.Value()
+		if batchCore < 0 {
+			batchCore = 0
+		}
+		batchMemory := int64(float64(totalMemory.Value())*memoryRatio) - usedMemory.Value()
+		if batchMemory < 0 {
+			batchMemory = 0
+		}
+		gpuBatchResources = append(gpuBatchResources, &extension.DeviceBatchResource{
+			Minor: *deviceInfo.Minor,
+			Resources: corev1.ResourceList{
+				extension.BatchGPUCore:   *resource.NewQuantity(batchCore, resource.DecimalSI),
+				extension.BatchGPUMemory: *resource.NewQuantity(batchMemory, resource.BinarySI),
+			},
+		})
+	}
+	sort.Slice(gpuBatchResources, func(i, j int) bool {
+		return gpuBatchResources[i].Minor < gpuBatchResources[j].Minor
+	})
+
+	if len(gpuBatchResources) <= 0 {
+		return extension.DeviceBatchResources{}
+	}
+	return extension.DeviceBatchResources{schedulingv1alpha1.GPU: gpuBatchResources}
+}
+
+// SyncDeviceBatchResources records the reclaimable device resources calculated for the node onto its Device.
+// The Device is cleaned up when the node Batch GPU resources are reset.
+func SyncDeviceBatchResources(c client.Client, strategy *configuration.ColocationStrategy, device *schedulingv1alpha1.Device,
+	nr *framework.NodeResource) error {
+	var batchResources extension.DeviceBatchResources
+	if !nr.Resets[extension.BatchGPUCore] && nr.Resources[extension.BatchGPUCore] != nil {
+		var err error
+		batchResources, err = extension.GetDeviceBatchResources(nr.Annotations)
+		if err != nil {
+			return fmt.Errorf("failed to parse device batch resources, err: %w", err)
+		}
+	}
+	return syncDeviceBatchResources(c, strategy, device, batchResources)
+}
+
+// syncDeviceBatchResources patches the reclaimable device resources onto the Device CRD when they are changed
+// significantly. It cleans up the annotation if the batchResources is nil.
+func syncDeviceBatchResources(c client.Client, strategy *configuration.ColocationStrategy, device *schedulingv1alpha1.Device,
+	batchResources extension.DeviceBatchResources) error {
+	oldBatchResources, err := extension.GetDeviceBatchResources(device.Annotations)
+	if err != nil {
+		klog.V(4).InfoS("failed to parse device batch resources, override it", "device", device.Name, "err", err)
+	} else if batchResources == nil && oldBatchResources == nil {
+		return nil
+	} else if batchResources != nil && oldBatchResources != nil &&
+		!isDeviceBatchResourcesDiff(oldBatchResources, batchResources, *strategy.ResourceDiffThreshold) {
+		return nil
+	}
+
+	newDevice := device.DeepCopy()
+	if batchResources == nil {
+		delete(newDevice.Annotations, extension.AnnotationDeviceBatchResources)
+	} else if err = extension.SetDeviceBatchResources(newDevice, batchResources); err != nil {
+		return fmt.Errorf("failed to set device batch resources, err: %w", err)
+	}
+	if err = c.Patch(context.TODO(), newDevice, client.MergeFrom(device)); err != nil {
+		return fmt.Errorf("failed to patch device batch resources, err: %w", err)
+	}
+	klog.V(5).InfoS("successfully patched device batch resources", "device", device.Name, "resources", batchResources)
+	return nil
+}
+
+func isDeviceBatchResourcesDiff(old, new extension.DeviceBatchResources, diffThreshold float64) bool {
+	if len(old) != len(new) {
+		return true
+	}
+	for deviceType, newResources := range new {
+		oldResources, ok := old[deviceType]
+		if !ok || len(oldResources) != len(newResources) {
+			return true
+		}
+		oldResourcesByMinor := map[int32]corev1.ResourceList{}
+		for _, r := range oldResources {
+			oldResourcesByMinor[r.Minor] = r.Resources
+		}
+		for _, r := range newResources {
+			oldResourceList, ok := oldResourcesByMinor[r.Minor]
+			if !ok {
+				return true
+			}
+			for _, resourceName := range ResourceNames {
+				if util.IsResourceDiff(oldResourceList, r.Resources, resourceName, diffThreshold) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func getGPUUsages(devices []schedulingv1alpha1.DeviceInfo) map[int32]corev1.ResourceList {
+	usages := map[int32]corev1.ResourceList{}
+	for _, deviceInfo := range devices {
+		if deviceInfo.Type != schedulingv1alpha1.GPU || deviceInfo.Minor == nil {
+			continue
+		}
+		usages[*deviceInfo.Minor] = corev1.ResourceList{
+			extension.ResourceGPUCore:   deviceInfo.Resources[extension.ResourceGPUCore],
+			extension.ResourceGPUMemory: deviceInfo.Resources[extension.ResourceGPUMemory],
+		}
+	}
+	return usages
+}
+
+func getThresholdRatio(thresholdPercent *int64) float64 {
+	if thresholdPercent == nil {
+		return 0
+	}
+	return float64(*thresholdPercent) / 100
+}
+
+func prepareNodeForResource(node *corev1.Node, nr *framework.NodeResource, name corev1.ResourceName) {
+	if q := nr.Resources[name]; nr.Resets[name] || q == nil {
+		delete(node.Status.Capacity, name)
+		delete(node.Status.Allocatable, name)
+	} else {
+		if _, ok := q.AsInt64(); !ok {
+			klog.V(2).InfoS("node batch gpu resource's quantity is not int64 and will be rounded",
+				"resource", name, "original", *q)
+			q.Set(q.Value())
+		}
+		node.Status.Capacity[name] = *q
+		node.Status.Allocatable[name] = *q
+	}
+}
@@ -0,0 +1,388 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batchgpuresource
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/koordinator-sh/koordinator/apis/configuration"
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/slo-controller/noderesource/framework"
+)
+
+func TestPlugin(t *testing.T) {
+	t.Run("test", func(t *testing.T) {
+		p := &Plugin{}
+		assert.Equal(t, PluginName, p.Name())
+	})
+}
+
+func TestPluginNeedSync(t *testing.T) {
+	strategy := &configuration.ColocationStrategy{
+		Enable:                pointer.Bool(true),
+		ResourceDiffThreshold: pointer.Float64(0.1),
+	}
+	oldNode := getTestNode(corev1.ResourceList{
+		extension.BatchGPUCore:   resource.MustParse("100"),
+		extension.BatchGPUMemory: resource.MustParse("16Gi"),
+	})
+	newNodeNotChanged := getTestNode(corev1.ResourceList{
+		extension.BatchGPUCore:   resource.MustParse("95"),
+		extension.BatchGPUMemory: resource.MustParse("16Gi"),
+	})
+	newNodeChanged := getTestNode(corev1.ResourceList{
+		extension.BatchGPUCore:   resource.MustParse("50"),
+		extension.BatchGPUMemory: resource.MustParse("16Gi"),
+	})
+
+	p := &Plugin{}
+	got, msg := p.NeedSync(strategy, oldNode, newNodeNotChanged)
+	assert.False(t, got)
+	assert.Equal(t, "", msg)
+	got, msg = p.NeedSync(strategy, oldNode, newNodeChanged)
+	assert.True(t, got)
+	assert.Equal(t, "batch gpu resource diff is big than threshold", msg)
+}
+
+func TestPluginExecute(t *testing.T) {
+	p := &Plugin{}
+	node := getTestNode(corev1.ResourceList{
+		extension.BatchGPUMemory: resource.MustParse("16Gi"),
+	})
+	nr := framework.NewNodeResource(framework.ResourceItem{
+		Name:     extension.BatchGPUCore,
+		Quantity: resource.NewQuantity(120, resource.DecimalSI),
+	}, framework.ResourceItem{
+		Name:  extension.BatchGPUMemory,
+		Reset: true,
+	})
+	assert.NoError(t, p.Execute(&configuration.ColocationStrategy{ResourceDiffThreshold: pointer.Float64(0.1)}, node, nr))
+	assert.Equal(t, *resource.NewQuantity(120, resource.DecimalSI), node.Status.Allocatable[extension.BatchGPUCore])
+	assert.Equal(t, *resource.NewQuantity(120, resource.DecimalSI), node.Status.Capacity[extension.BatchGPUCore])
+	_, ok := node.Status.Allocatable[extension.BatchGPUMemory]
+	assert.False(t, ok)
+}
+
+func TestPluginCalculate(t *testing.T) {
+	oldClock := clk
+	defer func() { clk = oldClock }()
+	now := time.Now()
+	clk = clock.NewFakeClock(now)
+
+	testStrategy := &configuration.ColocationStrategy{
+		Enable:                         pointer.Bool(true),
+		DegradeTimeMinutes:             pointer.Int64(15),
+		ResourceDiffThreshold:          pointer.Float64(0.1),
+		BatchGPUCoreThresholdPercent:   pointer.Int64(80),
+		BatchGPUMemoryThresholdPercent: pointer.Int64(50),
+	}
+	testPodList := &corev1.PodList{
+		Items: []corev1.Pod{
+			{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "prod-pod"},
+				Spec:       corev1.PodSpec{Priority: pointer.Int32(extension.PriorityProdValueMax)},
+				Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "batch-pod"},
+				Spec:       corev1.PodSpec{Priority: pointer.Int32(extension.PriorityBatchValueMax)},
+				Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+			},
+		},
+	}
+	testNodeMetric := &slov1alpha1.NodeMetric{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-node"},
+		Status: slov1alpha1.NodeMetricStatus{
+			UpdateTime: &metav1.Time{Time: now.Add(-time.Minute)},
+			NodeMetric: &slov1alpha1.NodeMetricInfo{
+				NodeUsage: slov1alpha1.ResourceMap{
+					Devices: []schedulingv1alpha1.DeviceInfo{
+						getTestGPUDeviceInfo(0, 40, 6<<30),
+						getTestGPUDeviceInfo(1, 90, 10<<30),
+					},
+				},
+			},
+			PodsMetric: []*slov1alpha1.PodMetricInfo{
+				{
+					Namespace: "default",
+					Name:      "prod-pod",
+					PodUsage: slov1alpha1.ResourceMap{
+						Devices: []schedulingv1alpha1.DeviceInfo{getTestGPUDeviceInfo(0, 20, 4<<30)},
+					},
+				},
+				{
+					Namespace: "default",
+					Name:      "batch-pod",
+					PodUsage: slov1alpha1.ResourceMap{
+						Devices: []schedulingv1alpha1.DeviceInfo{getTestGPUDeviceInfo(0, 20, 2<<30)},
+					},
+				},
+			},
+		},
+	}
+	testDevice := &schedulingv1alpha1.Device{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-node"},
+		Spec: schedulingv1alpha1.DeviceSpec{
+			Devices: []schedulingv1alpha1.DeviceInfo{
+				getTestGPUDevice(0, true),
+				getTestGPUDevice(1, true),
+				getTestGPUDevice(2, false),
+			},
+		},
+	}
+	testDeviceWithBatchResources := testDevice.DeepCopy()
+	assert.NoError(t, extension.SetDeviceBatchResources(testDeviceWithBatchResources, extension.DeviceBatchResources{
+		schedulingv1alpha1.GPU: {
+			{Minor: 0, Resources: getTestBatchResources(60, 6<<30)},
+		},
+	}))
+
+	testBatchResources := extension.DeviceBatchResources{
+		schedulingv1alpha1.GPU: {
+			// core: 100 * 0.8 - (40 - 20) = 60, memory: 16Gi * 0.5 - (6Gi - 2Gi) = 4Gi
+			{Minor: 0, Resources: getTestBatchResources(60, 4<<30)},
+			// core: 100 * 0.8 - 90 < 0, memory: 16Gi * 0.5 - 10Gi < 0
+			{Minor: 1, Resources: getTestBatchResources(0, 0)},
+		},
+	}
+	testBatchResourcesMeta := &metav1.ObjectMeta{}
+	assert.NoError(t, extension.SetDeviceBatchResources(testBatchResourcesMeta, testBatchResources))
+
+	tests := []struct {
+		name               string
+		strategy           *configuration.ColocationStrategy
+		nodeMetric         *slov1alpha1.NodeMetric
+		device             *schedulingv1alpha1.Device
+		want               []framework.ResourceItem
+		wantErr            bool
+		wantBatchResources extension.DeviceBatchResources
+	}{
+		{
+			name:       "reset for the node without device",
+			strategy:   testStrategy,
+			nodeMetric: testNodeMetric,
+			want:       (&Plugin{}).Reset(nil, "reset node batch gpu resource since the node has no Device"),
+		},
+		{
+			name: "reset and clean device annotation when thresholds are not set",
+			strategy: &configuration.ColocationStrategy{
+				Enable:                pointer.Bool(true),
+				DegradeTimeMinutes:    pointer.Int64(15),
+				ResourceDiffThreshold: pointer.Float64(0.1),
+			},
+			nodeMetric: testNodeMetric,
+			device:     testDeviceWithBatchResources,
+			want:       (&Plugin{}).Reset(nil, "reset node batch gpu resource since the gpu thresholds are not set"),
+		},
+		{
+			name:     "degrade for the expired node metric",
+			strategy: testStrategy,
+			nodeMetric: &slov1alpha1.NodeMetric{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-node"},
+				Status: slov1alpha1.NodeMetricStatus{
+					UpdateTime: &metav1.Time{Time: now.Add(-time.Hour)},
+					NodeMetric: &slov1alpha1.NodeMetricInfo{},
+				},
+			},
+			device: testDeviceWithBatchResources,
+			want: (&Plugin{}).Reset(nil,
+				"degrade node batch gpu resource because of abnormal nodeMetric, reason: degradedByBatchGPUResource"),
+		},
+		{
+			name:       "calculate batch gpu resources",
+			strategy:   testStrategy,
+			nodeMetric: testNodeMetric,
+			device:     testDevice,
+			want: []framework.ResourceItem{
+				{
+					Name:        extension.BatchGPUCore,
+					Quantity:    resource.NewQuantity(60, resource.DecimalSI),
+					Annotations: testBatchResourcesMeta.Annotations,
+					Message:     "batchAllocatable[GPUCore]:60 = sum(gpuTotal * thresholdRatio:0.8 - (gpuUsed - podBatchUsed))",
+				},
+				{
+					Name:     extension.BatchGPUMemory,
+					Quantity: resource.NewQuantity(4<<30, resource.BinarySI),
+					Message:  "batchAllocatable[GPUMemory(byte)]:4294967296 = sum(gpuTotal * thresholdRatio:0.5 - (gpuUsed - podBatchUsed))",
+				},
+			},
+			wantBatchResources: testBatchResources,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			assert.NoError(t, schedulingv1alpha1.AddToScheme(scheme))
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			if tt.device != nil {
+				builder = builder.WithObjects(tt.device.DeepCopy())
+			}
+			p := &Plugin{}
+			assert.NoError(t, p.Setup(framework.NewOption().WithClient(builder.Build())))
+			defer func() { kubeClient = nil }()
+
+			node := getTestNode(nil)
+			got, gotErr := p.Calculate(tt.strategy, node, testPodList, &framework.ResourceMetrics{
+				NodeMetric: tt.nodeMetric,
+			})
+			assert.Equal(t, tt.wantErr, gotErr != nil, gotErr)
+			assert.Equal(t, tt.want, got)
+
+			if tt.device == nil {
+				return
+			}
+			// the device is only updated by SyncDeviceBatchResources
+			gotDevice := &schedulingv1alpha1.Device{}
+			assert.NoError(t, kubeClient.Get(context.TODO(), types.NamespacedName{Name: tt.device.Name}, gotDevice))
+			assert.Equal(t, tt.device.Annotations, gotDevice.Annotations)
+
+			assert.NoError(t, p.Execute(tt.strategy, node, framework.NewNodeResource(got...)))
+			assert.NoError(t, kubeClient.Get(context.TODO(), types.NamespacedName{Name: tt.device.Name}, gotDevice))
+			assert.Equal(t, tt.device.Annotations, gotDevice.Annotations)
+
+			assert.NoError(t, SyncDeviceBatchResources(kubeClient, tt.strategy, gotDevice, framework.NewNodeResource(got...)))
+			assert.NoError(t, kubeClient.Get(context.TODO(), types.NamespacedName{Name: tt.device.Name}, gotDevice))
+			gotBatchResources, err := extension.GetDeviceBatchResources(gotDevice.Annotations)
+			assert.NoError(t, err)
+			var wantBatchResources extension.DeviceBatchResources
+			if tt.wantBatchResources != nil {
+				wantDevice := &schedulingv1alpha1.Device{}
+				assert.NoError(t, extension.SetDeviceBatchResources(wantDevice, tt.wantBatchResources))
+				wantBatchResources, err = extension.GetDeviceBatchResources(wantDevice.Annotations)
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, wantBatchResources, gotBatchResources)
+		})
+	}
+}
+
+func TestIsDeviceBatchResourcesDiff(t *testing.T) {
+	old := extension.DeviceBatchResources{
+		schedulingv1alpha1.GPU: {
+			{Minor: 0, Resources: getTestBatchResources(60, 4<<30)},
+		},
+	}
+	tests := []struct {
+		name string
+		new  extension.DeviceBatchResources
+		want bool
+	}{
+		{
+			name: "not changed",
+			new: extension.DeviceBatchResources{
+				schedulingv1alpha1.GPU: {
+					{Minor: 0, Resources: getTestBatchResources(58, 4<<30)},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "resources changed",
+			new: extension.DeviceBatchResources{
+				schedulingv1alpha1.GPU: {
+					{Minor: 0, Resources: getTestBatchResources(30, 4<<30)},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "minor changed",
+			new: extension.DeviceBatchResources{
+				schedulingv1alpha1.GPU: {
+					{Minor: 1, Resources: getTestBatchResources(60, 4<<30)},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "device removed",
+			new:  extension.DeviceBatchResources{},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isDeviceBatchResourcesDiff(old, tt.new, 0.1))
+		})
+	}
+}
+
+func getTestNode(resources corev1.ResourceList) *corev1.Node {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-node"},
+		Status: corev1.NodeStatus{
+			Capacity: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("100"),
+				corev1.ResourceMemory: resource.MustParse("200Gi"),
+			},
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("100"),
+				corev1.ResourceMemory: resource.MustParse("200Gi"),
+			},
+		},
+	}
+	for resourceName, q := range resources {
+		node.Status.Capacity[resourceName] = q
+		node.Status.Allocatable[resourceName] = q
+	}
+	return node
+}
+
+func getTestGPUDevice(minor int32, health bool) schedulingv1alpha1.DeviceInfo {
+	return schedulingv1alpha1.DeviceInfo{
+		Type:   schedulingv1alpha1.GPU,
+		Minor:  pointer.Int32(minor),
+		Health: health,
+		Resources: corev1.ResourceList{
+			extension.ResourceGPUCore:        *resource.NewQuantity(100, resource.DecimalSI),
+			extension.ResourceGPUMemory:      *resource.NewQuantity(16<<30, resource.BinarySI),
+			extension.ResourceGPUMemoryRatio: *resource.NewQuantity(100, resource.DecimalSI),
+		},
+	}
+}
+
+func getTestGPUDeviceInfo(minor int32, core, memory int64) schedulingv1alpha1.DeviceInfo {
+	return schedulingv1alpha1.DeviceInfo{
+		Type:  schedulingv1alpha1.GPU,
+		Minor: pointer.Int32(minor),
+		Resources: corev1.ResourceList{
+			extension.ResourceGPUCore:   *resource.NewQuantity(core, resource.DecimalSI),
+			extension.ResourceGPUMemory: *resource.NewQuantity(memory, resource.BinarySI),
+		},
+	}
+}
+
+func getTestBatchResources(core, memory int64) corev1.ResourceList {
+	return corev1.ResourceList{
+		extension.BatchGPUCore:   *resource.NewQuantity(core, resource.DecimalSI),
+		extension.BatchGPUMemory: *resource.NewQuantity(memory, resource.BinarySI),
+	}
+}
@@ -0,0 +1,274 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batchhugepagesresource
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/utils/pointer"
+
+	"github.com/koordinator-sh/koordinator/apis/configuration"
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/slo-controller/noderesource/framework"
+)
+
+func TestPlugin(t *testing.T) {
+	t.Run("test", func(t *testing.T) {
+		p := &Plugin{}
+		assert.Equal(t, PluginName, p.Name())
+	})
+}
+
+func TestPluginNeedSync(t *testing.T) {
+	strategy := &configuration.ColocationStrategy{
+		Enable:                pointer.Bool(true),
+		ResourceDiffThreshold: pointer.Float64(0.1),
+	}
+	oldNode := getTestNode(corev1.ResourceList{
+		extension.BatchHugePages2Mi: resource.MustParse("10Gi"),
+	})
+	newNodeNotChanged := getTestNode(corev1.ResourceList{
+		extension.BatchHugePages2Mi: resource.MustParse("10Gi"),
+	})
+	newNodeChanged := getTestNode(corev1.ResourceList{
+		extension.BatchHugePages2Mi: resource.MustParse("10Gi"),
+		extension.BatchHugePages1Gi: resource.MustParse("2Gi"),
+	})
+
+	p := &Plugin{}
+	got, msg := p.NeedSync(strategy, oldNode, newNodeNotChanged)
+	assert.False(t, got)
+	assert.Equal(t, "", msg)
+	got, msg = p.NeedSync(strategy, oldNode, newNodeChanged)
+	assert.True(t, got)
+	assert.Equal(t, "batch hugepages resource diff is big than threshold", msg)
+}
+
+func TestPluginExecute(t *testing.T) {
+	p := &Plugin{}
+	node := getTestNode(corev1.ResourceList{
+		extension.BatchHugePages1Gi: resource.MustParse("2Gi"),
+	})
+	nr := framework.NewNodeResource(framework.ResourceItem{
+		Name:     extension.BatchHugePages2Mi,
+		Quantity: resource.NewQuantity(4<<30, resource.BinarySI),
+	}, framework.ResourceItem{
+		Name:  extension.BatchHugePages1Gi,
+		Reset: true,
+	})
+	assert.NoError(t, p.Execute(nil, node, nr))
+	assert.Equal(t, *resource.NewQuantity(4<<30, resource.BinarySI), node.Status.Allocatable[extension.BatchHugePages2Mi])
+	assert.Equal(t, *resource.NewQuantity(4<<30, resource.BinarySI), node.Status.Capacity[extension.BatchHugePages2Mi])
+	_, ok := node.Status.Allocatable[extension.BatchHugePages1Gi]
+	assert.False(t, ok)
+}
+
+func TestPluginCalculate(t *testing.T) {
+	oldClock := clk
+	defer func() { clk = oldClock }()
+	now := time.Now()
+	clk = clock.NewFakeClock(now)
+
+	testStrategy := &configuration.ColocationStrategy{
+		Enable:                         pointer.Bool(true),
+		DegradeTimeMinutes:             pointer.Int64(15),
+		ResourceDiffThreshold:          pointer.Float64(0.1),
+		BatchHugePagesThresholdPercent: pointer.Int64(100),
+	}
+	testPodList := &corev1.PodList{
+		Items: []corev1.Pod{
+			{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "prod-pod"},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceHugePagesPrefix + "2Mi": resource.MustParse("8Gi"),
+								},
+							},
+						},
+					},
+				},
+				Status: corev1.PodStatus{Phase: corev1.PodRunning},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "batch-pod"},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									extension.BatchHugePages2Mi: resource.MustParse("2Gi"),
+								},
+							},
+						},
+					},
+				},
+				Status: corev1.PodStatus{Phase: corev1.PodRunning},
+			},
+		},
+	}
+	testNodeMetric := &slov1alpha1.NodeMetric{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-node"},
+		Status: slov1alpha1.NodeMetricStatus{
+			UpdateTime: &metav1.Time{Time: now.Add(-time.Minute)},
+			NodeMetric: &slov1alpha1.NodeMetricInfo{
+				NodeUsage: slov1alpha1.ResourceMap{
+					ResourceList: corev1.ResourceList{
+						corev1.ResourceHugePagesPrefix + "2Mi": resource.MustParse("5Gi"),
+					},
+				},
+			},
+		},
+	}
+	testNode := getTestNode(corev1.ResourceList{
+		corev1.ResourceHugePagesPrefix + "2Mi": resource.MustParse("10Gi"),
+	})
+
+	tests := []struct {
+		name       string
+		strategy   *configuration.ColocationStrategy
+		node       *corev1.Node
+		podList    *corev1.PodList
+		nodeMetric *slov1alpha1.NodeMetric
+		want       []framework.ResourceItem
+		wantErr    bool
+	}{
+		{
+			name:    "missing essential arguments",
+			wantErr: true,
+		},
+		{
+			name: "threshold not set and nothing to reset",
+			strategy: &configuration.ColocationStrategy{
+				Enable: pointer.Bool(true),
+			},
+			node:       testNode,
+			podList:    testPodList,
+			nodeMetric: testNodeMetric,
+			want:       nil,
+		},
+		{
+			name:     "degrade for invalid node metric",
+			strategy: testStrategy,
+			node:     testNode,
+			podList:  testPodList,
+			nodeMetric: &slov1alpha1.NodeMetric{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-node"},
+			},
+			want: []framework.ResourceItem{
+				{
+					Name:    extension.BatchHugePages2Mi,
+					Message: "degrade node batch hugepages resource because of abnormal nodeMetric, reason: degradedByBatchHugePagesResource",
+					Reset:   true,
+				},
+				{
+					Name:    extension.BatchHugePages1Gi,
+					Message: "degrade node batch hugepages resource because of abnormal nodeMetric, reason: degradedByBatchHugePagesResource",
+					Reset:   true,
+				},
+			},
+		},
+		{
+			name:       "calculate correctly",
+			strategy:   testStrategy,
+			node:       testNode,
+			podList:    testPodList,
+			nodeMetric: testNodeMetric,
+			want: []framework.ResourceItem{
+				{
+					Name: extension.BatchHugePages2Mi,
+					// 10Gi * 1.0 - (5Gi - 2Gi) = 7Gi
+					Quantity: resource.NewQuantity(7<<30, resource.BinarySI),
+					Message: "batchAllocatable[hugepages-2Mi(byte)]:7516192768 = nodeAllocatable:10737418240 * " +
+						"thresholdRatio:1 - max(nodeUsed:5368709120 - batchRequest:2147483648, 0)",
+				},
+				{
+					Name:    extension.BatchHugePages1Gi,
+					Message: "reset node batch hugepages resource since the node has no hugepages-1Gi",
+					Reset:   true,
+				},
+			},
+		},
+		{
+			name:     "reset since hugepages usage is not reported",
+			strategy: testStrategy,
+			node:     testNode,
+			podList:  testPodList,
+			nodeMetric: &slov1alpha1.NodeMetric{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-node"},
+				Status: slov1alpha1.NodeMetricStatus{
+					UpdateTime: &metav1.Time{Time: now.Add(-time.Minute)},
+					NodeMetric: &slov1alpha1.NodeMetricInfo{},
+				},
+			},
+			want: []framework.ResourceItem{
+				{
+					Name:    extension.BatchHugePages2Mi,
+					Message: "reset node batch hugepages resource since the node hugepages-2Mi usage is not reported",
+					Reset:   true,
+				},
+				{
+					Name:    extension.BatchHugePages1Gi,
+					Message: "reset node batch hugepages resource since the node has no hugepages-1Gi",
+					Reset:   true,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Plugin{}
+			var resourceMetrics *framework.ResourceMetrics
+			if tt.nodeMetric != nil {
+				resourceMetrics = &framework.ResourceMetrics{NodeMetric: tt.nodeMetric}
+			}
+			got, gotErr := p.Calculate(tt.strategy, tt.node, tt.podList, resourceMetrics)
+			assert.Equal(t, tt.wantErr, gotErr != nil, gotErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func getTestNode(resources corev1.ResourceList) *corev1.Node {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-node"},
+		Status: corev1.NodeStatus{
+			Capacity: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("100"),
+				corev1.ResourceMemory: resource.MustParse("200Gi"),
+			},
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("100"),
+				corev1.ResourceMemory: resource.MustParse("200Gi"),
+			},
+		},
+	}
+	for resourceName, q := range resources {
+		node.Status.Capacity[resourceName] = q
+		node.Status.Allocatable[resourceName] = q
+	}
+	return node
+}
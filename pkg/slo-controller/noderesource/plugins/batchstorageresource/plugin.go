@@ -0,0 +1,185 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batchstorageresource
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/klog/v2"
+
+	"github.com/koordinator-sh/koordinator/apis/configuration"
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/slo-controller/metrics"
+	"github.com/koordinator-sh/koordinator/pkg/slo-controller/noderesource/framework"
+	"github.com/koordinator-sh/koordinator/pkg/util"
+)
+
+const PluginName = "BatchStorageResource"
+
+// ResourceNames defines the Batch storage extended resource names to update.
+var ResourceNames = []corev1.ResourceName{extension.BatchEphemeralStorage}
+
+var clk clock.Clock = clock.RealClock{} // for testing
+
+type Plugin struct{}
+
+func (p *Plugin) Name() string {
+	return PluginName
+}
+
+func (p *Plugin) NeedSync(strategy *configuration.ColocationStrategy, oldNode, newNode *corev1.Node) (bool, string) {
+	// batch storage resource diff is bigger than ResourceDiffThreshold
+	for _, resourceName := range ResourceNames {
+		if util.IsResourceDiff(oldNode.Status.Allocatable, newNode.Status.Allocatable, resourceName,
+			*strategy.ResourceDiffThreshold) {
+			klog.V(4).Infof("node %v batch storage resource %v diff bigger than %v, need sync",
+				newNode.Name, resourceName, *strategy.ResourceDiffThreshold)
+			return true, "batch storage resource diff is big than threshold"
+		}
+	}
+
+	return false, ""
+}
+
+func (p *Plugin) Execute(strategy *configuration.ColocationStrategy, node *corev1.Node, nr *framework.NodeResource) error {
+	for _, resourceName := range ResourceNames {
+		prepareNodeForResource(node, nr, resourceName)
+	}
+	return nil
+}
+
+func (p *Plugin) Reset(node *corev1.Node, message string) []framework.ResourceItem {
+	items := make([]framework.ResourceItem, len(ResourceNames))
+	for i := range ResourceNames {
+		items[i].Name = ResourceNames[i]
+		items[i].Message = message
+		items[i].Reset = true
+	}
+
+	return items
+}
+
+// Calculate calculates the Batch ephemeral-storage using the formula below:
+// Batch.Alloc := Node.Alloc * ThresholdRatio - HP.Used,
+// where HP.Used := max(Node.Used - Pod(Batch).Request, 0).
+// The usage of a Batch pod is bounded by its request since the koordlet enforces the disk quota, so the Batch requests
+// are excluded from the node usage to keep the allocatable stable as the Batch pods write more.
+func (p *Plugin) Calculate(strategy *configuration.ColocationStrategy, node *corev1.Node, podList *corev1.PodList,
+	resourceMetrics *framework.ResourceMetrics) ([]framework.ResourceItem, error) {
+	if strategy == nil || node == nil || podList == nil || resourceMetrics == nil {
+		return nil, fmt.Errorf("missing essential arguments")
+	}
+
+	if strategy.BatchEphemeralStorageThresholdPercent == nil {
+		if !hasResources(node) {
+			// nothing to reset if the resources are never reclaimed on the node
+			return nil, nil
+		}
+		return p.Reset(node, "reset node batch storage resource since the threshold is not set"), nil
+	}
+
+	// if the node metric is abnormal, do degraded calculation
+	if p.isDegradeNeeded(strategy, resourceMetrics.NodeMetric) {
+		klog.InfoS("node batch storage need degradation, reset node resources", "node", node.Name)
+		return p.degradeCalculate(node,
+			"degrade node batch storage resource because of abnormal nodeMetric, reason: degradedByBatchStorageResource"), nil
+	}
+
+	nodeUsed, ok := resourceMetrics.NodeMetric.Status.NodeMetric.NodeUsage.ResourceList[corev1.ResourceEphemeralStorage]
+	if !ok {
+		return p.Reset(node, "reset node batch storage resource since the node storage usage is not reported"), nil
+	}
+	nodeAllocatable := node.Status.Allocatable[corev1.ResourceEphemeralStorage]
+
+	batchRequest := resource.NewQuantity(0, resource.BinarySI)
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.Status.Phase != corev1.PodRunning && pod.Status.Phase != corev1.PodPending {
+			continue
+		}
+		podRequest := util.GetPodRequest(pod, extension.BatchEphemeralStorage)
+		batchRequest.Add(podRequest[extension.BatchEphemeralStorage])
+	}
+
+	ratio := float64(*strategy.BatchEphemeralStorageThresholdPercent) / 100
+	hpUsed := nodeUsed.Value() - batchRequest.Value()
+	if hpUsed < 0 {
+		hpUsed = 0
+	}
+	batchAllocatable := int64(float64(nodeAllocatable.Value())*ratio) - hpUsed
+	if batchAllocatable < 0 {
+		batchAllocatable = 0
+	}
+	batchStorage := resource.NewQuantity(batchAllocatable, resource.BinarySI)
+
+	metrics.RecordNodeExtendedResourceAllocatableInternal(node, string(extension.BatchEphemeralStorage), metrics.UnitByte, float64(batchStorage.Value()))
+	klog.V(6).Infof("calculated batch ephemeral-storage allocatable for node %s, ephemeral-storage(byte) %v",
+		node.Name, batchStorage.Value())
+
+	return []framework.ResourceItem{
+		{
+			Name:     extension.BatchEphemeralStorage,
+			Quantity: batchStorage,
+			Message: fmt.Sprintf("batchAllocatable[EphemeralStorage(byte)]:%v = nodeAllocatable:%v * thresholdRatio:%v - max(nodeUsed:%v - batchRequest:%v, 0)",
+				batchStorage.Value(), nodeAllocatable.Value(), ratio, nodeUsed.Value(), batchRequest.Value()),
+		},
+	}, nil
+}
+
+func (p *Plugin) isDegradeNeeded(strategy *configuration.ColocationStrategy, nodeMetric *slov1alpha1.NodeMetric) bool {
+	if nodeMetric == nil || nodeMetric.Status.UpdateTime == nil || nodeMetric.Status.NodeMetric == nil {
+		klog.V(4).Infof("need degradation for batch storage, err: invalid nodeMetric %v", nodeMetric)
+		return true
+	}
+
+	now := clk.Now()
+	if now.After(nodeMetric.Status.UpdateTime.Add(time.Duration(*strategy.DegradeTimeMinutes) * time.Minute)) {
+		klog.V(4).Infof("need degradation for batch storage, err: timeout nodeMetric: %v, current timestamp: %v,"+
+			" metric last update timestamp: %v", nodeMetric.Name, now, nodeMetric.Status.UpdateTime)
+		return true
+	}
+
+	return false
+}
+
+func (p *Plugin) degradeCalculate(node *corev1.Node, message string) []framework.ResourceItem {
+	return p.Reset(node, message)
+}
+
+func hasResources(node *corev1.Node) bool {
+	for _, resourceName := range ResourceNames {
+		if _, ok := node.Status.Allocatable[resourceName]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func prepareNodeForResource(node *corev1.Node, nr *framework.NodeResource, name corev1.ResourceName) {
+	if q := nr.Resources[name]; nr.Resets[name] || q == nil {
+		delete(node.Status.Capacity, name)
+		delete(node.Status.Allocatable, name)
+	} else {
+		node.Status.Capacity[name] = *q
+		node.Status.Allocatable[name] = *q
+	}
+}
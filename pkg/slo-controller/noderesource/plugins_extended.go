@@ -0,0 +1,36 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package noderesource
+
+import (
+	"github.com/koordinator-sh/koordinator/pkg/slo-controller/noderesource/framework"
+	"github.com/koordinator-sh/koordinator/pkg/slo-controller/noderesource/plugins/batchhugepagesresource"
+	"github.com/koordinator-sh/koordinator/pkg/slo-controller/noderesource/plugins/batchstorageresource"
+)
+
+func init() {
+	addPluginOption(&batchstorageresource.Plugin{}, true)
+	// batch hugepages are reclaimed only when the plugin is enabled explicitly
+	addPluginOption(&batchhugepagesresource.Plugin{}, false)
+}
+
+// addExtendedPlugins registers the plugins of the extended batch resources out of the default plugin profile.
+func addExtendedPlugins(filter framework.FilterFn) {
+	framework.RegisterNodePrepareExtender(filter, &batchstorageresource.Plugin{}, &batchhugepagesresource.Plugin{})
+	framework.RegisterNodeSyncExtender(filter, &batchstorageresource.Plugin{}, &batchhugepagesresource.Plugin{})
+	framework.RegisterResourceCalculateExtender(filter, &batchstorageresource.Plugin{}, &batchhugepagesresource.Plugin{})
+}
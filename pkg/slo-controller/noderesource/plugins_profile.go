@@ -18,6 +18,7 @@ package noderesource
 
 import (
 	"github.com/koordinator-sh/koordinator/pkg/slo-controller/noderesource/framework"
+	"github.com/koordinator-sh/koordinator/pkg/slo-controller/noderesource/plugins/batchgpuresource"
 	"github.com/koordinator-sh/koordinator/pkg/slo-controller/noderesource/plugins/batchresource"
 	"github.com/koordinator-sh/koordinator/pkg/slo-controller/noderesource/plugins/midresource"
 )
@@ -28,6 +29,7 @@ func init() {
 	// set default plugins
 	addPluginOption(&midresource.Plugin{}, true)
 	addPluginOption(&batchresource.Plugin{}, true)
+	addPluginOption(&batchgpuresource.Plugin{}, true)
 }
 
 func addPlugins(filter framework.FilterFn) {
@@ -41,16 +43,20 @@ func addPlugins(filter framework.FilterFn) {
 
 var (
 	// SetupPlugins implement the setup for node resource plugin.
-	setupPlugins = []framework.SetupPlugin{}
+	setupPlugins = []framework.SetupPlugin{
+		&batchgpuresource.Plugin{},
+	}
 	// NodePreparePlugin implements node resource preparing for the calculated results.
 	nodePreparePlugins = []framework.NodePreparePlugin{
 		&midresource.Plugin{},
 		&batchresource.Plugin{},
+		&batchgpuresource.Plugin{},
 	}
 	// NodeSyncPlugin implements the check of resource updating.
 	nodeSyncPlugins = []framework.NodeSyncPlugin{
 		&midresource.Plugin{},
 		&batchresource.Plugin{},
+		&batchgpuresource.Plugin{},
 	}
 	// NodeMetaSyncPlugin implements the check of node meta updating.
 	nodeMetaSyncPlugins = []framework.NodeMetaSyncPlugin{}
@@ -58,5 +64,6 @@ var (
 	resourceCalculatePlugins = []framework.ResourceCalculatePlugin{
 		&midresource.Plugin{},
 		&batchresource.Plugin{},
+		&batchgpuresource.Plugin{},
 	}
 )
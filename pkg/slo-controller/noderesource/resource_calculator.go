@@ -164,9 +164,9 @@ func (r *NodeResourceReconciler) updateNodeMeta(node *corev1.Node, strategy *con
 }
 
 // updateNodeExtensions is an extension point for updating node other than node metric resources.
-func (r *NodeResourceReconciler) updateNodeExtensions(node *corev1.Node, nodeMetric *slov1alpha1.NodeMetric, podList *corev1.PodList) error {
+func (r *NodeResourceReconciler) updateNodeExtensions(node *corev1.Node, nr *framework.NodeResource, nodeMetric *slov1alpha1.NodeMetric, podList *corev1.PodList) error {
 	// update device resources
-	if err := r.updateDeviceResources(node); err != nil {
+	if err := r.updateDeviceResources(node, nr); err != nil {
 		metrics.RecordNodeResourceReconcileCount(false, "updateDeviceResources")
 		klog.V(4).InfoS("failed to update device resources for node", "node", node.Name,
 			"err", err)
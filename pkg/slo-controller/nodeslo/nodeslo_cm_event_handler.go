@@ -28,6 +28,7 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -41,6 +42,9 @@ var _ handler.EventHandler = &SLOCfgHandlerForConfigMapEvent{}
 
 type SLOCfgCache interface {
 	GetCfgCopy() *SLOCfg
+	// GetCfgCopyForNode returns the config which the node should apply during the rollout and the generation of the
+	// config.
+	GetCfgCopyForNode(node *corev1.Node) (*SLOCfg, string)
 	IsCfgAvailable() bool
 }
 
@@ -49,6 +53,7 @@ type SLOCfg struct {
 	ResourceQOSCfgMerged configuration.ResourceQOSCfg       `json:"resourceQOSCfgMerged,omitempty"`
 	CPUBurstCfgMerged    configuration.CPUBurstCfg          `json:"cpuBurstCfgMerged,omitempty"`
 	SystemCfgMerged      configuration.SystemCfg            `json:"systemCfgMerged,omitempty"`
+	PodStrategyCfgMerged configuration.PodStrategyCfg       `json:"podStrategyCfgMerged,omitempty"`
 	ExtensionCfgMerged   configuration.ExtensionCfgMap      `json:"extensionCfgMerged,omitempty"` // for third-party extension
 }
 
@@ -58,6 +63,7 @@ func (in *SLOCfg) DeepCopy() *SLOCfg {
 	out.CPUBurstCfgMerged = *in.CPUBurstCfgMerged.DeepCopy()
 	out.ResourceQOSCfgMerged = *in.ResourceQOSCfgMerged.DeepCopy()
 	out.SystemCfgMerged = *in.SystemCfgMerged.DeepCopy()
+	out.PodStrategyCfgMerged = *in.PodStrategyCfgMerged.DeepCopy()
 	out.ExtensionCfgMerged = *in.ExtensionCfgMerged.DeepCopy()
 	return out
 }
@@ -67,6 +73,14 @@ type sLOCfgCache struct {
 	// Config could be concurrently used by the Reconciliation and EventHandler
 	sloCfg    SLOCfg
 	available bool
+	// stableCfg is the config applied to all nodes, while sloCfg is the target config during a rollout
+	stableCfg SLOCfg
+	// stableData and targetData are the ConfigMap data of the stableCfg and the sloCfg, which are persisted to
+	// rebuild the rollout after the controller restarts
+	stableData map[string]string
+	targetData map[string]string
+	rolloutCfg configuration.RolloutCfg
+	rollout    rolloutStatus
 }
 
 func DefaultSLOCfg() SLOCfg {
@@ -75,6 +89,7 @@ func DefaultSLOCfg() SLOCfg {
 		ResourceQOSCfgMerged: configuration.ResourceQOSCfg{ClusterStrategy: &slov1alpha1.ResourceQOSStrategy{}},
 		CPUBurstCfgMerged:    configuration.CPUBurstCfg{ClusterStrategy: sloconfig.DefaultCPUBurstStrategy()},
 		SystemCfgMerged:      configuration.SystemCfg{ClusterStrategy: sloconfig.DefaultSystemStrategy()},
+		PodStrategyCfgMerged: configuration.PodStrategyCfg{},
 		ExtensionCfgMerged:   *getDefaultExtensionCfg(),
 	}
 }
@@ -85,10 +100,21 @@ type SLOCfgHandlerForConfigMapEvent struct {
 	Client   client.Client
 	cfgCache sLOCfgCache
 	recorder record.EventRecorder
+	// rolloutNodeEventCh receives the nodes which join the rollout when the rollout step progresses
+	rolloutNodeEventCh chan event.GenericEvent
 }
 
 func NewSLOCfgHandlerForConfigMapEvent(client client.Client, initCfg SLOCfg, recorder record.EventRecorder) *SLOCfgHandlerForConfigMapEvent {
-	sloHandler := &SLOCfgHandlerForConfigMapEvent{cfgCache: sLOCfgCache{sloCfg: initCfg}, Client: client, recorder: recorder}
+	sloHandler := &SLOCfgHandlerForConfigMapEvent{
+		cfgCache: sLOCfgCache{
+			sloCfg:    initCfg,
+			stableCfg: *initCfg.DeepCopy(),
+			rollout:   newCompletedRolloutStatus(getSLOCfgGeneration(&initCfg)),
+		},
+		Client:             client,
+		recorder:           recorder,
+		rolloutNodeEventCh: make(chan event.GenericEvent, rolloutNodeEventBufferSize),
+	}
 	sloHandler.SyncCacheIfChanged = sloHandler.syncNodeSLOSpecIfChanged
 	sloHandler.EnqueueRequest = sloHandler.triggerAllNodeEnqueue
 	return sloHandler
@@ -117,11 +143,24 @@ func (p *SLOCfgHandlerForConfigMapEvent) syncNodeSLOSpecIfChanged(configMap *cor
 func (p *SLOCfgHandlerForConfigMapEvent) syncConfig(configMap *corev1.ConfigMap) bool {
 	if configMap == nil {
 		klog.Warningf("config map is deleted!,use default config")
-		return p.updateCacheIfChanged(DefaultSLOCfg())
+		return p.updateCacheIfChanged(DefaultSLOCfg(), configuration.RolloutCfg{}, nil)
+	}
+
+	if !p.cfgCache.available {
+		p.restoreRollout(configMap)
+	}
+	newSLOCfg := p.calculateSLOCfg(configMap, p.cfgCache.sloCfg.DeepCopy())
+
+	newRolloutCfg, err := calculateRolloutCfg(p.cfgCache.rolloutCfg, configMap)
+	if err != nil {
+		klog.V(5).Infof("failed to get RolloutCfg, err: %s", err)
+		p.recorder.Eventf(configMap, "Warning", config.ReasonSLOConfigUnmarshalFailed, "failed to unmarshal RolloutCfg, err: %s", err)
 	}
+	return p.updateCacheIfChanged(newSLOCfg, newRolloutCfg, configMap.Data)
+}
 
+func (p *SLOCfgHandlerForConfigMapEvent) calculateSLOCfg(configMap *corev1.ConfigMap, oldSLOCfgCopy *SLOCfg) SLOCfg {
 	var newSLOCfg SLOCfg
-	oldSLOCfgCopy := p.cfgCache.sloCfg.DeepCopy()
 	var err error
 	newSLOCfg.ThresholdCfgMerged, err = calculateResourceThresholdCfgMerged(oldSLOCfgCopy.ThresholdCfgMerged, configMap)
 	if err != nil {
@@ -144,11 +183,18 @@ func (p *SLOCfgHandlerForConfigMapEvent) syncConfig(configMap *corev1.ConfigMap)
 		klog.V(5).Infof("failed to get SystemCfg, err: %s", err)
 		p.recorder.Eventf(configMap, "Warning", config.ReasonSLOConfigUnmarshalFailed, "failed to unmarshal SystemCfg, err: %s", err)
 	}
+
+	newSLOCfg.PodStrategyCfgMerged, err = calculatePodStrategyCfgMerged(oldSLOCfgCopy.PodStrategyCfgMerged, configMap)
+	if err != nil {
+		klog.V(5).Infof("failed to get PodStrategyCfg, err: %s", err)
+		p.recorder.Eventf(configMap, "Warning", config.ReasonSLOConfigUnmarshalFailed, "failed to unmarshal PodStrategyCfg, err: %s", err)
+	}
 	newSLOCfg.ExtensionCfgMerged = calculateExtensionsCfgMerged(oldSLOCfgCopy.ExtensionCfgMerged, configMap, p.recorder)
-	return p.updateCacheIfChanged(newSLOCfg)
+	return newSLOCfg
 }
 
-func (p *SLOCfgHandlerForConfigMapEvent) updateCacheIfChanged(newSLOCfg SLOCfg) bool {
+func (p *SLOCfgHandlerForConfigMapEvent) updateCacheIfChanged(newSLOCfg SLOCfg, newRolloutCfg configuration.RolloutCfg,
+	newData map[string]string) bool {
 	changed := !reflect.DeepEqual(p.cfgCache.sloCfg, newSLOCfg)
 
 	if changed {
@@ -157,9 +203,10 @@ func (p *SLOCfgHandlerForConfigMapEvent) updateCacheIfChanged(newSLOCfg SLOCfg)
 		klog.Infof("NodeSLO config Changed success! oldCfg:%s\n,newCfg:%s", string(oldInfoFmt), string(newInfoFmt))
 		p.cfgCache.sloCfg = newSLOCfg
 	}
+	rolloutChanged := p.cfgCache.updateRollout(&newSLOCfg, &newRolloutCfg, newData)
 	// set the available flag and never change it
 	p.cfgCache.available = true
-	return changed
+	return changed || rolloutChanged
 }
 
 func (p *SLOCfgHandlerForConfigMapEvent) GetCfgCopy() *SLOCfg {
@@ -168,6 +215,17 @@ func (p *SLOCfgHandlerForConfigMapEvent) GetCfgCopy() *SLOCfg {
 	return p.cfgCache.sloCfg.DeepCopy()
 }
 
+func (p *SLOCfgHandlerForConfigMapEvent) GetCfgCopyForNode(node *corev1.Node) (*SLOCfg, string) {
+	p.cfgCache.lock.RLock()
+	defer p.cfgCache.lock.RUnlock()
+	status := &p.cfgCache.rollout
+	if isNodeInRollout(node, &p.cfgCache.rolloutCfg, status) {
+		return p.cfgCache.sloCfg.DeepCopy(), status.TargetGeneration
+	}
+	// the node keeps the stable config until the rollout reaches it
+	return p.cfgCache.stableCfg.DeepCopy(), status.StableGeneration
+}
+
 func (p *SLOCfgHandlerForConfigMapEvent) IsCfgAvailable() bool {
 	p.cfgCache.lock.RLock()
 	defer p.cfgCache.lock.RUnlock()
@@ -177,7 +177,8 @@ func Test_syncNodeSLOSpecIfChanged(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
 			p := NewSLOCfgHandlerForConfigMapEvent(fakeClient, tt.fields.oldCfg.sloCfg, &record.FakeRecorder{})
-			p.cfgCache = sLOCfgCache{available: tt.fields.oldCfg.available, sloCfg: tt.fields.oldCfg.sloCfg}
+			p.cfgCache = sLOCfgCache{available: tt.fields.oldCfg.available, sloCfg: tt.fields.oldCfg.sloCfg,
+				stableCfg: tt.fields.oldCfg.sloCfg, rollout: newCompletedRolloutStatus(getSLOCfgGeneration(&tt.fields.oldCfg.sloCfg))}
 			p.cfgCache.available = tt.fields.oldCfg.available
 			got := p.syncNodeSLOSpecIfChanged(tt.args.configMap)
 			assert.Equal(t, tt.wantChanged, got)
@@ -23,12 +23,15 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
@@ -51,7 +54,8 @@ type NodeSLOReconciler struct {
 func (r *NodeSLOReconciler) initNodeSLO(node *corev1.Node, nodeSLO *slov1alpha1.NodeSLO) error {
 	// NOTE: the node and nodeSLO should not be nil
 	// get spec from a configmap
-	spec, err := r.getNodeSLOSpec(node, nil)
+	sloCfg, generation := r.sloCfgCache.GetCfgCopyForNode(node)
+	spec, err := r.getNodeSLOSpec(node, nil, sloCfg)
 	if err != nil {
 		klog.V(5).Infof("initNodeSLO failed to get NodeSLO %s spec, error: %v", node.GetName(), err)
 		return err
@@ -61,18 +65,17 @@ func (r *NodeSLOReconciler) initNodeSLO(node *corev1.Node, nodeSLO *slov1alpha1.
 	nodeSLO.Spec = *spec
 	nodeSLO.SetName(node.GetName())
 	nodeSLO.SetNamespace(node.GetNamespace())
+	setNodeSLOConfigGeneration(nodeSLO, generation)
 
 	return nil
 }
 
-func (r *NodeSLOReconciler) getNodeSLOSpec(node *corev1.Node, oldSpec *slov1alpha1.NodeSLOSpec) (*slov1alpha1.NodeSLOSpec, error) {
+func (r *NodeSLOReconciler) getNodeSLOSpec(node *corev1.Node, oldSpec *slov1alpha1.NodeSLOSpec, sloCfg *SLOCfg) (*slov1alpha1.NodeSLOSpec, error) {
 	nodeSLOSpec := &slov1alpha1.NodeSLOSpec{}
 	if oldSpec != nil {
 		nodeSLOSpec = oldSpec.DeepCopy()
 	}
 
-	sloCfg := r.sloCfgCache.GetCfgCopy()
-
 	var err error
 	nodeSLOSpec.ResourceUsedThresholdWithBE, err = getResourceThresholdSpec(node, &sloCfg.ThresholdCfgMerged)
 	if err != nil {
@@ -107,13 +110,29 @@ func (r *NodeSLOReconciler) getNodeSLOSpec(node *corev1.Node, oldSpec *slov1alph
 		metrics.RecordNodeSLOSpecParseCount(true, "getSystemConfigSpec")
 	}
 
+	nodeSLOSpec.PodStrategies = getPodStrategiesSpec(&sloCfg.PodStrategyCfgMerged)
+
 	nodeSLOSpec.Extensions = getExtensionsConfigSpec(node, oldSpec, &sloCfg.ExtensionCfgMerged)
 
 	return nodeSLOSpec, nil
 }
 
+func getNodeSLOConfigGeneration(nodeSLO *slov1alpha1.NodeSLO) string {
+	if nodeSLO.Annotations == nil {
+		return ""
+	}
+	return nodeSLO.Annotations[slov1alpha1.AnnotationNodeSLOConfigGeneration]
+}
+
+func setNodeSLOConfigGeneration(nodeSLO *slov1alpha1.NodeSLO, generation string) {
+	if nodeSLO.Annotations == nil {
+		nodeSLO.Annotations = map[string]string{}
+	}
+	nodeSLO.Annotations[slov1alpha1.AnnotationNodeSLOConfigGeneration] = generation
+}
+
 // +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
-// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;patch
 // +kubebuilder:rbac:groups=slo.koordinator.sh,resources=nodeslos,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=slo.koordinator.sh,resources=nodeslos/status,verbs=get;update;patch
 
@@ -177,7 +196,11 @@ func (r *NodeSLOReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		}
 		metrics.RecordNodeSLOReconcileCount(true, "deleteNodeSLO")
 		return ctrl.Result{}, nil
-	} else if !nodeSLOExist {
+	}
+
+	// nodes waiting for the rollout step are enqueued to apply the target config when the step progresses
+	sloCfg, generation := r.sloCfgCache.GetCfgCopyForNode(node)
+	if !nodeSLOExist {
 		// create and initialize CR if only the node exists
 		if err = r.initNodeSLO(node, nodeSLO); err != nil {
 			klog.Errorf("failed to init nodeSLO instance %v: %v", nodeSLOName, err)
@@ -192,13 +215,14 @@ func (r *NodeSLOReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		metrics.RecordNodeSLOReconcileCount(true, "createNodeSLO")
 	} else {
 		// update nodeSLO spec if both exists
-		nodeSLOSpec, err := r.getNodeSLOSpec(node, &nodeSLO.Spec)
+		nodeSLOSpec, err := r.getNodeSLOSpec(node, &nodeSLO.Spec, sloCfg)
 		if err != nil {
 			klog.Errorf("failed to get nodeSLO %v, spec: %v", nodeSLOName, err)
 			return ctrl.Result{Requeue: true}, err
 		}
-		if !reflect.DeepEqual(nodeSLOSpec, &nodeSLO.Spec) {
+		if !reflect.DeepEqual(nodeSLOSpec, &nodeSLO.Spec) || getNodeSLOConfigGeneration(nodeSLO) != generation {
 			nodeSLO.Spec = *nodeSLOSpec
+			setNodeSLOConfigGeneration(nodeSLO, generation)
 			err = r.Client.Update(context.TODO(), nodeSLO)
 			if err != nil {
 				metrics.RecordNodeSLOReconcileCount(false, "updateNodeSLO")
@@ -225,12 +249,21 @@ func Add(mgr ctrl.Manager) error {
 func (r *NodeSLOReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	configMapCacheHandler := NewSLOCfgHandlerForConfigMapEvent(r.Client, DefaultSLOCfg(), r.Recorder)
 	r.sloCfgCache = configMapCacheHandler
+	// evaluate and persist the rollout progress of the slo config periodically
+	err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		wait.Until(configMapCacheHandler.syncRollout, rolloutResyncInterval, ctx.Done())
+		return nil
+	}))
+	if err != nil {
+		return err
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&slov1alpha1.NodeSLO{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Watches(&source.Kind{Type: &corev1.Node{}}, &nodemetric.EnqueueRequestForNode{
 			Client: r.Client,
 		}).
 		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, configMapCacheHandler).
+		Watches(&source.Channel{Source: configMapCacheHandler.rolloutNodeEventCh}, &handler.EnqueueRequestForObject{}).
 		Named(Name).
 		Complete(r)
 }
@@ -94,6 +94,17 @@ func getSystemConfigSpec(node *corev1.Node, cfg *configuration.SystemCfg) (*slov
 	return cfg.ClusterStrategy.DeepCopy(), nil
 }
 
+func getPodStrategiesSpec(cfg *configuration.PodStrategyCfg) []slov1alpha1.PodStrategy {
+	if len(cfg.PodStrategies) <= 0 {
+		return nil
+	}
+	strategies := make([]slov1alpha1.PodStrategy, len(cfg.PodStrategies))
+	for i := range cfg.PodStrategies {
+		cfg.PodStrategies[i].DeepCopyInto(&strategies[i])
+	}
+	return strategies
+}
+
 func calculateResourceThresholdCfgMerged(oldCfg configuration.ResourceThresholdCfg, configMap *corev1.ConfigMap) (configuration.ResourceThresholdCfg, error) {
 	cfgStr, ok := configMap.Data[configuration.ResourceThresholdConfigKey]
 	if !ok {
@@ -235,3 +246,20 @@ func calculateSystemConfigMerged(oldCfg configuration.SystemCfg, configMap *core
 
 	return mergedCfg, nil
 }
+
+func calculatePodStrategyCfgMerged(oldCfg configuration.PodStrategyCfg, configMap *corev1.ConfigMap) (configuration.PodStrategyCfg, error) {
+	cfgStr, ok := configMap.Data[configuration.PodStrategyConfigKey]
+	if !ok {
+		return DefaultSLOCfg().PodStrategyCfgMerged, nil
+	}
+
+	mergedCfg := configuration.PodStrategyCfg{}
+	if err := json.Unmarshal([]byte(cfgStr), &mergedCfg); err != nil {
+		klog.Warningf("failed to unmarshal config %s, err: %s", configuration.PodStrategyConfigKey, err)
+		return oldCfg, err
+	}
+
+	// pod strategies are not merged with the node-level strategies here, since the koordlet replaces the node-level
+	// config of each feature specified in the first matched strategy (e.g. the memory qos) as a whole
+	return mergedCfg, nil
+}
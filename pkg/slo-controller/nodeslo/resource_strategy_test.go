@@ -1072,3 +1072,81 @@ func Test_calculateSystemConfigMerged(t *testing.T) {
 		})
 	}
 }
+
+func Test_calculatePodStrategyCfgMerged(t *testing.T) {
+	defaultSLOCfg := DefaultSLOCfg()
+
+	oldSLOCfg := DefaultSLOCfg()
+	oldSLOCfg.PodStrategyCfgMerged.PodStrategies = []slov1alpha1.PodStrategy{
+		{
+			Name:       "old",
+			Namespaces: []string{"old"},
+		},
+	}
+
+	testingPodStrategyCfg := &configuration.PodStrategyCfg{
+		PodStrategies: []slov1alpha1.PodStrategy{
+			{
+				Name:       "tenant-a",
+				Namespaces: []string{"tenant-a"},
+				PodSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"app": "web",
+					},
+				},
+				MemoryQOS: &slov1alpha1.PodMemoryQOSConfig{
+					Policy: slov1alpha1.PodMemoryQOSPolicyAuto,
+				},
+				CPUBurst: &slov1alpha1.CPUBurstConfig{
+					Policy: slov1alpha1.CPUBurstNone,
+				},
+			},
+		},
+	}
+	testingPodStrategyCfgStr, _ := json.Marshal(testingPodStrategyCfg)
+
+	tests := []struct {
+		name      string
+		configMap *corev1.ConfigMap
+		want      *configuration.PodStrategyCfg
+		wantErr   bool
+	}{
+		{
+			name:      "config is null! use default config",
+			configMap: &corev1.ConfigMap{},
+			want:      &defaultSLOCfg.PodStrategyCfgMerged,
+			wantErr:   false,
+		},
+		{
+			name: "throw error for configmap unmarshal failed",
+			configMap: &corev1.ConfigMap{
+				Data: map[string]string{
+					configuration.PodStrategyConfigKey: "invalid_content",
+				},
+			},
+			want:    &oldSLOCfg.PodStrategyCfgMerged,
+			wantErr: true,
+		},
+		{
+			name: "pod strategies parsed",
+			configMap: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      sloconfig.SLOCtrlConfigMap,
+					Namespace: sloconfig.ConfigNameSpace,
+				},
+				Data: map[string]string{
+					configuration.PodStrategyConfigKey: string(testingPodStrategyCfgStr),
+				},
+			},
+			want: testingPodStrategyCfg,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotErr := calculatePodStrategyCfgMerged(oldSLOCfg.PodStrategyCfgMerged, tt.configMap)
+			assert.Equal(t, tt.wantErr, gotErr != nil)
+			assert.Equal(t, tt.want, &got)
+			assert.Equal(t, tt.want.PodStrategies, getPodStrategiesSpec(&got))
+		})
+	}
+}
@@ -0,0 +1,446 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeslo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/koordinator-sh/koordinator/apis/configuration"
+	apiext "github.com/koordinator-sh/koordinator/apis/extension"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/slo-controller/config"
+	"github.com/koordinator-sh/koordinator/pkg/slo-controller/metrics"
+)
+
+const (
+	// AnnotationSLOConfigRollout is the rollout status of the slo-controller config persisted on the ConfigMap.
+	// It is used to rebuild the rollout after the controller restarts.
+	AnnotationSLOConfigRollout = apiext.DomainPrefix + "slo-config-rollout"
+
+	// rolloutResyncInterval is the interval to evaluate the rollout progress and to persist the rollout status.
+	rolloutResyncInterval = 30 * time.Second
+	// rolloutNodeEventBufferSize is the buffer size of the nodes to enqueue when the rollout step progresses.
+	rolloutNodeEventBufferSize = 128
+
+	defaultRolloutStepIntervalSeconds = int64(300)
+	defaultRolloutStepTimeoutSeconds  = int64(1800)
+	// minRolloutEvictionRateWindow is the minimum window to average the pods evicted by a node.
+	minRolloutEvictionRateWindow = time.Hour
+	// maxRolloutPendingNodesInMessage is the maximum number of the pending nodes shown in the rollout message.
+	maxRolloutPendingNodesInMessage = 10
+)
+
+var clk clock.Clock = clock.RealClock{} // for testing
+
+type RolloutPhase string
+
+const (
+	// RolloutPhaseProgressing indicates the target config generation is rolling out step by step.
+	RolloutPhaseProgressing RolloutPhase = "Progressing"
+	// RolloutPhasePaused indicates the rollout is paused since some updated nodes are unhealthy. The nodes which
+	// have applied the target config generation keep it, while the others keep the stable one until a new config.
+	RolloutPhasePaused RolloutPhase = "Paused"
+	// RolloutPhaseCompleted indicates the target config generation is applied to all nodes.
+	RolloutPhaseCompleted RolloutPhase = "Completed"
+)
+
+// rolloutStatus is the progress of rolling out the target config generation.
+type rolloutStatus struct {
+	Phase            RolloutPhase `json:"phase,omitempty"`
+	StableGeneration string       `json:"stableGeneration,omitempty"`
+	TargetGeneration string       `json:"targetGeneration,omitempty"`
+	// Step is the index of the current step in the StepPercents. -1 indicates the canary step.
+	Step          int       `json:"step"`
+	StepStartTime time.Time `json:"stepStartTime,omitempty"`
+	Message       string    `json:"message,omitempty"`
+}
+
+// rolloutState is the persisted rollout status.
+type rolloutState struct {
+	Status rolloutStatus `json:"status"`
+	// StableData is the ConfigMap data of the stable config generation, which rebuilds the stable config.
+	StableData map[string]string `json:"stableData,omitempty"`
+}
+
+func newCompletedRolloutStatus(generation string) rolloutStatus {
+	return rolloutStatus{
+		Phase:            RolloutPhaseCompleted,
+		StableGeneration: generation,
+		TargetGeneration: generation,
+		StepStartTime:    clk.Now(),
+	}
+}
+
+// getSLOCfgGeneration returns the generation of the config, which is a hash of the config contents.
+func getSLOCfgGeneration(cfg *SLOCfg) string {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		klog.Warningf("failed to marshal slo config for generation, err: %v", err)
+		return ""
+	}
+	hasher := fnv.New64a()
+	_, _ = hasher.Write(data)
+	return strconv.FormatUint(hasher.Sum64(), 16)
+}
+
+func isRolloutEnabled(cfg *configuration.RolloutCfg) bool {
+	return cfg != nil && cfg.Enable != nil && *cfg.Enable
+}
+
+// getNodeRolloutBucket returns a stable bucket in [0, 100) for the node to decide the step it joins the rollout.
+func getNodeRolloutBucket(nodeName string) int64 {
+	hasher := fnv.New32a()
+	_, _ = hasher.Write([]byte(nodeName))
+	return int64(hasher.Sum32() % 100)
+}
+
+// isNodeInRollout checks whether the node should apply the target config generation.
+func isNodeInRollout(node *corev1.Node, cfg *configuration.RolloutCfg, status *rolloutStatus) bool {
+	if status.Phase == RolloutPhaseCompleted {
+		return true
+	}
+	if cfg.CanaryNodeSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(cfg.CanaryNodeSelector)
+		if err != nil {
+			klog.Errorf("failed to parse canary node selector %v for RolloutCfg, err: %v", cfg.CanaryNodeSelector, err)
+		} else if selector.Matches(labels.Set(node.Labels)) {
+			return true
+		}
+	}
+	if status.Step < 0 || status.Step >= len(cfg.StepPercents) {
+		return false
+	}
+	return getNodeRolloutBucket(node.Name) < cfg.StepPercents[status.Step]
+}
+
+// getNodeSLOUnhealthyReason returns the reason if the NodeSLO reports unhealthy status for the rollout config.
+// The pods evicted since the node applied the config are compared as a rate per hour, which is averaged over
+// minRolloutEvictionRateWindow at least. The older koordlet does not report when it applied the config, and its
+// evictions are counted in the window.
+func getNodeSLOUnhealthyReason(nodeSLO *slov1alpha1.NodeSLO, cfg *configuration.RolloutCfg) string {
+	if cfg.MaxPodsEvictedPerHourPerNode != nil && nodeSLO.Status.PodsEvicted > 0 {
+		window := minRolloutEvictionRateWindow
+		if generationTime := nodeSLO.Status.ConfigGenerationTime; generationTime != nil && clk.Since(generationTime.Time) > window {
+			window = clk.Since(generationTime.Time)
+		}
+		evictedPerHour := float64(nodeSLO.Status.PodsEvicted) / window.Hours()
+		if evictedPerHour > float64(*cfg.MaxPodsEvictedPerHourPerNode) {
+			return fmt.Sprintf("podsEvicted %.2f/h > %d/h", evictedPerHour, *cfg.MaxPodsEvictedPerHourPerNode)
+		}
+	}
+	if cfg.MaxApplyErrorsPerNode != nil && nodeSLO.Status.ApplyErrors > *cfg.MaxApplyErrorsPerNode {
+		return fmt.Sprintf("applyErrors %d > %d", nodeSLO.Status.ApplyErrors, *cfg.MaxApplyErrorsPerNode)
+	}
+	return ""
+}
+
+// isNodeReady returns false if the node reports the Ready condition not True.
+func isNodeReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return true
+}
+
+func calculateRolloutCfg(oldCfg configuration.RolloutCfg, configMap *corev1.ConfigMap) (configuration.RolloutCfg, error) {
+	cfgStr, ok := configMap.Data[configuration.RolloutConfigKey]
+	if !ok {
+		return configuration.RolloutCfg{}, nil
+	}
+
+	cfg := configuration.RolloutCfg{}
+	if err := json.Unmarshal([]byte(cfgStr), &cfg); err != nil {
+		klog.Warningf("failed to unmarshal config %s, err: %s", configuration.RolloutConfigKey, err)
+		return oldCfg, err
+	}
+	return cfg, nil
+}
+
+// updateRollout updates the rollout with the new config, its ConfigMap data and the rollout config. It starts a new
+// rollout when a new config generation is set, while the config is applied directly if the cache is not initialized,
+// the rollout is disabled, or the config is rolled back to the stable generation.
+// It returns true if the rollout status changes.
+func (c *sLOCfgCache) updateRollout(newSLOCfg *SLOCfg, newRolloutCfg *configuration.RolloutCfg,
+	newData map[string]string) bool {
+	oldStatus := c.rollout
+	c.rolloutCfg = *newRolloutCfg.DeepCopy()
+	c.targetData = newData
+
+	targetGeneration := getSLOCfgGeneration(newSLOCfg)
+	if !c.available || !isRolloutEnabled(newRolloutCfg) || targetGeneration == c.rollout.StableGeneration {
+		if oldStatus.Phase != RolloutPhaseCompleted || oldStatus.TargetGeneration != targetGeneration {
+			c.stableCfg = *newSLOCfg.DeepCopy()
+			c.rollout = newCompletedRolloutStatus(targetGeneration)
+		}
+		c.stableData = newData
+	} else if targetGeneration != c.rollout.TargetGeneration {
+		// restart from the canary step; the stable config keeps unchanged if the last rollout is not completed
+		c.rollout = rolloutStatus{
+			Phase:            RolloutPhaseProgressing,
+			StableGeneration: c.rollout.StableGeneration,
+			TargetGeneration: targetGeneration,
+			Step:             -1,
+			StepStartTime:    clk.Now(),
+		}
+		klog.Infof("start rolling out slo config generation %s, stable generation %s",
+			targetGeneration, c.rollout.StableGeneration)
+	}
+	metrics.RecordNodeSLORolloutStep(string(c.rollout.Phase), c.rollout.Step)
+
+	return oldStatus.Phase != c.rollout.Phase || oldStatus.TargetGeneration != c.rollout.TargetGeneration ||
+		oldStatus.StableGeneration != c.rollout.StableGeneration || oldStatus.Step != c.rollout.Step
+}
+
+// restoreRollout rebuilds the rollout status and the stable config from the status persisted on the ConfigMap.
+// It is invoked before the cache is initialized, so the rollout continues instead of applying the target config to all
+// nodes after the controller restarts.
+func (p *SLOCfgHandlerForConfigMapEvent) restoreRollout(configMap *corev1.ConfigMap) {
+	data, ok := configMap.Annotations[AnnotationSLOConfigRollout]
+	if !ok {
+		return
+	}
+	state := rolloutState{}
+	if err := json.Unmarshal([]byte(data), &state); err != nil {
+		klog.Warningf("failed to unmarshal slo config rollout status, err: %v", err)
+		return
+	}
+
+	stableConfigMap := configMap.DeepCopy()
+	stableConfigMap.Data = state.StableData
+	defaultCfg := DefaultSLOCfg()
+	stableCfg := p.calculateSLOCfg(stableConfigMap, &defaultCfg)
+	if generation := getSLOCfgGeneration(&stableCfg); generation != state.Status.StableGeneration {
+		klog.Warningf("failed to restore slo config rollout, stable generation %s mismatches the persisted %s",
+			generation, state.Status.StableGeneration)
+		return
+	}
+
+	p.cfgCache.stableCfg = stableCfg
+	p.cfgCache.stableData = state.StableData
+	p.cfgCache.rollout = state.Status
+	p.cfgCache.available = true
+	klog.Infof("restore slo config rollout, phase %s, step %d, stable generation %s, target generation %s",
+		state.Status.Phase, state.Status.Step, state.Status.StableGeneration, state.Status.TargetGeneration)
+}
+
+// persistRollout records the rollout status on the ConfigMap if it changes.
+func (p *SLOCfgHandlerForConfigMapEvent) persistRollout() {
+	p.cfgCache.lock.RLock()
+	available := p.cfgCache.available
+	state := rolloutState{
+		Status:     p.cfgCache.rollout,
+		StableData: p.cfgCache.stableData,
+	}
+	p.cfgCache.lock.RUnlock()
+	if !available {
+		return
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		klog.Warningf("failed to marshal slo config rollout status, err: %v", err)
+		return
+	}
+
+	configMap, err := config.GetConfigMapForCache(p.Client)
+	if err != nil || configMap == nil {
+		klog.V(4).Infof("failed to get configmap for persisting rollout status, err: %v", err)
+		return
+	}
+	if configMap.Annotations[AnnotationSLOConfigRollout] == string(data) {
+		return
+	}
+	newConfigMap := configMap.DeepCopy()
+	if newConfigMap.Annotations == nil {
+		newConfigMap.Annotations = map[string]string{}
+	}
+	newConfigMap.Annotations[AnnotationSLOConfigRollout] = string(data)
+	if err = p.Client.Patch(context.TODO(), newConfigMap, client.MergeFrom(configMap)); err != nil {
+		klog.Warningf("failed to persist slo config rollout status, err: %v", err)
+		return
+	}
+	klog.V(5).Infof("persist slo config rollout status %s", string(data))
+}
+
+// syncRollout evaluates the rollout progress and then persists the rollout status.
+func (p *SLOCfgHandlerForConfigMapEvent) syncRollout() {
+	p.evaluateRollout()
+	p.persistRollout()
+}
+
+// evaluateRollout evaluates the health of the nodes which have applied the target config generation, and then
+// progresses or pauses the rollout. The nodes joining the rollout are enqueued when the rollout progresses.
+func (p *SLOCfgHandlerForConfigMapEvent) evaluateRollout() {
+	p.cfgCache.lock.RLock()
+	rolloutCfg := p.cfgCache.rolloutCfg.DeepCopy()
+	status := p.cfgCache.rollout
+	p.cfgCache.lock.RUnlock()
+	if status.Phase != RolloutPhaseProgressing {
+		return
+	}
+
+	nodeList := &corev1.NodeList{}
+	if err := p.Client.List(context.TODO(), nodeList); err != nil {
+		klog.Warningf("failed to list nodes for slo config rollout, err: %v", err)
+		return
+	}
+	nodeSLOList := &slov1alpha1.NodeSLOList{}
+	if err := p.Client.List(context.TODO(), nodeSLOList); err != nil {
+		klog.Warningf("failed to list nodeSLOs for slo config rollout, err: %v", err)
+		return
+	}
+	nodeSLOs := make(map[string]*slov1alpha1.NodeSLO, len(nodeSLOList.Items))
+	for i := range nodeSLOList.Items {
+		nodeSLOs[nodeSLOList.Items[i].Name] = &nodeSLOList.Items[i]
+	}
+
+	var updated int
+	var pendingNodes, unhealthyNodes []string
+	for i := range nodeList.Items {
+		node := &nodeList.Items[i]
+		if !isNodeInRollout(node, rolloutCfg, &status) {
+			continue
+		}
+		nodeSLO, ok := nodeSLOs[node.Name]
+		if !ok || nodeSLO.Status.ConfigGeneration != status.TargetGeneration {
+			// the NotReady node cannot report the status, so it does not block the rollout
+			if isNodeReady(node) {
+				pendingNodes = append(pendingNodes, node.Name)
+			}
+			continue
+		}
+		if reason := getNodeSLOUnhealthyReason(nodeSLO, rolloutCfg); len(reason) > 0 {
+			unhealthyNodes = append(unhealthyNodes, fmt.Sprintf("%s(%s)", node.Name, reason))
+		}
+		updated++
+	}
+
+	stepInterval := defaultRolloutStepIntervalSeconds
+	if rolloutCfg.StepIntervalSeconds != nil {
+		stepInterval = *rolloutCfg.StepIntervalSeconds
+	}
+
+	stepTimeout := defaultRolloutStepTimeoutSeconds
+	if rolloutCfg.StepTimeoutSeconds != nil {
+		stepTimeout = *rolloutCfg.StepTimeoutSeconds
+	}
+
+	eventType, reason, message := p.progressRollout(&status, updated, pendingNodes, unhealthyNodes,
+		time.Duration(stepInterval)*time.Second, time.Duration(stepTimeout)*time.Second)
+	if len(message) <= 0 {
+		return
+	}
+	if eventType == corev1.EventTypeNormal {
+		p.enqueueRolloutNodes(nodeList, &status)
+	}
+	configMap, err := config.GetConfigMapForCache(p.Client)
+	if err != nil || configMap == nil {
+		klog.V(4).Infof("failed to get configmap for recording rollout event, err: %v", err)
+		return
+	}
+	p.recorder.Eventf(configMap, eventType, reason, message)
+}
+
+// progressRollout updates the rollout status according to the evaluated result of the current step.
+// It returns the event to record if the rollout is progressed or paused.
+func (p *SLOCfgHandlerForConfigMapEvent) progressRollout(evaluated *rolloutStatus, updated int,
+	pendingNodes, unhealthyNodes []string, stepInterval, stepTimeout time.Duration) (string, string, string) {
+	p.cfgCache.lock.Lock()
+	defer p.cfgCache.lock.Unlock()
+	status := &p.cfgCache.rollout
+	// skip if the rollout is changed during the evaluation
+	if status.Phase != RolloutPhaseProgressing || status.TargetGeneration != evaluated.TargetGeneration ||
+		status.Step != evaluated.Step {
+		return "", "", ""
+	}
+
+	if len(unhealthyNodes) > 0 {
+		status.Phase = RolloutPhasePaused
+		status.Message = fmt.Sprintf("rollout of slo config generation %s is paused at step %d, unhealthy nodes: %s",
+			status.TargetGeneration, status.Step, strings.Join(unhealthyNodes, ", "))
+		klog.Warning(status.Message)
+		metrics.RecordNodeSLORolloutStep(string(status.Phase), status.Step)
+		return corev1.EventTypeWarning, config.ReasonSLOConfigRolloutPaused, status.Message
+	}
+
+	if len(pendingNodes) > 0 && clk.Since(status.StepStartTime) >= stepTimeout {
+		shownNodes := pendingNodes
+		if len(shownNodes) > maxRolloutPendingNodesInMessage {
+			shownNodes = shownNodes[:maxRolloutPendingNodesInMessage]
+		}
+		status.Phase = RolloutPhasePaused
+		status.Message = fmt.Sprintf("rollout of slo config generation %s is paused at step %d, %d nodes have not "+
+			"applied it in %v: %s", status.TargetGeneration, status.Step, len(pendingNodes), stepTimeout,
+			strings.Join(shownNodes, ", "))
+		klog.Warning(status.Message)
+		metrics.RecordNodeSLORolloutStep(string(status.Phase), status.Step)
+		return corev1.EventTypeWarning, config.ReasonSLOConfigRolloutPaused, status.Message
+	}
+
+	if len(pendingNodes) > 0 || clk.Since(status.StepStartTime) < stepInterval {
+		klog.V(5).Infof("rollout of slo config generation %s is waiting at step %d, updated %d, pending %d",
+			status.TargetGeneration, status.Step, updated, len(pendingNodes))
+		return "", "", ""
+	}
+
+	status.Step++
+	status.StepStartTime = clk.Now()
+	if status.Step >= len(p.cfgCache.rolloutCfg.StepPercents) {
+		p.cfgCache.stableCfg = *p.cfgCache.sloCfg.DeepCopy()
+		p.cfgCache.stableData = p.cfgCache.targetData
+		status.Phase = RolloutPhaseCompleted
+		status.StableGeneration = status.TargetGeneration
+		status.Message = fmt.Sprintf("rollout of slo config generation %s is completed", status.TargetGeneration)
+	} else {
+		status.Message = fmt.Sprintf("rollout of slo config generation %s progresses to step %d with %d%% nodes",
+			status.TargetGeneration, status.Step, p.cfgCache.rolloutCfg.StepPercents[status.Step])
+	}
+	klog.Info(status.Message)
+	metrics.RecordNodeSLORolloutStep(string(status.Phase), status.Step)
+	return corev1.EventTypeNormal, config.ReasonSLOConfigRolloutProgressed, status.Message
+}
+
+// enqueueRolloutNodes enqueues the nodes which join the rollout since the evaluated step.
+func (p *SLOCfgHandlerForConfigMapEvent) enqueueRolloutNodes(nodeList *corev1.NodeList, evaluated *rolloutStatus) {
+	p.cfgCache.lock.RLock()
+	rolloutCfg := p.cfgCache.rolloutCfg.DeepCopy()
+	status := p.cfgCache.rollout
+	p.cfgCache.lock.RUnlock()
+
+	for i := range nodeList.Items {
+		node := &nodeList.Items[i]
+		if isNodeInRollout(node, rolloutCfg, evaluated) || !isNodeInRollout(node, rolloutCfg, &status) {
+			continue
+		}
+		p.rolloutNodeEventCh <- event.GenericEvent{Object: node}
+	}
+}
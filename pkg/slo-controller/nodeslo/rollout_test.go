@@ -0,0 +1,499 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeslo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/koordinator-sh/koordinator/apis/configuration"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/util/sloconfig"
+)
+
+func Test_isNodeInRollout(t *testing.T) {
+	testNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-node",
+			Labels: map[string]string{
+				"canary": "true",
+			},
+		},
+	}
+	bucket := getNodeRolloutBucket(testNode.Name)
+	tests := []struct {
+		name   string
+		node   *corev1.Node
+		cfg    *configuration.RolloutCfg
+		status *rolloutStatus
+		want   bool
+	}{
+		{
+			name:   "completed rollout applies to all nodes",
+			node:   testNode,
+			cfg:    &configuration.RolloutCfg{},
+			status: &rolloutStatus{Phase: RolloutPhaseCompleted},
+			want:   true,
+		},
+		{
+			name: "canary node matches the selector",
+			node: testNode,
+			cfg: &configuration.RolloutCfg{
+				CanaryNodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}},
+			},
+			status: &rolloutStatus{Phase: RolloutPhaseProgressing, Step: -1},
+			want:   true,
+		},
+		{
+			name: "non-canary node at the canary step",
+			node: testNode,
+			cfg: &configuration.RolloutCfg{
+				CanaryNodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "false"}},
+				StepPercents:       []int64{100},
+			},
+			status: &rolloutStatus{Phase: RolloutPhaseProgressing, Step: -1},
+			want:   false,
+		},
+		{
+			name: "node bucket is in the step percent",
+			node: testNode,
+			cfg: &configuration.RolloutCfg{
+				StepPercents: []int64{bucket + 1},
+			},
+			status: &rolloutStatus{Phase: RolloutPhaseProgressing, Step: 0},
+			want:   true,
+		},
+		{
+			name: "node bucket is out of the step percent",
+			node: testNode,
+			cfg: &configuration.RolloutCfg{
+				StepPercents: []int64{bucket, 100},
+			},
+			status: &rolloutStatus{Phase: RolloutPhasePaused, Step: 0},
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := isNodeInRollout(tt.node, tt.cfg, tt.status)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_getNodeSLOUnhealthyReason(t *testing.T) {
+	oldClk := clk
+	defer func() {
+		clk = oldClk
+	}()
+	now := time.Now()
+	clk = clock.NewFakeClock(now)
+
+	rolloutCfg := &configuration.RolloutCfg{
+		MaxPodsEvictedPerHourPerNode: pointer.Int64(2),
+		MaxApplyErrorsPerNode:        pointer.Int64(0),
+	}
+	tests := []struct {
+		name          string
+		status        slov1alpha1.NodeSLOStatus
+		wantUnhealthy bool
+	}{
+		{
+			name:          "healthy",
+			status:        slov1alpha1.NodeSLOStatus{PodsEvicted: 2, ConfigGenerationTime: &metav1.Time{Time: now.Add(-time.Minute)}},
+			wantUnhealthy: false,
+		},
+		{
+			name:          "evict too many pods in the first hour",
+			status:        slov1alpha1.NodeSLOStatus{PodsEvicted: 3, ConfigGenerationTime: &metav1.Time{Time: now.Add(-time.Minute)}},
+			wantUnhealthy: true,
+		},
+		{
+			name:          "routine evictions over a long time",
+			status:        slov1alpha1.NodeSLOStatus{PodsEvicted: 30, ConfigGenerationTime: &metav1.Time{Time: now.Add(-24 * time.Hour)}},
+			wantUnhealthy: false,
+		},
+		{
+			name:          "evictions of the older koordlet are counted in the minimum window",
+			status:        slov1alpha1.NodeSLOStatus{PodsEvicted: 3},
+			wantUnhealthy: true,
+		},
+		{
+			name:          "apply errors",
+			status:        slov1alpha1.NodeSLOStatus{ApplyErrors: 1},
+			wantUnhealthy: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := getNodeSLOUnhealthyReason(&slov1alpha1.NodeSLO{Status: tt.status}, rolloutCfg)
+			assert.Equal(t, tt.wantUnhealthy, len(got) > 0, got)
+		})
+	}
+}
+
+func Test_updateRollout(t *testing.T) {
+	stableCfg := DefaultSLOCfg()
+	stableGeneration := getSLOCfgGeneration(&stableCfg)
+	targetCfg := DefaultSLOCfg()
+	targetCfg.CPUBurstCfgMerged.ClusterStrategy.CPUBurstPercent = pointer.Int64(200)
+	targetGeneration := getSLOCfgGeneration(&targetCfg)
+	enabledRolloutCfg := configuration.RolloutCfg{
+		Enable:       pointer.Bool(true),
+		StepPercents: []int64{50, 100},
+	}
+	tests := []struct {
+		name        string
+		available   bool
+		oldStatus   rolloutStatus
+		rolloutCfg  configuration.RolloutCfg
+		wantChanged bool
+		wantStatus  rolloutStatus
+	}{
+		{
+			name:        "apply directly when cache is unavailable",
+			available:   false,
+			oldStatus:   newCompletedRolloutStatus(stableGeneration),
+			rolloutCfg:  enabledRolloutCfg,
+			wantChanged: true,
+			wantStatus:  rolloutStatus{Phase: RolloutPhaseCompleted, StableGeneration: targetGeneration, TargetGeneration: targetGeneration},
+		},
+		{
+			name:        "apply directly when rollout is disabled",
+			available:   true,
+			oldStatus:   newCompletedRolloutStatus(stableGeneration),
+			rolloutCfg:  configuration.RolloutCfg{StepPercents: []int64{50, 100}},
+			wantChanged: true,
+			wantStatus:  rolloutStatus{Phase: RolloutPhaseCompleted, StableGeneration: targetGeneration, TargetGeneration: targetGeneration},
+		},
+		{
+			name:        "start a new rollout from the canary step",
+			available:   true,
+			oldStatus:   newCompletedRolloutStatus(stableGeneration),
+			rolloutCfg:  enabledRolloutCfg,
+			wantChanged: true,
+			wantStatus:  rolloutStatus{Phase: RolloutPhaseProgressing, StableGeneration: stableGeneration, TargetGeneration: targetGeneration, Step: -1},
+		},
+		{
+			name:      "keep the rollout progress with the same target",
+			available: true,
+			oldStatus: rolloutStatus{
+				Phase:            RolloutPhaseProgressing,
+				StableGeneration: stableGeneration,
+				TargetGeneration: targetGeneration,
+				Step:             1,
+			},
+			rolloutCfg:  enabledRolloutCfg,
+			wantChanged: false,
+			wantStatus:  rolloutStatus{Phase: RolloutPhaseProgressing, StableGeneration: stableGeneration, TargetGeneration: targetGeneration, Step: 1},
+		},
+		{
+			name:      "restart a paused rollout with a new target",
+			available: true,
+			oldStatus: rolloutStatus{
+				Phase:            RolloutPhasePaused,
+				StableGeneration: stableGeneration,
+				TargetGeneration: "old-target",
+				Step:             0,
+			},
+			rolloutCfg:  enabledRolloutCfg,
+			wantChanged: true,
+			wantStatus:  rolloutStatus{Phase: RolloutPhaseProgressing, StableGeneration: stableGeneration, TargetGeneration: targetGeneration, Step: -1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &sLOCfgCache{
+				sloCfg:    targetCfg,
+				available: tt.available,
+				stableCfg: stableCfg,
+				rollout:   tt.oldStatus,
+			}
+			got := c.updateRollout(&targetCfg, &tt.rolloutCfg, nil)
+			assert.Equal(t, tt.wantChanged, got)
+			assert.Equal(t, tt.wantStatus.Phase, c.rollout.Phase)
+			assert.Equal(t, tt.wantStatus.StableGeneration, c.rollout.StableGeneration)
+			assert.Equal(t, tt.wantStatus.TargetGeneration, c.rollout.TargetGeneration)
+			assert.Equal(t, tt.wantStatus.Step, c.rollout.Step)
+		})
+	}
+}
+
+func Test_syncRollout(t *testing.T) {
+	oldClk := clk
+	defer func() {
+		clk = oldClk
+	}()
+	now := time.Now()
+	fakeClock := clock.NewFakeClock(now)
+	clk = fakeClock
+
+	scheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(scheme)
+	slov1alpha1.AddToScheme(scheme)
+
+	stableCfg := DefaultSLOCfg()
+	targetCfg := DefaultSLOCfg()
+	targetCfg.CPUBurstCfgMerged.ClusterStrategy.CPUBurstPercent = pointer.Int64(200)
+	targetGeneration := getSLOCfgGeneration(&targetCfg)
+	rolloutCfg := configuration.RolloutCfg{
+		Enable: pointer.Bool(true),
+		CanaryNodeSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"canary": "true"},
+		},
+		StepPercents:          []int64{100},
+		StepIntervalSeconds:   pointer.Int64(60),
+		MaxApplyErrorsPerNode: pointer.Int64(0),
+	}
+	canaryNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "canary-node",
+			Labels: map[string]string{"canary": "true"},
+		},
+	}
+	otherNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "other-node",
+		},
+	}
+
+	tests := []struct {
+		name           string
+		canaryStatus   slov1alpha1.NodeSLOStatus
+		canaryNotReady bool
+		elapsed        time.Duration
+		wantPhase      RolloutPhase
+		wantStep       int
+		wantStableCfg  SLOCfg
+		wantEnqueued   []string
+	}{
+		{
+			name:          "wait for the canary node to apply",
+			canaryStatus:  slov1alpha1.NodeSLOStatus{ConfigGeneration: "stale"},
+			elapsed:       2 * time.Minute,
+			wantPhase:     RolloutPhaseProgressing,
+			wantStep:      -1,
+			wantStableCfg: stableCfg,
+		},
+		{
+			name:          "pause since the canary node has not applied in the step timeout",
+			canaryStatus:  slov1alpha1.NodeSLOStatus{ConfigGeneration: "stale"},
+			elapsed:       time.Hour,
+			wantPhase:     RolloutPhasePaused,
+			wantStep:      -1,
+			wantStableCfg: stableCfg,
+		},
+		{
+			name:           "not wait for the NotReady canary node",
+			canaryStatus:   slov1alpha1.NodeSLOStatus{ConfigGeneration: "stale"},
+			canaryNotReady: true,
+			elapsed:        2 * time.Minute,
+			wantPhase:      RolloutPhaseProgressing,
+			wantStep:       0,
+			wantStableCfg:  stableCfg,
+			wantEnqueued:   []string{otherNode.Name},
+		},
+		{
+			name:          "wait for the step interval",
+			canaryStatus:  slov1alpha1.NodeSLOStatus{ConfigGeneration: targetGeneration},
+			elapsed:       10 * time.Second,
+			wantPhase:     RolloutPhaseProgressing,
+			wantStep:      -1,
+			wantStableCfg: stableCfg,
+		},
+		{
+			name:          "progress to the next step",
+			canaryStatus:  slov1alpha1.NodeSLOStatus{ConfigGeneration: targetGeneration},
+			elapsed:       2 * time.Minute,
+			wantPhase:     RolloutPhaseProgressing,
+			wantStep:      0,
+			wantStableCfg: stableCfg,
+			wantEnqueued:  []string{otherNode.Name},
+		},
+		{
+			name:          "pause since the canary node is unhealthy",
+			canaryStatus:  slov1alpha1.NodeSLOStatus{ConfigGeneration: targetGeneration, ApplyErrors: 1},
+			elapsed:       2 * time.Minute,
+			wantPhase:     RolloutPhasePaused,
+			wantStep:      -1,
+			wantStableCfg: stableCfg,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			canaryNodeSLO := &slov1alpha1.NodeSLO{
+				ObjectMeta: metav1.ObjectMeta{Name: canaryNode.Name},
+				Status:     tt.canaryStatus,
+			}
+			otherNodeSLO := &slov1alpha1.NodeSLO{
+				ObjectMeta: metav1.ObjectMeta{Name: otherNode.Name},
+			}
+			testCanaryNode := canaryNode.DeepCopy()
+			if tt.canaryNotReady {
+				testCanaryNode.Status.Conditions = []corev1.NodeCondition{
+					{Type: corev1.NodeReady, Status: corev1.ConditionUnknown},
+				}
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(testCanaryNode, otherNode, canaryNodeSLO, otherNodeSLO).Build()
+			p := NewSLOCfgHandlerForConfigMapEvent(fakeClient, stableCfg, &record.FakeRecorder{})
+			p.cfgCache.available = true
+			p.cfgCache.sloCfg = targetCfg
+			p.cfgCache.rolloutCfg = rolloutCfg
+			p.cfgCache.rollout = rolloutStatus{
+				Phase:            RolloutPhaseProgressing,
+				StableGeneration: getSLOCfgGeneration(&stableCfg),
+				TargetGeneration: targetGeneration,
+				Step:             -1,
+				StepStartTime:    now,
+			}
+			fakeClock.SetTime(now.Add(tt.elapsed))
+
+			p.syncRollout()
+			assert.Equal(t, tt.wantPhase, p.cfgCache.rollout.Phase)
+			assert.Equal(t, tt.wantStep, p.cfgCache.rollout.Step)
+			assert.Equal(t, tt.wantStableCfg, p.cfgCache.stableCfg)
+
+			gotCfg, gotGeneration := p.GetCfgCopyForNode(canaryNode)
+			assert.Equal(t, targetCfg, *gotCfg)
+			assert.Equal(t, targetGeneration, gotGeneration)
+
+			var gotEnqueued []string
+			for len(p.rolloutNodeEventCh) > 0 {
+				gotEnqueued = append(gotEnqueued, (<-p.rolloutNodeEventCh).Object.GetName())
+			}
+			assert.Equal(t, tt.wantEnqueued, gotEnqueued)
+		})
+	}
+}
+
+func Test_syncRolloutCompleted(t *testing.T) {
+	oldClk := clk
+	defer func() {
+		clk = oldClk
+	}()
+	now := time.Now()
+	clk = clock.NewFakeClock(now.Add(time.Hour))
+
+	scheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(scheme)
+	slov1alpha1.AddToScheme(scheme)
+
+	stableCfg := DefaultSLOCfg()
+	targetCfg := DefaultSLOCfg()
+	targetCfg.CPUBurstCfgMerged.ClusterStrategy.CPUBurstPercent = pointer.Int64(200)
+	targetGeneration := getSLOCfgGeneration(&targetCfg)
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-node"},
+	}
+	nodeSLO := &slov1alpha1.NodeSLO{
+		ObjectMeta: metav1.ObjectMeta{Name: node.Name},
+		Status:     slov1alpha1.NodeSLOStatus{ConfigGeneration: targetGeneration},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(node, nodeSLO).Build()
+	p := NewSLOCfgHandlerForConfigMapEvent(fakeClient, stableCfg, &record.FakeRecorder{})
+	p.cfgCache.available = true
+	p.cfgCache.sloCfg = targetCfg
+	p.cfgCache.rolloutCfg = configuration.RolloutCfg{
+		Enable:       pointer.Bool(true),
+		StepPercents: []int64{100},
+	}
+	p.cfgCache.rollout = rolloutStatus{
+		Phase:            RolloutPhaseProgressing,
+		StableGeneration: getSLOCfgGeneration(&stableCfg),
+		TargetGeneration: targetGeneration,
+		Step:             0,
+		StepStartTime:    now,
+	}
+
+	p.syncRollout()
+	assert.Equal(t, RolloutPhaseCompleted, p.cfgCache.rollout.Phase)
+	assert.Equal(t, targetGeneration, p.cfgCache.rollout.StableGeneration)
+	assert.Equal(t, targetCfg, p.cfgCache.stableCfg)
+
+	gotCfg, gotGeneration := p.GetCfgCopyForNode(node)
+	assert.Equal(t, targetCfg, *gotCfg)
+	assert.Equal(t, targetGeneration, gotGeneration)
+}
+
+func Test_restoreRollout(t *testing.T) {
+	scheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(scheme)
+	slov1alpha1.AddToScheme(scheme)
+
+	stableData := map[string]string{
+		configuration.RolloutConfigKey: `{"enable":true,"stepPercents":[50,100]}`,
+	}
+	targetData := map[string]string{
+		configuration.RolloutConfigKey:  `{"enable":true,"stepPercents":[50,100]}`,
+		configuration.CPUBurstConfigKey: `{"clusterStrategy":{"cpuBurstPercent":200}}`,
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: sloconfig.ConfigNameSpace,
+			Name:      sloconfig.SLOCtrlConfigMap,
+		},
+		Data: stableData,
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(configMap).Build()
+
+	// start a rollout and persist it
+	p := NewSLOCfgHandlerForConfigMapEvent(fakeClient, DefaultSLOCfg(), &record.FakeRecorder{})
+	p.syncNodeSLOSpecIfChanged(configMap)
+	assert.Equal(t, RolloutPhaseCompleted, p.cfgCache.rollout.Phase)
+	configMap.Data = targetData
+	assert.NoError(t, fakeClient.Update(context.TODO(), configMap))
+	assert.True(t, p.syncNodeSLOSpecIfChanged(configMap))
+	assert.Equal(t, RolloutPhaseProgressing, p.cfgCache.rollout.Phase)
+	p.persistRollout()
+
+	gotConfigMap := &corev1.ConfigMap{}
+	assert.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{
+		Namespace: sloconfig.ConfigNameSpace,
+		Name:      sloconfig.SLOCtrlConfigMap,
+	}, gotConfigMap))
+	assert.Contains(t, gotConfigMap.Annotations, AnnotationSLOConfigRollout)
+
+	// the rollout continues after the controller restarts
+	restarted := NewSLOCfgHandlerForConfigMapEvent(fakeClient, DefaultSLOCfg(), &record.FakeRecorder{})
+	restarted.syncNodeSLOSpecIfChanged(gotConfigMap)
+	assert.Equal(t, p.cfgCache.rollout.Phase, restarted.cfgCache.rollout.Phase)
+	assert.Equal(t, p.cfgCache.rollout.Step, restarted.cfgCache.rollout.Step)
+	assert.Equal(t, p.cfgCache.rollout.StableGeneration, restarted.cfgCache.rollout.StableGeneration)
+	assert.Equal(t, p.cfgCache.rollout.TargetGeneration, restarted.cfgCache.rollout.TargetGeneration)
+	assert.Equal(t, p.cfgCache.stableCfg, restarted.cfgCache.stableCfg)
+	assert.Equal(t, p.cfgCache.sloCfg, restarted.cfgCache.sloCfg)
+
+	// the target config is applied directly without the persisted rollout status
+	delete(gotConfigMap.Annotations, AnnotationSLOConfigRollout)
+	restarted = NewSLOCfgHandlerForConfigMapEvent(fakeClient, DefaultSLOCfg(), &record.FakeRecorder{})
+	restarted.syncNodeSLOSpecIfChanged(gotConfigMap)
+	assert.Equal(t, RolloutPhaseCompleted, restarted.cfgCache.rollout.Phase)
+	assert.Equal(t, p.cfgCache.rollout.TargetGeneration, restarted.cfgCache.rollout.StableGeneration)
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadprofile
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/util/histogram"
+)
+
+const (
+	// cpu histogram is in cores, covering [0, 1024) cores
+	cpuHistogramMaxValue        = 1024
+	cpuHistogramFirstBucketSize = 0.01
+	// memory histogram is in bytes, covering [0, 1Ti) bytes
+	memoryHistogramMaxValue        = 1 << 40
+	memoryHistogramFirstBucketSize = 1 << 20
+
+	histogramBucketSizeGrowth = 0.05
+	histogramEpsilon          = 0.0001
+)
+
+var profileAggregationTypes = map[extension.AggregationType]float64{
+	extension.P50: 0.5,
+	extension.P90: 0.9,
+	extension.P95: 0.95,
+	extension.P99: 0.99,
+}
+
+type workloadHistogram struct {
+	cpu            histogram.Histogram
+	memory         histogram.Histogram
+	samples        int64
+	lastSampleTime time.Time
+}
+
+// profileCollector aggregates the PodMetricInfo reported in NodeMetrics into per-workload decaying histograms.
+type profileCollector struct {
+	lock      sync.Mutex
+	halfLife  time.Duration
+	workloads map[extension.WorkloadReference]*workloadHistogram
+	// nodeUpdateTimes records the UpdateTime of the last aggregated NodeMetric to avoid counting a sample twice.
+	nodeUpdateTimes map[string]time.Time
+}
+
+func newProfileCollector(halfLife time.Duration) *profileCollector {
+	return &profileCollector{
+		halfLife:        halfLife,
+		workloads:       map[extension.WorkloadReference]*workloadHistogram{},
+		nodeUpdateTimes: map[string]time.Time{},
+	}
+}
+
+func (c *profileCollector) newWorkloadHistogram() (*workloadHistogram, error) {
+	cpuOptions, err := histogram.NewExponentialHistogramOptions(cpuHistogramMaxValue, cpuHistogramFirstBucketSize, 1.+histogramBucketSizeGrowth, histogramEpsilon)
+	if err != nil {
+		return nil, err
+	}
+	memoryOptions, err := histogram.NewExponentialHistogramOptions(memoryHistogramMaxValue, memoryHistogramFirstBucketSize, 1.+histogramBucketSizeGrowth, histogramEpsilon)
+	if err != nil {
+		return nil, err
+	}
+	return &workloadHistogram{
+		cpu:    histogram.NewDecayingHistogram(cpuOptions, c.halfLife),
+		memory: histogram.NewDecayingHistogram(memoryOptions, c.halfLife),
+	}, nil
+}
+
+// addNodeMetric aggregates the pods metric of the NodeMetric. getPod returns nil if the pod is not found.
+func (c *profileCollector) addNodeMetric(nodeMetric *slov1alpha1.NodeMetric, getPod func(namespace, name string) *corev1.Pod) error {
+	if nodeMetric.Status.UpdateTime == nil {
+		return nil
+	}
+	updateTime := nodeMetric.Status.UpdateTime.Time
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if lastUpdateTime, ok := c.nodeUpdateTimes[nodeMetric.Name]; ok && !updateTime.After(lastUpdateTime) {
+		return nil
+	}
+	c.nodeUpdateTimes[nodeMetric.Name] = updateTime
+
+	for _, podMetric := range nodeMetric.Status.PodsMetric {
+		if podMetric == nil {
+			continue
+		}
+		pod := getPod(podMetric.Namespace, podMetric.Name)
+		if pod == nil {
+			continue
+		}
+		ref := extension.GetPodWorkloadReference(pod)
+		if ref == nil {
+			continue
+		}
+		h := c.workloads[*ref]
+		if h == nil {
+			var err error
+			if h, err = c.newWorkloadHistogram(); err != nil {
+				return err
+			}
+			c.workloads[*ref] = h
+		}
+		cpu := podMetric.PodUsage.ResourceList[corev1.ResourceCPU]
+		memory := podMetric.PodUsage.ResourceList[corev1.ResourceMemory]
+		h.cpu.AddSample(float64(cpu.MilliValue())/1000, 1, updateTime)
+		h.memory.AddSample(float64(memory.Value()), 1, updateTime)
+		h.samples++
+		if updateTime.After(h.lastSampleTime) {
+			h.lastSampleTime = updateTime
+		}
+	}
+	return nil
+}
+
+// retainNodes forgets the NodeMetric update times of the deleted nodes.
+func (c *profileCollector) retainNodes(nodes sets.String) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for nodeName := range c.nodeUpdateTimes {
+		if !nodes.Has(nodeName) {
+			delete(c.nodeUpdateTimes, nodeName)
+		}
+	}
+}
+
+// getProfiles returns the profiles sorted by the number of samples in descending order, whose serialized size is at
+// most maxBytes so that they fit in the ConfigMap. Workloads without any sample in the expiration are garbage collected.
+func (c *profileCollector) getProfiles(now time.Time, expiration time.Duration, maxBytes int) []*extension.WorkloadProfile {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	profiles := make([]*extension.WorkloadProfile, 0, len(c.workloads))
+	for ref, h := range c.workloads {
+		if now.Sub(h.lastSampleTime) > expiration {
+			delete(c.workloads, ref)
+			continue
+		}
+		profile := &extension.WorkloadProfile{
+			WorkloadReference: ref,
+			Usage:             map[extension.AggregationType]corev1.ResourceList{},
+			Samples:           h.samples,
+			UpdateTime:        &metav1.Time{Time: h.lastSampleTime},
+		}
+		for aggregationType, percentile := range profileAggregationTypes {
+			profile.Usage[aggregationType] = corev1.ResourceList{
+				corev1.ResourceCPU:    *resource.NewMilliQuantity(int64(h.cpu.Percentile(percentile)*1000), resource.DecimalSI),
+				corev1.ResourceMemory: *resource.NewQuantity(int64(h.memory.Percentile(percentile)), resource.BinarySI),
+			}
+		}
+		profiles = append(profiles, profile)
+	}
+
+	sort.Slice(profiles, func(i, j int) bool {
+		if profiles[i].Samples != profiles[j].Samples {
+			return profiles[i].Samples > profiles[j].Samples
+		}
+		return profiles[i].WorkloadReference.String() < profiles[j].WorkloadReference.String()
+	})
+	if maxBytes > 0 {
+		profiles = limitProfilesBySize(profiles, maxBytes)
+	}
+	return profiles
+}
+
+// limitProfilesBySize returns the longest prefix of the profiles whose serialized json array is at most maxBytes.
+func limitProfilesBySize(profiles []*extension.WorkloadProfile, maxBytes int) []*extension.WorkloadProfile {
+	size := len("[]")
+	for i, profile := range profiles {
+		data, err := json.Marshal(profile)
+		if err != nil {
+			return profiles[:i]
+		}
+		profileSize := len(data)
+		if i > 0 {
+			profileSize += len(",")
+		}
+		if size+profileSize > maxBytes {
+			return profiles[:i]
+		}
+		size += profileSize
+	}
+	return profiles
+}
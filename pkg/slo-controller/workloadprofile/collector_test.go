@@ -0,0 +1,143 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadprofile
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/pointer"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+)
+
+func TestProfileCollector(t *testing.T) {
+	pods := map[string]*corev1.Pod{
+		"test-pod-1": {
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test-pod-1",
+				OwnerReferences: []metav1.OwnerReference{
+					{Kind: "StatefulSet", Name: "test-sts", Controller: pointer.Bool(true)},
+				},
+			},
+		},
+		"test-pod-2": {
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test-pod-2",
+			},
+		},
+	}
+	getPod := func(namespace, name string) *corev1.Pod {
+		return pods[name]
+	}
+	newNodeMetric := func(updateTime time.Time, cpu string) *slov1alpha1.NodeMetric {
+		return &slov1alpha1.NodeMetric{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-node"},
+			Status: slov1alpha1.NodeMetricStatus{
+				UpdateTime: &metav1.Time{Time: updateTime},
+				PodsMetric: []*slov1alpha1.PodMetricInfo{
+					{
+						Namespace: "default",
+						Name:      "test-pod-1",
+						PodUsage: slov1alpha1.ResourceMap{
+							ResourceList: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse(cpu),
+								corev1.ResourceMemory: resource.MustParse("1Gi"),
+							},
+						},
+					},
+					{
+						Namespace: "default",
+						Name:      "test-pod-2",
+						PodUsage: slov1alpha1.ResourceMap{
+							ResourceList: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("1"),
+							},
+						},
+					},
+					{
+						Namespace: "default",
+						Name:      "deleted-pod",
+					},
+				},
+			},
+		}
+	}
+
+	now := time.Now()
+	c := newProfileCollector(time.Hour)
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, c.addNodeMetric(newNodeMetric(now.Add(time.Duration(i)*time.Minute), "1"), getPod))
+	}
+	// duplicated NodeMetric must not be aggregated twice
+	assert.NoError(t, c.addNodeMetric(newNodeMetric(now, "100"), getPod))
+
+	profiles := c.getProfiles(now.Add(10*time.Minute), time.Hour, 0)
+	assert.Len(t, profiles, 1)
+	profile := profiles[0]
+	assert.Equal(t, extension.WorkloadReference{Namespace: "default", Kind: "StatefulSet", Name: "test-sts"}, profile.WorkloadReference)
+	assert.Equal(t, int64(10), profile.Samples)
+	p95 := profile.Usage[extension.P95]
+	assert.InDelta(t, 1000, p95.Cpu().MilliValue(), 100)
+	assert.InDelta(t, 1<<30, p95.Memory().Value(), 1<<26)
+
+	c.retainNodes(sets.NewString())
+	assert.Empty(t, c.nodeUpdateTimes)
+
+	profiles = c.getProfiles(now.Add(2*time.Hour), time.Hour, 0)
+	assert.Empty(t, profiles)
+	assert.Empty(t, c.workloads)
+}
+
+func Test_limitProfilesBySize(t *testing.T) {
+	var profiles []*extension.WorkloadProfile
+	for i := 0; i < 5000; i++ {
+		profiles = append(profiles, &extension.WorkloadProfile{
+			WorkloadReference: extension.WorkloadReference{Namespace: "default", Kind: "Deployment", Name: fmt.Sprintf("test-deployment-%d", i)},
+			Usage: map[extension.AggregationType]corev1.ResourceList{
+				extension.P95: {
+					corev1.ResourceCPU:    resource.MustParse("1500m"),
+					corev1.ResourceMemory: resource.MustParse("2Gi"),
+				},
+			},
+			Samples: int64(5000 - i),
+		})
+	}
+	maxBytes := 64 * 1024
+	got := limitProfilesBySize(profiles, maxBytes)
+	assert.NotEmpty(t, got)
+	assert.Less(t, len(got), len(profiles))
+	data, err := json.Marshal(got)
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, len(data), maxBytes)
+	// one more profile exceeds the limit
+	data, err = json.Marshal(profiles[:len(got)+1])
+	assert.NoError(t, err)
+	assert.Greater(t, len(data), maxBytes)
+
+	assert.Equal(t, profiles[:2], limitProfilesBySize(profiles[:2], maxBytes))
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadprofile
+
+import (
+	"context"
+	"flag"
+	"reflect"
+	"time"
+
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/util/sloconfig"
+)
+
+const Name = "workloadprofile"
+
+var (
+	CollectInterval   = time.Minute
+	SyncInterval      = 5 * time.Minute
+	HistogramHalfLife = 24 * time.Hour
+	ProfileExpiration = 7 * 24 * time.Hour
+	// MaxProfilesBytes bounds the serialized size of the profiles under the 1 MiB size limit of the ConfigMap.
+	MaxProfilesBytes = 900 * 1024
+)
+
+var _ manager.LeaderElectionRunnable = &Controller{}
+
+// Controller aggregates the historical pod usage in NodeMetrics into per-workload profiles,
+// and stores them in the workload profile ConfigMap for the LoadAware scheduling.
+type Controller struct {
+	client    client.Client
+	collector *profileCollector
+}
+
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=slo.koordinator.sh,resources=nodemetrics,verbs=get;list;watch
+
+func InitFlags(fs *flag.FlagSet) {
+	pflag.DurationVar(&CollectInterval, "workload-profile-collect-interval", CollectInterval, "The interval to collect pod usage from NodeMetrics into workload profiles.")
+	pflag.DurationVar(&SyncInterval, "workload-profile-sync-interval", SyncInterval, "The interval to sync workload profiles into the ConfigMap.")
+	pflag.DurationVar(&HistogramHalfLife, "workload-profile-half-life", HistogramHalfLife, "The half life of the samples in workload profiles.")
+	pflag.DurationVar(&ProfileExpiration, "workload-profile-expiration", ProfileExpiration, "The duration after which a workload profile without new samples is removed.")
+	pflag.IntVar(&MaxProfilesBytes, "workload-profile-max-bytes", MaxProfilesBytes, "The max serialized size in bytes of the workload profiles stored in the ConfigMap, "+
+		"the profiles with fewer samples are dropped first when exceeded.")
+}
+
+func Add(mgr ctrl.Manager) error {
+	return mgr.Add(&Controller{
+		client:    mgr.GetClient(),
+		collector: newProfileCollector(HistogramHalfLife),
+	})
+}
+
+func (c *Controller) NeedLeaderElection() bool {
+	return true
+}
+
+func (c *Controller) Start(ctx context.Context) error {
+	klog.Infof("start workload profile controller")
+	go wait.UntilWithContext(ctx, c.sync, SyncInterval)
+	wait.UntilWithContext(ctx, c.collect, CollectInterval)
+	return nil
+}
+
+func (c *Controller) collect(ctx context.Context) {
+	nodeMetricList := &slov1alpha1.NodeMetricList{}
+	if err := c.client.List(ctx, nodeMetricList); err != nil {
+		klog.Errorf("failed to list NodeMetrics for workload profile, err: %v", err)
+		return
+	}
+	getPod := func(namespace, name string) *corev1.Pod {
+		pod := &corev1.Pod{}
+		if err := c.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, pod); err != nil {
+			return nil
+		}
+		return pod
+	}
+	nodes := sets.NewString()
+	for i := range nodeMetricList.Items {
+		nodeMetric := &nodeMetricList.Items[i]
+		nodes.Insert(nodeMetric.Name)
+		if err := c.collector.addNodeMetric(nodeMetric, getPod); err != nil {
+			klog.Errorf("failed to collect NodeMetric %s for workload profile, err: %v", nodeMetric.Name, err)
+		}
+	}
+	c.collector.retainNodes(nodes)
+}
+
+func (c *Controller) sync(ctx context.Context) {
+	profiles := c.collector.getProfiles(time.Now(), ProfileExpiration, MaxProfilesBytes)
+
+	configMap := &corev1.ConfigMap{}
+	err := c.client.Get(ctx, types.NamespacedName{Namespace: sloconfig.ConfigNameSpace, Name: extension.WorkloadProfileConfigMapName}, configMap)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			klog.Errorf("failed to get workload profile ConfigMap, err: %v", err)
+			return
+		}
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: sloconfig.ConfigNameSpace,
+				Name:      extension.WorkloadProfileConfigMapName,
+			},
+		}
+		if err = extension.SetWorkloadProfiles(configMap, profiles); err != nil {
+			klog.Errorf("failed to marshal workload profiles, err: %v", err)
+			return
+		}
+		if err = c.client.Create(ctx, configMap); err != nil {
+			klog.Errorf("failed to create workload profile ConfigMap, err: %v", err)
+			return
+		}
+		klog.V(4).Infof("created workload profile ConfigMap with %d profiles", len(profiles))
+		return
+	}
+
+	newConfigMap := configMap.DeepCopy()
+	if err = extension.SetWorkloadProfiles(newConfigMap, profiles); err != nil {
+		klog.Errorf("failed to marshal workload profiles, err: %v", err)
+		return
+	}
+	if reflect.DeepEqual(configMap.Data, newConfigMap.Data) {
+		return
+	}
+	if err = c.client.Update(ctx, newConfigMap); err != nil {
+		klog.Errorf("failed to update workload profile ConfigMap, err: %v", err)
+		return
+	}
+	klog.V(4).Infof("updated workload profile ConfigMap with %d profiles", len(profiles))
+}
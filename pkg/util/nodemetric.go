@@ -0,0 +1,82 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+)
+
+// GetTargetAggregatedUsage returns the aggregated node usage of the aggregationType in the aggregatedDuration.
+// If no specific period is set, the maximum period recorded by NodeMetrics will be used by default.
+func GetTargetAggregatedUsage(nodeMetric *slov1alpha1.NodeMetric, aggregatedDuration *metav1.Duration, aggregationType extension.AggregationType) *slov1alpha1.ResourceMap {
+	if nodeMetric.Status.NodeMetric == nil {
+		return nil
+	}
+	return getTargetAggregatedUsage(nodeMetric.Status.NodeMetric.AggregatedNodeUsages, aggregatedDuration, aggregationType)
+}
+
+// GetPodTargetAggregatedUsage returns the aggregated pod usage of the aggregationType in the same window as
+// the node usage returned by GetTargetAggregatedUsage.
+func GetPodTargetAggregatedUsage(nodeMetric *slov1alpha1.NodeMetric, podMetric *slov1alpha1.PodMetricInfo, aggregatedDuration *metav1.Duration, aggregationType extension.AggregationType) *slov1alpha1.ResourceMap {
+	if nodeMetric.Status.NodeMetric == nil || len(nodeMetric.Status.NodeMetric.AggregatedNodeUsages) == 0 {
+		return nil
+	}
+	if aggregatedDuration == nil || aggregatedDuration.Duration == 0 {
+		aggregatedDuration = &nodeMetric.Status.NodeMetric.AggregatedNodeUsages[maxDurationIndex(nodeMetric.Status.NodeMetric.AggregatedNodeUsages)].Duration
+	}
+	return getTargetAggregatedUsage(podMetric.AggregatedPodUsages, aggregatedDuration, aggregationType)
+}
+
+func maxDurationIndex(aggregatedUsages []slov1alpha1.AggregatedUsage) int {
+	var maxDuration time.Duration
+	var maxIndex int
+	for i, v := range aggregatedUsages {
+		if v.Duration.Duration > maxDuration {
+			maxDuration = v.Duration.Duration
+			maxIndex = i
+		}
+	}
+	return maxIndex
+}
+
+func getTargetAggregatedUsage(aggregatedUsages []slov1alpha1.AggregatedUsage, aggregatedDuration *metav1.Duration, aggregationType extension.AggregationType) *slov1alpha1.ResourceMap {
+	if len(aggregatedUsages) == 0 {
+		return nil
+	}
+
+	if aggregatedDuration == nil || aggregatedDuration.Duration == 0 {
+		usage := aggregatedUsages[maxDurationIndex(aggregatedUsages)].Usage[aggregationType]
+		if len(usage.ResourceList) > 0 {
+			return &usage
+		}
+		return nil
+	}
+	for _, v := range aggregatedUsages {
+		if v.Duration.Duration == aggregatedDuration.Duration {
+			usage := v.Usage[aggregationType]
+			if len(usage.ResourceList) > 0 {
+				return &usage
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+)
+
+func TestGetTargetAggregatedUsage(t *testing.T) {
+	cpuUsage := func(cpu string) slov1alpha1.ResourceMap {
+		return slov1alpha1.ResourceMap{ResourceList: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)}}
+	}
+	nodeMetric := &slov1alpha1.NodeMetric{
+		Status: slov1alpha1.NodeMetricStatus{
+			NodeMetric: &slov1alpha1.NodeMetricInfo{
+				AggregatedNodeUsages: []slov1alpha1.AggregatedUsage{
+					{
+						Duration: metav1.Duration{Duration: 5 * time.Minute},
+						Usage:    map[extension.AggregationType]slov1alpha1.ResourceMap{extension.P95: cpuUsage("8")},
+					},
+					{
+						Duration: metav1.Duration{Duration: 10 * time.Minute},
+						Usage:    map[extension.AggregationType]slov1alpha1.ResourceMap{extension.P95: cpuUsage("6")},
+					},
+				},
+			},
+		},
+	}
+	podMetric := &slov1alpha1.PodMetricInfo{
+		AggregatedPodUsages: []slov1alpha1.AggregatedUsage{
+			{
+				Duration: metav1.Duration{Duration: 5 * time.Minute},
+				Usage:    map[extension.AggregationType]slov1alpha1.ResourceMap{extension.P95: cpuUsage("2")},
+			},
+			{
+				Duration: metav1.Duration{Duration: 10 * time.Minute},
+				Usage:    map[extension.AggregationType]slov1alpha1.ResourceMap{extension.P95: cpuUsage("1")},
+			},
+		},
+	}
+	tests := []struct {
+		name               string
+		nodeMetric         *slov1alpha1.NodeMetric
+		aggregatedDuration *metav1.Duration
+		aggregationType    extension.AggregationType
+		wantNodeUsage      *slov1alpha1.ResourceMap
+		wantPodUsage       *slov1alpha1.ResourceMap
+	}{
+		{
+			name:            "no aggregated usages",
+			nodeMetric:      &slov1alpha1.NodeMetric{},
+			aggregationType: extension.P95,
+		},
+		{
+			name:            "max duration by default",
+			nodeMetric:      nodeMetric,
+			aggregationType: extension.P95,
+			wantNodeUsage:   &slov1alpha1.ResourceMap{ResourceList: cpuUsage("6").ResourceList},
+			wantPodUsage:    &slov1alpha1.ResourceMap{ResourceList: cpuUsage("1").ResourceList},
+		},
+		{
+			name:               "specified duration",
+			nodeMetric:         nodeMetric,
+			aggregatedDuration: &metav1.Duration{Duration: 5 * time.Minute},
+			aggregationType:    extension.P95,
+			wantNodeUsage:      &slov1alpha1.ResourceMap{ResourceList: cpuUsage("8").ResourceList},
+			wantPodUsage:       &slov1alpha1.ResourceMap{ResourceList: cpuUsage("2").ResourceList},
+		},
+		{
+			name:               "duration not reported",
+			nodeMetric:         nodeMetric,
+			aggregatedDuration: &metav1.Duration{Duration: 30 * time.Minute},
+			aggregationType:    extension.P95,
+		},
+		{
+			name:            "aggregation type not reported",
+			nodeMetric:      nodeMetric,
+			aggregationType: extension.P50,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantNodeUsage, GetTargetAggregatedUsage(tt.nodeMetric, tt.aggregatedDuration, tt.aggregationType))
+			assert.Equal(t, tt.wantPodUsage, GetPodTargetAggregatedUsage(tt.nodeMetric, podMetric, tt.aggregatedDuration, tt.aggregationType))
+		})
+	}
+}
@@ -20,12 +20,13 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/component-helpers/scheduling/corev1/nodeaffinity"
 	"k8s.io/klog/v2"
-	"k8s.io/kubernetes/pkg/api/v1/resource"
+	resourceapi "k8s.io/kubernetes/pkg/api/v1/resource"
 	"k8s.io/kubernetes/pkg/scheduler/framework"
 	"k8s.io/utils/pointer"
 
@@ -92,6 +93,11 @@ func NewReservePod(r *schedulingv1alpha1.Reservation) *corev1.Pod {
 		reservePod.Spec.Priority = pointer.Int32(0)
 	}
 
+	// the Available reservation may be resized, keep the reserve pod requests consistent with the reserved resources
+	if IsReservationAvailable(r) && len(r.Status.Allocatable) > 0 {
+		resizeReservePod(reservePod, r.Status.Allocatable)
+	}
+
 	if IsReservationSucceeded(r) {
 		reservePod.Status.Phase = corev1.PodSucceeded
 	} else if IsReservationExpired(r) || IsReservationFailed(r) {
@@ -284,7 +290,7 @@ func SetReservationAvailable(r *schedulingv1alpha1.Reservation, nodeName string)
 
 func ReservationRequests(r *schedulingv1alpha1.Reservation) corev1.ResourceList {
 	if r.Spec.Template != nil {
-		requests, _ := resource.PodRequestsAndLimits(&corev1.Pod{
+		requests, _ := resourceapi.PodRequestsAndLimits(&corev1.Pod{
 			Spec: r.Spec.Template.Spec,
 		})
 		return requests
@@ -292,6 +298,80 @@ func ReservationRequests(r *schedulingv1alpha1.Reservation) corev1.ResourceList
 	return nil
 }
 
+// ReservedResources returns the resources actually reserved on the node.
+// The Available reservation reserves its status.allocatable which may differ from the template after resizing,
+// and other reservations reserve the template requests.
+func ReservedResources(r *schedulingv1alpha1.Reservation) corev1.ResourceList {
+	if IsReservationAvailable(r) && len(r.Status.Allocatable) > 0 {
+		return r.Status.Allocatable.DeepCopy()
+	}
+	return ReservationRequests(r)
+}
+
+// resizeReservePod adjusts the container requests of the reserve pod to make the pod requests equal to the allocatable.
+// The increment is added to the first container, and the decrement is subtracted from the containers in order.
+func resizeReservePod(pod *corev1.Pod, allocatable corev1.ResourceList) {
+	if len(pod.Spec.Containers) == 0 {
+		return
+	}
+	requests, _ := resourceapi.PodRequestsAndLimits(pod)
+	for resourceName, quantity := range allocatable {
+		requested := requests[resourceName]
+		if quantity.Cmp(requested) == 0 {
+			continue
+		}
+		for i := range pod.Spec.InitContainers {
+			setContainerRequest(&pod.Spec.InitContainers[i], resourceName, quantity, true)
+		}
+		delta := quantity.DeepCopy()
+		delta.Sub(requested)
+		if delta.Sign() > 0 {
+			container := &pod.Spec.Containers[0]
+			newRequest := container.Resources.Requests[resourceName]
+			newRequest.Add(delta)
+			setContainerRequest(container, resourceName, newRequest, false)
+			continue
+		}
+		delta.Neg()
+		for i := range pod.Spec.Containers {
+			if delta.IsZero() {
+				break
+			}
+			container := &pod.Spec.Containers[i]
+			newRequest := container.Resources.Requests[resourceName]
+			if newRequest.Cmp(delta) > 0 {
+				newRequest.Sub(delta)
+				delta.Set(0)
+			} else {
+				delta.Sub(newRequest)
+				newRequest.Set(0)
+			}
+			setContainerRequest(container, resourceName, newRequest, false)
+		}
+	}
+}
+
+// setContainerRequest updates the request of the container and keeps the limit not less than the request.
+// The limit equal to the request is updated together to keep the QoS class of the reserve pod.
+func setContainerRequest(container *corev1.Container, resourceName corev1.ResourceName, request resource.Quantity, onlyDecrease bool) {
+	oldRequest, ok := container.Resources.Requests[resourceName]
+	if !ok && onlyDecrease {
+		return
+	}
+	if onlyDecrease && oldRequest.Cmp(request) <= 0 {
+		return
+	}
+	if container.Resources.Requests == nil {
+		container.Resources.Requests = corev1.ResourceList{}
+	}
+	container.Resources.Requests[resourceName] = request.DeepCopy()
+	if limit, ok := container.Resources.Limits[resourceName]; ok {
+		if limit.Cmp(oldRequest) == 0 || limit.Cmp(request) < 0 {
+			container.Resources.Limits[resourceName] = request.DeepCopy()
+		}
+	}
+}
+
 func ReservePorts(r *schedulingv1alpha1.Reservation) framework.HostPortInfo {
 	portInfo := framework.HostPortInfo{}
 	for _, container := range r.Spec.Template.Spec.Containers {
@@ -44,6 +44,8 @@ func CreateCheckersAll(oldConfig *corev1.ConfigMap, config *corev1.ConfigMap, ne
 		NewResourceQOSChecker(oldConfig, config, needUnmarshal),
 		NewSystemConfigChecker(oldConfig, config, needUnmarshal),
 		NewCPUBurstChecker(oldConfig, config, needUnmarshal),
+		NewPodStrategyChecker(oldConfig, config, needUnmarshal),
+		NewRolloutChecker(oldConfig, config, needUnmarshal),
 	}
 }
 
@@ -0,0 +1,95 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sloconfig
+
+import (
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/koordinator-sh/koordinator/apis/configuration"
+)
+
+var _ ConfigChecker = &PodStrategyChecker{}
+
+type PodStrategyChecker struct {
+	cfg *configuration.PodStrategyCfg
+	CommonChecker
+}
+
+func NewPodStrategyChecker(oldConfig, newConfig *corev1.ConfigMap, needUnmarshal bool) *PodStrategyChecker {
+	checker := &PodStrategyChecker{CommonChecker: CommonChecker{OldConfigMap: oldConfig, NewConfigMap: newConfig, configKey: configuration.PodStrategyConfigKey, initStatus: NotInit}}
+	if !checker.IsCfgNotEmptyAndChanged() && !needUnmarshal {
+		return checker
+	}
+	if err := checker.initConfig(); err != nil {
+		checker.initStatus = err.Error()
+	} else {
+		checker.initStatus = InitSuccess
+	}
+	return checker
+}
+
+func (c *PodStrategyChecker) ConfigParamValid() error {
+	if err := c.CheckByValidator(c.cfg); err != nil {
+		return err
+	}
+	names := map[string]struct{}{}
+	for _, strategy := range c.cfg.PodStrategies {
+		if _, ok := names[strategy.Name]; ok {
+			return buildParamInvalidError(fmt.Errorf("podStrategies name conflict! configType: %s, name: %s", configuration.PodStrategyConfigKey, strategy.Name))
+		}
+		names[strategy.Name] = struct{}{}
+		if strategy.PodSelector == nil {
+			continue
+		}
+		if _, err := metav1.LabelSelectorAsSelector(strategy.PodSelector); err != nil {
+			return buildParamInvalidError(fmt.Errorf("podStrategies PodSelector parse error! configType: %s, name: %s, err: %s", configuration.PodStrategyConfigKey, strategy.Name, err))
+		}
+	}
+	return nil
+}
+
+func (c *PodStrategyChecker) initConfig() error {
+	cfg := &configuration.PodStrategyCfg{}
+	configStr := c.NewConfigMap.Data[configuration.PodStrategyConfigKey]
+	err := json.Unmarshal([]byte(configStr), &cfg)
+	if err != nil {
+		message := fmt.Sprintf("Failed to parse PodStrategy config in configmap %s/%s, err: %s",
+			c.NewConfigMap.Namespace, c.NewConfigMap.Name, err.Error())
+		klog.Error(message)
+		return buildJsonError(ReasonParseFail, message)
+	}
+	c.cfg = cfg
+
+	// pod strategies are not selected by nodes, so there is no node profile to check
+	c.NodeConfigProfileChecker, err = CreateNodeConfigProfileChecker(configuration.PodStrategyConfigKey, c.getConfigProfiles)
+	if err != nil {
+		klog.Error(fmt.Sprintf("Failed to parse PodStrategy config in configmap %s/%s, err: %s",
+			c.NewConfigMap.Namespace, c.NewConfigMap.Name, err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+func (c *PodStrategyChecker) getConfigProfiles() []configuration.NodeCfgProfile {
+	return nil
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sloconfig
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/koordinator-sh/koordinator/apis/configuration"
+	slov1alpha1 "github.com/koordinator-sh/koordinator/apis/slo/v1alpha1"
+)
+
+func Test_PodStrategy_NewChecker_InitStatus(t *testing.T) {
+	cfgValid := &configuration.PodStrategyCfg{
+		PodStrategies: []slov1alpha1.PodStrategy{
+			{
+				Name:       "strict-memory-qos",
+				Namespaces: []string{"tenant-a"},
+				MemoryQOS: &slov1alpha1.PodMemoryQOSConfig{
+					Policy: slov1alpha1.PodMemoryQOSPolicyAuto,
+				},
+			},
+		},
+	}
+	cfgValidBytes, _ := json.Marshal(cfgValid)
+
+	tests := []struct {
+		name          string
+		configMap     *corev1.ConfigMap
+		needUnmarshal bool
+		wantCfg       *configuration.PodStrategyCfg
+		wantStatus    string
+	}{
+		{
+			name: "config is nil and notNeedInit",
+			configMap: &corev1.ConfigMap{
+				Data: map[string]string{},
+			},
+			wantStatus: NotInit,
+		},
+		{
+			name: "config parse failed",
+			configMap: &corev1.ConfigMap{
+				Data: map[string]string{
+					configuration.PodStrategyConfigKey: "invalid config",
+				},
+			},
+			wantStatus: "invalid character",
+		},
+		{
+			name: "config valid",
+			configMap: &corev1.ConfigMap{
+				Data: map[string]string{
+					configuration.PodStrategyConfigKey: string(cfgValidBytes),
+				},
+			},
+			wantCfg:    cfgValid,
+			wantStatus: InitSuccess,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewPodStrategyChecker(nil, tt.configMap, tt.needUnmarshal)
+			gotInitStatus := checker.InitStatus()
+			assert.True(t, strings.Contains(gotInitStatus, tt.wantStatus), "gotStatus:%s", gotInitStatus)
+			assert.Equal(t, tt.wantCfg, checker.cfg)
+		})
+	}
+}
+
+func Test_PodStrategy_ConfigContentsValid(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     configuration.PodStrategyCfg
+		wantErr bool
+	}{
+		{
+			name:    "empty config",
+			cfg:     configuration.PodStrategyCfg{},
+			wantErr: false,
+		},
+		{
+			name: "name is empty",
+			cfg: configuration.PodStrategyCfg{
+				PodStrategies: []slov1alpha1.PodStrategy{
+					{
+						Namespaces: []string{"tenant-a"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "name conflict",
+			cfg: configuration.PodStrategyCfg{
+				PodStrategies: []slov1alpha1.PodStrategy{
+					{
+						Name:       "tenant",
+						Namespaces: []string{"tenant-a"},
+					},
+					{
+						Name:       "tenant",
+						Namespaces: []string{"tenant-b"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "pod selector invalid",
+			cfg: configuration.PodStrategyCfg{
+				PodStrategies: []slov1alpha1.PodStrategy{
+					{
+						Name: "tenant",
+						PodSelector: &metav1.LabelSelector{
+							MatchExpressions: []metav1.LabelSelectorRequirement{
+								{
+									Key:      "tenant",
+									Operator: "unknown",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "cpu burst percent invalid",
+			cfg: configuration.PodStrategyCfg{
+				PodStrategies: []slov1alpha1.PodStrategy{
+					{
+						Name: "tenant",
+						CPUBurst: &slov1alpha1.CPUBurstConfig{
+							CPUBurstPercent: pointer.Int64(0),
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "group identity invalid",
+			cfg: configuration.PodStrategyCfg{
+				PodStrategies: []slov1alpha1.PodStrategy{
+					{
+						Name: "tenant",
+						CPUQOS: &slov1alpha1.CPUQOS{
+							GroupIdentity: pointer.Int64(3),
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "config valid",
+			cfg: configuration.PodStrategyCfg{
+				PodStrategies: []slov1alpha1.PodStrategy{
+					{
+						Name:       "tenant-a",
+						Namespaces: []string{"tenant-a"},
+						PodSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"app": "web",
+							},
+						},
+						CPUQOS: &slov1alpha1.CPUQOS{
+							GroupIdentity: pointer.Int64(2),
+						},
+						MemoryQOS: &slov1alpha1.PodMemoryQOSConfig{
+							Policy: slov1alpha1.PodMemoryQOSPolicyAuto,
+						},
+						CPUBurst: &slov1alpha1.CPUBurstConfig{
+							Policy:          slov1alpha1.CPUBurstAuto,
+							CPUBurstPercent: pointer.Int64(200),
+						},
+					},
+					{
+						Name:       "tenant-b",
+						Namespaces: []string{"tenant-b"},
+						CPUBurst: &slov1alpha1.CPUBurstConfig{
+							Policy: slov1alpha1.CPUBurstNone,
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := PodStrategyChecker{cfg: &tt.cfg}
+			gotErr := checker.ConfigParamValid()
+			assert.Equal(t, tt.wantErr, gotErr != nil, gotErr)
+		})
+	}
+}
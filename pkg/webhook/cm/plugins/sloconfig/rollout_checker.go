@@ -0,0 +1,95 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sloconfig
+
+import (
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/koordinator-sh/koordinator/apis/configuration"
+)
+
+var _ ConfigChecker = &RolloutChecker{}
+
+type RolloutChecker struct {
+	cfg *configuration.RolloutCfg
+	CommonChecker
+}
+
+func NewRolloutChecker(oldConfig, newConfig *corev1.ConfigMap, needUnmarshal bool) *RolloutChecker {
+	checker := &RolloutChecker{CommonChecker: CommonChecker{OldConfigMap: oldConfig, NewConfigMap: newConfig, configKey: configuration.RolloutConfigKey, initStatus: NotInit}}
+	if !checker.IsCfgNotEmptyAndChanged() && !needUnmarshal {
+		return checker
+	}
+	if err := checker.initConfig(); err != nil {
+		checker.initStatus = err.Error()
+	} else {
+		checker.initStatus = InitSuccess
+	}
+	return checker
+}
+
+func (c *RolloutChecker) ConfigParamValid() error {
+	if err := c.CheckByValidator(c.cfg); err != nil {
+		return err
+	}
+	for i := 1; i < len(c.cfg.StepPercents); i++ {
+		if c.cfg.StepPercents[i] <= c.cfg.StepPercents[i-1] {
+			return buildParamInvalidError(fmt.Errorf("stepPercents must be in ascending order! configType: %s, stepPercents: %v", configuration.RolloutConfigKey, c.cfg.StepPercents))
+		}
+	}
+	if c.cfg.StepIntervalSeconds != nil && *c.cfg.StepIntervalSeconds < 0 {
+		return buildParamInvalidError(fmt.Errorf("stepIntervalSeconds must not be negative! configType: %s, stepIntervalSeconds: %d", configuration.RolloutConfigKey, *c.cfg.StepIntervalSeconds))
+	}
+	if c.cfg.CanaryNodeSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(c.cfg.CanaryNodeSelector); err != nil {
+			return buildParamInvalidError(fmt.Errorf("canaryNodeSelector parse error! configType: %s, err: %s", configuration.RolloutConfigKey, err))
+		}
+	}
+	return nil
+}
+
+func (c *RolloutChecker) initConfig() error {
+	cfg := &configuration.RolloutCfg{}
+	configStr := c.NewConfigMap.Data[configuration.RolloutConfigKey]
+	err := json.Unmarshal([]byte(configStr), &cfg)
+	if err != nil {
+		message := fmt.Sprintf("Failed to parse Rollout config in configmap %s/%s, err: %s",
+			c.NewConfigMap.Namespace, c.NewConfigMap.Name, err.Error())
+		klog.Error(message)
+		return buildJsonError(ReasonParseFail, message)
+	}
+	c.cfg = cfg
+
+	// the rollout config is cluster-wide, so there is no node profile to check
+	c.NodeConfigProfileChecker, err = CreateNodeConfigProfileChecker(configuration.RolloutConfigKey, c.getConfigProfiles)
+	if err != nil {
+		klog.Error(fmt.Sprintf("Failed to parse Rollout config in configmap %s/%s, err: %s",
+			c.NewConfigMap.Namespace, c.NewConfigMap.Name, err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+func (c *RolloutChecker) getConfigProfiles() []configuration.NodeCfgProfile {
+	return nil
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sloconfig
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/koordinator-sh/koordinator/apis/configuration"
+)
+
+func Test_Rollout_NewChecker_InitStatus(t *testing.T) {
+	cfgValid := &configuration.RolloutCfg{
+		Enable: pointer.Bool(true),
+		CanaryNodeSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"canary": "true"},
+		},
+		StepPercents: []int64{10, 50, 100},
+	}
+	cfgValidBytes, _ := json.Marshal(cfgValid)
+
+	tests := []struct {
+		name          string
+		configMap     *corev1.ConfigMap
+		needUnmarshal bool
+		wantCfg       *configuration.RolloutCfg
+		wantStatus    string
+	}{
+		{
+			name: "config is nil and notNeedInit",
+			configMap: &corev1.ConfigMap{
+				Data: map[string]string{},
+			},
+			wantStatus: NotInit,
+		},
+		{
+			name: "config parse failed",
+			configMap: &corev1.ConfigMap{
+				Data: map[string]string{
+					configuration.RolloutConfigKey: "invalid config",
+				},
+			},
+			wantStatus: "invalid character",
+		},
+		{
+			name: "config valid",
+			configMap: &corev1.ConfigMap{
+				Data: map[string]string{
+					configuration.RolloutConfigKey: string(cfgValidBytes),
+				},
+			},
+			wantCfg:    cfgValid,
+			wantStatus: InitSuccess,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewRolloutChecker(nil, tt.configMap, tt.needUnmarshal)
+			gotInitStatus := checker.InitStatus()
+			assert.True(t, strings.Contains(gotInitStatus, tt.wantStatus), "gotStatus:%s", gotInitStatus)
+			assert.Equal(t, tt.wantCfg, checker.cfg)
+		})
+	}
+}
+
+func Test_Rollout_ConfigContentsValid(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     configuration.RolloutCfg
+		wantErr bool
+	}{
+		{
+			name:    "empty config",
+			cfg:     configuration.RolloutCfg{},
+			wantErr: false,
+		},
+		{
+			name: "step percent out of range",
+			cfg: configuration.RolloutCfg{
+				StepPercents: []int64{0, 100},
+			},
+			wantErr: true,
+		},
+		{
+			name: "step percents not ascending",
+			cfg: configuration.RolloutCfg{
+				StepPercents: []int64{50, 20},
+			},
+			wantErr: true,
+		},
+		{
+			name: "step interval negative",
+			cfg: configuration.RolloutCfg{
+				StepIntervalSeconds: pointer.Int64(-1),
+			},
+			wantErr: true,
+		},
+		{
+			name: "canary node selector invalid",
+			cfg: configuration.RolloutCfg{
+				CanaryNodeSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{
+							Key:      "canary",
+							Operator: "unknown",
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "config valid",
+			cfg: configuration.RolloutCfg{
+				Enable: pointer.Bool(true),
+				CanaryNodeSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"canary": "true"},
+				},
+				StepPercents:                 []int64{10, 50, 100},
+				StepIntervalSeconds:          pointer.Int64(600),
+				MaxPodsEvictedPerHourPerNode: pointer.Int64(5),
+				MaxApplyErrorsPerNode:        pointer.Int64(0),
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := RolloutChecker{cfg: &tt.cfg}
+			gotErr := checker.ConfigParamValid()
+			assert.Equal(t, tt.wantErr, gotErr != nil, gotErr)
+		})
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+)
+
+// DeviceValidatingHandler handles Device
+type DeviceValidatingHandler struct {
+	Client client.Client
+
+	// Decoder decodes objects
+	Decoder *admission.Decoder
+}
+
+var _ admission.Handler = &DeviceValidatingHandler{}
+
+func shouldIgnoreIfNotDevice(req admission.Request) bool {
+	// Ignore all calls to sub resources or resources other than devices.
+	if len(req.AdmissionRequest.SubResource) != 0 ||
+		req.AdmissionRequest.Resource.Resource != "devices" {
+		return true
+	}
+	return false
+}
+
+// Handle handles admission requests.
+func (h *DeviceValidatingHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if shouldIgnoreIfNotDevice(req) {
+		return admission.ValidationResponse(true, "")
+	}
+
+	obj := &schedulingv1alpha1.Device{}
+	if err := h.Decoder.Decode(req, obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if err := validateDeviceSpec(&obj.Spec, field.NewPath("spec")).ToAggregate(); err != nil {
+		klog.V(4).Infof("Webhook denied Device %s, err: %v", obj.Name, err)
+		return admission.ValidationResponse(false, err.Error())
+	}
+	return admission.ValidationResponse(true, "")
+}
+
+func validateDeviceSpec(spec *schedulingv1alpha1.DeviceSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	uuids := sets.NewString()
+	minors := map[schedulingv1alpha1.DeviceType]sets.Int32{}
+	for i := range spec.Devices {
+		device := &spec.Devices[i]
+		devicePath := fldPath.Child("devices").Index(i)
+
+		switch device.Type {
+		case schedulingv1alpha1.GPU, schedulingv1alpha1.FPGA, schedulingv1alpha1.RDMA:
+		default:
+			allErrs = append(allErrs, field.NotSupported(devicePath.Child("type"), device.Type, []string{
+				string(schedulingv1alpha1.GPU), string(schedulingv1alpha1.FPGA), string(schedulingv1alpha1.RDMA),
+			}))
+		}
+
+		if device.UUID != "" {
+			if uuids.Has(device.UUID) {
+				allErrs = append(allErrs, field.Duplicate(devicePath.Child("id"), device.UUID))
+			}
+			uuids.Insert(device.UUID)
+		}
+
+		// the scheduler allocates the GPUs by the minors
+		if device.Minor == nil {
+			if device.Type == schedulingv1alpha1.GPU {
+				allErrs = append(allErrs, field.Required(devicePath.Child("minor"), "minor must be set for gpu"))
+			}
+		} else if *device.Minor < 0 {
+			allErrs = append(allErrs, field.Invalid(devicePath.Child("minor"), *device.Minor, "minor must be greater than or equal to 0"))
+		} else {
+			if minors[device.Type] == nil {
+				minors[device.Type] = sets.NewInt32()
+			}
+			if minors[device.Type].Has(*device.Minor) {
+				allErrs = append(allErrs, field.Duplicate(devicePath.Child("minor"), fmt.Sprintf("%s %d", device.Type, *device.Minor)))
+			}
+			minors[device.Type].Insert(*device.Minor)
+		}
+
+		for resourceName, quantity := range device.Resources {
+			if quantity.Sign() < 0 {
+				allErrs = append(allErrs, field.Invalid(devicePath.Child("resources").Key(string(resourceName)), quantity.String(), "must be greater than or equal to 0"))
+			}
+		}
+
+		vfMinors := sets.NewInt32()
+		for j, group := range device.VFGroups {
+			for k, vf := range group.VFs {
+				vfPath := devicePath.Child("vfGroups").Index(j).Child("vfs").Index(k).Child("minor")
+				if vf.Minor < 0 {
+					allErrs = append(allErrs, field.Invalid(vfPath, vf.Minor, "minor must be greater than or equal to 0"))
+				}
+				if vfMinors.Has(vf.Minor) {
+					allErrs = append(allErrs, field.Duplicate(vfPath, vf.Minor))
+				}
+				vfMinors.Insert(vf.Minor)
+			}
+		}
+	}
+	return allErrs
+}
+
+var _ inject.Client = &DeviceValidatingHandler{}
+
+// InjectClient injects the client into the DeviceValidatingHandler
+func (h *DeviceValidatingHandler) InjectClient(c client.Client) error {
+	h.Client = c
+	return nil
+}
+
+var _ admission.DecoderInjector = &DeviceValidatingHandler{}
+
+// InjectDecoder injects the decoder into the DeviceValidatingHandler
+func (h *DeviceValidatingHandler) InjectDecoder(d *admission.Decoder) error {
+	h.Decoder = d
+	return nil
+}
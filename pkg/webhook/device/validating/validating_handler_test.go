@@ -0,0 +1,148 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+)
+
+func makeTestHandler() *DeviceValidatingHandler {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = schedulingv1alpha1.AddToScheme(scheme)
+	decoder, _ := admission.NewDecoder(scheme)
+	handler := &DeviceValidatingHandler{}
+	_ = handler.InjectClient(fake.NewClientBuilder().WithScheme(scheme).Build())
+	_ = handler.InjectDecoder(decoder)
+	return handler
+}
+
+func TestDeviceValidatingHandler(t *testing.T) {
+	gpu := func(uuid string, minor *int32) schedulingv1alpha1.DeviceInfo {
+		return schedulingv1alpha1.DeviceInfo{
+			Type:   schedulingv1alpha1.GPU,
+			UUID:   uuid,
+			Minor:  minor,
+			Health: true,
+			Resources: corev1.ResourceList{
+				extension.ResourceGPUCore: resource.MustParse("100"),
+			},
+		}
+	}
+	tests := []struct {
+		name        string
+		devices     []schedulingv1alpha1.DeviceInfo
+		wantAllowed bool
+	}{
+		{
+			name:        "valid devices",
+			devices:     []schedulingv1alpha1.DeviceInfo{gpu("gpu-0", pointer.Int32(0)), gpu("gpu-1", pointer.Int32(1))},
+			wantAllowed: true,
+		},
+		{
+			name:    "unsupported type",
+			devices: []schedulingv1alpha1.DeviceInfo{{Type: "npu", Minor: pointer.Int32(0)}},
+		},
+		{
+			name:    "gpu without minor",
+			devices: []schedulingv1alpha1.DeviceInfo{gpu("gpu-0", nil)},
+		},
+		{
+			name:    "negative minor",
+			devices: []schedulingv1alpha1.DeviceInfo{gpu("gpu-0", pointer.Int32(-1))},
+		},
+		{
+			name:    "duplicate minor",
+			devices: []schedulingv1alpha1.DeviceInfo{gpu("gpu-0", pointer.Int32(0)), gpu("gpu-1", pointer.Int32(0))},
+		},
+		{
+			name:    "duplicate uuid",
+			devices: []schedulingv1alpha1.DeviceInfo{gpu("gpu-0", pointer.Int32(0)), gpu("gpu-0", pointer.Int32(1))},
+		},
+		{
+			name: "same minor of different types",
+			devices: []schedulingv1alpha1.DeviceInfo{
+				gpu("gpu-0", pointer.Int32(0)),
+				{Type: schedulingv1alpha1.RDMA, Minor: pointer.Int32(0)},
+			},
+			wantAllowed: true,
+		},
+		{
+			name: "negative resources",
+			devices: []schedulingv1alpha1.DeviceInfo{
+				{
+					Type:  schedulingv1alpha1.GPU,
+					Minor: pointer.Int32(0),
+					Resources: corev1.ResourceList{
+						extension.ResourceGPUMemory: resource.MustParse("-1Gi"),
+					},
+				},
+			},
+		},
+		{
+			name: "duplicate virtual functions",
+			devices: []schedulingv1alpha1.DeviceInfo{
+				{
+					Type:  schedulingv1alpha1.RDMA,
+					Minor: pointer.Int32(0),
+					VFGroups: []schedulingv1alpha1.VirtualFunctionGroup{
+						{VFs: []schedulingv1alpha1.VirtualFunction{{Minor: 1}}},
+						{VFs: []schedulingv1alpha1.VirtualFunction{{Minor: 1}}},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			device := &schedulingv1alpha1.Device{
+				ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+				Spec:       schedulingv1alpha1.DeviceSpec{Devices: tt.devices},
+			}
+			raw, err := json.Marshal(device)
+			assert.NoError(t, err)
+			resp := makeTestHandler().Handle(context.TODO(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Resource: metav1.GroupVersionResource{
+						Group:    schedulingv1alpha1.GroupVersion.Group,
+						Version:  schedulingv1alpha1.GroupVersion.Version,
+						Resource: "devices",
+					},
+					Operation: admissionv1.Create,
+					Object:    runtime.RawExtension{Raw: raw},
+				},
+			})
+			assert.Equal(t, tt.wantAllowed, resp.Allowed, resp.Result)
+		})
+	}
+}
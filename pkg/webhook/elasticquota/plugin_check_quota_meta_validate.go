@@ -108,6 +108,23 @@ func (c *QuotaMetaChecker) ValidatePod(ctx context.Context, req admission.Reques
 	return nil
 }
 
+// ValidatePodQuotaAdmission checks the requests of the created Pod against the max of its quota,
+// and returns the warnings if the Pod is admitted.
+func (c *QuotaMetaChecker) ValidatePodQuotaAdmission(ctx context.Context, req admission.Request) ([]string, error) {
+	if req.AdmissionRequest.Operation != v1.Create {
+		return nil, nil
+	}
+	pod := &corev1.Pod{}
+	if err := c.Decoder.DecodeRaw(req.Object, pod); err != nil {
+		return nil, err
+	}
+	// when pod.namespace is empty, using req.namespace
+	if pod.Namespace == "" {
+		pod.Namespace = req.Namespace
+	}
+	return c.QuotaTopo.ValidateQuotaAdmission(pod)
+}
+
 func (c *QuotaMetaChecker) GetQuotaTopologyInfo() *QuotaTopologySummary {
 	if c.QuotaTopo == nil {
 		return nil
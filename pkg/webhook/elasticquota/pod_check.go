@@ -19,6 +19,8 @@ package elasticquota
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -28,6 +30,7 @@ import (
 	"sigs.k8s.io/scheduler-plugins/pkg/apis/scheduling/v1alpha1"
 
 	"github.com/koordinator-sh/koordinator/apis/extension"
+	"github.com/koordinator-sh/koordinator/pkg/util"
 )
 
 // TODO If the parentQuotaGroup submits pods, the runtime will be calculated incorrectly.
@@ -53,6 +56,93 @@ func (qt *quotaTopology) ValidateUpdatePod(oldPod, newPod *corev1.Pod) error {
 	return qt.ValidateAddPod(newPod)
 }
 
+// ValidateQuotaAdmission checks whether the requests of the Pod would exceed the max of its quota and the ancestors
+// according to the admission policy of its quota, and returns the warnings if the Pod is admitted.
+func (qt *quotaTopology) ValidateQuotaAdmission(pod *corev1.Pod) ([]string, error) {
+	qt.lock.Lock()
+	quotaName := qt.getQuotaNameFromPodNoLock(pod)
+	var policy extension.QuotaAdmissionPolicy
+	var quotaChain []*QuotaInfo
+	if quotaInfo := qt.quotaInfoMap[quotaName]; quotaName != extension.DefaultQuotaName && quotaInfo != nil {
+		policy = quotaInfo.AdmissionPolicy
+	}
+	if policy != extension.QuotaAdmissionPolicyNone {
+		for name := quotaName; name != extension.RootQuotaName && len(quotaChain) <= len(qt.quotaInfoMap); {
+			quotaInfo, exist := qt.quotaInfoMap[name]
+			if !exist {
+				break
+			}
+			quotaChain = append(quotaChain, quotaInfo)
+			name = quotaInfo.ParentName
+		}
+	}
+	qt.lock.Unlock()
+	if len(quotaChain) == 0 {
+		return nil, nil
+	}
+
+	// only the quotas in the chain are fetched for the used resources
+	usedMap := make(map[string]corev1.ResourceList, len(quotaChain))
+	for _, quotaInfo := range quotaChain {
+		quota := &v1alpha1.ElasticQuota{}
+		err := qt.client.Get(context.TODO(), types.NamespacedName{Namespace: quotaInfo.Namespace, Name: quotaInfo.Name}, quota)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		usedMap[quotaInfo.Name] = quota.Status.Used
+	}
+
+	podRequest := util.GetPodRequest(pod)
+	var exceedMax, exceedRemaining []string
+	for _, quotaInfo := range quotaChain {
+		used := usedMap[quotaInfo.Name]
+		resourceNames := make([]string, 0, len(quotaInfo.CalculateInfo.Max))
+		for resourceName := range quotaInfo.CalculateInfo.Max {
+			resourceNames = append(resourceNames, string(resourceName))
+		}
+		sort.Strings(resourceNames)
+		for _, name := range resourceNames {
+			resourceName := corev1.ResourceName(name)
+			request, ok := podRequest[resourceName]
+			if !ok || request.IsZero() {
+				continue
+			}
+			maxQuantity := quotaInfo.CalculateInfo.Max[resourceName]
+			if request.Cmp(maxQuantity) > 0 {
+				exceedMax = append(exceedMax, fmt.Sprintf("quota %v %v request %v > max %v",
+					quotaInfo.Name, resourceName, request.String(), maxQuantity.String()))
+				continue
+			}
+			usedQuantity := used[resourceName]
+			total := usedQuantity.DeepCopy()
+			total.Add(request)
+			if total.Cmp(maxQuantity) > 0 {
+				exceedRemaining = append(exceedRemaining, fmt.Sprintf("quota %v %v used %v + request %v > max %v",
+					quotaInfo.Name, resourceName, usedQuantity.String(), request.String(), maxQuantity.String()))
+			}
+		}
+	}
+	if len(exceedMax) == 0 && len(exceedRemaining) == 0 {
+		return nil, nil
+	}
+
+	switch policy {
+	case extension.QuotaAdmissionPolicyReject:
+		return nil, fmt.Errorf("pod %v/%v exceeds the max of quota: %v", pod.Namespace, pod.Name,
+			strings.Join(append(exceedMax, exceedRemaining...), "; "))
+	case extension.QuotaAdmissionPolicyQueue:
+		if len(exceedMax) > 0 {
+			return nil, fmt.Errorf("pod %v/%v can never be scheduled in the quota: %v", pod.Namespace, pod.Name,
+				strings.Join(exceedMax, "; "))
+		}
+		return []string{fmt.Sprintf("pod will be pending until the quota is released: %v", strings.Join(exceedRemaining, "; "))}, nil
+	}
+	return []string{fmt.Sprintf("pod exceeds the max of quota: %v", strings.Join(append(exceedMax, exceedRemaining...), "; "))}, nil
+}
+
 func (qt *quotaTopology) getQuotaNameFromPodNoLock(pod *corev1.Pod) string {
 	quotaLabelName := GetQuotaName(pod, qt.client)
 	if _, exist := qt.quotaInfoMap[quotaLabelName]; !exist {
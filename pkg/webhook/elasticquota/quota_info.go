@@ -29,6 +29,9 @@ type QuotaInfo struct {
 	Name              string
 	ParentName        string
 	CalculateInfo     QuotaCalculateInfo
+	// Namespace and AdmissionPolicy are used by the pod admission to check the max of the quota.
+	Namespace       string
+	AdmissionPolicy extension.QuotaAdmissionPolicy
 }
 
 type QuotaCalculateInfo struct {
@@ -59,6 +62,8 @@ func NewQuotaInfoFromQuota(quota *v1alpha1.ElasticQuota) *QuotaInfo {
 	allowLentResource := extension.IsAllowLentResource(quota)
 
 	quotaInfo := NewQuotaInfo(isParent, allowLentResource, quota.Name, parentName)
+	quotaInfo.Namespace = quota.Namespace
+	quotaInfo.AdmissionPolicy = extension.GetQuotaAdmissionPolicy(quota)
 	quotaInfo.setMinQuotaNoLock(quota.Spec.Min)
 	quotaInfo.setMaxQuotaNoLock(quota.Spec.Max)
 	return quotaInfo
@@ -59,6 +59,13 @@ func (qt *quotaTopology) validateQuotaSelfItem(quota *v1alpha1.ElasticQuota) err
 			return fmt.Errorf("%v min :%v > max,%v", quota.Name, quota.Spec.Min, quota.Spec.Max)
 		}
 	}
+
+	switch policy := extension.GetQuotaAdmissionPolicy(quota); policy {
+	case extension.QuotaAdmissionPolicyNone, extension.QuotaAdmissionPolicyReject,
+		extension.QuotaAdmissionPolicyWarn, extension.QuotaAdmissionPolicyQueue:
+	default:
+		return fmt.Errorf("%v quota.Annotation[%v]'s value %v is not supported", quota.Name, extension.AnnotationAdmissionPolicy, policy)
+	}
 	return nil
 }
 
@@ -230,6 +237,9 @@ func quotaFieldsCopy(q *v1alpha1.ElasticQuota) v1alpha1.ElasticQuota {
 				extension.LabelQuotaParent:   q.Labels[extension.LabelQuotaParent],
 				extension.LabelQuotaIsParent: q.Labels[extension.LabelQuotaIsParent],
 			},
+			Annotations: map[string]string{
+				extension.AnnotationAdmissionPolicy: q.Annotations[extension.AnnotationAdmissionPolicy],
+			},
 		},
 		Spec: *q.Spec.DeepCopy(),
 	}
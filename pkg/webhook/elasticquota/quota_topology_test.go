@@ -90,6 +90,12 @@ func TestQuotaTopology_basicItemCheck(t *testing.T) {
 			quota: MakeQuota("temp").sharedWeight(MakeResourceList().CPU(-1).Mem(1048576).Obj()).Obj(),
 			err:   fmt.Errorf("%v quota.Annotation[%v]'s value < 0, in dimension :%v", "temp", extension.AnnotationSharedWeight, "[cpu]"),
 		},
+		{
+			name: "unsupported admission policy",
+			quota: MakeQuota("temp").Max(MakeResourceList().CPU(10).Obj()).
+				Annotations(map[string]string{extension.AnnotationAdmissionPolicy: "Drop"}).Obj(),
+			err: fmt.Errorf("%v quota.Annotation[%v]'s value %v is not supported", "temp", extension.AnnotationAdmissionPolicy, "Drop"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -502,6 +508,82 @@ func TestQuotaTopology_AddPod_UpdatePod(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestQuotaTopology_ValidateQuotaAdmission(t *testing.T) {
+	tests := []struct {
+		name         string
+		policy       extension.QuotaAdmissionPolicy
+		podRequest   v1.ResourceList
+		wantWarnings []string
+		wantErr      error
+	}{
+		{
+			name:       "no admission policy",
+			podRequest: MakeResourceList().CPU(100).Obj(),
+		},
+		{
+			name:       "reject pod fit in quota",
+			policy:     extension.QuotaAdmissionPolicyReject,
+			podRequest: MakeResourceList().CPU(10).Mem(100).Obj(),
+		},
+		{
+			name:       "reject pod exceeding remaining of parent",
+			policy:     extension.QuotaAdmissionPolicyReject,
+			podRequest: MakeResourceList().CPU(30).Obj(),
+			wantErr:    fmt.Errorf("pod test-ns/test-pod exceeds the max of quota: quota parent cpu used 80 + request 30 > max 100"),
+		},
+		{
+			name:       "warn pod exceeding max",
+			policy:     extension.QuotaAdmissionPolicyWarn,
+			podRequest: MakeResourceList().CPU(60).Obj(),
+			wantWarnings: []string{
+				"pod exceeds the max of quota: quota sub-1 cpu request 60 > max 50; quota parent cpu used 80 + request 60 > max 100",
+			},
+		},
+		{
+			name:       "queue pod exceeding remaining",
+			policy:     extension.QuotaAdmissionPolicyQueue,
+			podRequest: MakeResourceList().CPU(30).Obj(),
+			wantWarnings: []string{
+				"pod will be pending until the quota is released: quota parent cpu used 80 + request 30 > max 100",
+			},
+		},
+		{
+			name:       "queue pod never fit",
+			policy:     extension.QuotaAdmissionPolicyQueue,
+			podRequest: MakeResourceList().CPU(60).Obj(),
+			wantErr:    fmt.Errorf("pod test-ns/test-pod can never be scheduled in the quota: quota sub-1 cpu request 60 > max 50"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClientBuilder().Build()
+			v1alpha1.AddToScheme(client.Scheme())
+			qt := newFakeQuotaTopology()
+			qt.client = client
+
+			parent := MakeQuota("parent").Namespace("test-ns").IsParent(true).
+				Max(MakeResourceList().CPU(100).Mem(1000).Obj()).Obj()
+			parent.Status.Used = MakeResourceList().CPU(80).Obj()
+			sub := MakeQuota("sub-1").Namespace("test-ns").ParentName("parent").IsParent(false).
+				Max(MakeResourceList().CPU(50).Mem(1000).Obj()).
+				Annotations(map[string]string{extension.AnnotationAdmissionPolicy: string(tt.policy)}).Obj()
+			sub.Status.Used = MakeResourceList().CPU(10).Obj()
+			for _, eq := range []*v1alpha1.ElasticQuota{parent, sub} {
+				assert.NoError(t, client.Create(context.TODO(), eq))
+				qt.OnQuotaAdd(eq)
+			}
+
+			pod := MakePod("test-ns", "test-pod").Label(extension.LabelQuotaName, "sub-1").Obj()
+			pod.Spec.Containers = []v1.Container{
+				{Name: "main", Resources: v1.ResourceRequirements{Requests: tt.podRequest}},
+			}
+			warnings, err := qt.ValidateQuotaAdmission(pod)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantWarnings, warnings)
+		})
+	}
+}
+
 func TestQuotaTopology_getQuotaNameFromPod(t *testing.T) {
 	tests := []struct {
 		name              string
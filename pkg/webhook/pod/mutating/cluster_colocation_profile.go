@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/rand"
+	"strings"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -33,6 +34,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	configv1alpha1 "github.com/koordinator-sh/koordinator/apis/config/v1alpha1"
@@ -55,54 +57,41 @@ func (h *PodMutatingHandler) clusterColocationProfileMutatingPod(ctx context.Con
 		return nil
 	}
 
-	profileList := &configv1alpha1.ClusterColocationProfileList{}
-	err := h.Client.List(ctx, profileList, utilclient.DisableDeepCopy)
+	matchedProfiles, err := h.matchColocationProfiles(ctx, pod)
 	if err != nil {
 		return err
 	}
-
-	if len(profileList.Items) == 0 {
-		return nil
-	}
-
-	var matchedProfiles []*configv1alpha1.ClusterColocationProfile
-	for i := range profileList.Items {
-		profile := &profileList.Items[i]
-		if profile.Spec.NamespaceSelector != nil {
-			matched, err := h.matchNamespaceSelector(ctx, pod.Namespace, profile.Spec.NamespaceSelector)
-			if !matched && err == nil {
-				continue
-			}
-		}
-		if profile.Spec.Selector != nil {
-			matched, err := h.matchObjectSelector(pod, nil, profile.Spec.Selector)
-			if !matched && err == nil {
-				continue
-			}
-		}
-		matchedProfiles = append(matchedProfiles, profile)
-	}
 	if len(matchedProfiles) == 0 {
 		return nil
 	}
 	skipUpdateResourceFromProfile := false
 	for _, profile := range matchedProfiles {
-		if extension.ShouldSkipUpdateResource(profile) {
+		if extension.ShouldSkipUpdateResource(profile) && !profile.Spec.DryRun {
 			skipUpdateResourceFromProfile = true
 		}
 		skip, err := shouldSkipProfile(profile)
+		if err == nil && !skip {
+			if profile.Spec.DryRun {
+				err = h.dryRunMutateByColocationProfile(ctx, pod, profile)
+			} else {
+				err = h.doMutateByColocationProfile(ctx, pod, profile)
+			}
+		}
 		if err != nil {
+			profileStats.recordError(profile.Name, profile.Spec.DryRun, err)
+			// the dry-run profile never affects the Pod, its error is only recorded in the status of the profile
+			if profile.Spec.DryRun {
+				klog.V(4).Infof("failed to dry-run clusterColocationProfile %s on Pod %s/%s, err: %v", profile.Name, pod.Namespace, pod.Name, err)
+				continue
+			}
 			return err
 		}
 		if skip {
 			klog.V(4).Infof("skip mutate Pod %s/%s by clusterColocationProfile %s", pod.Namespace, pod.Name, profile.Name)
 			continue
 		}
-		err = h.doMutateByColocationProfile(ctx, pod, profile)
-		if err != nil {
-			return err
-		}
-		klog.V(4).Infof("mutate Pod %s/%s by clusterColocationProfile %s", pod.Namespace, pod.Name, profile.Name)
+		profileStats.recordMutation(profile.Name, profile.Spec.DryRun)
+		klog.V(4).Infof("mutate Pod %s/%s by clusterColocationProfile %s, dryRun %v", pod.Namespace, pod.Name, profile.Name, profile.Spec.DryRun)
 	}
 	if skipUpdateResourceFromProfile || utilfeature.DefaultFeatureGate.Enabled(features.ColocationProfileSkipMutatingResources) {
 		return nil
@@ -110,6 +99,142 @@ func (h *PodMutatingHandler) clusterColocationProfileMutatingPod(ctx context.Con
 	return h.mutatePodResourceSpec(pod)
 }
 
+// dryRunMutateByColocationProfile records the patch that the profile would apply to the Pod
+// in the annotation instead of mutating the Pod.
+func (h *PodMutatingHandler) dryRunMutateByColocationProfile(ctx context.Context, pod *corev1.Pod, profile *configv1alpha1.ClusterColocationProfile) error {
+	mutated := pod.DeepCopy()
+	if err := h.doMutateByColocationProfile(ctx, mutated, profile); err != nil {
+		return err
+	}
+	if !extension.ShouldSkipUpdateResource(profile) && !utilfeature.DefaultFeatureGate.Enabled(features.ColocationProfileSkipMutatingResources) {
+		if err := h.mutatePodResourceSpec(mutated); err != nil {
+			return err
+		}
+	}
+	patch, err := util.GeneratePodPatch(pod, mutated)
+	if err != nil {
+		return err
+	}
+
+	dryRunPatches := map[string]json.RawMessage{}
+	if value := pod.Annotations[extension.AnnotationColocationProfileDryRun]; value != "" {
+		if err = json.Unmarshal([]byte(value), &dryRunPatches); err != nil {
+			klog.V(4).Infof("failed to parse the dry-run annotation of Pod %s/%s, overwrite it, err: %v", pod.Namespace, pod.Name, err)
+			dryRunPatches = map[string]json.RawMessage{}
+		}
+	}
+	dryRunPatches[profile.Name] = patch
+	value, err := json.Marshal(dryRunPatches)
+	if err != nil {
+		return err
+	}
+	if pod.Annotations == nil {
+		pod.Annotations = make(map[string]string)
+	}
+	pod.Annotations[extension.AnnotationColocationProfileDryRun] = string(value)
+	return nil
+}
+
+// MutatePodTemplateByColocationProfiles mutates the pod template of a workload in the namespace by the matched
+// ClusterColocationProfiles and returns the names of the applied profiles.
+// Profiles with a Probability less than 100% are skipped since the decision must be made for each Pod,
+// and so are the dry-run profiles which are recorded when the Pods are created.
+func MutatePodTemplateByColocationProfiles(ctx context.Context, c client.Client, namespace string, template *corev1.PodTemplateSpec) ([]string, error) {
+	h := &PodMutatingHandler{Client: c}
+	pod := &corev1.Pod{
+		ObjectMeta: *template.ObjectMeta.DeepCopy(),
+		Spec:       *template.Spec.DeepCopy(),
+	}
+	pod.Namespace = namespace
+
+	matchedProfiles, err := h.matchColocationProfiles(ctx, pod)
+	if err != nil {
+		return nil, err
+	}
+	if len(matchedProfiles) == 0 {
+		return nil, nil
+	}
+
+	var appliedProfiles []string
+	skipUpdateResourceFromProfile := false
+	priorityClassMutated := false
+	for _, profile := range matchedProfiles {
+		if profile.Spec.DryRun {
+			klog.V(4).Infof("skip mutate pod template in namespace %s by dry-run clusterColocationProfile %s", namespace, profile.Name)
+			continue
+		}
+		if profile.Spec.Probability != nil {
+			percent, err := intstr.GetScaledValueFromIntOrPercent(profile.Spec.Probability, 100, false)
+			if err != nil {
+				return nil, err
+			}
+			if percent != 100 {
+				klog.V(4).Infof("skip mutate pod template in namespace %s by probabilistic clusterColocationProfile %s", namespace, profile.Name)
+				continue
+			}
+		}
+		if extension.ShouldSkipUpdateResource(profile) {
+			skipUpdateResourceFromProfile = true
+		}
+		if profile.Spec.PriorityClassName != "" {
+			priorityClassMutated = true
+		}
+		if err = h.doMutateByColocationProfile(ctx, pod, profile); err != nil {
+			return nil, err
+		}
+		appliedProfiles = append(appliedProfiles, profile.Name)
+	}
+	if len(appliedProfiles) == 0 {
+		return nil, nil
+	}
+	// the priority is resolved from the PriorityClassName by the apiserver when the Pods are created,
+	// it must not be persisted in the template since the value of the PriorityClass may change.
+	if priorityClassMutated {
+		pod.Spec.Priority = nil
+	}
+	if !skipUpdateResourceFromProfile && !utilfeature.DefaultFeatureGate.Enabled(features.ColocationProfileSkipMutatingResources) {
+		if err = h.mutatePodResourceSpec(pod); err != nil {
+			return nil, err
+		}
+	}
+
+	if pod.Annotations == nil {
+		pod.Annotations = make(map[string]string)
+	}
+	pod.Annotations[extension.AnnotationColocationProfile] = strings.Join(appliedProfiles, ",")
+	pod.Namespace = template.Namespace
+	template.ObjectMeta = pod.ObjectMeta
+	template.Spec = pod.Spec
+	return appliedProfiles, nil
+}
+
+func (h *PodMutatingHandler) matchColocationProfiles(ctx context.Context, pod *corev1.Pod) ([]*configv1alpha1.ClusterColocationProfile, error) {
+	profileList := &configv1alpha1.ClusterColocationProfileList{}
+	err := h.Client.List(ctx, profileList, utilclient.DisableDeepCopy)
+	if err != nil {
+		return nil, err
+	}
+
+	var matchedProfiles []*configv1alpha1.ClusterColocationProfile
+	for i := range profileList.Items {
+		profile := &profileList.Items[i]
+		if profile.Spec.NamespaceSelector != nil {
+			matched, err := h.matchNamespaceSelector(ctx, pod.Namespace, profile.Spec.NamespaceSelector)
+			if !matched && err == nil {
+				continue
+			}
+		}
+		if profile.Spec.Selector != nil {
+			matched, err := h.matchObjectSelector(pod, nil, profile.Spec.Selector)
+			if !matched && err == nil {
+				continue
+			}
+		}
+		matchedProfiles = append(matchedProfiles, profile)
+	}
+	return matchedProfiles, nil
+}
+
 func (h *PodMutatingHandler) matchNamespaceSelector(ctx context.Context, namespaceName string, namespaceSelector *metav1.LabelSelector) (bool, error) {
 	selector, err := util.GetFastLabelSelector(namespaceSelector)
 	if err != nil {
@@ -0,0 +1,289 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutating
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	configv1alpha1 "github.com/koordinator-sh/koordinator/apis/config/v1alpha1"
+	webhookutil "github.com/koordinator-sh/koordinator/pkg/webhook/util"
+)
+
+// +kubebuilder:rbac:groups=config.koordinator.sh,resources=clustercolocationprofiles/status,verbs=get;update;patch
+
+var (
+	// ColocationProfileStatusWindow is the statistic window of the ClusterColocationProfile status.
+	ColocationProfileStatusWindow = time.Minute
+	// colocationProfileReplicaExpiration is the duration after which the entry of a replica not reporting is removed.
+	colocationProfileReplicaExpiration = 10 * ColocationProfileStatusWindow
+
+	colocationProfilePodsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: "webhook",
+		Name:      "cluster_colocation_profile_pods_total",
+		Help:      "the count of pods handled by the ClusterColocationProfile",
+	}, []string{"profile", "dry_run", "status"})
+
+	profileStats = newProfileStatsRecorder()
+)
+
+func init() {
+	metrics.Registry.MustRegister(colocationProfilePodsTotal)
+}
+
+type colocationProfileStats struct {
+	mutatedPods      int64
+	dryRunPods       int64
+	lastMutationTime *metav1.Time
+	lastError        string
+}
+
+// profileStatsRecorder records the statistics of the ClusterColocationProfiles in the current window.
+type profileStatsRecorder struct {
+	lock  sync.Mutex
+	stats map[string]*colocationProfileStats
+}
+
+func newProfileStatsRecorder() *profileStatsRecorder {
+	return &profileStatsRecorder{
+		stats: map[string]*colocationProfileStats{},
+	}
+}
+
+func (r *profileStatsRecorder) getOrCreate(name string) *colocationProfileStats {
+	stats := r.stats[name]
+	if stats == nil {
+		stats = &colocationProfileStats{}
+		r.stats[name] = stats
+	}
+	return stats
+}
+
+func (r *profileStatsRecorder) recordMutation(name string, dryRun bool) {
+	colocationProfilePodsTotal.WithLabelValues(name, strconv.FormatBool(dryRun), "succeeded").Inc()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	stats := r.getOrCreate(name)
+	if dryRun {
+		stats.dryRunPods++
+	} else {
+		stats.mutatedPods++
+	}
+	now := metav1.Now()
+	stats.lastMutationTime = &now
+}
+
+func (r *profileStatsRecorder) recordError(name string, dryRun bool, err error) {
+	colocationProfilePodsTotal.WithLabelValues(name, strconv.FormatBool(dryRun), "failed").Inc()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.getOrCreate(name).lastError = err.Error()
+}
+
+// flush returns the statistics of the current window and starts a new one.
+func (r *profileStatsRecorder) flush() map[string]*colocationProfileStats {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	stats := r.stats
+	r.stats = map[string]*colocationProfileStats{}
+	return stats
+}
+
+// Runnables returns the loops reporting the statistics of the ClusterColocationProfiles into their status.
+// Every replica reports the Pods it handled into its own entry of the status, and the leader aggregates the entries,
+// so that the replicas never overwrite the statistics of each other.
+func (h *PodMutatingHandler) Runnables() []manager.Runnable {
+	return []manager.Runnable{
+		&colocationProfileStatusReporter{client: h.Client, replica: webhookutil.GetPodName()},
+		&colocationProfileStatusAggregator{client: h.Client},
+	}
+}
+
+// colocationProfileStatusReporter reports the statistics of the current replica into the status of the profiles.
+type colocationProfileStatusReporter struct {
+	client  client.Client
+	replica string
+}
+
+var _ manager.Runnable = &colocationProfileStatusReporter{}
+var _ manager.LeaderElectionRunnable = &colocationProfileStatusReporter{}
+
+// NeedLeaderElection returns false since every replica reports the Pods it handled.
+func (r *colocationProfileStatusReporter) NeedLeaderElection() bool {
+	return false
+}
+
+// Start reports the statistics of the replica periodically.
+func (r *colocationProfileStatusReporter) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, r.report, ColocationProfileStatusWindow)
+	return nil
+}
+
+func (r *colocationProfileStatusReporter) report(ctx context.Context) {
+	windowStats := profileStats.flush()
+
+	profileList := &configv1alpha1.ClusterColocationProfileList{}
+	if err := r.client.List(ctx, profileList); err != nil {
+		klog.Errorf("Failed to list ClusterColocationProfiles for status, err: %v", err)
+		return
+	}
+	now := metav1.Now()
+	for i := range profileList.Items {
+		profile := &profileList.Items[i]
+		var oldStatus *configv1alpha1.ColocationProfileReplicaStatus
+		if replicaStatus, ok := profile.Status.Replicas[r.replica]; ok {
+			oldStatus = &replicaStatus
+		}
+		newStatus := calculateReplicaStatus(oldStatus, windowStats[profile.Name], now)
+		if newStatus == nil {
+			continue
+		}
+		// the merge patch only carries the entry of the replica, the entries of the other replicas are kept
+		patch := client.MergeFrom(profile.DeepCopy())
+		if profile.Status.Replicas == nil {
+			profile.Status.Replicas = map[string]configv1alpha1.ColocationProfileReplicaStatus{}
+		}
+		profile.Status.Replicas[r.replica] = *newStatus
+		if err := r.client.Status().Patch(ctx, profile, patch); err != nil {
+			klog.Errorf("Failed to report status of ClusterColocationProfile %s, replica %s, err: %v", profile.Name, r.replica, err)
+		}
+	}
+}
+
+// calculateReplicaStatus returns the status of the replica in the window,
+// or nil if there is nothing to report since no Pod was handled in both the last and current windows.
+func calculateReplicaStatus(oldStatus *configv1alpha1.ColocationProfileReplicaStatus, stats *colocationProfileStats, now metav1.Time) *configv1alpha1.ColocationProfileReplicaStatus {
+	if stats == nil && (oldStatus == nil || (oldStatus.MutatedPods == 0 && oldStatus.DryRunPods == 0)) {
+		return nil
+	}
+	status := &configv1alpha1.ColocationProfileReplicaStatus{UpdateTime: now}
+	if oldStatus != nil {
+		status.LastMutationTime = oldStatus.LastMutationTime
+		status.LastError = oldStatus.LastError
+	}
+	if stats == nil {
+		return status
+	}
+	status.MutatedPods = stats.mutatedPods
+	status.DryRunPods = stats.dryRunPods
+	if stats.lastMutationTime != nil {
+		status.LastMutationTime = stats.lastMutationTime
+	}
+	if stats.lastError != "" {
+		status.LastError = stats.lastError
+	} else if stats.mutatedPods > 0 || stats.dryRunPods > 0 {
+		// the profile has been applied successfully in the window
+		status.LastError = ""
+	}
+	return status
+}
+
+// colocationProfileStatusAggregator aggregates the statistics reported by the replicas into the status of the profiles.
+type colocationProfileStatusAggregator struct {
+	client client.Client
+}
+
+var _ manager.Runnable = &colocationProfileStatusAggregator{}
+var _ manager.LeaderElectionRunnable = &colocationProfileStatusAggregator{}
+
+// NeedLeaderElection returns true since the aggregated status is written by the leader only.
+func (a *colocationProfileStatusAggregator) NeedLeaderElection() bool {
+	return true
+}
+
+// Start aggregates the statistics of the replicas periodically.
+func (a *colocationProfileStatusAggregator) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, a.aggregate, ColocationProfileStatusWindow)
+	return nil
+}
+
+func (a *colocationProfileStatusAggregator) aggregate(ctx context.Context) {
+	profileList := &configv1alpha1.ClusterColocationProfileList{}
+	if err := a.client.List(ctx, profileList); err != nil {
+		klog.Errorf("Failed to list ClusterColocationProfiles for status, err: %v", err)
+		return
+	}
+	now := time.Now()
+	for i := range profileList.Items {
+		profile := &profileList.Items[i]
+		newStatus := aggregateColocationProfileStatus(profile, now)
+		if reflect.DeepEqual(newStatus, &profile.Status) {
+			continue
+		}
+		patch := client.MergeFrom(profile.DeepCopy())
+		profile.Status = *newStatus
+		if err := a.client.Status().Patch(ctx, profile, patch); err != nil {
+			klog.Errorf("Failed to update status of ClusterColocationProfile %s, err: %v", profile.Name, err)
+		}
+	}
+}
+
+// aggregateColocationProfileStatus sums up the Pods reported by the replicas in the last window,
+// and removes the entries of the replicas which have not reported for a long time, e.g. the deleted ones.
+func aggregateColocationProfileStatus(profile *configv1alpha1.ClusterColocationProfile, now time.Time) *configv1alpha1.ClusterColocationProfileStatus {
+	status := profile.Status.DeepCopy()
+	status.ObservedGeneration = profile.Generation
+	status.MutatedPods = 0
+	status.DryRunPods = 0
+
+	var lastErrorTime time.Time
+	lastError := ""
+	for name, replicaStatus := range status.Replicas {
+		elapsed := now.Sub(replicaStatus.UpdateTime.Time)
+		if elapsed > colocationProfileReplicaExpiration {
+			delete(status.Replicas, name)
+			continue
+		}
+		if replicaStatus.LastMutationTime != nil &&
+			(status.LastMutationTime == nil || replicaStatus.LastMutationTime.After(status.LastMutationTime.Time)) {
+			status.LastMutationTime = replicaStatus.LastMutationTime.DeepCopy()
+		}
+		// the counts of the replica which missed the last window are out of date
+		if elapsed > 2*ColocationProfileStatusWindow {
+			continue
+		}
+		status.MutatedPods += replicaStatus.MutatedPods
+		status.DryRunPods += replicaStatus.DryRunPods
+		if replicaStatus.LastError != "" && replicaStatus.UpdateTime.After(lastErrorTime) {
+			lastError = replicaStatus.LastError
+			lastErrorTime = replicaStatus.UpdateTime.Time
+		}
+	}
+	if lastError != "" {
+		status.LastError = lastError
+	} else if status.MutatedPods > 0 || status.DryRunPods > 0 {
+		// the profile has been applied successfully in the window
+		status.LastError = ""
+	}
+	if len(status.Replicas) == 0 {
+		status.Replicas = nil
+	}
+	return status
+}
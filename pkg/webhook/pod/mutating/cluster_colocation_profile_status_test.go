@@ -0,0 +1,358 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutating
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	configv1alpha1 "github.com/koordinator-sh/koordinator/apis/config/v1alpha1"
+	"github.com/koordinator-sh/koordinator/apis/extension"
+)
+
+func TestClusterColocationProfileDryRun(t *testing.T) {
+	profileStats.flush()
+	defer profileStats.flush()
+
+	client := fake.NewClientBuilder().Build()
+	handler := &PodMutatingHandler{Client: client}
+	assert.NoError(t, client.Create(context.TODO(), &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "default"},
+	}))
+	assert.NoError(t, client.Create(context.TODO(), &schedulingv1.PriorityClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "koordinator-batch"},
+		Value:      extension.PriorityBatchValueMax,
+	}))
+	for _, name := range []string{"test-dry-run-profile-a", "test-dry-run-profile-b"} {
+		assert.NoError(t, client.Create(context.TODO(), &configv1alpha1.ClusterColocationProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: configv1alpha1.ClusterColocationProfileSpec{
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"koordinator-colocation-pod": "true"},
+				},
+				QoSClass:          string(extension.QoSBE),
+				PriorityClassName: "koordinator-batch",
+				DryRun:            true,
+			},
+		}))
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-pod-1",
+			Labels:    map[string]string{"koordinator-colocation-pod": "true"},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "test-container-a",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("1"),
+						},
+					},
+				},
+			},
+		},
+	}
+	original := pod.DeepCopy()
+
+	req := newAdmission(admissionv1.Create, runtime.RawExtension{}, runtime.RawExtension{}, "")
+	assert.NoError(t, handler.clusterColocationProfileMutatingPod(context.TODO(), req, pod))
+
+	// the Pod is not mutated except the dry-run annotation
+	dryRunValue := pod.Annotations[extension.AnnotationColocationProfileDryRun]
+	assert.NotEmpty(t, dryRunValue)
+	pod.Annotations = nil
+	assert.Equal(t, original, pod)
+
+	dryRunPatches := map[string]map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal([]byte(dryRunValue), &dryRunPatches))
+	assert.Len(t, dryRunPatches, 2)
+	patch := dryRunPatches["test-dry-run-profile-a"]
+	assert.Equal(t, map[string]interface{}{
+		"labels": map[string]interface{}{extension.LabelPodQoS: string(extension.QoSBE)},
+	}, patch["metadata"])
+	spec := patch["spec"].(map[string]interface{})
+	assert.Equal(t, "koordinator-batch", spec["priorityClassName"])
+	assert.Contains(t, spec["containers"].([]interface{})[0].(map[string]interface{})["resources"].(map[string]interface{})["requests"], string(extension.BatchCPU))
+
+	stats := profileStats.flush()
+	assert.Equal(t, int64(1), stats["test-dry-run-profile-a"].dryRunPods)
+	assert.Equal(t, int64(0), stats["test-dry-run-profile-a"].mutatedPods)
+	assert.NotNil(t, stats["test-dry-run-profile-a"].lastMutationTime)
+}
+
+func TestClusterColocationProfileDryRunError(t *testing.T) {
+	profileStats.flush()
+	defer profileStats.flush()
+
+	client := fake.NewClientBuilder().Build()
+	handler := &PodMutatingHandler{Client: client}
+	assert.NoError(t, client.Create(context.TODO(), &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "default"},
+	}))
+	assert.NoError(t, client.Create(context.TODO(), &configv1alpha1.ClusterColocationProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-dry-run-profile"},
+		Spec: configv1alpha1.ClusterColocationProfileSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"koordinator-colocation-pod": "true"},
+			},
+			PriorityClassName: "koordinator-batch",
+			DryRun:            true,
+		},
+	}))
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-pod-1",
+			Labels:    map[string]string{"koordinator-colocation-pod": "true"},
+		},
+	}
+	original := pod.DeepCopy()
+
+	// the missing PriorityClass fails the dry-run profile but not the admission
+	req := newAdmission(admissionv1.Create, runtime.RawExtension{}, runtime.RawExtension{}, "")
+	assert.NoError(t, handler.clusterColocationProfileMutatingPod(context.TODO(), req, pod))
+	assert.Equal(t, original, pod)
+
+	stats := profileStats.flush()
+	assert.Equal(t, int64(0), stats["test-dry-run-profile"].dryRunPods)
+	assert.NotEmpty(t, stats["test-dry-run-profile"].lastError)
+}
+
+func TestCalculateReplicaStatus(t *testing.T) {
+	lastTime := metav1.NewTime(time.Now().Add(-time.Hour))
+	now := metav1.Now()
+	tests := []struct {
+		name      string
+		oldStatus *configv1alpha1.ColocationProfileReplicaStatus
+		stats     *colocationProfileStats
+		want      *configv1alpha1.ColocationProfileReplicaStatus
+	}{
+		{
+			name: "nothing to report",
+		},
+		{
+			name: "nothing to report after an idle window",
+			oldStatus: &configv1alpha1.ColocationProfileReplicaStatus{
+				LastMutationTime: &lastTime,
+				UpdateTime:       lastTime,
+			},
+		},
+		{
+			name: "reset the counts of the last window",
+			oldStatus: &configv1alpha1.ColocationProfileReplicaStatus{
+				MutatedPods:      3,
+				LastMutationTime: &lastTime,
+				LastError:        "failed",
+				UpdateTime:       lastTime,
+			},
+			want: &configv1alpha1.ColocationProfileReplicaStatus{
+				LastMutationTime: &lastTime,
+				LastError:        "failed",
+				UpdateTime:       now,
+			},
+		},
+		{
+			name: "pods mutated in the window",
+			oldStatus: &configv1alpha1.ColocationProfileReplicaStatus{
+				LastError:  "failed",
+				UpdateTime: lastTime,
+			},
+			stats: &colocationProfileStats{
+				mutatedPods:      2,
+				dryRunPods:       1,
+				lastMutationTime: &now,
+			},
+			want: &configv1alpha1.ColocationProfileReplicaStatus{
+				MutatedPods:      2,
+				DryRunPods:       1,
+				LastMutationTime: &now,
+				UpdateTime:       now,
+			},
+		},
+		{
+			name: "failed in the window",
+			stats: &colocationProfileStats{
+				mutatedPods:      1,
+				lastMutationTime: &now,
+				lastError:        "priorityclasses.scheduling.k8s.io \"koordinator-batch\" not found",
+			},
+			want: &configv1alpha1.ColocationProfileReplicaStatus{
+				MutatedPods:      1,
+				LastMutationTime: &now,
+				LastError:        "priorityclasses.scheduling.k8s.io \"koordinator-batch\" not found",
+				UpdateTime:       now,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := calculateReplicaStatus(tt.oldStatus, tt.stats, now)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAggregateColocationProfileStatus(t *testing.T) {
+	now := time.Now()
+	earlier := metav1.NewTime(now.Add(-30 * time.Second))
+	latest := metav1.NewTime(now.Add(-10 * time.Second))
+	missed := metav1.NewTime(now.Add(-3 * ColocationProfileStatusWindow))
+	expired := metav1.NewTime(now.Add(-2 * colocationProfileReplicaExpiration))
+	tests := []struct {
+		name    string
+		profile *configv1alpha1.ClusterColocationProfile
+		want    *configv1alpha1.ClusterColocationProfileStatus
+	}{
+		{
+			name: "no replicas reported",
+			profile: &configv1alpha1.ClusterColocationProfile{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-profile", Generation: 2},
+				Status: configv1alpha1.ClusterColocationProfileStatus{
+					ObservedGeneration: 1,
+					MutatedPods:        3,
+					LastMutationTime:   &earlier,
+					LastError:          "failed",
+				},
+			},
+			want: &configv1alpha1.ClusterColocationProfileStatus{
+				ObservedGeneration: 2,
+				LastMutationTime:   &earlier,
+				LastError:          "failed",
+			},
+		},
+		{
+			name: "sum up the replicas",
+			profile: &configv1alpha1.ClusterColocationProfile{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-profile", Generation: 1},
+				Status: configv1alpha1.ClusterColocationProfileStatus{
+					LastError: "failed",
+					Replicas: map[string]configv1alpha1.ColocationProfileReplicaStatus{
+						"replica-a": {MutatedPods: 2, LastMutationTime: &earlier, UpdateTime: latest},
+						"replica-b": {MutatedPods: 1, DryRunPods: 1, LastMutationTime: &latest, UpdateTime: latest},
+						"replica-c": {MutatedPods: 5, LastMutationTime: &missed, UpdateTime: missed},
+						"replica-d": {MutatedPods: 7, UpdateTime: expired},
+					},
+				},
+			},
+			want: &configv1alpha1.ClusterColocationProfileStatus{
+				ObservedGeneration: 1,
+				MutatedPods:        3,
+				DryRunPods:         1,
+				LastMutationTime:   &latest,
+				Replicas: map[string]configv1alpha1.ColocationProfileReplicaStatus{
+					"replica-a": {MutatedPods: 2, LastMutationTime: &earlier, UpdateTime: latest},
+					"replica-b": {MutatedPods: 1, DryRunPods: 1, LastMutationTime: &latest, UpdateTime: latest},
+					"replica-c": {MutatedPods: 5, LastMutationTime: &missed, UpdateTime: missed},
+				},
+			},
+		},
+		{
+			name: "the latest error of the replicas",
+			profile: &configv1alpha1.ClusterColocationProfile{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-profile", Generation: 1},
+				Status: configv1alpha1.ClusterColocationProfileStatus{
+					Replicas: map[string]configv1alpha1.ColocationProfileReplicaStatus{
+						"replica-a": {MutatedPods: 1, LastError: "earlier error", UpdateTime: earlier},
+						"replica-b": {LastError: "latest error", UpdateTime: latest},
+					},
+				},
+			},
+			want: &configv1alpha1.ClusterColocationProfileStatus{
+				ObservedGeneration: 1,
+				MutatedPods:        1,
+				LastError:          "latest error",
+				Replicas: map[string]configv1alpha1.ColocationProfileReplicaStatus{
+					"replica-a": {MutatedPods: 1, LastError: "earlier error", UpdateTime: earlier},
+					"replica-b": {LastError: "latest error", UpdateTime: latest},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := aggregateColocationProfileStatus(tt.profile, now)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUpdateColocationProfileStatus(t *testing.T) {
+	profileStats.flush()
+	defer profileStats.flush()
+
+	profiles := []*configv1alpha1.ClusterColocationProfile{
+		{ObjectMeta: metav1.ObjectMeta{Name: "test-profile-a", Generation: 1}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "test-profile-b", Generation: 1}},
+	}
+	builder := fake.NewClientBuilder().WithScheme(scheme.Scheme)
+	for _, profile := range profiles {
+		builder = builder.WithObjects(profile)
+	}
+	client := builder.Build()
+	reporterA := &colocationProfileStatusReporter{client: client, replica: "replica-a"}
+	reporterB := &colocationProfileStatusReporter{client: client, replica: "replica-b"}
+	aggregator := &colocationProfileStatusAggregator{client: client}
+
+	// the replicas report the pods they handled without overwriting each other
+	profileStats.recordMutation("test-profile-a", false)
+	profileStats.recordMutation("test-profile-a", false)
+	profileStats.recordError("test-profile-b", false, errors.New("expected error"))
+	reporterA.report(context.TODO())
+	profileStats.recordMutation("test-profile-a", false)
+	reporterB.report(context.TODO())
+	aggregator.aggregate(context.TODO())
+
+	got := &configv1alpha1.ClusterColocationProfile{}
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Name: "test-profile-a"}, got))
+	assert.Equal(t, int64(1), got.Status.ObservedGeneration)
+	assert.Equal(t, int64(3), got.Status.MutatedPods)
+	assert.NotNil(t, got.Status.LastMutationTime)
+	assert.Empty(t, got.Status.LastError)
+	assert.Len(t, got.Status.Replicas, 2)
+
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Name: "test-profile-b"}, got))
+	assert.Equal(t, int64(0), got.Status.MutatedPods)
+	assert.Nil(t, got.Status.LastMutationTime)
+	assert.Equal(t, "expected error", got.Status.LastError)
+
+	// the counts are reset in the next window
+	reporterA.report(context.TODO())
+	reporterB.report(context.TODO())
+	aggregator.aggregate(context.TODO())
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Name: "test-profile-a"}, got))
+	assert.Equal(t, int64(0), got.Status.MutatedPods)
+	assert.NotNil(t, got.Status.LastMutationTime)
+}
@@ -48,7 +48,7 @@ func shouldIgnoreIfNotPod(req admission.Request) bool {
 	return false
 }
 
-func (h *PodValidatingHandler) validatingPodFn(ctx context.Context, req admission.Request) (allowed bool, reason string, err error) {
+func (h *PodValidatingHandler) validatingPodFn(ctx context.Context, req admission.Request) (allowed bool, reason string, warnings []string, err error) {
 	allowed = true
 	if shouldIgnoreIfNotPod(req) {
 		return
@@ -62,7 +62,10 @@ func (h *PodValidatingHandler) validatingPodFn(ctx context.Context, req admissio
 	if err == nil {
 		plugin := elasticquota.NewPlugin(h.Decoder, h.Client)
 		if err = plugin.ValidatePod(ctx, req); err != nil {
-			return false, "", err
+			return false, "", nil, err
+		}
+		if warnings, err = plugin.ValidatePodQuotaAdmission(ctx, req); err != nil {
+			return false, "", nil, err
 		}
 	}
 	return
@@ -72,11 +75,11 @@ var _ admission.Handler = &PodValidatingHandler{}
 
 // Handle handles admission requests.
 func (h *PodValidatingHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
-	allowed, reason, err := h.validatingPodFn(ctx, req)
+	allowed, reason, warnings, err := h.validatingPodFn(ctx, req)
 	if err != nil {
 		return admission.Errored(http.StatusBadRequest, err)
 	}
-	return admission.ValidationResponse(allowed, reason)
+	return admission.ValidationResponse(allowed, reason).WithWarnings(warnings...)
 }
 
 var _ inject.Client = &PodValidatingHandler{}
@@ -0,0 +1,113 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutating
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	deschedulerconfigv1alpha2 "github.com/koordinator-sh/koordinator/pkg/descheduler/apis/config/v1alpha2"
+)
+
+// PodMigrationJobMutatingHandler handles PodMigrationJob
+type PodMigrationJobMutatingHandler struct {
+	Client client.Client
+
+	// Decoder decodes objects
+	Decoder *admission.Decoder
+}
+
+var _ admission.Handler = &PodMigrationJobMutatingHandler{}
+
+func shouldIgnoreIfNotPodMigrationJob(req admission.Request) bool {
+	// Ignore all calls to sub resources or resources other than podmigrationjobs.
+	if len(req.AdmissionRequest.SubResource) != 0 ||
+		req.AdmissionRequest.Resource.Resource != "podmigrationjobs" {
+		return true
+	}
+	return false
+}
+
+// Handle handles admission requests.
+func (h *PodMigrationJobMutatingHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if shouldIgnoreIfNotPodMigrationJob(req) {
+		return admission.Allowed("")
+	}
+
+	obj := &schedulingv1alpha1.PodMigrationJob{}
+	if err := h.Decoder.Decode(req, obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	clone := obj.DeepCopy()
+
+	setDefaultsPodMigrationJob(obj)
+
+	if reflect.DeepEqual(obj, clone) {
+		return admission.Allowed("")
+	}
+	marshaled, err := json.Marshal(obj)
+	if err != nil {
+		klog.Errorf("Failed to marshal mutated PodMigrationJob %s, err: %v", obj.Name, err)
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.AdmissionRequest.Object.Raw, marshaled)
+}
+
+// setDefaultsPodMigrationJob sets the mode and TTL of the job as the defaults of the migration controller,
+// so that the job behaves the same no matter which descheduler processes it.
+func setDefaultsPodMigrationJob(job *schedulingv1alpha1.PodMigrationJob) {
+	controllerArgs := &deschedulerconfigv1alpha2.MigrationControllerArgs{}
+	deschedulerconfigv1alpha2.SetDefaults_MigrationControllerArgs(controllerArgs)
+	if job.Spec.Mode == "" {
+		job.Spec.Mode = schedulingv1alpha1.PodMigrationJobMode(controllerArgs.DefaultJobMode)
+	}
+	if job.Spec.TTL == nil {
+		job.Spec.TTL = controllerArgs.DefaultJobTTL
+	}
+	if podRef := job.Spec.PodRef; podRef != nil {
+		if podRef.Kind == "" {
+			podRef.Kind = "Pod"
+		}
+		if podRef.APIVersion == "" {
+			podRef.APIVersion = "v1"
+		}
+	}
+}
+
+var _ inject.Client = &PodMigrationJobMutatingHandler{}
+
+// InjectClient injects the client into the PodMigrationJobMutatingHandler
+func (h *PodMigrationJobMutatingHandler) InjectClient(c client.Client) error {
+	h.Client = c
+	return nil
+}
+
+var _ admission.DecoderInjector = &PodMigrationJobMutatingHandler{}
+
+// InjectDecoder injects the decoder into the PodMigrationJobMutatingHandler
+func (h *PodMigrationJobMutatingHandler) InjectDecoder(d *admission.Decoder) error {
+	h.Decoder = d
+	return nil
+}
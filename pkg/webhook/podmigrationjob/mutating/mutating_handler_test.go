@@ -0,0 +1,110 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutating
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+)
+
+func makeTestHandler() *PodMigrationJobMutatingHandler {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = schedulingv1alpha1.AddToScheme(scheme)
+	decoder, _ := admission.NewDecoder(scheme)
+	handler := &PodMigrationJobMutatingHandler{}
+	_ = handler.InjectClient(fake.NewClientBuilder().WithScheme(scheme).Build())
+	_ = handler.InjectDecoder(decoder)
+	return handler
+}
+
+func TestPodMigrationJobMutatingHandler(t *testing.T) {
+	tests := []struct {
+		name        string
+		job         *schedulingv1alpha1.PodMigrationJob
+		wantPatched bool
+		wantSpec    schedulingv1alpha1.PodMigrationJobSpec
+	}{
+		{
+			name: "set defaults of the migration controller",
+			job: &schedulingv1alpha1.PodMigrationJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "job1"},
+				Spec: schedulingv1alpha1.PodMigrationJobSpec{
+					PodRef: &corev1.ObjectReference{Namespace: "default", Name: "pod1"},
+				},
+			},
+			wantPatched: true,
+			wantSpec: schedulingv1alpha1.PodMigrationJobSpec{
+				PodRef: &corev1.ObjectReference{Kind: "Pod", APIVersion: "v1", Namespace: "default", Name: "pod1"},
+				Mode:   schedulingv1alpha1.PodMigrationJobModeReservationFirst,
+				TTL:    &metav1.Duration{Duration: 5 * time.Minute},
+			},
+		},
+		{
+			name: "keep the configured spec",
+			job: &schedulingv1alpha1.PodMigrationJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "job1"},
+				Spec: schedulingv1alpha1.PodMigrationJobSpec{
+					PodRef: &corev1.ObjectReference{Kind: "Pod", APIVersion: "v1", Namespace: "default", Name: "pod1"},
+					Mode:   schedulingv1alpha1.PodMigrationJobModeEvictionDirectly,
+					TTL:    &metav1.Duration{Duration: time.Hour},
+				},
+			},
+			wantSpec: schedulingv1alpha1.PodMigrationJobSpec{
+				PodRef: &corev1.ObjectReference{Kind: "Pod", APIVersion: "v1", Namespace: "default", Name: "pod1"},
+				Mode:   schedulingv1alpha1.PodMigrationJobModeEvictionDirectly,
+				TTL:    &metav1.Duration{Duration: time.Hour},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := json.Marshal(tt.job)
+			assert.NoError(t, err)
+			resp := makeTestHandler().Handle(context.TODO(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Resource: metav1.GroupVersionResource{
+						Group:    schedulingv1alpha1.GroupVersion.Group,
+						Version:  schedulingv1alpha1.GroupVersion.Version,
+						Resource: "podmigrationjobs",
+					},
+					Operation: admissionv1.Create,
+					Object:    runtime.RawExtension{Raw: raw},
+				},
+			})
+			assert.True(t, resp.Allowed)
+			assert.Equal(t, tt.wantPatched, len(resp.Patches) > 0)
+
+			job := tt.job.DeepCopy()
+			setDefaultsPodMigrationJob(job)
+			assert.Equal(t, tt.wantSpec, job.Spec)
+		})
+	}
+}
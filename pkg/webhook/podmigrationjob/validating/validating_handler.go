@@ -0,0 +1,225 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+)
+
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+
+// PodMigrationJobValidatingHandler handles PodMigrationJob
+type PodMigrationJobValidatingHandler struct {
+	Client client.Client
+
+	// Decoder decodes objects
+	Decoder *admission.Decoder
+}
+
+var _ admission.Handler = &PodMigrationJobValidatingHandler{}
+
+func shouldIgnoreIfNotPodMigrationJob(req admission.Request) bool {
+	// Ignore all calls to sub resources or resources other than podmigrationjobs.
+	if len(req.AdmissionRequest.SubResource) != 0 ||
+		req.AdmissionRequest.Resource.Resource != "podmigrationjobs" {
+		return true
+	}
+	return false
+}
+
+// Handle handles admission requests.
+func (h *PodMigrationJobValidatingHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if shouldIgnoreIfNotPodMigrationJob(req) {
+		return admission.ValidationResponse(true, "")
+	}
+
+	obj := &schedulingv1alpha1.PodMigrationJob{}
+	if err := h.Decoder.Decode(req, obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	var allErrs field.ErrorList
+	switch req.Operation {
+	case admissionv1.Create:
+		allErrs = append(allErrs, validatePodMigrationJobSpec(&obj.Spec, field.NewPath("spec"))...)
+		// the migration controller fills the reservationRef of the job created with the template later
+		if obj.Spec.ReservationOptions != nil && obj.Spec.ReservationOptions.ReservationRef != nil && obj.Spec.ReservationOptions.Template != nil {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "reservationOptions"), "reservationRef and template are mutually exclusive"))
+		}
+		podErrs, err := h.validatePodExists(ctx, obj)
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		allErrs = append(allErrs, podErrs...)
+	case admissionv1.Update:
+		oldObj := &schedulingv1alpha1.PodMigrationJob{}
+		if err := h.Decoder.DecodeRaw(req.OldObject, oldObj); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		allErrs = append(allErrs, validatePodMigrationJobUpdate(oldObj, obj)...)
+	}
+
+	if err := allErrs.ToAggregate(); err != nil {
+		klog.V(4).Infof("Webhook denied PodMigrationJob %s, err: %v", obj.Name, err)
+		return admission.ValidationResponse(false, err.Error())
+	}
+	return admission.ValidationResponse(true, "")
+}
+
+func validatePodMigrationJobSpec(spec *schedulingv1alpha1.PodMigrationJobSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	allErrs = append(allErrs, validatePodRef(spec.PodRef, fldPath.Child("podRef"))...)
+	allErrs = append(allErrs, validateTTL(spec.TTL, fldPath.Child("ttl"))...)
+	allErrs = append(allErrs, validateModeAndReservationOptions(spec, fldPath)...)
+	return allErrs
+}
+
+func validatePodRef(podRef *corev1.ObjectReference, fldPath *field.Path) field.ErrorList {
+	if podRef == nil {
+		return field.ErrorList{field.Required(fldPath, "podRef must be set")}
+	}
+	var allErrs field.ErrorList
+	// the migration controller resolves the Pod by the namespace and name, the uid is optional
+	if podRef.Namespace == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("namespace"), "namespace must be set"))
+	}
+	if podRef.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), "name must be set"))
+	}
+	return allErrs
+}
+
+func validateTTL(ttl *metav1.Duration, fldPath *field.Path) field.ErrorList {
+	if ttl != nil && ttl.Duration < 0 {
+		return field.ErrorList{field.Invalid(fldPath, ttl.Duration.String(), "ttl must be greater than or equal to 0")}
+	}
+	return nil
+}
+
+func validateModeAndReservationOptions(spec *schedulingv1alpha1.PodMigrationJobSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	switch spec.Mode {
+	case "", schedulingv1alpha1.PodMigrationJobModeReservationFirst:
+	case schedulingv1alpha1.PodMigrationJobModeEvictionDirectly:
+		if spec.ReservationOptions != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("reservationOptions"),
+				fmt.Sprintf("reservationOptions can not be used in mode %s", spec.Mode)))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("mode"), spec.Mode, []string{
+			string(schedulingv1alpha1.PodMigrationJobModeReservationFirst),
+			string(schedulingv1alpha1.PodMigrationJobModeEvictionDirectly),
+		}))
+	}
+	return allErrs
+}
+
+// validatePodMigrationJobUpdate validates only the fields changed by the update,
+// so that the jobs admitted before are not blocked by the rules added later.
+func validatePodMigrationJobUpdate(oldObj, newObj *schedulingv1alpha1.PodMigrationJob) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+	oldSpec, newSpec := &oldObj.Spec, &newObj.Spec
+	if !apiequality.Semantic.DeepEqual(newSpec.PodRef, oldSpec.PodRef) {
+		allErrs = append(allErrs, validatePodRef(newSpec.PodRef, specPath.Child("podRef"))...)
+		allErrs = append(allErrs, validatePodRefUpdate(oldSpec.PodRef, newSpec.PodRef, specPath.Child("podRef"))...)
+	}
+	if !apiequality.Semantic.DeepEqual(newSpec.TTL, oldSpec.TTL) {
+		allErrs = append(allErrs, validateTTL(newSpec.TTL, specPath.Child("ttl"))...)
+	}
+	if !apiequality.Semantic.DeepEqual(newSpec.ReservationOptions, oldSpec.ReservationOptions) {
+		allErrs = append(allErrs, validateModeAndReservationOptions(newSpec, specPath)...)
+	}
+	allErrs = append(allErrs, apivalidation.ValidateImmutableField(newSpec.Mode, oldSpec.Mode, specPath.Child("mode"))...)
+	return allErrs
+}
+
+// validatePodRefUpdate allows the fields of podRef to be filled, e.g. the uid resolved by the migration controller,
+// but rejects the changes of the fields already set.
+func validatePodRefUpdate(oldRef, newRef *corev1.ObjectReference, fldPath *field.Path) field.ErrorList {
+	if oldRef == nil {
+		return nil
+	}
+	if newRef == nil {
+		return field.ErrorList{field.Forbidden(fldPath, "podRef can not be removed")}
+	}
+	var allErrs field.ErrorList
+	setOnce := func(oldValue, newValue string, fldPath *field.Path) {
+		if oldValue != "" && oldValue != newValue {
+			allErrs = append(allErrs, field.Invalid(fldPath, newValue, apivalidation.FieldImmutableErrorMsg))
+		}
+	}
+	setOnce(oldRef.Kind, newRef.Kind, fldPath.Child("kind"))
+	setOnce(oldRef.APIVersion, newRef.APIVersion, fldPath.Child("apiVersion"))
+	setOnce(oldRef.Namespace, newRef.Namespace, fldPath.Child("namespace"))
+	setOnce(oldRef.Name, newRef.Name, fldPath.Child("name"))
+	setOnce(string(oldRef.UID), string(newRef.UID), fldPath.Child("uid"))
+	return allErrs
+}
+
+// validatePodExists checks the Pod referenced by the job exists, the job can never succeed otherwise.
+func (h *PodMigrationJobValidatingHandler) validatePodExists(ctx context.Context, job *schedulingv1alpha1.PodMigrationJob) (field.ErrorList, error) {
+	podRef := job.Spec.PodRef
+	if podRef == nil || podRef.Name == "" || podRef.Namespace == "" {
+		return nil, nil
+	}
+	pod := &corev1.Pod{}
+	err := h.Client.Get(ctx, types.NamespacedName{Namespace: podRef.Namespace, Name: podRef.Name}, pod)
+	if errors.IsNotFound(err) {
+		return field.ErrorList{field.NotFound(field.NewPath("spec", "podRef"), fmt.Sprintf("%s/%s", podRef.Namespace, podRef.Name))}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod %s/%s, err: %v", podRef.Namespace, podRef.Name, err)
+	}
+	if podRef.UID != "" && podRef.UID != pod.UID {
+		return field.ErrorList{field.Invalid(field.NewPath("spec", "podRef", "uid"), podRef.UID, "the uid does not match the pod")}, nil
+	}
+	return nil, nil
+}
+
+var _ inject.Client = &PodMigrationJobValidatingHandler{}
+
+// InjectClient injects the client into the PodMigrationJobValidatingHandler
+func (h *PodMigrationJobValidatingHandler) InjectClient(c client.Client) error {
+	h.Client = c
+	return nil
+}
+
+var _ admission.DecoderInjector = &PodMigrationJobValidatingHandler{}
+
+// InjectDecoder injects the decoder into the PodMigrationJobValidatingHandler
+func (h *PodMigrationJobValidatingHandler) InjectDecoder(d *admission.Decoder) error {
+	h.Decoder = d
+	return nil
+}
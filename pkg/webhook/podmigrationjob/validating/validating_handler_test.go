@@ -0,0 +1,235 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+)
+
+func makeTestHandler(objs ...runtime.Object) *PodMigrationJobValidatingHandler {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = schedulingv1alpha1.AddToScheme(scheme)
+	decoder, _ := admission.NewDecoder(scheme)
+	handler := &PodMigrationJobValidatingHandler{}
+	_ = handler.InjectClient(fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objs...).Build())
+	_ = handler.InjectDecoder(decoder)
+	return handler
+}
+
+func newTestPodMigrationJob() *schedulingv1alpha1.PodMigrationJob {
+	return &schedulingv1alpha1.PodMigrationJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "job1"},
+		Spec: schedulingv1alpha1.PodMigrationJobSpec{
+			PodRef: &corev1.ObjectReference{Namespace: "default", Name: "pod1", UID: "pod1-uid"},
+			Mode:   schedulingv1alpha1.PodMigrationJobModeReservationFirst,
+		},
+	}
+}
+
+func TestPodMigrationJobValidatingHandler(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod1", UID: "pod1-uid"}}
+	tests := []struct {
+		name        string
+		operation   admissionv1.Operation
+		modifyOld   func(job *schedulingv1alpha1.PodMigrationJob)
+		modify      func(job *schedulingv1alpha1.PodMigrationJob)
+		wantAllowed bool
+	}{
+		{
+			name:        "valid job",
+			operation:   admissionv1.Create,
+			wantAllowed: true,
+		},
+		{
+			name:      "missing podRef",
+			operation: admissionv1.Create,
+			modify: func(job *schedulingv1alpha1.PodMigrationJob) {
+				job.Spec.PodRef = nil
+			},
+		},
+		{
+			name:      "podRef with the uid only",
+			operation: admissionv1.Create,
+			modify: func(job *schedulingv1alpha1.PodMigrationJob) {
+				job.Spec.PodRef = &corev1.ObjectReference{UID: "pod1-uid"}
+			},
+		},
+		{
+			name:      "podRef without namespace",
+			operation: admissionv1.Create,
+			modify: func(job *schedulingv1alpha1.PodMigrationJob) {
+				job.Spec.PodRef.Namespace = ""
+			},
+		},
+		{
+			name:      "pod not found",
+			operation: admissionv1.Create,
+			modify: func(job *schedulingv1alpha1.PodMigrationJob) {
+				job.Spec.PodRef.Name = "pod2"
+				job.Spec.PodRef.UID = ""
+			},
+		},
+		{
+			name:      "pod uid mismatched",
+			operation: admissionv1.Create,
+			modify: func(job *schedulingv1alpha1.PodMigrationJob) {
+				job.Spec.PodRef.UID = "other-uid"
+			},
+		},
+		{
+			name:      "negative ttl",
+			operation: admissionv1.Create,
+			modify: func(job *schedulingv1alpha1.PodMigrationJob) {
+				job.Spec.TTL = &metav1.Duration{Duration: -time.Minute}
+			},
+		},
+		{
+			name:      "unsupported mode",
+			operation: admissionv1.Create,
+			modify: func(job *schedulingv1alpha1.PodMigrationJob) {
+				job.Spec.Mode = "Unknown"
+			},
+		},
+		{
+			name:      "reservation options with evict directly mode",
+			operation: admissionv1.Create,
+			modify: func(job *schedulingv1alpha1.PodMigrationJob) {
+				job.Spec.Mode = schedulingv1alpha1.PodMigrationJobModeEvictionDirectly
+				job.Spec.ReservationOptions = &schedulingv1alpha1.PodMigrateReservationOptions{
+					Template: &schedulingv1alpha1.ReservationTemplateSpec{},
+				}
+			},
+		},
+		{
+			name:      "both reservationRef and template",
+			operation: admissionv1.Create,
+			modify: func(job *schedulingv1alpha1.PodMigrationJob) {
+				job.Spec.ReservationOptions = &schedulingv1alpha1.PodMigrateReservationOptions{
+					ReservationRef: &corev1.ObjectReference{Name: "r1"},
+					Template:       &schedulingv1alpha1.ReservationTemplateSpec{},
+				}
+			},
+		},
+		{
+			name:      "update the mode",
+			operation: admissionv1.Update,
+			modify: func(job *schedulingv1alpha1.PodMigrationJob) {
+				job.Spec.Mode = schedulingv1alpha1.PodMigrationJobModeEvictionDirectly
+			},
+		},
+		{
+			name:      "controller fills the uid of podRef",
+			operation: admissionv1.Update,
+			modifyOld: func(job *schedulingv1alpha1.PodMigrationJob) {
+				job.Spec.PodRef.UID = ""
+			},
+			wantAllowed: true,
+		},
+		{
+			name:      "update the uid of podRef",
+			operation: admissionv1.Update,
+			modify: func(job *schedulingv1alpha1.PodMigrationJob) {
+				job.Spec.PodRef.UID = "other-uid"
+			},
+		},
+		{
+			name:      "update the name of podRef",
+			operation: admissionv1.Update,
+			modify: func(job *schedulingv1alpha1.PodMigrationJob) {
+				job.Spec.PodRef.Name = "pod2"
+			},
+		},
+		{
+			name:      "update the job admitted before with the unchanged invalid ttl",
+			operation: admissionv1.Update,
+			modifyOld: func(job *schedulingv1alpha1.PodMigrationJob) {
+				job.Spec.TTL = &metav1.Duration{Duration: -time.Minute}
+			},
+			modify: func(job *schedulingv1alpha1.PodMigrationJob) {
+				job.Spec.TTL = &metav1.Duration{Duration: -time.Minute}
+				job.Labels = map[string]string{"foo": "bar"}
+			},
+			wantAllowed: true,
+		},
+		{
+			name:      "update the ttl to negative",
+			operation: admissionv1.Update,
+			modify: func(job *schedulingv1alpha1.PodMigrationJob) {
+				job.Spec.TTL = &metav1.Duration{Duration: -time.Minute}
+			},
+		},
+		{
+			name:      "controller fills the reservationRef",
+			operation: admissionv1.Update,
+			modify: func(job *schedulingv1alpha1.PodMigrationJob) {
+				job.Spec.ReservationOptions = &schedulingv1alpha1.PodMigrateReservationOptions{
+					ReservationRef: &corev1.ObjectReference{Name: "r1"},
+					Template:       &schedulingv1alpha1.ReservationTemplateSpec{},
+				}
+			},
+			wantAllowed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := makeTestHandler(pod)
+			oldObj := newTestPodMigrationJob()
+			if tt.modifyOld != nil {
+				tt.modifyOld(oldObj)
+			}
+			obj := newTestPodMigrationJob()
+			if tt.modify != nil {
+				tt.modify(obj)
+			}
+			raw, err := json.Marshal(obj)
+			assert.NoError(t, err)
+			oldRaw, err := json.Marshal(oldObj)
+			assert.NoError(t, err)
+			req := admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Resource: metav1.GroupVersionResource{
+						Group:    schedulingv1alpha1.GroupVersion.Group,
+						Version:  schedulingv1alpha1.GroupVersion.Version,
+						Resource: "podmigrationjobs",
+					},
+					Operation: tt.operation,
+					Object:    runtime.RawExtension{Raw: raw},
+				},
+			}
+			if tt.operation == admissionv1.Update {
+				req.OldObject = runtime.RawExtension{Raw: oldRaw}
+			}
+			resp := handler.Handle(context.TODO(), req)
+			assert.Equal(t, tt.wantAllowed, resp.Allowed, resp.Result)
+		})
+	}
+}
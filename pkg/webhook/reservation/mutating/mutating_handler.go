@@ -0,0 +1,117 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutating
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+)
+
+const (
+	// DefaultReservationTTL is the TTL of the Reservation specifying neither TTL nor Expires.
+	DefaultReservationTTL = 24 * time.Hour
+	// DefaultReservationAllocatePolicy is compatible with the reservations of the Pods in the reservation operating mode.
+	DefaultReservationAllocatePolicy = schedulingv1alpha1.ReservationAllocatePolicyAligned
+)
+
+// ReservationMutatingHandler handles Reservation
+type ReservationMutatingHandler struct {
+	Client client.Client
+
+	// Decoder decodes objects
+	Decoder *admission.Decoder
+}
+
+var _ admission.Handler = &ReservationMutatingHandler{}
+
+func shouldIgnoreIfNotReservation(req admission.Request) bool {
+	// Ignore all calls to sub resources or resources other than reservations.
+	if len(req.AdmissionRequest.SubResource) != 0 ||
+		req.AdmissionRequest.Resource.Resource != "reservations" {
+		return true
+	}
+	return false
+}
+
+// Handle handles admission requests.
+func (h *ReservationMutatingHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if shouldIgnoreIfNotReservation(req) {
+		return admission.Allowed("")
+	}
+
+	obj := &schedulingv1alpha1.Reservation{}
+	if err := h.Decoder.Decode(req, obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	clone := obj.DeepCopy()
+
+	setDefaultsReservation(obj)
+
+	if reflect.DeepEqual(obj, clone) {
+		return admission.Allowed("")
+	}
+	marshaled, err := json.Marshal(obj)
+	if err != nil {
+		klog.Errorf("Failed to marshal mutated Reservation %s, err: %v", obj.Name, err)
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.AdmissionRequest.Object.Raw, marshaled)
+}
+
+func setDefaultsReservation(r *schedulingv1alpha1.Reservation) {
+	// the apiserver defaults the TTL by the CRD schema even if the Expires is set,
+	// drop it since the Expires is checked first and they are mutually exclusive.
+	if r.Spec.Expires != nil {
+		r.Spec.TTL = nil
+	} else if r.Spec.TTL == nil {
+		r.Spec.TTL = &metav1.Duration{Duration: DefaultReservationTTL}
+	}
+	if r.Spec.AllocateOnce == nil {
+		r.Spec.AllocateOnce = pointer.Bool(true)
+	}
+	if r.Spec.AllocatePolicy == schedulingv1alpha1.ReservationAllocatePolicyDefault {
+		r.Spec.AllocatePolicy = DefaultReservationAllocatePolicy
+	}
+}
+
+var _ inject.Client = &ReservationMutatingHandler{}
+
+// InjectClient injects the client into the ReservationMutatingHandler
+func (h *ReservationMutatingHandler) InjectClient(c client.Client) error {
+	h.Client = c
+	return nil
+}
+
+var _ admission.DecoderInjector = &ReservationMutatingHandler{}
+
+// InjectDecoder injects the decoder into the ReservationMutatingHandler
+func (h *ReservationMutatingHandler) InjectDecoder(d *admission.Decoder) error {
+	h.Decoder = d
+	return nil
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutating
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+)
+
+func makeTestHandler() *ReservationMutatingHandler {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = schedulingv1alpha1.AddToScheme(scheme)
+	decoder, _ := admission.NewDecoder(scheme)
+	handler := &ReservationMutatingHandler{}
+	_ = handler.InjectClient(fake.NewClientBuilder().WithScheme(scheme).Build())
+	_ = handler.InjectDecoder(decoder)
+	return handler
+}
+
+func gvr(resource string) metav1.GroupVersionResource {
+	return metav1.GroupVersionResource{
+		Group:    schedulingv1alpha1.GroupVersion.Group,
+		Version:  schedulingv1alpha1.GroupVersion.Version,
+		Resource: resource,
+	}
+}
+
+func TestReservationMutatingHandler(t *testing.T) {
+	expires := metav1.NewTime(time.Now().Add(time.Hour))
+	tests := []struct {
+		name        string
+		resource    string
+		reservation *schedulingv1alpha1.Reservation
+		wantPatched bool
+		wantSpec    schedulingv1alpha1.ReservationSpec
+	}{
+		{
+			name:     "ignore other resources",
+			resource: "pods",
+			reservation: &schedulingv1alpha1.Reservation{
+				ObjectMeta: metav1.ObjectMeta{Name: "r1"},
+			},
+		},
+		{
+			name:     "set defaults",
+			resource: "reservations",
+			reservation: &schedulingv1alpha1.Reservation{
+				ObjectMeta: metav1.ObjectMeta{Name: "r1"},
+			},
+			wantPatched: true,
+			wantSpec: schedulingv1alpha1.ReservationSpec{
+				TTL:            &metav1.Duration{Duration: DefaultReservationTTL},
+				AllocateOnce:   pointer.Bool(true),
+				AllocatePolicy: DefaultReservationAllocatePolicy,
+			},
+		},
+		{
+			name:     "drop the ttl if expires is set",
+			resource: "reservations",
+			reservation: &schedulingv1alpha1.Reservation{
+				ObjectMeta: metav1.ObjectMeta{Name: "r1"},
+				Spec: schedulingv1alpha1.ReservationSpec{
+					TTL:            &metav1.Duration{Duration: DefaultReservationTTL},
+					Expires:        &expires,
+					AllocateOnce:   pointer.Bool(false),
+					AllocatePolicy: schedulingv1alpha1.ReservationAllocatePolicyRestricted,
+				},
+			},
+			wantPatched: true,
+			wantSpec: schedulingv1alpha1.ReservationSpec{
+				Expires:        &expires,
+				AllocateOnce:   pointer.Bool(false),
+				AllocatePolicy: schedulingv1alpha1.ReservationAllocatePolicyRestricted,
+			},
+		},
+		{
+			name:     "keep the configured spec",
+			resource: "reservations",
+			reservation: &schedulingv1alpha1.Reservation{
+				ObjectMeta: metav1.ObjectMeta{Name: "r1"},
+				Spec: schedulingv1alpha1.ReservationSpec{
+					TTL:            &metav1.Duration{Duration: time.Hour},
+					AllocateOnce:   pointer.Bool(true),
+					AllocatePolicy: schedulingv1alpha1.ReservationAllocatePolicyAligned,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := makeTestHandler()
+			raw, err := json.Marshal(tt.reservation)
+			assert.NoError(t, err)
+			resp := handler.Handle(context.TODO(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Resource:  gvr(tt.resource),
+					Operation: admissionv1.Create,
+					Object:    runtime.RawExtension{Raw: raw},
+				},
+			})
+			assert.True(t, resp.Allowed)
+			assert.Equal(t, tt.wantPatched, len(resp.Patches) > 0)
+			if !tt.wantPatched {
+				return
+			}
+			mutated := tt.reservation.DeepCopy()
+			setDefaultsReservation(mutated)
+			assert.Equal(t, tt.wantSpec.TTL, mutated.Spec.TTL)
+			assert.Equal(t, tt.wantSpec.AllocateOnce, mutated.Spec.AllocateOnce)
+			assert.Equal(t, tt.wantSpec.AllocatePolicy, mutated.Spec.AllocatePolicy)
+		})
+	}
+
+	resp := makeTestHandler().Handle(context.TODO(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Resource:  gvr("reservations"),
+			Operation: admissionv1.Create,
+		},
+	})
+	assert.False(t, resp.Allowed)
+	assert.Equal(t, int32(http.StatusBadRequest), resp.Result.Code)
+}
@@ -0,0 +1,262 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+	"github.com/koordinator-sh/koordinator/pkg/util"
+)
+
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+
+// crdDefaultTTL is the TTL defaulted by the CRD schema, it is set by the apiserver even if the Expires is set.
+const crdDefaultTTL = 24 * time.Hour
+
+// ReservationValidatingHandler handles Reservation
+type ReservationValidatingHandler struct {
+	Client client.Client
+
+	// Decoder decodes objects
+	Decoder *admission.Decoder
+}
+
+var _ admission.Handler = &ReservationValidatingHandler{}
+
+func shouldIgnoreIfNotReservation(req admission.Request) bool {
+	// Ignore all calls to sub resources or resources other than reservations.
+	if len(req.AdmissionRequest.SubResource) != 0 ||
+		req.AdmissionRequest.Resource.Resource != "reservations" {
+		return true
+	}
+	return false
+}
+
+// Handle handles admission requests.
+func (h *ReservationValidatingHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if shouldIgnoreIfNotReservation(req) {
+		return admission.ValidationResponse(true, "")
+	}
+
+	obj := &schedulingv1alpha1.Reservation{}
+	if err := h.Decoder.Decode(req, obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	var allErrs field.ErrorList
+	switch req.Operation {
+	case admissionv1.Create:
+		allErrs = append(allErrs, validateReservationSpec(&obj.Spec, field.NewPath("spec"))...)
+		if obj.Spec.Expires != nil && !obj.Spec.Expires.After(time.Now()) {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "expires"), obj.Spec.Expires, "expires must be in the future"))
+		}
+		nodeErrs, err := h.validateTemplateNodeName(ctx, obj)
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		allErrs = append(allErrs, nodeErrs...)
+	case admissionv1.Update:
+		oldObj := &schedulingv1alpha1.Reservation{}
+		if err := h.Decoder.DecodeRaw(req.OldObject, oldObj); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		allErrs = append(allErrs, validateReservationUpdate(oldObj, obj)...)
+	}
+
+	if err := allErrs.ToAggregate(); err != nil {
+		klog.V(4).Infof("Webhook denied Reservation %s, err: %v", obj.Name, err)
+		return admission.ValidationResponse(false, err.Error())
+	}
+	return admission.ValidationResponse(true, "")
+}
+
+func validateReservationSpec(spec *schedulingv1alpha1.ReservationSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	allErrs = append(allErrs, validateReservationTemplateField(spec.Template, fldPath.Child("template"))...)
+	allErrs = append(allErrs, validateReservationOwners(spec.Owners, fldPath.Child("owners"))...)
+	allErrs = append(allErrs, validateReservationExpiration(spec, fldPath)...)
+
+	switch spec.AllocatePolicy {
+	case schedulingv1alpha1.ReservationAllocatePolicyDefault,
+		schedulingv1alpha1.ReservationAllocatePolicyAligned,
+		schedulingv1alpha1.ReservationAllocatePolicyRestricted:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("allocatePolicy"), spec.AllocatePolicy, []string{
+			string(schedulingv1alpha1.ReservationAllocatePolicyAligned),
+			string(schedulingv1alpha1.ReservationAllocatePolicyRestricted),
+		}))
+	}
+	return allErrs
+}
+
+func validateReservationTemplateField(template *corev1.PodTemplateSpec, fldPath *field.Path) field.ErrorList {
+	if template == nil {
+		return field.ErrorList{field.Required(fldPath, "template must be set")}
+	}
+	return validateReservationTemplate(template, fldPath)
+}
+
+func validateReservationOwners(owners []schedulingv1alpha1.ReservationOwner, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if len(owners) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath, "at least one owner must be set"))
+	}
+	for i := range owners {
+		allErrs = append(allErrs, validateReservationOwner(&owners[i], fldPath.Index(i))...)
+	}
+	return allErrs
+}
+
+func validateReservationExpiration(spec *schedulingv1alpha1.ReservationSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if spec.TTL != nil {
+		if spec.TTL.Duration < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("ttl"), spec.TTL.Duration.String(), "ttl must be greater than or equal to 0"))
+		}
+		// the TTL defaulted by the CRD schema is tolerated along with the Expires
+		if spec.Expires != nil && spec.TTL.Duration != crdDefaultTTL {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("ttl"), "ttl and expires are mutually exclusive"))
+		}
+	}
+	return allErrs
+}
+
+// validateReservationTemplate checks the template can be satisfied by the scheduler.
+func validateReservationTemplate(template *corev1.PodTemplateSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := fldPath.Child("spec")
+	if len(template.Spec.Containers) == 0 {
+		allErrs = append(allErrs, field.Required(specPath.Child("containers"), "at least one container must be set"))
+		return allErrs
+	}
+	for i, container := range template.Spec.Containers {
+		for resourceName, quantity := range container.Resources.Requests {
+			if quantity.Sign() < 0 {
+				allErrs = append(allErrs, field.Invalid(specPath.Child("containers").Index(i).Child("resources", "requests").Key(string(resourceName)),
+					quantity.String(), "must be greater than or equal to 0"))
+			}
+		}
+	}
+	requests := util.GetPodRequest(&corev1.Pod{Spec: template.Spec})
+	reserved := false
+	for _, quantity := range requests {
+		if quantity.Sign() > 0 {
+			reserved = true
+			break
+		}
+	}
+	if !reserved {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("containers"), "", "the template must request some resources to reserve"))
+	}
+	if template.Spec.Affinity != nil && template.Spec.Affinity.NodeAffinity != nil &&
+		template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil &&
+		len(template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms) == 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("affinity", "nodeAffinity", "requiredDuringSchedulingIgnoredDuringExecution"),
+			"", "the required node affinity without terms selects no nodes"))
+	}
+	return allErrs
+}
+
+func validateReservationOwner(owner *schedulingv1alpha1.ReservationOwner, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if owner.Object == nil && owner.Controller == nil && owner.LabelSelector == nil {
+		allErrs = append(allErrs, field.Required(fldPath, "one of object, controller and labelSelector must be set"))
+	}
+	if owner.Object != nil && owner.Object.Name == "" && owner.Object.UID == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("object"), "name or uid must be set"))
+	}
+	if owner.Controller != nil && owner.Controller.Name == "" && owner.Controller.UID == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("controller"), "name or uid must be set"))
+	}
+	if owner.LabelSelector != nil {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(owner.LabelSelector, fldPath.Child("labelSelector"))...)
+	}
+	return allErrs
+}
+
+// validateReservationUpdate validates only the fields changed by the update,
+// so that the Reservations admitted before are not blocked by the rules added later.
+func validateReservationUpdate(oldObj, newObj *schedulingv1alpha1.Reservation) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+	oldSpec, newSpec := &oldObj.Spec, &newObj.Spec
+	if !apiequality.Semantic.DeepEqual(newSpec.Template, oldSpec.Template) {
+		allErrs = append(allErrs, validateReservationTemplateField(newSpec.Template, specPath.Child("template"))...)
+	}
+	if !apiequality.Semantic.DeepEqual(newSpec.Owners, oldSpec.Owners) {
+		allErrs = append(allErrs, validateReservationOwners(newSpec.Owners, specPath.Child("owners"))...)
+	}
+	if !apiequality.Semantic.DeepEqual(newSpec.TTL, oldSpec.TTL) || !apiequality.Semantic.DeepEqual(newSpec.Expires, oldSpec.Expires) {
+		allErrs = append(allErrs, validateReservationExpiration(newSpec, specPath)...)
+	}
+	allErrs = append(allErrs, apivalidation.ValidateImmutableField(newSpec.AllocatePolicy, oldSpec.AllocatePolicy, specPath.Child("allocatePolicy"))...)
+	if oldSpec.AllocateOnce != nil && newSpec.AllocateOnce != nil {
+		allErrs = append(allErrs, apivalidation.ValidateImmutableField(*newSpec.AllocateOnce, *oldSpec.AllocateOnce, specPath.Child("allocateOnce"))...)
+	}
+	return allErrs
+}
+
+// validateTemplateNodeName checks the node specified in the template exists,
+// otherwise the Reservation is never scheduled.
+func (h *ReservationValidatingHandler) validateTemplateNodeName(ctx context.Context, r *schedulingv1alpha1.Reservation) (field.ErrorList, error) {
+	if r.Spec.Template == nil || r.Spec.Template.Spec.NodeName == "" {
+		return nil, nil
+	}
+	nodeName := r.Spec.Template.Spec.NodeName
+	node := &corev1.Node{}
+	err := h.Client.Get(ctx, types.NamespacedName{Name: nodeName}, node)
+	if errors.IsNotFound(err) {
+		return field.ErrorList{field.NotFound(field.NewPath("spec", "template", "spec", "nodeName"), nodeName)}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node %s, err: %v", nodeName, err)
+	}
+	return nil, nil
+}
+
+var _ inject.Client = &ReservationValidatingHandler{}
+
+// InjectClient injects the client into the ReservationValidatingHandler
+func (h *ReservationValidatingHandler) InjectClient(c client.Client) error {
+	h.Client = c
+	return nil
+}
+
+var _ admission.DecoderInjector = &ReservationValidatingHandler{}
+
+// InjectDecoder injects the decoder into the ReservationValidatingHandler
+func (h *ReservationValidatingHandler) InjectDecoder(d *admission.Decoder) error {
+	h.Decoder = d
+	return nil
+}
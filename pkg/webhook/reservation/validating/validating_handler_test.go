@@ -0,0 +1,263 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	schedulingv1alpha1 "github.com/koordinator-sh/koordinator/apis/scheduling/v1alpha1"
+)
+
+func makeTestHandler(objs ...runtime.Object) *ReservationValidatingHandler {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = schedulingv1alpha1.AddToScheme(scheme)
+	decoder, _ := admission.NewDecoder(scheme)
+	handler := &ReservationValidatingHandler{}
+	_ = handler.InjectClient(fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objs...).Build())
+	_ = handler.InjectDecoder(decoder)
+	return handler
+}
+
+func newTestReservation() *schedulingv1alpha1.Reservation {
+	return &schedulingv1alpha1.Reservation{
+		ObjectMeta: metav1.ObjectMeta{Name: "r1"},
+		Spec: schedulingv1alpha1.ReservationSpec{
+			Template: &corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU: resource.MustParse("1"),
+								},
+							},
+						},
+					},
+				},
+			},
+			Owners: []schedulingv1alpha1.ReservationOwner{
+				{
+					Object: &corev1.ObjectReference{Name: "pod1", Namespace: "default"},
+				},
+			},
+			TTL: &metav1.Duration{Duration: time.Hour},
+		},
+	}
+}
+
+func TestReservationValidatingHandler(t *testing.T) {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
+	tests := []struct {
+		name        string
+		operation   admissionv1.Operation
+		modifyOld   func(r *schedulingv1alpha1.Reservation)
+		modify      func(r *schedulingv1alpha1.Reservation)
+		wantAllowed bool
+	}{
+		{
+			name:        "valid reservation",
+			operation:   admissionv1.Create,
+			wantAllowed: true,
+		},
+		{
+			name:      "empty owners",
+			operation: admissionv1.Create,
+			modify: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.Owners = nil
+			},
+		},
+		{
+			name:      "owner without selectors",
+			operation: admissionv1.Create,
+			modify: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.Owners = []schedulingv1alpha1.ReservationOwner{{}}
+			},
+		},
+		{
+			name:      "invalid owner label selector",
+			operation: admissionv1.Create,
+			modify: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.Owners = []schedulingv1alpha1.ReservationOwner{
+					{
+						LabelSelector: &metav1.LabelSelector{
+							MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Unknown"}},
+						},
+					},
+				}
+			},
+		},
+		{
+			name:      "both ttl and expires",
+			operation: admissionv1.Create,
+			modify: func(r *schedulingv1alpha1.Reservation) {
+				expires := metav1.NewTime(time.Now().Add(time.Hour))
+				r.Spec.Expires = &expires
+			},
+		},
+		{
+			name:      "expires with the ttl defaulted by crd",
+			operation: admissionv1.Create,
+			modify: func(r *schedulingv1alpha1.Reservation) {
+				expires := metav1.NewTime(time.Now().Add(time.Hour))
+				r.Spec.Expires = &expires
+				r.Spec.TTL = &metav1.Duration{Duration: crdDefaultTTL}
+			},
+			wantAllowed: true,
+		},
+		{
+			name:      "expires in the past",
+			operation: admissionv1.Create,
+			modify: func(r *schedulingv1alpha1.Reservation) {
+				expires := metav1.NewTime(time.Now().Add(-time.Hour))
+				r.Spec.Expires = &expires
+				r.Spec.TTL = nil
+			},
+		},
+		{
+			name:      "template requests nothing",
+			operation: admissionv1.Create,
+			modify: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.Template.Spec.Containers[0].Resources.Requests = nil
+			},
+		},
+		{
+			name:      "template without containers",
+			operation: admissionv1.Create,
+			modify: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.Template.Spec.Containers = nil
+			},
+		},
+		{
+			name:      "template on a missing node",
+			operation: admissionv1.Create,
+			modify: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.Template.Spec.NodeName = "node2"
+			},
+		},
+		{
+			name:      "template on an existing node",
+			operation: admissionv1.Create,
+			modify: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.Template.Spec.NodeName = "node1"
+			},
+			wantAllowed: true,
+		},
+		{
+			name:      "unsupported allocate policy",
+			operation: admissionv1.Create,
+			modify: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.AllocatePolicy = "Unknown"
+			},
+		},
+		{
+			name:      "update the allocate policy",
+			operation: admissionv1.Update,
+			modify: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.AllocatePolicy = schedulingv1alpha1.ReservationAllocatePolicyRestricted
+			},
+		},
+		{
+			name:      "update the unschedulable",
+			operation: admissionv1.Update,
+			modify: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.Unschedulable = true
+			},
+			wantAllowed: true,
+		},
+		{
+			name:      "update the reservation admitted before the owner rules",
+			operation: admissionv1.Update,
+			modifyOld: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.Owners = []schedulingv1alpha1.ReservationOwner{{}}
+			},
+			modify: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.Owners = []schedulingv1alpha1.ReservationOwner{{}}
+				r.Spec.Unschedulable = true
+			},
+			wantAllowed: true,
+		},
+		{
+			name:      "update the owners to invalid",
+			operation: admissionv1.Update,
+			modify: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.Owners = nil
+			},
+		},
+		{
+			name:      "update the template to request nothing",
+			operation: admissionv1.Update,
+			modify: func(r *schedulingv1alpha1.Reservation) {
+				r.Spec.Template.Spec.Containers[0].Resources.Requests = nil
+			},
+		},
+		{
+			name:      "update the expires along with the ttl",
+			operation: admissionv1.Update,
+			modify: func(r *schedulingv1alpha1.Reservation) {
+				expires := metav1.NewTime(time.Now().Add(time.Hour))
+				r.Spec.Expires = &expires
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := makeTestHandler(node)
+			oldObj := newTestReservation()
+			if tt.modifyOld != nil {
+				tt.modifyOld(oldObj)
+			}
+			obj := newTestReservation()
+			if tt.modify != nil {
+				tt.modify(obj)
+			}
+			raw, err := json.Marshal(obj)
+			assert.NoError(t, err)
+			oldRaw, err := json.Marshal(oldObj)
+			assert.NoError(t, err)
+			req := admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Resource: metav1.GroupVersionResource{
+						Group:    schedulingv1alpha1.GroupVersion.Group,
+						Version:  schedulingv1alpha1.GroupVersion.Version,
+						Resource: "reservations",
+					},
+					Operation: tt.operation,
+					Object:    runtime.RawExtension{Raw: raw},
+				},
+			}
+			if tt.operation == admissionv1.Update {
+				req.OldObject = runtime.RawExtension{Raw: oldRaw}
+			}
+			resp := handler.Handle(context.TODO(), req)
+			assert.Equal(t, tt.wantAllowed, resp.Allowed, resp.Result)
+		})
+	}
+}
@@ -77,6 +77,12 @@ func filterActiveHandlers() {
 	}
 }
 
+// runnablesProvider is implemented by the handlers with several background loops,
+// e.g. some of them run on every replica and the others run on the leader only.
+type runnablesProvider interface {
+	Runnables() []manager.Runnable
+}
+
 func SetupWithManager(mgr manager.Manager) error {
 	server := mgr.GetWebhookServer()
 	server.Host = "0.0.0.0"
@@ -88,6 +94,19 @@ func SetupWithManager(mgr manager.Manager) error {
 	for path, handler := range HandlerMap {
 		server.Register(path, &webhook.Admission{Handler: handler})
 		klog.V(3).Infof("Registered webhook handler %s", path)
+		// the handlers with background loops are started by the manager
+		if runnable, ok := handler.(manager.Runnable); ok {
+			if err := mgr.Add(runnable); err != nil {
+				return err
+			}
+		}
+		if provider, ok := handler.(runnablesProvider); ok {
+			for _, runnable := range provider.Runnables() {
+				if err := mgr.Add(runnable); err != nil {
+					return err
+				}
+			}
+		}
 	}
 
 	// register conversion webhook
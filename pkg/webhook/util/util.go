@@ -41,6 +41,18 @@ func GetHost() string {
 	return os.Getenv("WEBHOOK_HOST")
 }
 
+// GetPodName returns the name of the webhook replica.
+func GetPodName() string {
+	if name := os.Getenv("POD_NAME"); len(name) > 0 {
+		return name
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		klog.Warningf("failed to get hostname, err: %v", err)
+	}
+	return hostname
+}
+
 func GetNamespace() string {
 	if ns := os.Getenv("POD_NAMESPACE"); len(ns) > 0 {
 		return ns
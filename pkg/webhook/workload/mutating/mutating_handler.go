@@ -0,0 +1,140 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutating
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/koordinator-sh/koordinator/apis/extension"
+	podmutating "github.com/koordinator-sh/koordinator/pkg/webhook/pod/mutating"
+)
+
+// WorkloadMutatingHandler mutates the pod templates of the workloads by ClusterColocationProfiles,
+// so that the effective QoS and batch resources are visible before the Pods are created.
+type WorkloadMutatingHandler struct {
+	Client client.Client
+
+	// Decoder decodes objects
+	Decoder *admission.Decoder
+}
+
+var _ admission.Handler = &WorkloadMutatingHandler{}
+
+func newWorkloadObject(req admission.Request) (client.Object, *corev1.PodTemplateSpec) {
+	if len(req.AdmissionRequest.SubResource) != 0 {
+		return nil, nil
+	}
+	switch req.AdmissionRequest.Resource.Resource {
+	case "deployments":
+		obj := &appsv1.Deployment{}
+		return obj, &obj.Spec.Template
+	case "statefulsets":
+		obj := &appsv1.StatefulSet{}
+		return obj, &obj.Spec.Template
+	case "jobs":
+		obj := &batchv1.Job{}
+		return obj, &obj.Spec.Template
+	case "cronjobs":
+		obj := &batchv1.CronJob{}
+		return obj, &obj.Spec.JobTemplate.Spec.Template
+	}
+	return nil, nil
+}
+
+// Handle handles admission requests.
+func (h *WorkloadMutatingHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	// Ignore all calls to sub resources or resources other than the supported workloads.
+	obj, template := newWorkloadObject(req)
+	if obj == nil || (req.Operation != admissionv1.Create && req.Operation != admissionv1.Update) {
+		return admission.Allowed("")
+	}
+
+	if err := h.Decoder.Decode(req, obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	// The templates mutated before are skipped, e.g. the Jobs created from the mutated template of a CronJob.
+	if _, ok := template.Annotations[extension.AnnotationColocationProfile]; ok {
+		return admission.Allowed("")
+	}
+	if req.Operation == admissionv1.Update {
+		oldObj, oldTemplate := newWorkloadObject(req)
+		if err := h.Decoder.DecodeRaw(req.OldObject, oldObj); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if reflect.DeepEqual(template, oldTemplate) {
+			return admission.Allowed("")
+		}
+	}
+	// when workload.namespace is empty, using req.namespace
+	namespace := obj.GetNamespace()
+	if namespace == "" {
+		namespace = req.Namespace
+	}
+
+	clone := template.DeepCopy()
+	appliedProfiles, err := podmutating.MutatePodTemplateByColocationProfiles(ctx, h.Client, namespace, template)
+	if err != nil {
+		klog.Errorf("Failed to mutating %s %s/%s by ClusterColocationProfile, err: %v",
+			req.Kind.Kind, namespace, obj.GetName(), err)
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if len(appliedProfiles) == 0 || reflect.DeepEqual(template, clone) {
+		return admission.Allowed("")
+	}
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[extension.AnnotationColocationProfile] = template.Annotations[extension.AnnotationColocationProfile]
+	obj.SetAnnotations(annotations)
+	klog.V(4).Infof("mutate %s %s/%s by clusterColocationProfiles %v", req.Kind.Kind, namespace, obj.GetName(), appliedProfiles)
+
+	marshaled, err := json.Marshal(obj)
+	if err != nil {
+		klog.Errorf("Failed to marshal mutated %s %s/%s, err: %v", req.Kind.Kind, namespace, obj.GetName(), err)
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.AdmissionRequest.Object.Raw, marshaled)
+}
+
+var _ inject.Client = &WorkloadMutatingHandler{}
+
+// InjectClient injects the client into the WorkloadMutatingHandler
+func (h *WorkloadMutatingHandler) InjectClient(c client.Client) error {
+	h.Client = c
+	return nil
+}
+
+var _ admission.DecoderInjector = &WorkloadMutatingHandler{}
+
+// InjectDecoder injects the decoder into the WorkloadMutatingHandler
+func (h *WorkloadMutatingHandler) InjectDecoder(d *admission.Decoder) error {
+	h.Decoder = d
+	return nil
+}
@@ -0,0 +1,409 @@
+/*
+Copyright 2022 The Koordinator Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutating
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	configv1alpha1 "github.com/koordinator-sh/koordinator/apis/config/v1alpha1"
+	"github.com/koordinator-sh/koordinator/apis/extension"
+)
+
+func init() {
+	_ = configv1alpha1.AddToScheme(scheme.Scheme)
+}
+
+func newAdmission(op admissionv1.Operation, resource string, obj runtime.Object, subResource string) admission.Request {
+	raw, _ := json.Marshal(obj)
+	return admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Resource:    metav1.GroupVersionResource{Resource: resource},
+			Operation:   op,
+			Namespace:   "default",
+			Object:      runtime.RawExtension{Raw: raw},
+			SubResource: subResource,
+		},
+	}
+}
+
+func applyPatches(raw []byte, resp admission.Response, obj runtime.Object) error {
+	patchBytes, err := json.Marshal(resp.Patches)
+	if err != nil {
+		return err
+	}
+	patch, err := jsonpatch.DecodePatch(patchBytes)
+	if err != nil {
+		return err
+	}
+	patched, err := patch.Apply(raw)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(patched, obj)
+}
+
+func newTestPodTemplate() corev1.PodTemplateSpec {
+	return corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				"koordinator-colocation-pod": "true",
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "test-container-a",
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("1"),
+							corev1.ResourceMemory: resource.MustParse("4Gi"),
+						},
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("1"),
+							corev1.ResourceMemory: resource.MustParse("4Gi"),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func newExpectedPodTemplate(profiles string) corev1.PodTemplateSpec {
+	return corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				"koordinator-colocation-pod": "true",
+				extension.LabelPodQoS:        string(extension.QoSBE),
+			},
+			Annotations: map[string]string{
+				extension.AnnotationColocationProfile: profiles,
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "test-container-a",
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{
+							extension.BatchCPU:    *resource.NewQuantity(1000, resource.DecimalSI),
+							extension.BatchMemory: resource.MustParse("4Gi"),
+						},
+						Requests: corev1.ResourceList{
+							extension.BatchCPU:    *resource.NewQuantity(1000, resource.DecimalSI),
+							extension.BatchMemory: resource.MustParse("4Gi"),
+						},
+					},
+				},
+			},
+			PriorityClassName: "koordinator-batch",
+		},
+	}
+}
+
+func newTestProfile(name string) *configv1alpha1.ClusterColocationProfile {
+	return &configv1alpha1.ClusterColocationProfile{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: configv1alpha1.ClusterColocationProfileSpec{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"enable-koordinator-colocation": "true",
+				},
+			},
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"koordinator-colocation-pod": "true",
+				},
+			},
+			QoSClass:          string(extension.QoSBE),
+			PriorityClassName: "koordinator-batch",
+		},
+	}
+}
+
+func TestWorkloadMutatingHandler(t *testing.T) {
+	probabilisticProfile := newTestProfile("test-probabilistic-profile")
+	probabilisticProfile.Spec.Probability = &intstr.IntOrString{Type: intstr.String, StrVal: "50%"}
+
+	tests := []struct {
+		name        string
+		operation   admissionv1.Operation
+		resource    string
+		subResource string
+		obj         client.Object
+		oldObj      client.Object
+		profiles    []*configv1alpha1.ClusterColocationProfile
+		wantAllowed bool
+		wantPatched bool
+		wantObj     client.Object
+	}{
+		{
+			name:      "mutate deployment",
+			operation: admissionv1.Create,
+			resource:  "deployments",
+			obj: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-deployment"},
+				Spec:       appsv1.DeploymentSpec{Template: newTestPodTemplate()},
+			},
+			profiles:    []*configv1alpha1.ClusterColocationProfile{newTestProfile("test-profile")},
+			wantAllowed: true,
+			wantPatched: true,
+			wantObj: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "default",
+					Name:        "test-deployment",
+					Annotations: map[string]string{extension.AnnotationColocationProfile: "test-profile"},
+				},
+				Spec: appsv1.DeploymentSpec{Template: newExpectedPodTemplate("test-profile")},
+			},
+		},
+		{
+			name:      "mutate statefulset",
+			operation: admissionv1.Create,
+			resource:  "statefulsets",
+			obj: &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-statefulset"},
+				Spec:       appsv1.StatefulSetSpec{Template: newTestPodTemplate()},
+			},
+			profiles:    []*configv1alpha1.ClusterColocationProfile{newTestProfile("test-profile")},
+			wantAllowed: true,
+			wantPatched: true,
+			wantObj: &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "default",
+					Name:        "test-statefulset",
+					Annotations: map[string]string{extension.AnnotationColocationProfile: "test-profile"},
+				},
+				Spec: appsv1.StatefulSetSpec{Template: newExpectedPodTemplate("test-profile")},
+			},
+		},
+		{
+			name:      "mutate job",
+			operation: admissionv1.Create,
+			resource:  "jobs",
+			obj: &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-job"},
+				Spec:       batchv1.JobSpec{Template: newTestPodTemplate()},
+			},
+			profiles:    []*configv1alpha1.ClusterColocationProfile{newTestProfile("test-profile")},
+			wantAllowed: true,
+			wantPatched: true,
+			wantObj: &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "default",
+					Name:        "test-job",
+					Annotations: map[string]string{extension.AnnotationColocationProfile: "test-profile"},
+				},
+				Spec: batchv1.JobSpec{Template: newExpectedPodTemplate("test-profile")},
+			},
+		},
+		{
+			name:      "mutate cronjob by multiple profiles",
+			operation: admissionv1.Create,
+			resource:  "cronjobs",
+			obj: &batchv1.CronJob{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-cronjob"},
+				Spec: batchv1.CronJobSpec{
+					JobTemplate: batchv1.JobTemplateSpec{
+						Spec: batchv1.JobSpec{Template: newTestPodTemplate()},
+					},
+				},
+			},
+			profiles: []*configv1alpha1.ClusterColocationProfile{
+				newTestProfile("test-profile-a"),
+				newTestProfile("test-profile-b"),
+			},
+			wantAllowed: true,
+			wantPatched: true,
+			wantObj: &batchv1.CronJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "default",
+					Name:        "test-cronjob",
+					Annotations: map[string]string{extension.AnnotationColocationProfile: "test-profile-a,test-profile-b"},
+				},
+				Spec: batchv1.CronJobSpec{
+					JobTemplate: batchv1.JobTemplateSpec{
+						Spec: batchv1.JobSpec{Template: newExpectedPodTemplate("test-profile-a,test-profile-b")},
+					},
+				},
+			},
+		},
+		{
+			name:      "skip probabilistic profile",
+			operation: admissionv1.Create,
+			resource:  "deployments",
+			obj: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-deployment"},
+				Spec:       appsv1.DeploymentSpec{Template: newTestPodTemplate()},
+			},
+			profiles:    []*configv1alpha1.ClusterColocationProfile{probabilisticProfile},
+			wantAllowed: true,
+		},
+		{
+			name:      "no matched profile",
+			operation: admissionv1.Create,
+			resource:  "deployments",
+			obj: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-deployment"},
+				Spec: appsv1.DeploymentSpec{
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "test"}},
+					},
+				},
+			},
+			profiles:    []*configv1alpha1.ClusterColocationProfile{newTestProfile("test-profile")},
+			wantAllowed: true,
+		},
+		{
+			name:      "mutate deployment on template update",
+			operation: admissionv1.Update,
+			resource:  "deployments",
+			obj: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-deployment"},
+				Spec:       appsv1.DeploymentSpec{Template: newTestPodTemplate()},
+			},
+			oldObj: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-deployment"},
+				Spec: appsv1.DeploymentSpec{
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "test"}},
+					},
+				},
+			},
+			profiles:    []*configv1alpha1.ClusterColocationProfile{newTestProfile("test-profile")},
+			wantAllowed: true,
+			wantPatched: true,
+			wantObj: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "default",
+					Name:        "test-deployment",
+					Annotations: map[string]string{extension.AnnotationColocationProfile: "test-profile"},
+				},
+				Spec: appsv1.DeploymentSpec{Template: newExpectedPodTemplate("test-profile")},
+			},
+		},
+		{
+			name:      "ignore update without template change",
+			operation: admissionv1.Update,
+			resource:  "deployments",
+			obj: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-deployment"},
+				Spec:       appsv1.DeploymentSpec{Replicas: pointer.Int32(2), Template: newTestPodTemplate()},
+			},
+			oldObj: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-deployment"},
+				Spec:       appsv1.DeploymentSpec{Replicas: pointer.Int32(1), Template: newTestPodTemplate()},
+			},
+			profiles:    []*configv1alpha1.ClusterColocationProfile{newTestProfile("test-profile")},
+			wantAllowed: true,
+		},
+		{
+			name:      "ignore mutated template",
+			operation: admissionv1.Create,
+			resource:  "jobs",
+			obj: &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-job"},
+				Spec:       batchv1.JobSpec{Template: newExpectedPodTemplate("test-profile")},
+			},
+			profiles:    []*configv1alpha1.ClusterColocationProfile{newTestProfile("test-profile")},
+			wantAllowed: true,
+		},
+		{
+			name:        "ignore sub resource",
+			operation:   admissionv1.Create,
+			resource:    "deployments",
+			subResource: "scale",
+			obj:         &appsv1.Deployment{},
+			profiles:    []*configv1alpha1.ClusterColocationProfile{newTestProfile("test-profile")},
+			wantAllowed: true,
+		},
+		{
+			name:        "ignore other resources",
+			operation:   admissionv1.Create,
+			resource:    "daemonsets",
+			obj:         &appsv1.DaemonSet{},
+			profiles:    []*configv1alpha1.ClusterColocationProfile{newTestProfile("test-profile")},
+			wantAllowed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().Build()
+			decoder, _ := admission.NewDecoder(scheme.Scheme)
+			handler := &WorkloadMutatingHandler{
+				Client:  fakeClient,
+				Decoder: decoder,
+			}
+
+			namespace := &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "default",
+					Labels: map[string]string{"enable-koordinator-colocation": "true"},
+				},
+			}
+			assert.NoError(t, fakeClient.Create(context.TODO(), namespace))
+			batchPriorityClass := &schedulingv1.PriorityClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "koordinator-batch"},
+				Value:      extension.PriorityBatchValueMax,
+			}
+			assert.NoError(t, fakeClient.Create(context.TODO(), batchPriorityClass))
+			for _, profile := range tt.profiles {
+				assert.NoError(t, fakeClient.Create(context.TODO(), profile))
+			}
+
+			req := newAdmission(tt.operation, tt.resource, tt.obj, tt.subResource)
+			if tt.oldObj != nil {
+				req.OldObject.Raw, _ = json.Marshal(tt.oldObj)
+			}
+			resp := handler.Handle(context.TODO(), req)
+			assert.Equal(t, tt.wantAllowed, resp.Allowed, resp.Result)
+			assert.Equal(t, tt.wantPatched, len(resp.Patches) > 0)
+			if !tt.wantPatched {
+				return
+			}
+
+			got, _ := newWorkloadObject(req)
+			assert.NoError(t, applyPatches(req.Object.Raw, resp, got))
+			// round-trip the expected object to normalize the serialized quantities
+			want, _ := newWorkloadObject(req)
+			wantRaw, _ := json.Marshal(tt.wantObj)
+			assert.NoError(t, json.Unmarshal(wantRaw, want))
+			assert.Equal(t, want, got)
+		})
+	}
+}